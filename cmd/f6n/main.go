@@ -2,16 +2,25 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"f6n/internal/aws"
 	"f6n/internal/config"
 	"f6n/internal/logger"
+	"f6n/internal/mcp"
 	"f6n/internal/provider"
+	"f6n/internal/report"
+	"f6n/internal/server"
+	"f6n/internal/telemetry"
 	"f6n/internal/ui"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -20,54 +29,291 @@ import (
 
 func main() {
 	cfg := config.Load()
+	switch cfg.Command {
+	case "", "serve", "mcp", "report":
+	default:
+		log.Fatalf("unknown command %q (expected serve, mcp or report)", cfg.Command)
+	}
 
 	// Mirror logs to stdout when verbose/debug is requested to help during local dev or inside containers.
-	if cfg.Verbose || strings.EqualFold(cfg.LogLevel, "debug") {
+	// The MCP server owns stdout for protocol messages, so it only logs to the file.
+	if (cfg.Verbose || strings.EqualFold(cfg.LogLevel, "debug")) && cfg.Command != "mcp" {
 		logger.Logger.SetOutput(io.MultiWriter(os.Stdout, logger.Logger.Writer()))
 		logger.Logger.SetPrefix("[DEBUG] ")
 	}
 
 	ctx := context.Background()
 
-	prov, err := initProvider(ctx, cfg)
+	shutdownTelemetry, err := telemetry.Setup(ctx, cfg.OTLPEndpoint)
 	if err != nil {
+		log.Fatalf("failed to set up telemetry export: %v", err)
+	}
+	defer func() {
+		if err := shutdownTelemetry(context.Background()); err != nil {
+			logger.Logger.Printf("Error flushing telemetry: %v", err)
+		}
+	}()
+
+	// Responses recorded with --record are written out on exit
+	defer provider.FlushRecordings()
+
+	prov, err := initProvider(ctx, cfg)
+	var credsErr *provider.CredentialsError
+	switch {
+	case errors.As(err, &credsErr) && cfg.Command == "":
+		// Guide the user through logging in rather than exiting
+		bus := ui.NewBus()
+		runTUI(ui.NewCredentialsPrompt(cfg, bus, credsErr, func() (provider.Provider, error) {
+			return initProvider(ctx, cfg)
+		}), bus, shutdownTelemetry)
+		return
+	case err != nil:
 		log.Fatalf("failed to initialize provider: %v", err)
 	}
 
-	model := ui.NewModel(prov, cfg.Environment)
+	switch cfg.Command {
+	case "":
+		bus := ui.NewBus()
+		runTUI(ui.NewModel(prov, cfg, bus), bus, shutdownTelemetry)
+	case "serve":
+		if err := runServe(ctx, prov, cfg); err != nil {
+			shutdownTelemetry(context.Background())
+			log.Fatalf("serve: %v", err)
+		}
+	case "mcp":
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		if err := mcp.New(prov).Serve(ctx, os.Stdin, os.Stdout); err != nil {
+			shutdownTelemetry(context.Background())
+			log.Fatalf("mcp: %v", err)
+		}
+	case "report":
+		if err := runReport(ctx, prov, cfg); err != nil {
+			shutdownTelemetry(context.Background())
+			log.Fatalf("report: %v", err)
+		}
+	}
+}
+
+// runTUI runs the interactive terminal UI, delivering the messages published
+// on bus
+func runTUI(model tea.Model, bus *ui.Bus, shutdownTelemetry func(context.Context) error) {
 	program := tea.NewProgram(model, tea.WithAltScreen())
+	bus.Attach(program)
 
-	if _, err := program.Run(); err != nil {
+	final, err := program.Run()
+	if m, ok := final.(ui.Model); ok {
+		// An edit in progress is kept as a draft, however the program ended
+		m.SaveDraft()
+		restoreCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		m.RestorePowerTune(restoreCtx)
+		cancel()
+		sendCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		m.SendUsage(sendCtx)
+		cancel()
+	}
+	if err != nil {
+		shutdownTelemetry(context.Background())
 		log.Fatalf("failed to start TUI: %v", err)
 	}
 }
 
-// initProvider wires up the selected cloud provider implementation.
+// runServe serves the provider API until interrupted
+func runServe(ctx context.Context, prov provider.Provider, cfg *config.Config) error {
+	if !cfg.ServeAPI {
+		return fmt.Errorf("nothing to serve (use f6n serve --api)")
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv, err := server.New(prov, cfg.APIToken)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("f6n API for %s (%s) listening on http://%s/api/v1\n", strings.ToUpper(string(prov.GetProviderName())), prov.GetRegion(), cfg.Listen)
+	err = srv.ListenAndServe(ctx, cfg.Listen)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// runReport writes an inventory report to the output file or stdout
+func runReport(ctx context.Context, prov provider.Provider, cfg *config.Config) error {
+	if err := report.CheckFormat(cfg.ReportFormat); err != nil {
+		return err
+	}
+	r, err := report.Build(ctx, prov, cfg.ReportWindow)
+	if err != nil {
+		return err
+	}
+
+	if cfg.ReportOutput == "" {
+		return report.Render(os.Stdout, r, cfg.ReportFormat)
+	}
+	f, err := os.Create(cfg.ReportOutput)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", cfg.ReportOutput, err)
+	}
+	if err := report.Render(f, r, cfg.ReportFormat); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", cfg.ReportOutput, err)
+	}
+	fmt.Fprintf(os.Stderr, "Report for %d functions written to %s\n", len(r.Functions), cfg.ReportOutput)
+	return nil
+}
+
+// initProvider wires up the selected cloud provider implementation, or the
+// recording to replay, and records its responses when asked to
 func initProvider(ctx context.Context, cfg *config.Config) (provider.Provider, error) {
-	switch strings.ToLower(cfg.Provider) {
-	case "aws", "":
-		lambdaClient, err := aws.NewLambdaClient(ctx, cfg.Region, cfg.Profile)
+	if cfg.ReplayPath != "" {
+		if cfg.RecordPath != "" || cfg.Demo {
+			return nil, fmt.Errorf("--replay cannot be combined with --record or --demo")
+		}
+		rec, err := provider.LoadRecording(cfg.ReplayPath)
 		if err != nil {
-			return nil, fmt.Errorf("unable to create AWS Lambda client: %w", err)
+			return nil, err
 		}
+		return provider.NewReplayProvider(rec), nil
+	}
 
-		stsClient, err := aws.NewStsClient(ctx, cfg.Region, cfg.Profile)
-		if err != nil {
-			return nil, fmt.Errorf("unable to create AWS STS client: %w", err)
+	prov, err := newProvider(ctx, cfg)
+	if err != nil || cfg.RecordPath == "" {
+		return prov, err
+	}
+	return provider.NewRecordingProvider(prov, cfg.RecordPath), nil
+}
+
+// newProvider creates the provider selected by --provider, or the demo provider
+func newProvider(ctx context.Context, cfg *config.Config) (provider.Provider, error) {
+	if cfg.Demo {
+		return provider.NewDemoProvider(), nil
+	}
+
+	switch strings.ToLower(cfg.Provider) {
+	case "aws", "":
+		profiles := splitProfiles(cfg.Profile)
+		switch len(profiles) {
+		case 0:
+			return newAWSProvider(ctx, cfg.Region, "", cfg.EndpointURL)
+		case 1:
+			return newAWSProvider(ctx, cfg.Region, profiles[0], cfg.EndpointURL)
 		}
 
-		return provider.NewAWSProvider(lambdaClient, stsClient), nil
+		providers := make([]provider.Provider, len(profiles))
+		for i, profile := range profiles {
+			p, err := newAWSProvider(ctx, cfg.Region, profile, cfg.EndpointURL)
+			if err != nil {
+				return nil, fmt.Errorf("profile %s: %w", profile, err)
+			}
+			providers[i] = p
+		}
+		return provider.NewMultiProvider(profiles, providers), nil
 
 	case "gcp":
 		if strings.TrimSpace(cfg.GCPProject) == "" {
 			return nil, fmt.Errorf("gcp provider selected but --gcp-project / GCP_PROJECT is not set")
 		}
 
-		return provider.NewGCPProvider(cfg.GCPProject, cfg.GCPRegion, option.WithScopes(
-			"https://www.googleapis.com/auth/cloud-platform",
-		))
+		if err := provider.CheckGCPCredentials(ctx); err != nil {
+			return nil, err
+		}
+
+		return provider.NewGCPProvider(cfg.GCPProject, cfg.GCPRegion, option.WithScopes(provider.CloudPlatformScope))
+
+	case "openfaas":
+		return provider.NewOpenFaaSProvider(cfg.OpenFaaSGateway, cfg.OpenFaaSNamespace, provider.OpenFaaSAuth{
+			Username: cfg.OpenFaaSUsername,
+			Password: cfg.OpenFaaSPassword,
+			Token:    cfg.OpenFaaSToken,
+		})
+
+	case "knative":
+		return provider.NewKnativeProvider(cfg.Kubeconfig, cfg.KubeContext, cfg.KnativeNamespace)
 
 	default:
-		return nil, fmt.Errorf("unknown provider %q (expected aws or gcp)", cfg.Provider)
+		return nil, fmt.Errorf("unknown provider %q (expected aws, gcp, openfaas or knative)", cfg.Provider)
+	}
+}
+
+// splitProfiles returns the comma-separated profiles of --profile
+func splitProfiles(value string) []string {
+	var profiles []string
+	for _, profile := range strings.Split(value, ",") {
+		if profile = strings.TrimSpace(profile); profile != "" {
+			profiles = append(profiles, profile)
+		}
+	}
+	return profiles
+}
+
+// newAWSProvider creates the AWS provider for a region and credentials
+// profile, calling endpointURL instead of AWS when it is set
+func newAWSProvider(ctx context.Context, region, profile, endpointURL string) (provider.Provider, error) {
+	lambdaClient, err := aws.NewLambdaClient(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create AWS Lambda client: %w", err)
+	}
+
+	stsClient, err := aws.NewStsClient(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create AWS STS client: %w", err)
+	}
+
+	cwClient, err := aws.NewCloudWatchClient(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create AWS CloudWatch client: %w", err)
 	}
+
+	logsClient, err := aws.NewLogsClient(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create AWS CloudWatch Logs client: %w", err)
+	}
+
+	sqsClient, err := aws.NewSQSClient(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create AWS SQS client: %w", err)
+	}
+
+	iamClient, err := aws.NewIAMClient(ctx, profile, endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create AWS IAM client: %w", err)
+	}
+
+	taggingClient, err := aws.NewTaggingClient(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create AWS Resource Groups Tagging client: %w", err)
+	}
+
+	s3Client, err := aws.NewS3Client(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create AWS S3 client: %w", err)
+	}
+
+	trailClient, err := aws.NewCloudTrailClient(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create AWS CloudTrail client: %w", err)
+	}
+
+	deployClient, err := aws.NewCodeDeployClient(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create AWS CodeDeploy client: %w", err)
+	}
+
+	return provider.NewAWSProvider(provider.AWSClients{
+		Lambda:     lambdaClient,
+		STS:        stsClient,
+		CloudWatch: cwClient,
+		Logs:       logsClient,
+		SQS:        sqsClient,
+		IAM:        iamClient,
+		Tagging:    taggingClient,
+		S3:         s3Client,
+		CloudTrail: trailClient,
+		CodeDeploy: deployClient,
+	}), nil
 }
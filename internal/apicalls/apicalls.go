@@ -0,0 +1,99 @@
+// Package apicalls counts the cloud API calls f6n makes and the throttled
+// responses among them, so the header can show why refreshes are slow.
+package apicalls
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// window is the period calls are counted over
+	window = time.Minute
+	// backoffWindow is how long after a throttled response the SDKs are
+	// assumed to still be backing off and retrying
+	backoffWindow = 30 * time.Second
+)
+
+// Stats summarizes the calls of the last minute
+type Stats struct {
+	CallsPerMinute     int
+	ThrottledPerMinute int
+	// Backoff is set while calls are being retried after a throttled response
+	Backoff bool
+}
+
+// Tracker records the time of each call
+type Tracker struct {
+	mu            sync.Mutex
+	calls         []time.Time
+	throttled     []time.Time
+	lastThrottled time.Time
+}
+
+// Default is the tracker the provider clients record their calls in
+var Default = &Tracker{}
+
+// Record counts a call made now, and whether the service throttled it
+func (t *Tracker) Record(throttled bool) {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.calls = append(prune(t.calls, now), now)
+	if throttled {
+		t.throttled = append(prune(t.throttled, now), now)
+		t.lastThrottled = now
+	}
+}
+
+// Stats returns the calls and throttled responses of the last minute
+func (t *Tracker) Stats() Stats {
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.calls = prune(t.calls, now)
+	t.throttled = prune(t.throttled, now)
+	return Stats{
+		CallsPerMinute:     len(t.calls),
+		ThrottledPerMinute: len(t.throttled),
+		Backoff:            !t.lastThrottled.IsZero() && now.Sub(t.lastThrottled) < backoffWindow,
+	}
+}
+
+// prune drops the times older than the window; times are in ascending order
+func prune(times []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-window)
+	i := 0
+	for i < len(times) && times[i].Before(cutoff) {
+		i++
+	}
+	return times[i:]
+}
+
+// Record counts a call in the default tracker
+func Record(throttled bool) {
+	Default.Record(throttled)
+}
+
+// Current returns the stats of the default tracker
+func Current() Stats {
+	return Default.Stats()
+}
+
+// Transport wraps an http.RoundTripper to record each call in the default
+// tracker. isThrottled tells the service's throttling responses apart.
+func Transport(rt http.RoundTripper, isThrottled func(*http.Response) bool) http.RoundTripper {
+	return countingTransport{rt: rt, isThrottled: isThrottled}
+}
+
+type countingTransport struct {
+	rt          http.RoundTripper
+	isThrottled func(*http.Response) bool
+}
+
+func (t countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := t.rt.RoundTrip(r)
+	Record(err == nil && t.isThrottled(resp))
+	return resp, err
+}
@@ -0,0 +1,117 @@
+// Package audit keeps an append-only log of the changes made to functions.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"f6n/internal/config"
+)
+
+// Outcomes of a recorded action
+const (
+	OutcomeOK     = "ok"
+	OutcomeFailed = "failed"
+)
+
+// Entry is an action recorded in the audit log
+type Entry struct {
+	Time     time.Time `json:"time"`
+	Provider string    `json:"provider"`
+	Region   string    `json:"region,omitempty"`
+	Action   string    `json:"action"` // e.g. update-environment, replay-event, create-alarm
+	Target   string    `json:"target"` // function name
+	Detail   string    `json:"detail,omitempty"`
+	Outcome  string    `json:"outcome"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Log appends entries to a JSON Lines file, one entry per line
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+// DefaultPath returns where the audit log is kept: audit.jsonl in the state directory
+func DefaultPath() string {
+	return filepath.Join(config.StateDir(), "audit.jsonl")
+}
+
+// New creates an audit log writing to path. The file is created on the first entry.
+func New(path string) *Log {
+	return &Log{path: path}
+}
+
+// Path returns the file the log is written to
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Record appends an entry, stamping it with the current time when it has none
+func (l *Log) Record(e Entry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.path), 0700); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return f.Close()
+}
+
+// Recent returns up to limit of the latest entries, newest first. Lines that
+// cannot be parsed are skipped; a missing file yields no entries.
+func (l *Log) Recent(limit int) ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e Entry
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		entries = append(entries, e)
+		if len(entries) > limit {
+			entries = entries[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
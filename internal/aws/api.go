@@ -0,0 +1,287 @@
+package aws
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"f6n/internal/apicalls"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// apiClient is a minimal SigV4-signed client for the AWS JSON and Query
+// protocols. f6n only needs a handful of operations from services such as
+// CloudWatch and CloudWatch Logs, so this avoids pulling in a full SDK module
+// for each of them.
+type apiClient struct {
+	cfg      aws.Config
+	service  string // SigV4 signing name, e.g. "monitoring"
+	endpoint string // e.g. https://monitoring.us-east-1.amazonaws.com
+	signer   *v4.Signer
+	http     aws.HTTPClient // the config's client, which is traced
+}
+
+// apiRequestTimeout bounds a single API call, including reading the response
+const apiRequestTimeout = 30 * time.Second
+
+// APIError is an error returned by an AWS service
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s (HTTP %d)", e.Code, e.StatusCode)
+	}
+	return fmt.Sprintf("%s: %s (HTTP %d)", e.Code, e.Message, e.StatusCode)
+}
+
+// loadConfig loads the shared AWS configuration for the given region and
+// profile. A non-empty endpointURL sends every service's requests there, e.g.
+// to LocalStack; otherwise AWS_ENDPOINT_URL or the profile's endpoint_url
+// may, as read by the SDK.
+func loadConfig(ctx context.Context, region, profile, endpointURL string) (aws.Config, error) {
+	var opts []func(*config.LoadOptions) error
+
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+
+	if profile != "" {
+		opts = append(opts, config.WithSharedConfigProfile(profile))
+	}
+
+	if endpointURL != "" {
+		opts = append(opts, config.WithBaseEndpoint(endpointURL))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	cfg.HTTPClient = traceHTTPClient(cfg.HTTPClient)
+	return cfg, nil
+}
+
+// traceHTTPClient wraps the SDK's HTTP client, keeping its settings such as a
+// custom CA bundle, to record a span per AWS API call. Spans are only exported
+// when an OTLP endpoint is configured; otherwise the global tracer is a no-op.
+// Each call is also counted for the rate-limit indicator.
+func traceHTTPClient(client aws.HTTPClient) aws.HTTPClient {
+	counted := apicalls.Transport(clientTransport{client}, isThrottled)
+	return roundTripperClient{otelhttp.NewTransport(counted, otelhttp.WithSpanNameFormatter(awsSpanName))}
+}
+
+// throttlingCodes are the error codes AWS services throttle requests with
+var throttlingCodes = []string{"Throttl", "TooManyRequests", "RequestLimitExceeded", "SlowDown"}
+
+// isThrottled reports whether a response throttles the request. The JSON
+// protocol names the error in a header; the Query protocol only in the body,
+// which is read and restored for client errors.
+func isThrottled(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if resp.StatusCode != http.StatusBadRequest && resp.StatusCode != http.StatusServiceUnavailable {
+		return false
+	}
+	code := resp.Header.Get("X-Amzn-Errortype")
+	if code == "" && resp.Body != nil {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return false
+		}
+		code = string(body)
+	}
+	for _, throttling := range throttlingCodes {
+		if strings.Contains(code, throttling) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientTransport adapts an aws.HTTPClient to an http.RoundTripper
+type clientTransport struct{ client aws.HTTPClient }
+
+func (t clientTransport) RoundTrip(r *http.Request) (*http.Response, error) { return t.client.Do(r) }
+
+// roundTripperClient adapts an http.RoundTripper to an aws.HTTPClient
+type roundTripperClient struct{ rt http.RoundTripper }
+
+func (c roundTripperClient) Do(r *http.Request) (*http.Response, error) { return c.rt.RoundTrip(r) }
+
+// awsSpanName names a span after the service host and the JSON target or REST path
+func awsSpanName(_ string, r *http.Request) string {
+	service := strings.SplitN(r.URL.Host, ".", 2)[0]
+	if target := r.Header.Get("X-Amz-Target"); target != "" {
+		return "aws " + service + " " + target
+	}
+	return "aws " + service + " " + r.Method + " " + r.URL.Path
+}
+
+// newAPIClient creates a client for the regional endpoint of a service, or
+// for the config's base endpoint when one is set
+func newAPIClient(cfg aws.Config, service, endpointPrefix string) *apiClient {
+	endpoint := fmt.Sprintf("https://%s.%s.amazonaws.com", endpointPrefix, cfg.Region)
+	if cfg.BaseEndpoint != nil && *cfg.BaseEndpoint != "" {
+		endpoint = strings.TrimSuffix(*cfg.BaseEndpoint, "/")
+	}
+	return &apiClient{
+		cfg:      cfg,
+		service:  service,
+		endpoint: endpoint,
+		signer:   v4.NewSigner(),
+		http:     cfg.HTTPClient,
+	}
+}
+
+// customEndpoint reports whether requests go to a base endpoint rather than
+// AWS, e.g. LocalStack
+func (c *apiClient) customEndpoint() bool {
+	return c.cfg.BaseEndpoint != nil && *c.cfg.BaseEndpoint != ""
+}
+
+// callJSON invokes an operation using the AWS JSON protocol (e.g. jsonVersion
+// "1.1" with target "Logs_20140328.FilterLogEvents")
+func (c *apiClient) callJSON(ctx context.Context, jsonVersion, target string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", target, err)
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, "/", body, map[string]string{
+		"Content-Type": "application/x-amz-json-" + jsonVersion,
+		"X-Amz-Target": target,
+	})
+	if err != nil {
+		return err
+	}
+
+	if out == nil || len(resp) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp, out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", target, err)
+	}
+	return nil
+}
+
+// callQuery invokes an operation using the AWS Query protocol and decodes the
+// XML response into out
+func (c *apiClient) callQuery(ctx context.Context, action, version string, params url.Values, out any) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("Action", action)
+	params.Set("Version", version)
+
+	resp, err := c.do(ctx, http.MethodPost, "/", []byte(params.Encode()), map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded; charset=utf-8",
+	})
+	if err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := xml.Unmarshal(resp, out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", action, err)
+	}
+	return nil
+}
+
+// do signs and sends a request, returning the response body or an *APIError
+func (c *apiClient) do(ctx context.Context, method, path string, body []byte, headers map[string]string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s request: %w", c.service, err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	creds, err := c.cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	hash := sha256.Sum256(body)
+	if err := c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), c.service, c.cfg.Region, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign %s request: %w", c.service, err)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request failed: %w", c.service, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s response: %w", c.service, err)
+	}
+
+	if resp.StatusCode >= 300 {
+		return nil, parseAPIError(resp, data)
+	}
+	return data, nil
+}
+
+// parseAPIError extracts the error code and message from a JSON or XML error body
+func parseAPIError(resp *http.Response, data []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Code: resp.Status}
+
+	var jsonErr struct {
+		Type         string `json:"__type"`
+		Message      string `json:"message"`
+		MessageUpper string `json:"Message"`
+	}
+	if json.Unmarshal(data, &jsonErr) == nil && jsonErr.Type != "" {
+		apiErr.Code = jsonErr.Type[strings.LastIndex(jsonErr.Type, "#")+1:]
+		apiErr.Message = jsonErr.Message
+		if apiErr.Message == "" {
+			apiErr.Message = jsonErr.MessageUpper
+		}
+		return apiErr
+	}
+
+	// Query services wrap the error in <ErrorResponse>, REST-XML services don't
+	var xmlErr struct {
+		Code    string `xml:"Error>Code"`
+		Message string `xml:"Error>Message"`
+	}
+	var restErr struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if xml.Unmarshal(data, &xmlErr) == nil && xmlErr.Code != "" {
+		apiErr.Code, apiErr.Message = xmlErr.Code, xmlErr.Message
+	} else if xml.Unmarshal(data, &restErr) == nil && restErr.Code != "" {
+		apiErr.Code, apiErr.Message = restErr.Code, restErr.Message
+	} else if errType := resp.Header.Get("X-Amzn-ErrorType"); errType != "" {
+		apiErr.Code = strings.SplitN(errType, ":", 2)[0]
+	}
+	return apiErr
+}
@@ -0,0 +1,83 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestParseAPIError(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		header  http.Header
+		body    string
+		code    string
+		message string
+	}{
+		{
+			name:    "json",
+			status:  404,
+			body:    `{"__type":"ResourceNotFoundException","message":"Function not found: orders"}`,
+			code:    "ResourceNotFoundException",
+			message: "Function not found: orders",
+		},
+		{
+			name:    "json with namespace",
+			status:  400,
+			body:    `{"__type":"com.amazonaws.logs#InvalidParameterException","message":"bad limit"}`,
+			code:    "InvalidParameterException",
+			message: "bad limit",
+		},
+		{
+			name:    "json capitalized message",
+			status:  429,
+			body:    `{"__type":"TooManyRequestsException","Message":"Rate exceeded"}`,
+			code:    "TooManyRequestsException",
+			message: "Rate exceeded",
+		},
+		{
+			name:   "query xml",
+			status: 403,
+			body: `<ErrorResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <Error><Type>Sender</Type><Code>AccessDenied</Code><Message>Not authorized</Message></Error>
+  <RequestId>1234</RequestId>
+</ErrorResponse>`,
+			code:    "AccessDenied",
+			message: "Not authorized",
+		},
+		{
+			name:    "rest xml",
+			status:  404,
+			body:    `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`,
+			code:    "NoSuchKey",
+			message: "The specified key does not exist.",
+		},
+		{
+			name:   "error type header",
+			status: 400,
+			header: http.Header{"X-Amzn-Errortype": {"ValidationException:http://internal.amazon.com/coral/validate/"}},
+			body:   `{}`,
+			code:   "ValidationException",
+		},
+		{
+			name:   "unknown body",
+			status: 502,
+			body:   `Bad Gateway`,
+			code:   "502 Bad Gateway",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := tt.header
+			if header == nil {
+				header = http.Header{}
+			}
+			resp := &http.Response{StatusCode: tt.status, Status: fmt.Sprintf("%d %s", tt.status, http.StatusText(tt.status)), Header: header}
+			err := parseAPIError(resp, []byte(tt.body))
+			if err.StatusCode != tt.status || err.Code != tt.code || err.Message != tt.message {
+				t.Errorf("parseAPIError = {%d %q %q}, want {%d %q %q}", err.StatusCode, err.Code, err.Message, tt.status, tt.code, tt.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// cloudTrailTargetPrefix is the JSON protocol target prefix for CloudTrail
+const cloudTrailTargetPrefix = "com.amazonaws.cloudtrail.v20131101.CloudTrail_20131101."
+
+// maxLookupPages bounds how many LookupEvents pages are read per call, as
+// CloudTrail only allows two lookups per second
+const maxLookupPages = 5
+
+// CloudTrailClient wraps the CloudTrail event history API
+type CloudTrailClient struct {
+	api *apiClient
+}
+
+// NewCloudTrailClient creates a new CloudTrail client
+func NewCloudTrailClient(ctx context.Context, region, profile, endpointURL string) (*CloudTrailClient, error) {
+	cfg, err := loadConfig(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CloudTrailClient{
+		api: newAPIClient(cfg, "cloudtrail", "cloudtrail"),
+	}, nil
+}
+
+// Region returns the region the client looks up events in
+func (c *CloudTrailClient) Region() string {
+	return c.api.cfg.Region
+}
+
+// TrailEvent is a management event recorded in the CloudTrail event history
+type TrailEvent struct {
+	ID       string
+	Name     string // e.g. UpdateFunctionCode20150331v2
+	Time     time.Time
+	Username string
+	Detail   string // the full event record, as JSON
+}
+
+type lookupAttribute struct {
+	AttributeKey   string `json:"AttributeKey"`
+	AttributeValue string `json:"AttributeValue"`
+}
+
+type lookupEventsRequest struct {
+	LookupAttributes []lookupAttribute `json:"LookupAttributes"`
+	StartTime        int64             `json:"StartTime,omitempty"`
+	MaxResults       int               `json:"MaxResults,omitempty"`
+	NextToken        string            `json:"NextToken,omitempty"`
+}
+
+type lookupEventsResponse struct {
+	Events []struct {
+		EventID         string  `json:"EventId"`
+		EventName       string  `json:"EventName"`
+		EventTime       float64 `json:"EventTime"`
+		Username        string  `json:"Username"`
+		CloudTrailEvent string  `json:"CloudTrailEvent"`
+	} `json:"Events"`
+	NextToken string `json:"NextToken"`
+}
+
+// LookupResourceEvents returns the events on a resource since the given
+// time, most recent first, stopping once keep returned true for limit events
+func (c *CloudTrailClient) LookupResourceEvents(ctx context.Context, resourceName string, since time.Time, limit int, keep func(TrailEvent) bool) ([]TrailEvent, error) {
+	req := lookupEventsRequest{
+		LookupAttributes: []lookupAttribute{{AttributeKey: "ResourceName", AttributeValue: resourceName}},
+		MaxResults:       50,
+	}
+	if !since.IsZero() {
+		req.StartTime = since.Unix()
+	}
+
+	var events []TrailEvent
+	for page := 0; page < maxLookupPages; page++ {
+		var resp lookupEventsResponse
+		if err := c.api.callJSON(ctx, "1.1", cloudTrailTargetPrefix+"LookupEvents", req, &resp); err != nil {
+			return nil, fmt.Errorf("failed to look up CloudTrail events: %w", err)
+		}
+
+		for _, e := range resp.Events {
+			event := TrailEvent{
+				ID:       e.EventID,
+				Name:     e.EventName,
+				Time:     time.UnixMilli(int64(e.EventTime * 1000)),
+				Username: e.Username,
+				Detail:   e.CloudTrailEvent,
+			}
+			if !keep(event) {
+				continue
+			}
+			events = append(events, event)
+			if len(events) >= limit {
+				return events, nil
+			}
+		}
+
+		if resp.NextToken == "" {
+			break
+		}
+		req.NextToken = resp.NextToken
+	}
+	return events, nil
+}
@@ -0,0 +1,237 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// cloudWatchAPIVersion is the CloudWatch Query API version
+const cloudWatchAPIVersion = "2010-08-01"
+
+// CloudWatchClient wraps the CloudWatch metrics and alarms API
+type CloudWatchClient struct {
+	api *apiClient
+}
+
+// NewCloudWatchClient creates a new CloudWatch client
+func NewCloudWatchClient(ctx context.Context, region, profile, endpointURL string) (*CloudWatchClient, error) {
+	cfg, err := loadConfig(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CloudWatchClient{
+		api: newAPIClient(cfg, "monitoring", "monitoring"),
+	}, nil
+}
+
+// MetricAlarmInput describes a CloudWatch alarm on a Lambda function metric
+type MetricAlarmInput struct {
+	AlarmName          string
+	FunctionName       string
+	MetricName         string // e.g. Errors, Throttles, Duration
+	Statistic          string // Sum, Average, Maximum, Minimum or SampleCount
+	ComparisonOperator string // e.g. GreaterThanOrEqualToThreshold
+	Threshold          float64
+	EvaluationPeriods  int32
+	PeriodSeconds      int32
+	AlarmActions       []string // SNS topic ARNs notified when the alarm fires
+}
+
+// PutMetricAlarm creates or updates a metric alarm in the AWS/Lambda namespace
+func (c *CloudWatchClient) PutMetricAlarm(ctx context.Context, in MetricAlarmInput) error {
+	params := url.Values{}
+	params.Set("AlarmName", in.AlarmName)
+	params.Set("Namespace", "AWS/Lambda")
+	params.Set("MetricName", in.MetricName)
+	params.Set("Dimensions.member.1.Name", "FunctionName")
+	params.Set("Dimensions.member.1.Value", in.FunctionName)
+	params.Set("Statistic", in.Statistic)
+	params.Set("ComparisonOperator", in.ComparisonOperator)
+	params.Set("Threshold", strconv.FormatFloat(in.Threshold, 'f', -1, 64))
+	params.Set("EvaluationPeriods", strconv.Itoa(int(in.EvaluationPeriods)))
+	params.Set("Period", strconv.Itoa(int(in.PeriodSeconds)))
+	params.Set("TreatMissingData", "notBreaching")
+	for i, action := range in.AlarmActions {
+		params.Set(fmt.Sprintf("AlarmActions.member.%d", i+1), action)
+	}
+
+	if err := c.api.callQuery(ctx, "PutMetricAlarm", cloudWatchAPIVersion, params, nil); err != nil {
+		return fmt.Errorf("failed to put metric alarm %s: %w", in.AlarmName, err)
+	}
+	return nil
+}
+
+// maxMetricDataQueries is the GetMetricData limit on queries per request
+const maxMetricDataQueries = 500
+
+// MetricDataQuery requests one statistic of a metric
+type MetricDataQuery struct {
+	ID            string // unique, must start with a lowercase letter
+	Namespace     string // defaults to AWS/Lambda
+	MetricName    string // e.g. Duration, Invocations
+	Dimensions    map[string]string
+	Stat          string // e.g. Maximum, Sum, p99
+	PeriodSeconds int32
+}
+
+// LambdaMetricQuery returns a query for a metric of a Lambda function
+func LambdaMetricQuery(id, functionName, metricName, stat string, periodSeconds int32) MetricDataQuery {
+	return MetricDataQuery{
+		ID:            id,
+		MetricName:    metricName,
+		Dimensions:    map[string]string{"FunctionName": functionName},
+		Stat:          stat,
+		PeriodSeconds: periodSeconds,
+	}
+}
+
+// MetricDataResult is the time series returned for a MetricDataQuery
+type MetricDataResult struct {
+	ID         string
+	Timestamps []time.Time
+	Values     []float64
+}
+
+type getMetricDataResponse struct {
+	Results []struct {
+		ID         string      `xml:"Id"`
+		Timestamps []time.Time `xml:"Timestamps>member"`
+		Values     []float64   `xml:"Values>member"`
+	} `xml:"GetMetricDataResult>MetricDataResults>member"`
+	NextToken string `xml:"GetMetricDataResult>NextToken"`
+}
+
+// GetMetricData fetches metric statistics for many queries at once,
+// splitting the queries into batches as required by the API
+func (c *CloudWatchClient) GetMetricData(ctx context.Context, queries []MetricDataQuery, start, end time.Time) ([]MetricDataResult, error) {
+	byID := map[string]int{}
+	var results []MetricDataResult
+
+	for first := 0; first < len(queries); first += maxMetricDataQueries {
+		batch := queries[first:min(first+maxMetricDataQueries, len(queries))]
+
+		params := url.Values{}
+		params.Set("StartTime", start.UTC().Format(time.RFC3339))
+		params.Set("EndTime", end.UTC().Format(time.RFC3339))
+		params.Set("ScanBy", "TimestampAscending")
+		for i, q := range batch {
+			prefix := fmt.Sprintf("MetricDataQueries.member.%d.", i+1)
+			namespace := q.Namespace
+			if namespace == "" {
+				namespace = "AWS/Lambda"
+			}
+			params.Set(prefix+"Id", q.ID)
+			params.Set(prefix+"MetricStat.Metric.Namespace", namespace)
+			params.Set(prefix+"MetricStat.Metric.MetricName", q.MetricName)
+			d := 1
+			for name, value := range q.Dimensions {
+				params.Set(fmt.Sprintf("%sMetricStat.Metric.Dimensions.member.%d.Name", prefix, d), name)
+				params.Set(fmt.Sprintf("%sMetricStat.Metric.Dimensions.member.%d.Value", prefix, d), value)
+				d++
+			}
+			params.Set(prefix+"MetricStat.Period", strconv.Itoa(int(q.PeriodSeconds)))
+			params.Set(prefix+"MetricStat.Stat", q.Stat)
+		}
+
+		for {
+			var resp getMetricDataResponse
+			if err := c.api.callQuery(ctx, "GetMetricData", cloudWatchAPIVersion, params, &resp); err != nil {
+				return nil, fmt.Errorf("failed to get metric data: %w", err)
+			}
+
+			// Paginated responses repeat the query IDs with further datapoints
+			for _, r := range resp.Results {
+				i, ok := byID[r.ID]
+				if !ok {
+					i = len(results)
+					byID[r.ID] = i
+					results = append(results, MetricDataResult{ID: r.ID})
+				}
+				results[i].Timestamps = append(results[i].Timestamps, r.Timestamps...)
+				results[i].Values = append(results[i].Values, r.Values...)
+			}
+
+			if resp.NextToken == "" {
+				break
+			}
+			params.Set("NextToken", resp.NextToken)
+		}
+	}
+
+	return results, nil
+}
+
+// Alarm is a CloudWatch metric alarm
+type Alarm struct {
+	Name       string
+	Namespace  string
+	MetricName string
+	StateValue string // OK, ALARM or INSUFFICIENT_DATA
+	Dimensions map[string]string
+}
+
+type describeAlarmsResponse struct {
+	Alarms []struct {
+		Name       string `xml:"AlarmName"`
+		Namespace  string `xml:"Namespace"`
+		MetricName string `xml:"MetricName"`
+		StateValue string `xml:"StateValue"`
+		Dimensions []struct {
+			Name  string `xml:"Name"`
+			Value string `xml:"Value"`
+		} `xml:"Dimensions>member"`
+	} `xml:"DescribeAlarmsResult>MetricAlarms>member"`
+	NextToken string `xml:"DescribeAlarmsResult>NextToken"`
+}
+
+// DescribeAlarmsInState lists the metric alarms currently in the given state
+func (c *CloudWatchClient) DescribeAlarmsInState(ctx context.Context, state string) ([]Alarm, error) {
+	params := url.Values{}
+	params.Set("StateValue", state)
+	params.Set("AlarmTypes.member.1", "MetricAlarm")
+	return c.describeAlarms(ctx, params)
+}
+
+// DescribeAlarmsNamed returns the metric alarms with the given names, up to 100
+func (c *CloudWatchClient) DescribeAlarmsNamed(ctx context.Context, names []string) ([]Alarm, error) {
+	params := url.Values{}
+	for i, name := range names {
+		params.Set(fmt.Sprintf("AlarmNames.member.%d", i+1), name)
+	}
+	params.Set("AlarmTypes.member.1", "MetricAlarm")
+	return c.describeAlarms(ctx, params)
+}
+
+// describeAlarms pages through DescribeAlarms
+func (c *CloudWatchClient) describeAlarms(ctx context.Context, params url.Values) ([]Alarm, error) {
+	var alarms []Alarm
+	for {
+		var resp describeAlarmsResponse
+		if err := c.api.callQuery(ctx, "DescribeAlarms", cloudWatchAPIVersion, params, &resp); err != nil {
+			return nil, fmt.Errorf("failed to describe alarms: %w", err)
+		}
+
+		for _, a := range resp.Alarms {
+			alarm := Alarm{
+				Name:       a.Name,
+				Namespace:  a.Namespace,
+				MetricName: a.MetricName,
+				StateValue: a.StateValue,
+				Dimensions: make(map[string]string, len(a.Dimensions)),
+			}
+			for _, d := range a.Dimensions {
+				alarm.Dimensions[d.Name] = d.Value
+			}
+			alarms = append(alarms, alarm)
+		}
+
+		if resp.NextToken == "" {
+			return alarms, nil
+		}
+		params.Set("NextToken", resp.NextToken)
+	}
+}
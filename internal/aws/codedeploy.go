@@ -0,0 +1,226 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// codeDeployTargetPrefix is the JSON protocol target prefix for CodeDeploy
+const codeDeployTargetPrefix = "CodeDeploy_20141006."
+
+// CodeDeployClient wraps the CodeDeploy API, which SAM and CDK gradual
+// deployments use to shift a Lambda alias to a new version
+type CodeDeployClient struct {
+	api *apiClient
+}
+
+// NewCodeDeployClient creates a new CodeDeploy client
+func NewCodeDeployClient(ctx context.Context, region, profile, endpointURL string) (*CodeDeployClient, error) {
+	cfg, err := loadConfig(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CodeDeployClient{
+		api: newAPIClient(cfg, "codedeploy", "codedeploy"),
+	}, nil
+}
+
+// Region returns the region the client reads deployments in
+func (c *CodeDeployClient) Region() string {
+	return c.api.cfg.Region
+}
+
+// CodeDeployDeployment is a CodeDeploy deployment
+type CodeDeployDeployment struct {
+	ID              string
+	Application     string
+	DeploymentGroup string
+	Config          string // e.g. CodeDeployDefault.LambdaCanary10Percent5Minutes
+	Status          string // Created, Queued, InProgress, Ready, Succeeded, Failed or Stopped
+	ComputePlatform string // Lambda, Server or ECS
+	Created         time.Time
+	Error           string
+}
+
+// LambdaDeploymentTarget is the alias a Lambda deployment shifts traffic on
+type LambdaDeploymentTarget struct {
+	FunctionName   string
+	Alias          string
+	CurrentVersion string
+	TargetVersion  string
+	TargetWeight   float64 // share of the alias's traffic on the target version, 0 to 1
+	Status         string
+}
+
+// DeploymentGroupSettings are the rollback settings of a deployment group
+type DeploymentGroupSettings struct {
+	Alarms         []string // alarms stopping the deployment when they fire
+	AlarmsEnabled  bool
+	AutoRollback   bool
+	RollbackEvents []string // e.g. DEPLOYMENT_FAILURE, DEPLOYMENT_STOP_ON_ALARM
+}
+
+// ActiveDeploymentIDs lists the deployments that have not finished yet
+func (c *CodeDeployClient) ActiveDeploymentIDs(ctx context.Context) ([]string, error) {
+	req := struct {
+		IncludeOnlyStatuses []string `json:"includeOnlyStatuses"`
+		NextToken           string   `json:"nextToken,omitempty"`
+	}{IncludeOnlyStatuses: []string{"Created", "Queued", "InProgress", "Ready"}}
+
+	var ids []string
+	for {
+		var resp struct {
+			Deployments []string `json:"deployments"`
+			NextToken   string   `json:"nextToken"`
+		}
+		if err := c.api.callJSON(ctx, "1.1", codeDeployTargetPrefix+"ListDeployments", req, &resp); err != nil {
+			return nil, fmt.Errorf("failed to list deployments: %w", err)
+		}
+		ids = append(ids, resp.Deployments...)
+		if resp.NextToken == "" {
+			return ids, nil
+		}
+		req.NextToken = resp.NextToken
+	}
+}
+
+type deploymentInfo struct {
+	DeploymentID        string  `json:"deploymentId"`
+	ApplicationName     string  `json:"applicationName"`
+	DeploymentGroupName string  `json:"deploymentGroupName"`
+	DeploymentConfig    string  `json:"deploymentConfigName"`
+	Status              string  `json:"status"`
+	ComputePlatform     string  `json:"computePlatform"`
+	CreateTime          float64 `json:"createTime"`
+	ErrorInformation    *struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"errorInformation"`
+}
+
+// GetDeployments describes deployments, 25 at most per request
+func (c *CodeDeployClient) GetDeployments(ctx context.Context, ids []string) ([]CodeDeployDeployment, error) {
+	var deployments []CodeDeployDeployment
+	for start := 0; start < len(ids); start += 25 {
+		req := struct {
+			DeploymentIDs []string `json:"deploymentIds"`
+		}{ids[start:min(start+25, len(ids))]}
+		var resp struct {
+			DeploymentsInfo []deploymentInfo `json:"deploymentsInfo"`
+		}
+		if err := c.api.callJSON(ctx, "1.1", codeDeployTargetPrefix+"BatchGetDeployments", req, &resp); err != nil {
+			return nil, fmt.Errorf("failed to get deployments: %w", err)
+		}
+
+		for _, d := range resp.DeploymentsInfo {
+			deployment := CodeDeployDeployment{
+				ID:              d.DeploymentID,
+				Application:     d.ApplicationName,
+				DeploymentGroup: d.DeploymentGroupName,
+				Config:          d.DeploymentConfig,
+				Status:          d.Status,
+				ComputePlatform: d.ComputePlatform,
+				Created:         time.UnixMilli(int64(d.CreateTime * 1000)),
+			}
+			if d.ErrorInformation != nil {
+				deployment.Error = fmt.Sprintf("%s: %s", d.ErrorInformation.Code, d.ErrorInformation.Message)
+			}
+			deployments = append(deployments, deployment)
+		}
+	}
+	return deployments, nil
+}
+
+// LambdaTargets returns the aliases a Lambda deployment shifts
+func (c *CodeDeployClient) LambdaTargets(ctx context.Context, deploymentID string) ([]LambdaDeploymentTarget, error) {
+	var list struct {
+		TargetIDs []string `json:"targetIds"`
+	}
+	req := struct {
+		DeploymentID string `json:"deploymentId"`
+	}{deploymentID}
+	if err := c.api.callJSON(ctx, "1.1", codeDeployTargetPrefix+"ListDeploymentTargets", req, &list); err != nil {
+		return nil, fmt.Errorf("failed to list deployment targets: %w", err)
+	}
+	if len(list.TargetIDs) == 0 {
+		return nil, nil
+	}
+
+	batch := struct {
+		DeploymentID string   `json:"deploymentId"`
+		TargetIDs    []string `json:"targetIds"`
+	}{deploymentID, list.TargetIDs}
+	var resp struct {
+		DeploymentTargets []struct {
+			LambdaTarget *struct {
+				Status             string `json:"status"`
+				LambdaFunctionInfo *struct {
+					FunctionName        string  `json:"functionName"`
+					FunctionAlias       string  `json:"functionAlias"`
+					CurrentVersion      string  `json:"currentVersion"`
+					TargetVersion       string  `json:"targetVersion"`
+					TargetVersionWeight float64 `json:"targetVersionWeight"`
+				} `json:"lambdaFunctionInfo"`
+			} `json:"lambdaTarget"`
+		} `json:"deploymentTargets"`
+	}
+	if err := c.api.callJSON(ctx, "1.1", codeDeployTargetPrefix+"BatchGetDeploymentTargets", batch, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get deployment targets: %w", err)
+	}
+
+	var targets []LambdaDeploymentTarget
+	for _, t := range resp.DeploymentTargets {
+		if t.LambdaTarget == nil || t.LambdaTarget.LambdaFunctionInfo == nil {
+			continue
+		}
+		info := t.LambdaTarget.LambdaFunctionInfo
+		targets = append(targets, LambdaDeploymentTarget{
+			FunctionName:   info.FunctionName,
+			Alias:          info.FunctionAlias,
+			CurrentVersion: info.CurrentVersion,
+			TargetVersion:  info.TargetVersion,
+			TargetWeight:   info.TargetVersionWeight,
+			Status:         t.LambdaTarget.Status,
+		})
+	}
+	return targets, nil
+}
+
+// GetDeploymentGroupSettings returns the rollback alarms and automatic
+// rollback settings of a deployment group
+func (c *CodeDeployClient) GetDeploymentGroupSettings(ctx context.Context, application, group string) (*DeploymentGroupSettings, error) {
+	req := struct {
+		ApplicationName     string `json:"applicationName"`
+		DeploymentGroupName string `json:"deploymentGroupName"`
+	}{application, group}
+	var resp struct {
+		DeploymentGroupInfo struct {
+			AlarmConfiguration struct {
+				Enabled bool `json:"enabled"`
+				Alarms  []struct {
+					Name string `json:"name"`
+				} `json:"alarms"`
+			} `json:"alarmConfiguration"`
+			AutoRollbackConfiguration struct {
+				Enabled bool     `json:"enabled"`
+				Events  []string `json:"events"`
+			} `json:"autoRollbackConfiguration"`
+		} `json:"deploymentGroupInfo"`
+	}
+	if err := c.api.callJSON(ctx, "1.1", codeDeployTargetPrefix+"GetDeploymentGroup", req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get deployment group %s: %w", group, err)
+	}
+
+	info := resp.DeploymentGroupInfo
+	settings := &DeploymentGroupSettings{
+		AlarmsEnabled:  info.AlarmConfiguration.Enabled,
+		AutoRollback:   info.AutoRollbackConfiguration.Enabled,
+		RollbackEvents: info.AutoRollbackConfiguration.Events,
+	}
+	for _, alarm := range info.AlarmConfiguration.Alarms {
+		settings.Alarms = append(settings.Alarms, alarm.Name)
+	}
+	return settings, nil
+}
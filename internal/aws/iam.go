@@ -0,0 +1,180 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// iamAPIVersion is the IAM Query API version
+const iamAPIVersion = "2010-05-08"
+
+// IAMClient reads the policies of IAM roles
+type IAMClient struct {
+	api *apiClient
+}
+
+// NewIAMClient creates a new IAM client. IAM is a global service, so requests
+// go to the global endpoint, or the base endpoint, signed for us-east-1
+// whatever the region.
+func NewIAMClient(ctx context.Context, profile, endpointURL string) (*IAMClient, error) {
+	cfg, err := loadConfig(ctx, "us-east-1", profile, endpointURL)
+	if err != nil {
+		return nil, err
+	}
+
+	api := newAPIClient(cfg, "iam", "iam")
+	if !api.customEndpoint() {
+		api.endpoint = "https://iam.amazonaws.com"
+	}
+	return &IAMClient{api: api}, nil
+}
+
+// AttachedPolicy is a managed policy attached to a role
+type AttachedPolicy struct {
+	Name string
+	ARN  string
+}
+
+type listAttachedRolePoliciesResponse struct {
+	Policies []struct {
+		Name string `xml:"PolicyName"`
+		ARN  string `xml:"PolicyArn"`
+	} `xml:"ListAttachedRolePoliciesResult>AttachedPolicies>member"`
+	IsTruncated bool   `xml:"ListAttachedRolePoliciesResult>IsTruncated"`
+	Marker      string `xml:"ListAttachedRolePoliciesResult>Marker"`
+}
+
+// ListAttachedRolePolicies lists the managed policies attached to a role
+func (c *IAMClient) ListAttachedRolePolicies(ctx context.Context, roleName string) ([]AttachedPolicy, error) {
+	params := url.Values{}
+	params.Set("RoleName", roleName)
+
+	var policies []AttachedPolicy
+	for {
+		var resp listAttachedRolePoliciesResponse
+		if err := c.api.callQuery(ctx, "ListAttachedRolePolicies", iamAPIVersion, params, &resp); err != nil {
+			return nil, fmt.Errorf("failed to list policies attached to role %s: %w", roleName, err)
+		}
+		for _, p := range resp.Policies {
+			policies = append(policies, AttachedPolicy{Name: p.Name, ARN: p.ARN})
+		}
+		if !resp.IsTruncated {
+			return policies, nil
+		}
+		params.Set("Marker", resp.Marker)
+	}
+}
+
+type listRolePoliciesResponse struct {
+	Names       []string `xml:"ListRolePoliciesResult>PolicyNames>member"`
+	IsTruncated bool     `xml:"ListRolePoliciesResult>IsTruncated"`
+	Marker      string   `xml:"ListRolePoliciesResult>Marker"`
+}
+
+// ListRolePolicies lists the names of a role's inline policies
+func (c *IAMClient) ListRolePolicies(ctx context.Context, roleName string) ([]string, error) {
+	params := url.Values{}
+	params.Set("RoleName", roleName)
+
+	var names []string
+	for {
+		var resp listRolePoliciesResponse
+		if err := c.api.callQuery(ctx, "ListRolePolicies", iamAPIVersion, params, &resp); err != nil {
+			return nil, fmt.Errorf("failed to list inline policies of role %s: %w", roleName, err)
+		}
+		names = append(names, resp.Names...)
+		if !resp.IsTruncated {
+			return names, nil
+		}
+		params.Set("Marker", resp.Marker)
+	}
+}
+
+// GetRolePolicy returns the JSON document of an inline role policy
+func (c *IAMClient) GetRolePolicy(ctx context.Context, roleName, policyName string) (string, error) {
+	params := url.Values{}
+	params.Set("RoleName", roleName)
+	params.Set("PolicyName", policyName)
+
+	var resp struct {
+		Document string `xml:"GetRolePolicyResult>PolicyDocument"`
+	}
+	if err := c.api.callQuery(ctx, "GetRolePolicy", iamAPIVersion, params, &resp); err != nil {
+		return "", fmt.Errorf("failed to get policy %s of role %s: %w", policyName, roleName, err)
+	}
+	// Policy documents are URL-encoded in IAM responses
+	return url.QueryUnescape(resp.Document)
+}
+
+// GetManagedPolicyDocument returns the JSON document of the default version
+// of a managed policy
+func (c *IAMClient) GetManagedPolicyDocument(ctx context.Context, policyARN string) (string, error) {
+	params := url.Values{}
+	params.Set("PolicyArn", policyARN)
+
+	var policy struct {
+		DefaultVersionID string `xml:"GetPolicyResult>Policy>DefaultVersionId"`
+	}
+	if err := c.api.callQuery(ctx, "GetPolicy", iamAPIVersion, params, &policy); err != nil {
+		return "", fmt.Errorf("failed to get policy %s: %w", policyARN, err)
+	}
+
+	params.Set("VersionId", policy.DefaultVersionID)
+	var version struct {
+		Document string `xml:"GetPolicyVersionResult>PolicyVersion>Document"`
+	}
+	if err := c.api.callQuery(ctx, "GetPolicyVersion", iamAPIVersion, params, &version); err != nil {
+		return "", fmt.Errorf("failed to get version %s of policy %s: %w", policy.DefaultVersionID, policyARN, err)
+	}
+	return url.QueryUnescape(version.Document)
+}
+
+// GetRoleARN returns the ARN of a role, including its path
+func (c *IAMClient) GetRoleARN(ctx context.Context, roleName string) (string, error) {
+	params := url.Values{}
+	params.Set("RoleName", roleName)
+
+	var resp struct {
+		ARN string `xml:"GetRoleResult>Role>Arn"`
+	}
+	if err := c.api.callQuery(ctx, "GetRole", iamAPIVersion, params, &resp); err != nil {
+		return "", fmt.Errorf("failed to get role %s: %w", roleName, err)
+	}
+	return resp.ARN, nil
+}
+
+type simulatePrincipalPolicyResponse struct {
+	Results []struct {
+		Action   string `xml:"EvalActionName"`
+		Decision string `xml:"EvalDecision"`
+	} `xml:"SimulatePrincipalPolicyResult>EvaluationResults>member"`
+	IsTruncated bool   `xml:"SimulatePrincipalPolicyResult>IsTruncated"`
+	Marker      string `xml:"SimulatePrincipalPolicyResult>Marker"`
+}
+
+// SimulatePrincipalPolicy evaluates the policies of a user or role for
+// actions on a resource, reporting whether each action is allowed
+func (c *IAMClient) SimulatePrincipalPolicy(ctx context.Context, principalARN string, actions []string, resourceARN string) (map[string]bool, error) {
+	params := url.Values{}
+	params.Set("PolicySourceArn", principalARN)
+	for i, action := range actions {
+		params.Set(fmt.Sprintf("ActionNames.member.%d", i+1), action)
+	}
+	params.Set("ResourceArns.member.1", resourceARN)
+
+	allowed := make(map[string]bool, len(actions))
+	for {
+		var resp simulatePrincipalPolicyResponse
+		if err := c.api.callQuery(ctx, "SimulatePrincipalPolicy", iamAPIVersion, params, &resp); err != nil {
+			return nil, fmt.Errorf("failed to simulate the policies of %s: %w", principalARN, err)
+		}
+		for _, r := range resp.Results {
+			allowed[r.Action] = r.Decision == "allowed"
+		}
+		if !resp.IsTruncated {
+			return allowed, nil
+		}
+		params.Set("Marker", resp.Marker)
+	}
+}
@@ -2,10 +2,14 @@ package aws
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/lambda"
 	"github.com/aws/aws-sdk-go-v2/service/lambda/types"
 )
@@ -14,28 +18,20 @@ import (
 type LambdaClient struct {
 	client *lambda.Client
 	region string
+	http   aws.HTTPClient // fetches code packages from their presigned URLs
 }
 
 // NewLambdaClient creates a new Lambda client for the specified region
-func NewLambdaClient(ctx context.Context, region, profile string) (*LambdaClient, error) {
-	var opts []func(*config.LoadOptions) error
-	
-	if region != "" {
-		opts = append(opts, config.WithRegion(region))
-	}
-	
-	if profile != "" {
-		opts = append(opts, config.WithSharedConfigProfile(profile))
-	}
-
-	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+func NewLambdaClient(ctx context.Context, region, profile, endpointURL string) (*LambdaClient, error) {
+	cfg, err := loadConfig(ctx, region, profile, endpointURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		return nil, err
 	}
 
 	return &LambdaClient{
 		client: lambda.NewFromConfig(cfg),
 		region: cfg.Region,
+		http:   cfg.HTTPClient,
 	}, nil
 }
 
@@ -79,6 +75,39 @@ func (c *LambdaClient) GetFunction(ctx context.Context, functionName string) (*l
 	return result, nil
 }
 
+// ErrPackageTooLarge is returned when a code package exceeds the size it may be fetched at
+var ErrPackageTooLarge = errors.New("code package too large")
+
+// FetchCodePackage downloads a function's deployment package from the
+// presigned URL GetFunction returns, up to maxBytes
+func (c *LambdaClient) FetchCodePackage(ctx context.Context, location string, maxBytes int64) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiRequestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid code location: %w", err)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch code package: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch code package: %s", resp.Status)
+	}
+	if resp.ContentLength > maxBytes {
+		return nil, ErrPackageTooLarge
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read code package: %w", err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, ErrPackageTooLarge
+	}
+	return data, nil
+}
+
 // GetFunctionConfiguration retrieves configuration for a specific function
 func (c *LambdaClient) GetFunctionConfiguration(ctx context.Context, functionName string) (*lambda.GetFunctionConfigurationOutput, error) {
 	input := &lambda.GetFunctionConfigurationInput{
@@ -97,3 +126,265 @@ func (c *LambdaClient) GetFunctionConfiguration(ctx context.Context, functionNam
 func (c *LambdaClient) Region() string {
 	return c.region
 }
+
+// Invoke synchronously invokes a function with the given payload
+func (c *LambdaClient) Invoke(ctx context.Context, functionName string, payload []byte) (*lambda.InvokeOutput, error) {
+	input := &lambda.InvokeInput{
+		FunctionName:   aws.String(functionName),
+		InvocationType: types.InvocationTypeRequestResponse,
+		Payload:        payload,
+	}
+
+	result, err := c.client.Invoke(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke function %s: %w", functionName, err)
+	}
+
+	return result, nil
+}
+
+// GetFunctionEventInvokeConfig retrieves the asynchronous invocation settings
+// (retries and destinations) of a function. It returns nil when none are configured.
+func (c *LambdaClient) GetFunctionEventInvokeConfig(ctx context.Context, functionName string) (*lambda.GetFunctionEventInvokeConfigOutput, error) {
+	input := &lambda.GetFunctionEventInvokeConfigInput{
+		FunctionName: aws.String(functionName),
+	}
+
+	result, err := c.client.GetFunctionEventInvokeConfig(ctx, input)
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get event invoke config %s: %w", functionName, err)
+	}
+
+	return result, nil
+}
+
+// ListEventSourceMappings retrieves the event source mappings (queue and stream triggers) of a function
+func (c *LambdaClient) ListEventSourceMappings(ctx context.Context, functionName string) ([]types.EventSourceMappingConfiguration, error) {
+	var mappings []types.EventSourceMappingConfiguration
+	var marker *string
+
+	for {
+		input := &lambda.ListEventSourceMappingsInput{
+			FunctionName: aws.String(functionName),
+			Marker:       marker,
+		}
+
+		result, err := c.client.ListEventSourceMappings(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list event source mappings for %s: %w", functionName, err)
+		}
+
+		mappings = append(mappings, result.EventSourceMappings...)
+
+		if result.NextMarker == nil {
+			break
+		}
+		marker = result.NextMarker
+	}
+
+	return mappings, nil
+}
+
+// GetReservedConcurrency returns the reserved concurrency of a function, or nil when none is set
+func (c *LambdaClient) GetReservedConcurrency(ctx context.Context, functionName string) (*int32, error) {
+	result, err := c.client.GetFunctionConcurrency(ctx, &lambda.GetFunctionConcurrencyInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get concurrency of %s: %w", functionName, err)
+	}
+
+	return result.ReservedConcurrentExecutions, nil
+}
+
+// GetAccountSettings retrieves the account's Lambda limits and usage
+func (c *LambdaClient) GetAccountSettings(ctx context.Context) (*lambda.GetAccountSettingsOutput, error) {
+	result, err := c.client.GetAccountSettings(ctx, &lambda.GetAccountSettingsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account settings: %w", err)
+	}
+
+	return result, nil
+}
+
+// ListProvisionedConcurrencyConfigs retrieves the provisioned concurrency configurations of a function
+func (c *LambdaClient) ListProvisionedConcurrencyConfigs(ctx context.Context, functionName string) ([]types.ProvisionedConcurrencyConfigListItem, error) {
+	var configs []types.ProvisionedConcurrencyConfigListItem
+	var marker *string
+
+	for {
+		result, err := c.client.ListProvisionedConcurrencyConfigs(ctx, &lambda.ListProvisionedConcurrencyConfigsInput{
+			FunctionName: aws.String(functionName),
+			Marker:       marker,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list provisioned concurrency of %s: %w", functionName, err)
+		}
+
+		configs = append(configs, result.ProvisionedConcurrencyConfigs...)
+
+		if result.NextMarker == nil {
+			break
+		}
+		marker = result.NextMarker
+	}
+
+	return configs, nil
+}
+
+// GetFunctionURLConfig returns the URL configuration of a function, or nil
+// when the function has no URL
+func (c *LambdaClient) GetFunctionURLConfig(ctx context.Context, functionName string) (*lambda.GetFunctionUrlConfigOutput, error) {
+	result, err := c.client.GetFunctionUrlConfig(ctx, &lambda.GetFunctionUrlConfigInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get function URL of %s: %w", functionName, err)
+	}
+
+	return result, nil
+}
+
+// GetFunctionURLAuthType returns the auth type (NONE or AWS_IAM) of a
+// function's URL, or "" when the function has no URL
+func (c *LambdaClient) GetFunctionURLAuthType(ctx context.Context, functionName string) (string, error) {
+	result, err := c.GetFunctionURLConfig(ctx, functionName)
+	if err != nil || result == nil {
+		return "", err
+	}
+
+	return string(result.AuthType), nil
+}
+
+// GetPolicy returns the resource-based policy document of a function, or ""
+// when it has none
+func (c *LambdaClient) GetPolicy(ctx context.Context, functionName string) (string, error) {
+	result, err := c.client.GetPolicy(ctx, &lambda.GetPolicyInput{
+		FunctionName: aws.String(functionName),
+	})
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get resource policy of %s: %w", functionName, err)
+	}
+
+	return aws.ToString(result.Policy), nil
+}
+
+// UpdateEnvironment replaces the environment variables of a function.
+// revisionID, when set, makes Lambda reject the update if the function has
+// changed since.
+func (c *LambdaClient) UpdateEnvironment(ctx context.Context, functionName string, variables map[string]string, revisionID string) error {
+	input := &lambda.UpdateFunctionConfigurationInput{
+		FunctionName: aws.String(functionName),
+		Environment:  &types.Environment{Variables: variables},
+	}
+	if revisionID != "" {
+		input.RevisionId = aws.String(revisionID)
+	}
+	_, err := c.client.UpdateFunctionConfiguration(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to update environment of %s: %w", functionName, err)
+	}
+	return nil
+}
+
+// UpdateFunctionCode deploys a new zip package to a function. revisionID is
+// as for UpdateEnvironment.
+func (c *LambdaClient) UpdateFunctionCode(ctx context.Context, functionName string, zipFile []byte, revisionID string) error {
+	input := &lambda.UpdateFunctionCodeInput{
+		FunctionName: aws.String(functionName),
+		ZipFile:      zipFile,
+	}
+	if revisionID != "" {
+		input.RevisionId = aws.String(revisionID)
+	}
+	_, err := c.client.UpdateFunctionCode(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to update code of %s: %w", functionName, err)
+	}
+	return nil
+}
+
+// UpdateDescription changes the description of a function
+func (c *LambdaClient) UpdateDescription(ctx context.Context, functionName, description string) error {
+	_, err := c.client.UpdateFunctionConfiguration(ctx, &lambda.UpdateFunctionConfigurationInput{
+		FunctionName: aws.String(functionName),
+		Description:  aws.String(description),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update description of %s: %w", functionName, err)
+	}
+	return nil
+}
+
+// UpdateLoggingConfig changes the log format, levels and group of a function
+func (c *LambdaClient) UpdateLoggingConfig(ctx context.Context, functionName string, config types.LoggingConfig) error {
+	_, err := c.client.UpdateFunctionConfiguration(ctx, &lambda.UpdateFunctionConfigurationInput{
+		FunctionName:  aws.String(functionName),
+		LoggingConfig: &config,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update logging configuration of %s: %w", functionName, err)
+	}
+	return nil
+}
+
+// memoryUpdateTimeout bounds the wait for a memory change to finish
+const memoryUpdateTimeout = 2 * time.Minute
+
+// UpdateMemory changes the memory size of a function and waits until the
+// update is done, so that invocations run with the new size. An update still
+// in progress, such as a power tuning step interrupted on exit, is waited for
+// first rather than failing with a ResourceConflictException.
+func (c *LambdaClient) UpdateMemory(ctx context.Context, functionName string, memoryMB int32) error {
+	input := &lambda.UpdateFunctionConfigurationInput{
+		FunctionName: aws.String(functionName),
+		MemorySize:   aws.Int32(memoryMB),
+	}
+	waiter := lambda.NewFunctionUpdatedV2Waiter(c.client)
+	_, err := c.client.UpdateFunctionConfiguration(ctx, input)
+	var conflict *types.ResourceConflictException
+	if errors.As(err, &conflict) {
+		// The wait fails if that update failed, which leaves the function
+		// free to update all the same
+		_ = waiter.Wait(ctx, &lambda.GetFunctionInput{FunctionName: aws.String(functionName)}, memoryUpdateTimeout)
+		_, err = c.client.UpdateFunctionConfiguration(ctx, input)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set the memory of %s to %d MB: %w", functionName, memoryMB, err)
+	}
+	if err := waiter.Wait(ctx, &lambda.GetFunctionInput{FunctionName: aws.String(functionName)}, memoryUpdateTimeout); err != nil {
+		return fmt.Errorf("waiting for the memory update of %s: %w", functionName, err)
+	}
+	return nil
+}
+
+// InvokeWithLog invokes a function synchronously, returning the last 4 KB of
+// the invocation's log along with the output
+func (c *LambdaClient) InvokeWithLog(ctx context.Context, functionName string, payload []byte) (*lambda.InvokeOutput, string, error) {
+	result, err := c.client.Invoke(ctx, &lambda.InvokeInput{
+		FunctionName:   aws.String(functionName),
+		InvocationType: types.InvocationTypeRequestResponse,
+		LogType:        types.LogTypeTail,
+		Payload:        payload,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to invoke function %s: %w", functionName, err)
+	}
+	log, err := base64.StdEncoding.DecodeString(aws.ToString(result.LogResult))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode the log of %s: %w", functionName, err)
+	}
+	return result, string(log), nil
+}
@@ -0,0 +1,176 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// logsTargetPrefix is the JSON protocol target prefix for CloudWatch Logs
+const logsTargetPrefix = "Logs_20140328."
+
+// maxFilterPages bounds how many FilterLogEvents pages are read per call
+const maxFilterPages = 20
+
+// LogsClient wraps the CloudWatch Logs API
+type LogsClient struct {
+	api *apiClient
+}
+
+// NewLogsClient creates a new CloudWatch Logs client
+func NewLogsClient(ctx context.Context, region, profile, endpointURL string) (*LogsClient, error) {
+	cfg, err := loadConfig(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LogsClient{
+		api: newAPIClient(cfg, "logs", "logs"),
+	}, nil
+}
+
+// LogEvent is a single CloudWatch Logs event
+type LogEvent struct {
+	Timestamp     time.Time
+	Message       string
+	LogStreamName string
+}
+
+// FilterLogEventsInput selects events from a log group
+type FilterLogEventsInput struct {
+	LogGroupName  string
+	StartTime     time.Time
+	EndTime       time.Time // zero means now
+	FilterPattern string    // CloudWatch Logs filter pattern, empty matches everything
+	Limit         int       // keep at most the Limit most recent events, 0 for no limit
+}
+
+// ErrLogGroupNotFound is returned when the log group does not exist, e.g.
+// because the function has never been invoked
+var ErrLogGroupNotFound = errors.New("log group not found")
+
+type filterLogEventsRequest struct {
+	LogGroupName  string `json:"logGroupName"`
+	StartTime     int64  `json:"startTime,omitempty"`
+	EndTime       int64  `json:"endTime,omitempty"`
+	FilterPattern string `json:"filterPattern,omitempty"`
+	NextToken     string `json:"nextToken,omitempty"`
+}
+
+type filterLogEventsResponse struct {
+	Events []struct {
+		Timestamp     int64  `json:"timestamp"`
+		Message       string `json:"message"`
+		LogStreamName string `json:"logStreamName"`
+	} `json:"events"`
+	NextToken string `json:"nextToken"`
+}
+
+// FilterLogEvents returns the matching events in chronological order
+func (c *LogsClient) FilterLogEvents(ctx context.Context, in FilterLogEventsInput) ([]LogEvent, error) {
+	req := filterLogEventsRequest{
+		LogGroupName:  in.LogGroupName,
+		FilterPattern: in.FilterPattern,
+	}
+	if !in.StartTime.IsZero() {
+		req.StartTime = in.StartTime.UnixMilli()
+	}
+	if !in.EndTime.IsZero() {
+		req.EndTime = in.EndTime.UnixMilli()
+	}
+
+	var events []LogEvent
+	for page := 0; page < maxFilterPages; page++ {
+		var resp filterLogEventsResponse
+		if err := c.api.callJSON(ctx, "1.1", logsTargetPrefix+"FilterLogEvents", req, &resp); err != nil {
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.Code == "ResourceNotFoundException" {
+				return nil, fmt.Errorf("%w: %s", ErrLogGroupNotFound, in.LogGroupName)
+			}
+			return nil, fmt.Errorf("failed to filter log events in %s: %w", in.LogGroupName, err)
+		}
+
+		for _, e := range resp.Events {
+			events = append(events, LogEvent{
+				Timestamp:     time.UnixMilli(e.Timestamp),
+				Message:       e.Message,
+				LogStreamName: e.LogStreamName,
+			})
+		}
+
+		if resp.NextToken == "" {
+			break
+		}
+		req.NextToken = resp.NextToken
+	}
+
+	// Pages are grouped by log stream, so order by time before keeping the
+	// most recent Limit events
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	if in.Limit > 0 && len(events) > in.Limit {
+		events = events[len(events)-in.Limit:]
+	}
+	return events, nil
+}
+
+// LogGroup is the retention and size of a log group
+type LogGroup struct {
+	Name          string
+	RetentionDays int32 // 0 when events never expire
+	StoredBytes   int64
+}
+
+type describeLogGroupsRequest struct {
+	LogGroupNamePrefix string `json:"logGroupNamePrefix"`
+}
+
+type describeLogGroupsResponse struct {
+	LogGroups []struct {
+		LogGroupName    string `json:"logGroupName"`
+		RetentionInDays int32  `json:"retentionInDays"`
+		StoredBytes     int64  `json:"storedBytes"`
+	} `json:"logGroups"`
+}
+
+// DescribeLogGroup returns the retention and size of a log group
+func (c *LogsClient) DescribeLogGroup(ctx context.Context, name string) (*LogGroup, error) {
+	var resp describeLogGroupsResponse
+	req := describeLogGroupsRequest{LogGroupNamePrefix: name}
+	if err := c.api.callJSON(ctx, "1.1", logsTargetPrefix+"DescribeLogGroups", req, &resp); err != nil {
+		return nil, fmt.Errorf("failed to describe log group %s: %w", name, err)
+	}
+	// The prefix also matches longer names, listed after the exact one
+	for _, group := range resp.LogGroups {
+		if group.LogGroupName == name {
+			return &LogGroup{Name: name, RetentionDays: group.RetentionInDays, StoredBytes: group.StoredBytes}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrLogGroupNotFound, name)
+}
+
+type retentionPolicyRequest struct {
+	LogGroupName    string `json:"logGroupName"`
+	RetentionInDays int32  `json:"retentionInDays,omitempty"`
+}
+
+// SetRetention sets how many days the events of a log group are kept, or
+// removes the retention policy when days is 0 so that they never expire
+func (c *LogsClient) SetRetention(ctx context.Context, name string, days int32) error {
+	target := "PutRetentionPolicy"
+	if days == 0 {
+		target = "DeleteRetentionPolicy"
+	}
+	req := retentionPolicyRequest{LogGroupName: name, RetentionInDays: days}
+	if err := c.api.callJSON(ctx, "1.1", logsTargetPrefix+target, req, nil); err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.Code == "ResourceNotFoundException" {
+			return fmt.Errorf("%w: %s", ErrLogGroupNotFound, name)
+		}
+		return fmt.Errorf("failed to set retention of %s: %w", name, err)
+	}
+	return nil
+}
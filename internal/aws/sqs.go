@@ -0,0 +1,139 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sqsTargetPrefix is the JSON protocol target prefix for SQS
+const sqsTargetPrefix = "AmazonSQS."
+
+// SQSClient wraps the SQS API
+type SQSClient struct {
+	api *apiClient
+}
+
+// NewSQSClient creates a new SQS client
+func NewSQSClient(ctx context.Context, region, profile, endpointURL string) (*SQSClient, error) {
+	cfg, err := loadConfig(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQSClient{
+		api: newAPIClient(cfg, "sqs", "sqs"),
+	}, nil
+}
+
+// SQSMessage is a message received from a queue
+type SQSMessage struct {
+	MessageID     string            `json:"MessageId"`
+	ReceiptHandle string            `json:"ReceiptHandle"`
+	Body          string            `json:"Body"`
+	Attributes    map[string]string `json:"Attributes"` // system attributes, e.g. SentTimestamp
+}
+
+// QueueURL resolves the URL of a queue from its ARN
+// (arn:aws:sqs:<region>:<account>:<name>)
+func (c *SQSClient) QueueURL(ctx context.Context, queueARN string) (string, error) {
+	parts := strings.Split(queueARN, ":")
+	if len(parts) != 6 || parts[2] != "sqs" {
+		return "", fmt.Errorf("not an SQS queue ARN: %s", queueARN)
+	}
+
+	// The queue may be in another region than the client
+	cfg := c.api.cfg.Copy()
+	cfg.Region = parts[3]
+	req := struct {
+		QueueName              string `json:"QueueName"`
+		QueueOwnerAWSAccountID string `json:"QueueOwnerAWSAccountId"`
+	}{parts[5], parts[4]}
+	var resp struct {
+		QueueURL string `json:"QueueUrl"`
+	}
+	if err := newAPIClient(cfg, "sqs", "sqs").callJSON(ctx, "1.0", sqsTargetPrefix+"GetQueueUrl", req, &resp); err != nil {
+		return "", fmt.Errorf("failed to get URL of queue %s: %w", parts[5], err)
+	}
+	return resp.QueueURL, nil
+}
+
+type receiveMessageRequest struct {
+	QueueURL                    string   `json:"QueueUrl"`
+	MaxNumberOfMessages         int      `json:"MaxNumberOfMessages"`
+	VisibilityTimeout           int32    `json:"VisibilityTimeout"`
+	MessageSystemAttributeNames []string `json:"MessageSystemAttributeNames"`
+}
+
+// PeekMessages receives up to max messages, hiding them from other consumers
+// for visibilitySeconds so they can be inspected
+func (c *SQSClient) PeekMessages(ctx context.Context, queueURL string, max int, visibilitySeconds int32) ([]SQSMessage, error) {
+	var messages []SQSMessage
+	for len(messages) < max {
+		req := receiveMessageRequest{
+			QueueURL:                    queueURL,
+			MaxNumberOfMessages:         min(max-len(messages), 10),
+			VisibilityTimeout:           visibilitySeconds,
+			MessageSystemAttributeNames: []string{"SentTimestamp"},
+		}
+		var resp struct {
+			Messages []SQSMessage `json:"Messages"`
+		}
+		if err := c.api.callJSON(ctx, "1.0", sqsTargetPrefix+"ReceiveMessage", req, &resp); err != nil {
+			return nil, fmt.Errorf("failed to receive messages: %w", err)
+		}
+		if len(resp.Messages) == 0 {
+			break
+		}
+		messages = append(messages, resp.Messages...)
+	}
+	return messages, nil
+}
+
+// DeleteMessage removes a received message from the queue
+func (c *SQSClient) DeleteMessage(ctx context.Context, queueURL, receiptHandle string) error {
+	req := struct {
+		QueueURL      string `json:"QueueUrl"`
+		ReceiptHandle string `json:"ReceiptHandle"`
+	}{queueURL, receiptHandle}
+	if err := c.api.callJSON(ctx, "1.0", sqsTargetPrefix+"DeleteMessage", req, nil); err != nil {
+		return fmt.Errorf("failed to delete message: %w", err)
+	}
+	return nil
+}
+
+// QueueDepth is the approximate number of messages in a queue
+type QueueDepth struct {
+	Visible  int64 // waiting to be received
+	InFlight int64 // received but not yet deleted
+	Delayed  int64
+}
+
+// GetQueueDepth returns the approximate message counts of a queue
+func (c *SQSClient) GetQueueDepth(ctx context.Context, queueURL string) (QueueDepth, error) {
+	req := struct {
+		QueueURL       string   `json:"QueueUrl"`
+		AttributeNames []string `json:"AttributeNames"`
+	}{queueURL, []string{
+		"ApproximateNumberOfMessages",
+		"ApproximateNumberOfMessagesNotVisible",
+		"ApproximateNumberOfMessagesDelayed",
+	}}
+	var resp struct {
+		Attributes map[string]string `json:"Attributes"`
+	}
+	if err := c.api.callJSON(ctx, "1.0", sqsTargetPrefix+"GetQueueAttributes", req, &resp); err != nil {
+		return QueueDepth{}, fmt.Errorf("failed to get queue attributes: %w", err)
+	}
+
+	count := func(name string) int64 {
+		n, _ := strconv.ParseInt(resp.Attributes[name], 10, 64)
+		return n
+	}
+	return QueueDepth{
+		Visible:  count("ApproximateNumberOfMessages"),
+		InFlight: count("ApproximateNumberOfMessagesNotVisible"),
+		Delayed:  count("ApproximateNumberOfMessagesDelayed"),
+	}, nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
@@ -14,20 +13,10 @@ type StsClient struct {
 }
 
 // NewStsClient creates a new STS client
-func NewStsClient(ctx context.Context, region, profile string) (*StsClient, error) {
-	var opts []func(*config.LoadOptions) error
-
-	if region != "" {
-		opts = append(opts, config.WithRegion(region))
-	}
-
-	if profile != "" {
-		opts = append(opts, config.WithSharedConfigProfile(profile))
-	}
-
-	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+func NewStsClient(ctx context.Context, region, profile, endpointURL string) (*StsClient, error) {
+	cfg, err := loadConfig(ctx, region, profile, endpointURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		return nil, err
 	}
 
 	return &StsClient{
@@ -46,3 +35,13 @@ func (c *StsClient) GetAccountID(ctx context.Context) (string, error) {
 
 	return *result.Account, nil
 }
+
+// GetCallerARN gets the ARN of the user or assumed role the credentials belong to
+func (c *StsClient) GetCallerARN(ctx context.Context) (string, error) {
+	result, err := c.client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get caller identity: %w", err)
+	}
+
+	return *result.Arn, nil
+}
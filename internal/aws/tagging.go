@@ -0,0 +1,70 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+)
+
+// taggingTargetPrefix is the JSON protocol target prefix for the Resource Groups Tagging API
+const taggingTargetPrefix = "ResourceGroupsTaggingAPI_20170126."
+
+// TaggingClient wraps the Resource Groups Tagging API, which returns the tags
+// of every function in one paginated call instead of one ListTags per function
+type TaggingClient struct {
+	api *apiClient
+}
+
+// NewTaggingClient creates a new Resource Groups Tagging API client
+func NewTaggingClient(ctx context.Context, region, profile, endpointURL string) (*TaggingClient, error) {
+	cfg, err := loadConfig(ctx, region, profile, endpointURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaggingClient{
+		api: newAPIClient(cfg, "tagging", "tagging"),
+	}, nil
+}
+
+type getResourcesRequest struct {
+	ResourceTypeFilters []string `json:"ResourceTypeFilters"`
+	PaginationToken     string   `json:"PaginationToken,omitempty"`
+}
+
+type getResourcesResponse struct {
+	ResourceTagMappingList []struct {
+		ResourceARN string `json:"ResourceARN"`
+		Tags        []struct {
+			Key   string `json:"Key"`
+			Value string `json:"Value"`
+		} `json:"Tags"`
+	} `json:"ResourceTagMappingList"`
+	PaginationToken string `json:"PaginationToken"`
+}
+
+// GetLambdaFunctionTags returns the tags of the region's Lambda functions by
+// function ARN. Functions that never had tags may be missing from the result.
+func (c *TaggingClient) GetLambdaFunctionTags(ctx context.Context) (map[string]map[string]string, error) {
+	tags := make(map[string]map[string]string)
+	req := getResourcesRequest{ResourceTypeFilters: []string{"lambda:function"}}
+
+	for {
+		var resp getResourcesResponse
+		if err := c.api.callJSON(ctx, "1.1", taggingTargetPrefix+"GetResources", req, &resp); err != nil {
+			return nil, fmt.Errorf("failed to get function tags: %w", err)
+		}
+
+		for _, r := range resp.ResourceTagMappingList {
+			m := make(map[string]string, len(r.Tags))
+			for _, t := range r.Tags {
+				m[t.Key] = t.Value
+			}
+			tags[r.ResourceARN] = m
+		}
+
+		if resp.PaginationToken == "" {
+			return tags, nil
+		}
+		req.PaginationToken = resp.PaginationToken
+	}
+}
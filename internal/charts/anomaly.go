@@ -0,0 +1,67 @@
+package charts
+
+import (
+	"fmt"
+	"math"
+
+	"f6n/internal/provider"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// anomalyWindow is the number of preceding points a point's baseline is
+	// computed over
+	anomalyWindow = 10
+	// anomalyMinBaseline is the number of preceding points needed before a
+	// point can be flagged
+	anomalyMinBaseline = 5
+	// anomalyDeviations is how many standard deviations above the baseline
+	// mean a point must be to be flagged
+	anomalyDeviations = 3.0
+	// anomalyMinSpread keeps near-constant series from flagging small changes:
+	// the spread is at least this fraction of the mean
+	anomalyMinSpread = 0.1
+)
+
+// anomalyMarker follows the values of anomalous points
+const anomalyMarker = " ▲"
+
+// anomalyStyle colors anomalous points
+var anomalyStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("208")).Bold(true)
+
+// AnomalyLegend explains the points flagged with the anomaly marker
+var AnomalyLegend = fmt.Sprintf("▲ above the rolling baseline (mean + %.0fσ of the previous %d points)", anomalyDeviations, anomalyWindow)
+
+// Anomalies flags the points that spike above the rolling baseline of the
+// points before them: their mean plus anomalyDeviations standard deviations.
+// Drops are not flagged, nor are the first points, which lack a baseline.
+func Anomalies(data []provider.MetricDataPoint) []bool {
+	flags := make([]bool, len(data))
+	for i := anomalyMinBaseline; i < len(data); i++ {
+		window := data[max(0, i-anomalyWindow):i]
+		mean := 0.0
+		for _, p := range window {
+			mean += p.Value
+		}
+		mean /= float64(len(window))
+		variance := 0.0
+		for _, p := range window {
+			variance += (p.Value - mean) * (p.Value - mean)
+		}
+		spread := max(math.Sqrt(variance/float64(len(window))), math.Abs(mean)*anomalyMinSpread)
+		flags[i] = data[i].Value > mean+anomalyDeviations*spread
+	}
+	return flags
+}
+
+// countAnomalies returns how many points are flagged
+func countAnomalies(flags []bool) int {
+	n := 0
+	for _, flagged := range flags {
+		if flagged {
+			n++
+		}
+	}
+	return n
+}
@@ -39,6 +39,7 @@ func RenderSparkline(data []provider.MetricDataPoint, width int) string {
 
 	// Calculate how many data points per character
 	pointsPerChar := float64(len(data)) / float64(width)
+	flags := Anomalies(data)
 
 	for i := 0; i < width; i++ {
 		// Get the data point index for this character position
@@ -58,6 +59,18 @@ func RenderSparkline(data []provider.MetricDataPoint, width int) string {
 			charIndex = int(normalized * float64(len(chars)-1))
 		}
 
+		// Color the character when any point it stands for is anomalous
+		last := int(float64(i+1) * pointsPerChar)
+		if last > len(data) {
+			last = len(data)
+		}
+		if last <= dataIndex {
+			last = dataIndex + 1
+		}
+		if countAnomalies(flags[dataIndex:last]) > 0 {
+			result.WriteString(anomalyStyle.Render(chars[charIndex]))
+			continue
+		}
 		result.WriteString(chars[charIndex])
 	}
 
@@ -162,6 +175,7 @@ func RenderTimeSeriesChart(data []provider.MetricDataPoint, width, height int, t
 	}
 
 	startIdx := len(data) - recentCount
+	flags := Anomalies(data)
 	for i := startIdx; i < len(data); i++ {
 		point := data[i]
 
@@ -184,12 +198,18 @@ func RenderTimeSeriesChart(data []provider.MetricDataPoint, width, height int, t
 		valueStr := fmt.Sprintf("%.1f", point.Value)
 
 		line := fmt.Sprintf("%s │%s %s", timeStr, bar, valueStr)
+		if flags[i] {
+			line = anomalyStyle.Render(line + anomalyMarker)
+		}
 		lines = append(lines, line)
 	}
 
 	// Add range info
 	lines = append(lines, "")
 	lines = append(lines, fmt.Sprintf("Range: %.1f - %.1f", min, max))
+	if countAnomalies(flags[startIdx:]) > 0 {
+		lines = append(lines, AnomalyLegend)
+	}
 
 	content := strings.Join(lines, "\n")
 	return ChartStyle.Render(content)
@@ -228,6 +248,15 @@ func RenderMetricsOverview(metrics *provider.FunctionMetrics, width int) string
 		sections = append(sections, durationChart, "")
 	}
 
+	// Errors chart, when there were any
+	if sum(metrics.Errors.DataPoints) > 0 {
+		errorsChart := RenderTimeSeriesChart(
+			metrics.Errors.DataPoints,
+			width-8, 8,
+			fmt.Sprintf("❌ %s (%s)", metrics.Errors.MetricName, metrics.Errors.Unit))
+		sections = append(sections, errorsChart, "")
+	}
+
 	// Memory chart
 	if len(metrics.Memory.DataPoints) > 0 {
 		memoryChart := RenderTimeSeriesChart(
@@ -266,3 +295,56 @@ func RenderMetricsOverview(metrics *provider.FunctionMetrics, width int) string
 
 	return strings.Join(sections, "\n")
 }
+
+// sum adds up the values of the points
+func sum(data []provider.MetricDataPoint) float64 {
+	total := 0.0
+	for _, point := range data {
+		total += point.Value
+	}
+	return total
+}
+
+// limitWarnStyle colors bars that approach the limit
+var limitWarnStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+
+// RenderLimitChart charts values against a fixed limit: bars are scaled to
+// the limit and those at or above warnRatio of it are highlighted and marked !
+func RenderLimitChart(data []provider.MetricDataPoint, limit, warnRatio float64, width, rows int, title string) string {
+	if len(data) == 0 || limit <= 0 {
+		return ChartStyle.Render(fmt.Sprintf("%s\n\nNo data available", title))
+	}
+
+	maxBar := width - 24
+	if maxBar < 10 {
+		maxBar = 10
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	if len(data) > rows {
+		data = data[len(data)-rows:]
+	}
+
+	lines := []string{title, ""}
+	for _, point := range data {
+		ratio := point.Value / limit
+		barLength := int(ratio * float64(maxBar))
+		if barLength > maxBar {
+			barLength = maxBar
+		}
+		if barLength < 1 && point.Value > 0 {
+			barLength = 1
+		}
+
+		bar := strings.Repeat("█", barLength) + strings.Repeat("·", maxBar-barLength)
+		line := fmt.Sprintf("%s │%s│ %.0f (%.0f%%)", point.Timestamp.Format("15:04"), bar, point.Value, ratio*100)
+		if ratio >= warnRatio {
+			line = limitWarnStyle.Render(line + " !")
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, "", fmt.Sprintf("Limit: %.0f • marked ! at ≥ %.0f%%", limit, warnRatio*100))
+
+	return ChartStyle.Render(strings.Join(lines, "\n"))
+}
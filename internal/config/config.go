@@ -4,21 +4,51 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"f6n/internal/version"
 )
 
 // Config holds the application configuration
 type Config struct {
-	Region      string
-	Environment string
-	Profile     string
-	LogLevel    string
-	ShowVersion bool
-	Provider    string // aws or gcp
-	GCPProject  string // GCP project ID
-	GCPRegion   string // GCP region
-	Verbose     bool   // shorthand for --log-level=debug
+	Command           string // subcommand, e.g. "serve"; empty runs the TUI
+	Region            string
+	Environment       string
+	Profile           string
+	EndpointURL       string // AWS endpoint every service is called at, e.g. LocalStack's; empty calls AWS
+	LogLevel          string
+	ShowVersion       bool
+	Provider          string        // aws, gcp, openfaas or knative
+	GCPProject        string        // GCP project ID
+	GCPRegion         string        // GCP region
+	OpenFaaSGateway   string        // OpenFaaS gateway URL
+	OpenFaaSNamespace string        // OpenFaaS function namespace
+	OpenFaaSUsername  string        // OpenFaaS basic auth user, from OPENFAAS_USERNAME only
+	OpenFaaSPassword  string        // OpenFaaS basic auth password, from OPENFAAS_PASSWORD only
+	OpenFaaSToken     string        // OpenFaaS bearer token, from OPENFAAS_TOKEN only; takes precedence over basic auth
+	Kubeconfig        string        // kubeconfig of the Knative cluster; empty reads KUBECONFIG or ~/.kube/config
+	KubeContext       string        // kubeconfig context; empty uses the current one
+	KnativeNamespace  string        // Knative service namespace; empty uses the context's
+	Verbose           bool          // shorthand for --log-level=debug
+	Accessible        bool          // plain-text rendering for screen readers and limited terminals
+	Language          string        // UI language, e.g. es; empty follows the locale
+	NoEmoji           bool          // replace emoji with ASCII markers
+	ConfigPath        string        // path to the config file
+	OTLPEndpoint      string        // OTLP/HTTP endpoint metrics and spans are exported to
+	WatchInterval     time.Duration // poll interval of watch mode, 0 when off
+	NotifyWebhook     string        // webhook/Slack URL watch mode notifies on anomalies
+	Demo              bool          // serve sample functions instead of a cloud account
+	RecordPath        string        // file provider responses are recorded to
+	ReplayPath        string        // recording to serve instead of calling the provider
+	ServeAPI          bool          // serve: expose the provider API over HTTP
+	Listen            string        // serve: address to listen on
+	APIToken          string        // serve: bearer token required by the API
+	ReportFormat      string        // report: markdown or html
+	ReportOutput      string        // report: output file, stdout when empty
+	ReportWindow      time.Duration // report: window of invocation stats and errors
+	File              FileConfig
+	fileErr           error // why the config file could not be loaded, if it couldn't
 }
 
 // Load reads configuration from environment variables and command-line flags
@@ -26,17 +56,46 @@ func Load() *Config {
 	cfg := &Config{}
 
 	// Define command-line flags
-	flag.StringVar(&cfg.Provider, "provider", "aws", "Cloud provider: aws or gcp (defaults to CLOUD_PROVIDER env var or aws)")
+	flag.StringVar(&cfg.Provider, "provider", "aws", "Cloud provider: aws, gcp, openfaas or knative (defaults to CLOUD_PROVIDER env var or aws)")
 	flag.StringVar(&cfg.Region, "region", "", "AWS region (defaults to AWS_REGION env var or us-east-1)")
 	flag.StringVar(&cfg.Environment, "env", "dev", "Environment name (defaults to STAGE env var or dev)")
-	flag.StringVar(&cfg.Profile, "profile", "", "AWS profile to use (defaults to AWS_PROFILE env var)")
+	flag.StringVar(&cfg.Profile, "profile", "", "AWS profile to use, or a comma-separated list to list several side by side (defaults to AWS_PROFILE env var)")
+	flag.StringVar(&cfg.EndpointURL, "endpoint-url", "", "AWS endpoint to call instead of AWS, e.g. http://localhost:4566 for LocalStack (defaults to AWS_ENDPOINT_URL env var)")
 	flag.StringVar(&cfg.GCPProject, "gcp-project", "", "GCP project ID (defaults to GCP_PROJECT env var)")
 	flag.StringVar(&cfg.GCPRegion, "gcp-region", "", "GCP region (defaults to GCP_REGION env var or us-central1)")
+	flag.StringVar(&cfg.OpenFaaSGateway, "openfaas-gateway", "", "OpenFaaS gateway URL (defaults to OPENFAAS_URL env var or http://127.0.0.1:8080)")
+	flag.StringVar(&cfg.OpenFaaSNamespace, "openfaas-namespace", "", "OpenFaaS function namespace (defaults to OPENFAAS_NAMESPACE env var or openfaas-fn)")
+	flag.StringVar(&cfg.Kubeconfig, "kubeconfig", "", "Kubeconfig of the Knative cluster (defaults to KUBECONFIG env var or ~/.kube/config)")
+	flag.StringVar(&cfg.KubeContext, "kube-context", "", "Kubeconfig context of the Knative cluster (defaults to the current context)")
+	flag.StringVar(&cfg.KnativeNamespace, "knative-namespace", "", "Knative service namespace (defaults to KNATIVE_NAMESPACE env var or the context's namespace)")
 	flag.StringVar(&cfg.LogLevel, "log-level", "info", "Log level (debug, info, warn, error)")
 	flag.BoolVar(&cfg.ShowVersion, "v", false, "Show version information (shorthand)")
 	flag.BoolVar(&cfg.ShowVersion, "version", false, "Show version information")
 	flag.BoolVar(&cfg.Verbose, "verbose", false, "Enable verbose logging (shorthand for --log-level=debug)")
-	flag.Parse()
+	flag.BoolVar(&cfg.Accessible, "accessible", false, "Plain-text rendering without colors, box drawing or emoji, for screen readers (defaults to ui.accessible in the config file)")
+	flag.BoolVar(&cfg.NoEmoji, "no-emoji", false, "Replace emoji with ASCII markers such as [OK] for terminals and fonts without them (defaults to ui.no_emoji in the config file)")
+	flag.StringVar(&cfg.Language, "lang", "", "UI language, e.g. en or es (defaults to ui.language in the config file, then LANG)")
+	flag.StringVar(&cfg.ConfigPath, "config", "", "Path to the config file (defaults to F6N_CONFIG env var or the user config dir)")
+	flag.StringVar(&cfg.OTLPEndpoint, "otlp-endpoint", "", "OTLP/HTTP endpoint to export metrics and traces to, e.g. http://localhost:4318 (defaults to OTEL_EXPORTER_OTLP_ENDPOINT env var)")
+	flag.DurationVar(&cfg.WatchInterval, "watch", 0, "Start in watch mode, polling functions at this interval (e.g. 1m)")
+	flag.StringVar(&cfg.NotifyWebhook, "notify-webhook", "", "Webhook or Slack URL notified of anomalies in watch mode (defaults to F6N_NOTIFY_WEBHOOK env var)")
+	flag.BoolVar(&cfg.Demo, "demo", false, "Explore sample functions with generated logs and metrics, without a cloud account")
+	flag.StringVar(&cfg.RecordPath, "record", "", "Record provider responses to this file for --replay")
+	flag.StringVar(&cfg.ReplayPath, "replay", "", "Serve the provider responses recorded with --record from this file, without credentials")
+	flag.BoolVar(&cfg.ServeAPI, "api", false, "serve: expose the provider API over HTTP")
+	flag.StringVar(&cfg.Listen, "listen", "127.0.0.1:8080", "serve: address to listen on")
+	flag.StringVar(&cfg.APIToken, "api-token", "", "serve: bearer token required by the API, which does not start without one (defaults to F6N_API_TOKEN env var)")
+	flag.StringVar(&cfg.ReportFormat, "format", "markdown", "report: output format, markdown or html")
+	flag.StringVar(&cfg.ReportOutput, "output", "", "report: file to write the report to (defaults to stdout)")
+	flag.DurationVar(&cfg.ReportWindow, "window", 7*24*time.Hour, "report: window of invocation stats, costs and errors")
+
+	// A leading non-flag argument selects a subcommand, e.g. "f6n serve --api" or "f6n report"
+	args := os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		cfg.Command = args[0]
+		args = args[1:]
+	}
+	flag.CommandLine.Parse(args)
 
 	// Handle version flag
 	if cfg.ShowVersion {
@@ -48,8 +107,31 @@ func Load() *Config {
 	cfg.Region = getWithEnvDefault(cfg.Region, "AWS_REGION", "us-east-1")
 	cfg.Environment = getWithEnvDefault(cfg.Environment, "STAGE", "dev")
 	cfg.Profile = getWithEnvDefault(cfg.Profile, "AWS_PROFILE", "")
+	cfg.EndpointURL = getWithEnvDefault(cfg.EndpointURL, "AWS_ENDPOINT_URL", "")
 	cfg.GCPProject = getWithEnvDefault(cfg.GCPProject, "GCP_PROJECT", "")
 	cfg.GCPRegion = getWithEnvDefault(cfg.GCPRegion, "GCP_REGION", "us-central1")
+	cfg.OpenFaaSGateway = getWithEnvDefault(cfg.OpenFaaSGateway, "OPENFAAS_URL", "http://127.0.0.1:8080")
+	cfg.OpenFaaSNamespace = getWithEnvDefault(cfg.OpenFaaSNamespace, "OPENFAAS_NAMESPACE", "openfaas-fn")
+	cfg.OpenFaaSUsername = getWithEnvDefault("", "OPENFAAS_USERNAME", "admin")
+	cfg.OpenFaaSPassword = os.Getenv("OPENFAAS_PASSWORD")
+	cfg.OpenFaaSToken = os.Getenv("OPENFAAS_TOKEN")
+	cfg.KnativeNamespace = getWithEnvDefault(cfg.KnativeNamespace, "KNATIVE_NAMESPACE", "")
+	cfg.ConfigPath = getWithEnvDefault(cfg.ConfigPath, "F6N_CONFIG", defaultConfigPath())
+	cfg.OTLPEndpoint = getWithEnvDefault(cfg.OTLPEndpoint, "OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	cfg.NotifyWebhook = getWithEnvDefault(cfg.NotifyWebhook, "F6N_NOTIFY_WEBHOOK", "")
+	cfg.APIToken = getWithEnvDefault(cfg.APIToken, "F6N_API_TOKEN", "")
+
+	fileCfg, err := loadFile(cfg.ConfigPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v (using defaults)\n", err)
+		cfg.fileErr = err
+	}
+	cfg.File = fileCfg
+	cfg.Accessible = cfg.Accessible || fileCfg.UI.Accessible
+	cfg.NoEmoji = cfg.NoEmoji || fileCfg.UI.NoEmoji
+	if cfg.Language == "" {
+		cfg.Language = fileCfg.UI.Language
+	}
 
 	return cfg
 }
@@ -0,0 +1,335 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileConfig holds the settings persisted in the f6n config file
+type FileConfig struct {
+	LogQueries     []LogQuery        `yaml:"log_queries,omitempty"`
+	Filters        []SavedFilter     `yaml:"filters,omitempty"`
+	HighlightRules []HighlightRule   `yaml:"highlight_rules,omitempty"`
+	Compliance     CompliancePolicy  `yaml:"compliance,omitempty"`
+	UI             UISettings        `yaml:"ui,omitempty"`
+	Logs           LogSettings       `yaml:"logs,omitempty"`
+	Telemetry      TelemetrySettings `yaml:"telemetry,omitempty"`
+	Favorites      []string          `yaml:"favorites,omitempty"` // function names, see :favorite
+	MetricsHistory HistorySettings   `yaml:"metrics_history,omitempty"`
+	Columns        []CustomColumn    `yaml:"columns,omitempty"`
+	Refresh        RefreshSettings   `yaml:"refresh,omitempty"`
+}
+
+// UISettings tunes the layout of the TUI
+type UISettings struct {
+	Logo       string `yaml:"logo,omitempty"`       // auto (default, hidden on short terminals), show or hide
+	Accessible bool   `yaml:"accessible,omitempty"` // plain-text rendering, same as --accessible
+	Language   string `yaml:"language,omitempty"`   // UI language, same as --lang
+	NoEmoji    bool   `yaml:"no_emoji,omitempty"`   // ASCII markers instead of emoji, same as --no-emoji
+	RawUnits   bool   `yaml:"raw_units,omitempty"`  // memory in MB, sizes in bytes and durations in s or ms
+}
+
+// LogSettings tunes how log timestamps are shown and saved
+type LogSettings struct {
+	TimestampFormat string `yaml:"timestamp_format,omitempty"` // rfc3339, epoch or short; 2006-01-02 15:04:05 when empty
+	Timezone        string `yaml:"timezone,omitempty"`         // local (default), UTC or an IANA name, e.g. Europe/Berlin
+}
+
+// TelemetrySettings opt in to anonymous usage reports
+type TelemetrySettings struct {
+	Enabled  bool   `yaml:"enabled,omitempty"`  // off unless set; DO_NOT_TRACK=1 turns it off again
+	Endpoint string `yaml:"endpoint,omitempty"` // URL the report is posted to on exit; nothing is sent without one
+}
+
+// HistorySettings sample the metrics of the favorite functions in the
+// background into a local history shown in MetricsView
+type HistorySettings struct {
+	Interval      string `yaml:"interval,omitempty"`       // e.g. 5m; no samples are taken when empty
+	RetentionDays int    `yaml:"retention_days,omitempty"` // how long samples are kept, 30 days when unset
+}
+
+// RefreshSettings reload the list, logs and metrics views while they are
+// shown, each at its own interval, e.g. 30s; off or empty never reloads
+type RefreshSettings struct {
+	List    string `yaml:"list,omitempty"`
+	Logs    string `yaml:"logs,omitempty"`
+	Metrics string `yaml:"metrics,omitempty"`
+}
+
+// LogQuery is a named log filter that can be applied to any function's logs
+type LogQuery struct {
+	Name     string `yaml:"name"`
+	Severity string `yaml:"severity,omitempty"` // minimum severity, e.g. ERROR
+	Text     string `yaml:"text,omitempty"`     // text the log message must contain
+	Since    string `yaml:"since,omitempty"`    // lookback window, e.g. 30m or 6h
+}
+
+// SavedFilter is a named function list filter expression, e.g.
+// "runtime:python memory>512"
+type SavedFilter struct {
+	Name       string `yaml:"name"`
+	Expression string `yaml:"expression"`
+}
+
+// CustomColumn is a function list column computed from a tag or an
+// environment variable, e.g. Stage from env:STAGE
+type CustomColumn struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"` // tag:<key> (label:<key> on GCP) or env:<variable>
+}
+
+// HighlightRule colors the parts of log lines matching a regular expression
+type HighlightRule struct {
+	Pattern string `yaml:"pattern"`
+	Color   string `yaml:"color,omitempty"` // hex or ANSI color, defaults to yellow
+	Bold    bool   `yaml:"bold,omitempty"`
+}
+
+// CompliancePolicy lists the rules every function is checked against. Empty
+// rules are not checked.
+type CompliancePolicy struct {
+	AllowedRuntimes  []string `yaml:"allowed_runtimes,omitempty"` // names or globs, e.g. python3.1*
+	MaxTimeout       int32    `yaml:"max_timeout,omitempty"`      // seconds
+	RequiredTags     []string `yaml:"required_tags,omitempty"`    // tag (AWS) or label (GCP) keys every function must have
+	ForbiddenRegions []string `yaml:"forbidden_regions,omitempty"`
+}
+
+// IsEmpty reports whether the policy has no rules
+func (p CompliancePolicy) IsEmpty() bool {
+	return len(p.AllowedRuntimes) == 0 && p.MaxTimeout == 0 && len(p.RequiredTags) == 0 && len(p.ForbiddenRegions) == 0
+}
+
+// defaultConfigPath returns the config file location under the user's config directory
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "f6n.yaml"
+	}
+	return filepath.Join(dir, "f6n", "config.yaml")
+}
+
+// StateDir returns the directory f6n keeps data in between runs, such as
+// drafts of unsaved edits: $XDG_STATE_HOME/f6n, or ~/.local/state/f6n
+func StateDir() string {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "f6n")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".f6n"
+	}
+	return filepath.Join(home, ".local", "state", "f6n")
+}
+
+// loadFile reads the config file at path. A missing file yields an empty configuration.
+func loadFile(path string) (FileConfig, error) {
+	var fc FileConfig
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fc, nil
+	}
+	if err != nil {
+		return fc, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, &fc); err != nil {
+		return fc, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return fc, nil
+}
+
+// Save writes the persisted settings back to the config file. The settings
+// are set in the file as it is on disk, keeping its comments and the keys f6n
+// doesn't know. It refuses to save when the file failed to load, since f6n
+// runs with the defaults then and would overwrite it with them.
+func (c *Config) Save() error {
+	if c.fileErr != nil {
+		return fmt.Errorf("not saving the settings over a config file that failed to load: %w", c.fileErr)
+	}
+	var settings yaml.Node
+	if err := settings.Encode(&c.File); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	doc, err := readConfigNode(c.ConfigPath)
+	if err != nil {
+		return err
+	}
+	mergeSettings(doc.Content[0], &settings, reflect.TypeOf(c.File))
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	data := buf.Bytes()
+
+	if err := os.MkdirAll(filepath.Dir(c.ConfigPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if err := os.WriteFile(c.ConfigPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file %s: %w", c.ConfigPath, err)
+	}
+	return nil
+}
+
+// readConfigNode reads the config file at path as a document whose content
+// is a mapping, empty when the file is missing or empty
+func readConfigNode(path string) (*yaml.Node, error) {
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{}}}
+	}
+	if root := doc.Content[0]; root.Kind != yaml.MappingNode {
+		doc.Content[0] = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", HeadComment: root.HeadComment}
+	}
+	return &doc, nil
+}
+
+// mergeSettings sets the fields of the struct type t in the mapping dst to
+// their values in src, the struct encoded. Fields src leaves out as empty are
+// removed, nested settings are merged the same way, and keys t has no field
+// for are left as they are.
+func mergeSettings(dst, src *yaml.Node, t reflect.Type) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		value := mappingValue(src, key)
+		at := mappingIndex(dst, key)
+
+		if field.Type.Kind() == reflect.Struct && at >= 0 && dst.Content[at+1].Kind == yaml.MappingNode {
+			if value == nil {
+				value = &yaml.Node{Kind: yaml.MappingNode}
+			}
+			mergeSettings(dst.Content[at+1], value, field.Type)
+			if len(dst.Content[at+1].Content) == 0 {
+				dst.Content = slices.Delete(dst.Content, at, at+2)
+			}
+			continue
+		}
+		switch {
+		case value == nil && at >= 0:
+			dst.Content = slices.Delete(dst.Content, at, at+2)
+		case value == nil:
+		case at < 0:
+			dst.Content = append(dst.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+		default:
+			old := dst.Content[at+1]
+			value.HeadComment, value.LineComment, value.FootComment = old.HeadComment, old.LineComment, old.FootComment
+			dst.Content[at+1] = value
+		}
+	}
+}
+
+// mappingIndex returns the index of key's node in a mapping, or -1
+func mappingIndex(mapping *yaml.Node, key string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// mappingValue returns the value of key in a mapping, nil when it has none
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if i := mappingIndex(mapping, key); i >= 0 {
+		return mapping.Content[i+1]
+	}
+	return nil
+}
+
+// IsFavorite reports whether a function is one of the favorites
+func (fc *FileConfig) IsFavorite(name string) bool {
+	return slices.Contains(fc.Favorites, name)
+}
+
+// ToggleFavorite adds a function to the favorites, or removes it, and
+// reports whether it is now a favorite
+func (fc *FileConfig) ToggleFavorite(name string) bool {
+	if i := slices.Index(fc.Favorites, name); i >= 0 {
+		fc.Favorites = slices.Delete(fc.Favorites, i, i+1)
+		return false
+	}
+	fc.Favorites = append(fc.Favorites, name)
+	return true
+}
+
+// FindLogQuery returns the saved log query with the given name
+func (fc *FileConfig) FindLogQuery(name string) (LogQuery, bool) {
+	for _, q := range fc.LogQueries {
+		if q.Name == name {
+			return q, true
+		}
+	}
+	return LogQuery{}, false
+}
+
+// SetLogQuery adds a log query, replacing any existing query with the same name
+func (fc *FileConfig) SetLogQuery(query LogQuery) {
+	for i, q := range fc.LogQueries {
+		if q.Name == query.Name {
+			fc.LogQueries[i] = query
+			return
+		}
+	}
+	fc.LogQueries = append(fc.LogQueries, query)
+}
+
+// FindFilter returns the saved function list filter with the given name
+func (fc *FileConfig) FindFilter(name string) (SavedFilter, bool) {
+	for _, f := range fc.Filters {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return SavedFilter{}, false
+}
+
+// SetFilter adds a function list filter, replacing any existing filter with
+// the same name
+func (fc *FileConfig) SetFilter(filter SavedFilter) {
+	for i, f := range fc.Filters {
+		if f.Name == filter.Name {
+			fc.Filters[i] = filter
+			return
+		}
+	}
+	fc.Filters = append(fc.Filters, filter)
+}
+
+// DeleteFilter removes the saved filter with the given name, reporting
+// whether there was one
+func (fc *FileConfig) DeleteFilter(name string) bool {
+	for i, f := range fc.Filters {
+		if f.Name == name {
+			fc.Filters = slices.Delete(fc.Filters, i, i+1)
+			return true
+		}
+	}
+	return false
+}
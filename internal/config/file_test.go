@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveKeepsComments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := `# f6n settings
+ui:
+  # shown on tall terminals only
+  logo: auto
+  theme: dark # not an f6n setting
+favorites:
+  - orders-api
+team: payments
+`
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fc, err := loadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &Config{ConfigPath: path, File: fc}
+	c.File.UI.NoEmoji = true
+	c.File.ToggleFavorite("orders-api")
+	c.File.ToggleFavorite("billing")
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := string(data)
+	for _, want := range []string{"# f6n settings", "# shown on tall terminals only", "logo: auto", "theme: dark # not an f6n setting", "no_emoji: true", "- billing", "team: payments"} {
+		if !strings.Contains(saved, want) {
+			t.Errorf("saved config lacks %q:\n%s", want, saved)
+		}
+	}
+	if strings.Contains(saved, "orders-api") {
+		t.Errorf("saved config keeps the removed favorite:\n%s", saved)
+	}
+
+	reloaded, err := loadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reloaded.UI.NoEmoji || reloaded.UI.Logo != "auto" || len(reloaded.Favorites) != 1 || reloaded.Favorites[0] != "billing" {
+		t.Errorf("reloaded config = %+v", reloaded)
+	}
+}
+
+func TestSaveRemovesEmptiedSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("ui:\n  no_emoji: true\nfavorites: [orders-api]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := &Config{ConfigPath: path}
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved := strings.TrimSpace(string(data)); saved != "{}" {
+		t.Errorf("saved config = %q, want the emptied settings removed", saved)
+	}
+}
+
+func TestSaveRefusesAfterLoadError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	const broken = "ui: [\n"
+	if err := os.WriteFile(path, []byte(broken), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err := loadFile(path)
+	if err == nil {
+		t.Fatal("loadFile parsed a broken config")
+	}
+	c := &Config{ConfigPath: path, fileErr: err}
+	if err := c.Save(); err == nil {
+		t.Error("Save succeeded over a config that failed to load")
+	}
+	if data, _ := os.ReadFile(path); string(data) != broken {
+		t.Errorf("config file changed to %q", data)
+	}
+}
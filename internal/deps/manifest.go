@@ -0,0 +1,208 @@
+// Package deps reads the dependencies declared in downloaded function code
+// and looks them up in the OSV vulnerability database.
+package deps
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Ecosystems as named by OSV
+const (
+	EcosystemNpm  = "npm"
+	EcosystemPyPI = "PyPI"
+	EcosystemGo   = "Go"
+)
+
+// Dependency is a package declared in a manifest
+type Dependency struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+	Version   string `json:"version"`  // As declared, e.g. "^1.2.0" or "==2.31.0"
+	Manifest  string `json:"manifest"` // Path relative to the scanned directory
+	Dev       bool   `json:"dev,omitempty"`
+	Indirect  bool   `json:"indirect,omitempty"`
+}
+
+// ExactVersion returns the version a vulnerability lookup can use: the pinned
+// version, or the lowest version of an npm caret/tilde range. It returns ""
+// for declarations that do not resolve to a single version.
+func (d Dependency) ExactVersion() string {
+	v := d.Version
+	switch d.Ecosystem {
+	case EcosystemPyPI:
+		if !strings.HasPrefix(v, "==") || strings.ContainsAny(v, "*,") {
+			return ""
+		}
+		return strings.TrimPrefix(v, "==")
+	case EcosystemNpm:
+		v = strings.TrimLeft(v, "^~=v")
+		if !npmVersion.MatchString(v) {
+			return ""
+		}
+		return v
+	default:
+		// OSV records Go module versions without the "v" prefix
+		return strings.TrimPrefix(v, "v")
+	}
+}
+
+var npmVersion = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?$`)
+
+// skipDirs are directories holding installed packages rather than manifests
+var skipDirs = map[string]bool{
+	"node_modules":  true,
+	"vendor":        true,
+	".git":          true,
+	"__pycache__":   true,
+	"site-packages": true,
+}
+
+// Scan finds package.json, requirements.txt and go.mod files under dir and
+// returns their dependencies ordered by manifest and name
+func Scan(dir string) ([]Dependency, error) {
+	var deps []Dependency
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		var parse func(string) ([]Dependency, error)
+		switch d.Name() {
+		case "package.json":
+			parse = parsePackageJSON
+		case "requirements.txt":
+			parse = parseRequirements
+		case "go.mod":
+			parse = parseGoMod
+		default:
+			return nil
+		}
+
+		found, err := parse(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		for i := range found {
+			found[i].Manifest = filepath.ToSlash(rel)
+		}
+		deps = append(deps, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(deps, func(i, j int) bool {
+		if deps[i].Manifest != deps[j].Manifest {
+			return deps[i].Manifest < deps[j].Manifest
+		}
+		return deps[i].Name < deps[j].Name
+	})
+	return deps, nil
+}
+
+func parsePackageJSON(path string) ([]Dependency, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var deps []Dependency
+	for name, version := range manifest.Dependencies {
+		deps = append(deps, Dependency{Ecosystem: EcosystemNpm, Name: name, Version: version})
+	}
+	for name, version := range manifest.DevDependencies {
+		deps = append(deps, Dependency{Ecosystem: EcosystemNpm, Name: name, Version: version, Dev: true})
+	}
+	return deps, nil
+}
+
+// requirementLine matches "name[extras] <op> version" in a requirements file
+var requirementLine = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)(\[[^\]]*\])?\s*((?:==|>=|<=|~=|!=|>|<|===)\s*[^;#\s,]+(?:\s*,\s*(?:==|>=|<=|~=|!=|>|<)\s*[^;#\s,]+)*)?`)
+
+func parseRequirements(path string) ([]Dependency, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var deps []Dependency
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		// Options such as -r, -e and --index-url are not packages
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		match := requirementLine.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		deps = append(deps, Dependency{
+			Ecosystem: EcosystemPyPI,
+			Name:      match[1],
+			Version:   strings.ReplaceAll(match[3], " ", ""),
+		})
+	}
+	return deps, scanner.Err()
+}
+
+func parseGoMod(path string) ([]Dependency, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var deps []Dependency
+	inRequire := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "require (":
+			inRequire = true
+			continue
+		case inRequire && line == ")":
+			inRequire = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require "))
+		case !inRequire:
+			continue
+		}
+
+		indirect := strings.HasSuffix(line, "// indirect")
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		deps = append(deps, Dependency{Ecosystem: EcosystemGo, Name: fields[0], Version: fields[1], Indirect: indirect})
+	}
+	return deps, scanner.Err()
+}
@@ -0,0 +1,107 @@
+package deps
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// osvBatchURL is the OSV batch query endpoint
+	osvBatchURL = "https://api.osv.dev/v1/querybatch"
+	// osvBatchSize is the maximum number of queries OSV accepts per batch
+	osvBatchSize = 1000
+)
+
+var osvClient = &http.Client{Timeout: 30 * time.Second}
+
+type osvQuery struct {
+	Package struct {
+		Name      string `json:"name"`
+		Ecosystem string `json:"ecosystem"`
+	} `json:"package"`
+	Version string `json:"version"`
+}
+
+// CheckVulnerabilities looks the pinned dependencies up in OSV and returns the
+// IDs of their known vulnerabilities, indexed like deps. Dependencies without
+// an exact version are not looked up.
+func CheckVulnerabilities(ctx context.Context, deps []Dependency) ([][]string, error) {
+	vulns := make([][]string, len(deps))
+
+	var queries []osvQuery
+	var indexes []int
+	for i, dep := range deps {
+		version := dep.ExactVersion()
+		if version == "" {
+			continue
+		}
+		var q osvQuery
+		q.Package.Name = dep.Name
+		q.Package.Ecosystem = dep.Ecosystem
+		q.Version = version
+		queries = append(queries, q)
+		indexes = append(indexes, i)
+	}
+
+	for start := 0; start < len(queries); start += osvBatchSize {
+		end := min(start+osvBatchSize, len(queries))
+		results, err := queryOSV(ctx, queries[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for j, ids := range results {
+			vulns[indexes[start+j]] = ids
+		}
+	}
+	return vulns, nil
+}
+
+func queryOSV(ctx context.Context, queries []osvQuery) ([][]string, error) {
+	body, err := json.Marshal(map[string][]osvQuery{"queries": queries})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OSV query: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, osvBatchURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OSV request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := osvClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OSV: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("OSV returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var result struct {
+		Results []struct {
+			Vulns []struct {
+				ID string `json:"id"`
+			} `json:"vulns"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode OSV response: %w", err)
+	}
+	if len(result.Results) != len(queries) {
+		return nil, fmt.Errorf("OSV returned %d results for %d queries", len(result.Results), len(queries))
+	}
+
+	ids := make([][]string, len(queries))
+	for i, r := range result.Results {
+		for _, v := range r.Vulns {
+			ids[i] = append(ids[i], v.ID)
+		}
+	}
+	return ids, nil
+}
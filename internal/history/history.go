@@ -0,0 +1,171 @@
+// Package history keeps a local history of function metrics, sampled in the
+// background, so that trends can be shown beyond the window the provider is
+// queried for and while it cannot be reached.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"f6n/internal/provider"
+)
+
+// Sample summarizes a function's metrics over one sampling interval
+type Sample struct {
+	Time        time.Time `json:"time"` // End of the interval
+	Function    string    `json:"function"`
+	Invocations float64   `json:"invocations"`
+	Errors      float64   `json:"errors"`
+	Throttles   float64   `json:"throttles"`
+	DurationMs  float64   `json:"durationMs"`  // Average duration
+	Concurrency float64   `json:"concurrency"` // Peak concurrent executions
+}
+
+// Summarize reduces the metrics fetched for an interval ending at end to a sample
+func Summarize(function string, metrics *provider.FunctionMetrics, end time.Time) Sample {
+	s := Sample{Time: end, Function: function}
+	if metrics == nil {
+		return s
+	}
+	s.Invocations = sum(metrics.Invocations.DataPoints)
+	s.Errors = sum(metrics.Errors.DataPoints)
+	s.Throttles = sum(metrics.Throttles.DataPoints)
+	if n := len(metrics.Duration.DataPoints); n > 0 {
+		s.DurationMs = sum(metrics.Duration.DataPoints) / float64(n)
+	}
+	for _, p := range metrics.ConcurrentExecutions.DataPoints {
+		s.Concurrency = max(s.Concurrency, p.Value)
+	}
+	return s
+}
+
+func sum(points []provider.MetricDataPoint) float64 {
+	total := 0.0
+	for _, p := range points {
+		total += p.Value
+	}
+	return total
+}
+
+// Store appends samples to a JSON Lines file, one sample per line
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// New creates a store kept at path. The file is created on the first sample.
+func New(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the file the samples are kept in
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Append adds samples to the store
+func (s *Store) Append(samples []Sample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	var data []byte
+	for _, sample := range samples {
+		line, err := json.Marshal(sample)
+		if err != nil {
+			return err
+		}
+		data = append(append(data, line...), '\n')
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create metrics history directory: %w", err)
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open metrics history: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write metrics history: %w", err)
+	}
+	return f.Close()
+}
+
+// Read returns the samples of a function taken since the given time, oldest
+// first. Lines that cannot be parsed are skipped; a missing file yields no
+// samples.
+func (s *Store) Read(function string, since time.Time) ([]Sample, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.read(func(sample Sample) bool {
+		return sample.Function == function && !sample.Time.Before(since)
+	})
+}
+
+// Prune drops the samples taken before the given time
+func (s *Store) Prune(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := os.Stat(s.path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	kept, err := s.read(func(sample Sample) bool { return !sample.Time.Before(before) })
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	for _, sample := range kept {
+		line, err := json.Marshal(sample)
+		if err != nil {
+			return err
+		}
+		data = append(append(data, line...), '\n')
+	}
+	// Replace the file at once, so that a crash leaves the old samples
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write metrics history: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace metrics history: %w", err)
+	}
+	return nil
+}
+
+// read returns the samples of the file that keep accepts, in file order.
+// The caller holds the lock.
+func (s *Store) read(keep func(Sample) bool) ([]Sample, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open metrics history: %w", err)
+	}
+	defer f.Close()
+
+	var samples []Sample
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var sample Sample
+		if json.Unmarshal(scanner.Bytes(), &sample) != nil {
+			continue
+		}
+		if keep(sample) {
+			samples = append(samples, sample)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read metrics history: %w", err)
+	}
+	return samples, nil
+}
@@ -0,0 +1,88 @@
+// Package i18n translates the user-facing strings of the TUI. Messages are
+// identified by their English text, so English needs no catalog and strings
+// missing from a catalog fall back to English.
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// English is the language of the message IDs
+const English = "en"
+
+//go:embed locales/*.yaml
+var locales embed.FS
+
+// catalog maps the English messages to the selected language, nil for English
+var catalog map[string]string
+
+// Languages returns the available languages, English first
+func Languages() []string {
+	langs := []string{English}
+	entries, _ := locales.ReadDir("locales")
+	var others []string
+	for _, entry := range entries {
+		others = append(others, strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())))
+	}
+	sort.Strings(others)
+	return append(langs, others...)
+}
+
+// Detect returns lang, or the language of the LC_ALL, LC_MESSAGES or LANG
+// environment variables when it is empty, e.g. "es" for es_ES.UTF-8
+func Detect(lang string) string {
+	for _, value := range []string{lang, os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")} {
+		if value == "" {
+			continue
+		}
+		value = strings.ToLower(value)
+		if i := strings.IndexAny(value, "_-.@"); i >= 0 {
+			value = value[:i]
+		}
+		if value == "c" || value == "posix" {
+			return English
+		}
+		return value
+	}
+	return English
+}
+
+// SetLanguage loads the catalog of lang. Unknown languages fall back to
+// English and return an error.
+func SetLanguage(lang string) error {
+	catalog = nil
+	if lang == "" || lang == English {
+		return nil
+	}
+
+	data, err := locales.ReadFile("locales/" + lang + ".yaml")
+	if err != nil {
+		return fmt.Errorf("no translation for language %q (available: %s)", lang, strings.Join(Languages(), ", "))
+	}
+	var messages map[string]string
+	if err := yaml.Unmarshal(data, &messages); err != nil {
+		return fmt.Errorf("failed to parse %s catalog: %w", lang, err)
+	}
+	catalog = messages
+	return nil
+}
+
+// T returns the translation of msg, or msg when it has none
+func T(msg string) string {
+	if translated, ok := catalog[msg]; ok && translated != "" {
+		return translated
+	}
+	return msg
+}
+
+// Tf formats the translation of format with args
+func Tf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
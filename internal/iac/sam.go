@@ -0,0 +1,139 @@
+package iac
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"f6n/internal/provider"
+
+	"gopkg.in/yaml.v3"
+)
+
+// logicalIDRe matches characters CloudFormation doesn't allow in logical IDs
+var logicalIDRe = regexp.MustCompile(`[^A-Za-z0-9]`)
+
+// logicalID turns a function name into a CloudFormation logical ID, e.g. image-resizer -> ImageResizer
+func logicalID(name string) string {
+	var b strings.Builder
+	for _, part := range logicalIDRe.Split(name, -1) {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	if b.Len() == 0 {
+		return "Function"
+	}
+	return b.String()
+}
+
+// arnService returns the service of an ARN, e.g. "sqs" for arn:aws:sqs:...
+func arnService(arn string) string {
+	parts := strings.SplitN(arn, ":", 4)
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[2]
+}
+
+type samTemplate struct {
+	AWSTemplateFormatVersion string                 `yaml:"AWSTemplateFormatVersion"`
+	Transform                string                 `yaml:"Transform"`
+	Description              string                 `yaml:"Description"`
+	Resources                map[string]samResource `yaml:"Resources"`
+}
+
+type samResource struct {
+	Type       string        `yaml:"Type"`
+	Properties samProperties `yaml:"Properties"`
+}
+
+type samProperties struct {
+	FunctionName string              `yaml:"FunctionName"`
+	Description  string              `yaml:"Description,omitempty"`
+	CodeURI      string              `yaml:"CodeUri"`
+	Handler      string              `yaml:"Handler,omitempty"`
+	Runtime      string              `yaml:"Runtime,omitempty"`
+	MemorySize   int32               `yaml:"MemorySize"`
+	Timeout      int32               `yaml:"Timeout"`
+	Role         string              `yaml:"Role,omitempty"`
+	Environment  *samEnvironment     `yaml:"Environment,omitempty"`
+	Events       map[string]samEvent `yaml:"Events,omitempty"`
+}
+
+type samEnvironment struct {
+	Variables map[string]string `yaml:"Variables"`
+}
+
+type samEvent struct {
+	Type       string         `yaml:"Type"`
+	Properties map[string]any `yaml:"Properties"`
+}
+
+// SAM renders the function and its event source mappings as an AWS SAM template
+func SAM(fn provider.FunctionInfo, cloud provider.CloudProvider, triggers []provider.EventSourceMapping) (string, error) {
+	if cloud != provider.AWS {
+		return "", fmt.Errorf("SAM templates are only available for AWS Lambda functions")
+	}
+
+	props := samProperties{
+		FunctionName: fn.Name,
+		Description:  fn.Description,
+		CodeURI:      "./" + fn.Name,
+		Handler:      fn.Handler,
+		Runtime:      fn.Runtime,
+		MemorySize:   fn.Memory,
+		Timeout:      fn.Timeout,
+		Role:         fn.Role,
+	}
+	if len(fn.Environment) > 0 {
+		props.Environment = &samEnvironment{Variables: fn.Environment}
+	}
+
+	counts := map[string]int{}
+	for _, t := range triggers {
+		var event samEvent
+		switch service := arnService(t.SourceARN); service {
+		case "sqs":
+			event = samEvent{Type: "SQS", Properties: map[string]any{"Queue": t.SourceARN}}
+		case "kinesis", "dynamodb":
+			eventType := map[string]string{"kinesis": "Kinesis", "dynamodb": "DynamoDB"}[service]
+			event = samEvent{Type: eventType, Properties: map[string]any{"Stream": t.SourceARN, "StartingPosition": "LATEST"}}
+		default:
+			continue
+		}
+		if t.BatchSize > 0 {
+			event.Properties["BatchSize"] = t.BatchSize
+		}
+		event.Properties["Enabled"] = strings.EqualFold(t.State, "Enabled")
+
+		counts[event.Type]++
+		if props.Events == nil {
+			props.Events = map[string]samEvent{}
+		}
+		props.Events[fmt.Sprintf("%sEvent%d", event.Type, counts[event.Type])] = event
+	}
+
+	tmpl := samTemplate{
+		AWSTemplateFormatVersion: "2010-09-09",
+		Transform:                "AWS::Serverless-2016-10-31",
+		Description:              fmt.Sprintf("%s (exported by f6n from %s)", fn.Name, fn.ARN),
+		Resources: map[string]samResource{
+			logicalID(fn.Name): {Type: "AWS::Serverless::Function", Properties: props},
+		},
+	}
+	return marshalYAML(tmpl)
+}
+
+func marshalYAML(v any) (string, error) {
+	var b strings.Builder
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return "", fmt.Errorf("failed to render YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", fmt.Errorf("failed to render YAML: %w", err)
+	}
+	return b.String(), nil
+}
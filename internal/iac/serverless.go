@@ -0,0 +1,98 @@
+package iac
+
+import (
+	"fmt"
+	"strings"
+
+	"f6n/internal/provider"
+)
+
+type serverlessConfig struct {
+	Service   string                        `yaml:"service"`
+	Provider  serverlessProvider            `yaml:"provider"`
+	Plugins   []string                      `yaml:"plugins,omitempty"`
+	Functions map[string]serverlessFunction `yaml:"functions"`
+}
+
+type serverlessProvider struct {
+	Name    string         `yaml:"name"`
+	Runtime string         `yaml:"runtime,omitempty"`
+	Region  string         `yaml:"region,omitempty"`
+	Project string         `yaml:"project,omitempty"`
+	IAM     *serverlessIAM `yaml:"iam,omitempty"`
+}
+
+type serverlessIAM struct {
+	Role string `yaml:"role"`
+}
+
+type serverlessFunction struct {
+	Name           string            `yaml:"name,omitempty"`
+	Handler        string            `yaml:"handler"`
+	Description    string            `yaml:"description,omitempty"`
+	MemorySize     int32             `yaml:"memorySize"`
+	Timeout        any               `yaml:"timeout"` // seconds on AWS, a duration string on GCP
+	ServiceAccount string            `yaml:"serviceAccountEmail,omitempty"`
+	Environment    map[string]string `yaml:"environment,omitempty"`
+	Events         []map[string]any  `yaml:"events,omitempty"`
+}
+
+// Serverless renders the function and its triggers as a Serverless Framework
+// serverless.yml. GCP functions use the serverless-google-cloudfunctions plugin.
+func Serverless(fn provider.FunctionInfo, cloud provider.CloudProvider, triggers []provider.EventSourceMapping) (string, error) {
+	cfg := serverlessConfig{Service: fn.Name}
+	function := serverlessFunction{
+		Handler:     fn.Handler,
+		Description: fn.Description,
+		MemorySize:  fn.Memory,
+		Environment: fn.Environment,
+	}
+
+	switch cloud {
+	case provider.AWS:
+		cfg.Provider = serverlessProvider{Name: "aws", Runtime: fn.Runtime, Region: fn.Region}
+		if fn.Role != "" {
+			cfg.Provider.IAM = &serverlessIAM{Role: fn.Role}
+		}
+		function.Name = fn.Name
+		function.Timeout = fn.Timeout
+		function.Events = serverlessAWSEvents(triggers)
+	case provider.GCP:
+		cfg.Provider = serverlessProvider{Name: "google", Runtime: fn.Runtime, Region: fn.Region, Project: gcpProject(fn.ARN)}
+		cfg.Plugins = []string{"serverless-google-cloudfunctions"}
+		function.Timeout = fmt.Sprintf("%ds", fn.Timeout)
+		function.ServiceAccount = fn.Role
+		function.Events = []map[string]any{{"http": "path"}}
+	default:
+		return "", fmt.Errorf("serverless.yml export is not supported for %s", cloud)
+	}
+
+	cfg.Functions = map[string]serverlessFunction{fn.Name: function}
+	out, err := marshalYAML(cfg)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("# Exported by f6n from %s\n", fn.ARN) + out, nil
+}
+
+// serverlessAWSEvents converts event source mappings to sqs and stream events
+func serverlessAWSEvents(triggers []provider.EventSourceMapping) []map[string]any {
+	var events []map[string]any
+	for _, t := range triggers {
+		props := map[string]any{"arn": t.SourceARN}
+		if t.BatchSize > 0 {
+			props["batchSize"] = t.BatchSize
+		}
+		props["enabled"] = strings.EqualFold(t.State, "Enabled")
+
+		switch service := arnService(t.SourceARN); service {
+		case "sqs":
+			events = append(events, map[string]any{"sqs": props})
+		case "kinesis", "dynamodb":
+			props["type"] = service
+			props["startingPosition"] = "LATEST"
+			events = append(events, map[string]any{"stream": props})
+		}
+	}
+	return events
+}
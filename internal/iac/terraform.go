@@ -0,0 +1,175 @@
+// Package iac renders live function configuration as infrastructure-as-code
+// to help adopt Terraform, SAM or the Serverless Framework.
+package iac
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"f6n/internal/provider"
+)
+
+// resourceNameRe matches characters Terraform doesn't allow in resource names
+var resourceNameRe = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// resourceName turns a function name into a Terraform resource name
+func resourceName(name string) string {
+	n := resourceNameRe.ReplaceAllString(name, "_")
+	if n == "" || (n[0] >= '0' && n[0] <= '9') {
+		n = "fn_" + n
+	}
+	return n
+}
+
+// gcpProject extracts the project from a projects/P/locations/L/functions/N resource name
+func gcpProject(resource string) string {
+	parts := strings.Split(resource, "/")
+	if len(parts) >= 2 && parts[0] == "projects" {
+		return parts[1]
+	}
+	return ""
+}
+
+// Terraform renders an import block, the equivalent `terraform import`
+// command and a resource block matching the function's live configuration.
+// GCP functions with an event trigger get an event_trigger block, the others
+// are HTTP triggered.
+func Terraform(fn provider.FunctionInfo, cloud provider.CloudProvider, triggers []provider.EventSourceMapping) (string, error) {
+	switch cloud {
+	case provider.AWS:
+		return terraformAWS(fn), nil
+	case provider.GCP:
+		return terraformGCP(fn, triggers), nil
+	default:
+		return "", fmt.Errorf("terraform export is not supported for %s", cloud)
+	}
+}
+
+func terraformAWS(fn provider.FunctionInfo) string {
+	name := resourceName(fn.Name)
+	address := "aws_lambda_function." + name
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by f6n from the live configuration of %s\n", fn.ARN)
+	fmt.Fprintf(&b, "# Terraform < 1.5: terraform import %s %s\n\n", address, fn.Name)
+	fmt.Fprintf(&b, "import {\n  to = %s\n  id = %s\n}\n\n", address, hclString(fn.Name))
+
+	fmt.Fprintf(&b, "resource \"aws_lambda_function\" %q {\n", name)
+	writeAttr(&b, "function_name", hclString(fn.Name))
+	writeAttr(&b, "role", hclString(fn.Role))
+	if fn.Handler != "" {
+		writeAttr(&b, "handler", hclString(fn.Handler))
+	}
+	if fn.Runtime != "" {
+		writeAttr(&b, "runtime", hclString(fn.Runtime))
+	}
+	writeAttr(&b, "memory_size", strconv.Itoa(int(fn.Memory)))
+	writeAttr(&b, "timeout", strconv.Itoa(int(fn.Timeout)))
+	if fn.Description != "" {
+		writeAttr(&b, "description", hclString(fn.Description))
+	}
+	b.WriteString("\n  # Point this at the deployment package, or use s3_bucket/s3_key or image_uri\n")
+	writeAttr(&b, "filename", hclString(fn.Name+".zip"))
+	if len(fn.Environment) > 0 {
+		b.WriteString("\n  environment {\n    variables = ")
+		writeMap(&b, fn.Environment, "    ")
+		b.WriteString("  }\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func terraformGCP(fn provider.FunctionInfo, triggers []provider.EventSourceMapping) string {
+	name := resourceName(fn.Name)
+	address := "google_cloudfunctions_function." + name
+	bucketVar, objectVar := name+"_source_archive_bucket", name+"_source_archive_object"
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Generated by f6n from the live configuration of %s\n", fn.ARN)
+	fmt.Fprintf(&b, "# Terraform < 1.5: terraform import %s %s\n\n", address, fn.ARN)
+	fmt.Fprintf(&b, "import {\n  to = %s\n  id = %s\n}\n\n", address, hclString(fn.ARN))
+	writeVariable(&b, bucketVar, "Cloud Storage bucket holding the source archive of "+fn.Name)
+	writeVariable(&b, objectVar, "Cloud Storage object of the source archive of "+fn.Name)
+
+	fmt.Fprintf(&b, "resource \"google_cloudfunctions_function\" %q {\n", name)
+	writeAttr(&b, "name", hclString(fn.Name))
+	if project := gcpProject(fn.ARN); project != "" {
+		writeAttr(&b, "project", hclString(project))
+	}
+	writeAttr(&b, "region", hclString(fn.Region))
+	writeAttr(&b, "runtime", hclString(fn.Runtime))
+	if fn.Handler != "" {
+		writeAttr(&b, "entry_point", hclString(fn.Handler))
+	}
+	writeAttr(&b, "available_memory_mb", strconv.Itoa(int(fn.Memory)))
+	writeAttr(&b, "timeout", strconv.Itoa(int(fn.Timeout)))
+	if fn.Description != "" {
+		writeAttr(&b, "description", hclString(fn.Description))
+	}
+	if fn.Role != "" {
+		writeAttr(&b, "service_account_email", hclString(fn.Role))
+	}
+	b.WriteString("\n")
+	writeAttr(&b, "source_archive_bucket", "var."+bucketVar)
+	writeAttr(&b, "source_archive_object", "var."+objectVar)
+	if len(triggers) > 0 {
+		writeEventTrigger(&b, triggers[0])
+	} else {
+		writeAttr(&b, "trigger_http", "true")
+	}
+	if len(fn.Environment) > 0 {
+		b.WriteString("\n  environment_variables = ")
+		writeMap(&b, fn.Environment, "  ")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// writeEventTrigger writes the event_trigger block of a GCP function. Bucket
+// triggers take the bucket name as their resource.
+func writeEventTrigger(b *strings.Builder, t provider.EventSourceMapping) {
+	b.WriteString("\n  event_trigger {\n")
+	fmt.Fprintf(b, "    event_type = %s\n", hclString(t.EventType))
+	fmt.Fprintf(b, "    resource = %s\n", hclString(strings.TrimPrefix(t.SourceARN, "gs://")))
+	if t.Retry == "RETRY_POLICY_RETRY" {
+		b.WriteString("    failure_policy {\n      retry = true\n    }\n")
+	}
+	b.WriteString("  }\n")
+}
+
+// writeVariable declares a string input variable
+func writeVariable(b *strings.Builder, name, description string) {
+	fmt.Fprintf(b, "variable %q {\n", name)
+	writeAttr(b, "description", hclString(description))
+	writeAttr(b, "type", "string")
+	b.WriteString("}\n\n")
+}
+
+// hclString quotes a string for HCL, escaping template sequences
+func hclString(s string) string {
+	q := strconv.Quote(s)
+	q = strings.ReplaceAll(q, "${", "$${")
+	return strings.ReplaceAll(q, "%{", "%%{")
+}
+
+func writeAttr(b *strings.Builder, key, value string) {
+	fmt.Fprintf(b, "  %s = %s\n", key, value)
+}
+
+// writeMap writes an HCL map literal with sorted keys, closing it at indent
+func writeMap(b *strings.Builder, m map[string]string, indent string) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	b.WriteString("{\n")
+	for _, k := range keys {
+		fmt.Fprintf(b, "%s  %s = %s\n", indent, hclString(k), hclString(m[k]))
+	}
+	b.WriteString(indent + "}\n")
+}
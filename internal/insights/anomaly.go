@@ -0,0 +1,100 @@
+package insights
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"f6n/internal/provider"
+)
+
+// spikeMinInvocations keeps a handful of failed calls on an idle function
+// from being reported as a spike
+const spikeMinInvocations = 10
+
+// AnomalyKind identifies what watch mode detected
+type AnomalyKind string
+
+const (
+	AnomalyFailedState    AnomalyKind = "failed-state"
+	AnomalyErrorRateSpike AnomalyKind = "error-rate-spike"
+)
+
+// Anomaly is a notable change in a function between two observations
+type Anomaly struct {
+	FunctionName string
+	Kind         AnomalyKind
+	Summary      string
+}
+
+// DetectStateChanges reports functions that entered a Failed state or whose
+// last update failed since the previous listing. Functions that were already
+// failing, or are new, are not reported.
+func DetectStateChanges(prev, cur []provider.FunctionInfo) []Anomaly {
+	before := make(map[string]provider.FunctionInfo, len(prev))
+	for _, fn := range prev {
+		before[fn.Name] = fn
+	}
+
+	var anomalies []Anomaly
+	for _, fn := range cur {
+		old, ok := before[fn.Name]
+		if !ok {
+			continue
+		}
+		switch {
+		case isFailed(fn.State) && !isFailed(old.State):
+			anomalies = append(anomalies, Anomaly{
+				FunctionName: fn.Name,
+				Kind:         AnomalyFailedState,
+				Summary:      fmt.Sprintf("state changed from %s to %s", old.State, withReason(fn.State, fn.StateReason)),
+			})
+		case isFailed(fn.LastUpdateStatus) && !isFailed(old.LastUpdateStatus):
+			anomalies = append(anomalies, Anomaly{
+				FunctionName: fn.Name,
+				Kind:         AnomalyFailedState,
+				Summary:      "last update failed: " + withReason(fn.LastUpdateStatus, fn.LastUpdateStatusReason),
+			})
+		}
+	}
+	return anomalies
+}
+
+// DetectErrorSpikes reports functions whose error rate crossed the critical
+// threshold since the previous observation. prev is nil on the first
+// observation, which only establishes the baseline.
+func DetectErrorSpikes(prev, cur map[string]provider.FunctionStats) []Anomaly {
+	if prev == nil {
+		return nil
+	}
+
+	var anomalies []Anomaly
+	for name, stats := range cur {
+		if stats.Invocations < spikeMinInvocations {
+			continue
+		}
+		rate := stats.ErrorRate()
+		prevRate := prev[name].ErrorRate()
+		if rate < critErrorRate || prevRate >= critErrorRate {
+			continue
+		}
+		anomalies = append(anomalies, Anomaly{
+			FunctionName: name,
+			Kind:         AnomalyErrorRateSpike,
+			Summary: fmt.Sprintf("error rate rose from %.2f%% to %.2f%% (%.0f of %.0f invocations)",
+				prevRate*100, rate*100, stats.Errors, stats.Invocations),
+		})
+	}
+	sort.Slice(anomalies, func(i, j int) bool { return anomalies[i].FunctionName < anomalies[j].FunctionName })
+	return anomalies
+}
+
+// isFailed reports whether a state or update status means failure; GCP
+// reports failed deployments as OFFLINE
+func isFailed(status string) bool {
+	switch strings.ToUpper(status) {
+	case "FAILED", "OFFLINE":
+		return true
+	}
+	return false
+}
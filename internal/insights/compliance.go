@@ -0,0 +1,65 @@
+package insights
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"f6n/internal/config"
+	"f6n/internal/provider"
+)
+
+// Violation is a compliance rule a function breaks
+type Violation struct {
+	FunctionName string
+	Rule         string
+	Detail       string
+}
+
+// CheckCompliance returns the rules of the policy that the function breaks.
+// Required tags are only checked when the provider loaded the function's tags.
+func CheckCompliance(fn provider.FunctionInfo, policy config.CompliancePolicy) []Violation {
+	var violations []Violation
+	add := func(rule, detail string) {
+		violations = append(violations, Violation{FunctionName: fn.Name, Rule: rule, Detail: detail})
+	}
+
+	if len(policy.AllowedRuntimes) > 0 && fn.Runtime != "" && !matchesAny(fn.Runtime, policy.AllowedRuntimes) {
+		add("runtime", fmt.Sprintf("runtime %s is not allowed (allowed: %s)", fn.Runtime, strings.Join(policy.AllowedRuntimes, ", ")))
+	}
+
+	if policy.MaxTimeout > 0 && fn.Timeout > policy.MaxTimeout {
+		add("timeout", fmt.Sprintf("timeout %ds exceeds the maximum of %ds", fn.Timeout, policy.MaxTimeout))
+	}
+
+	if fn.Tags != nil {
+		var missing []string
+		for _, key := range policy.RequiredTags {
+			if _, ok := fn.Tags[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			add("tags", "missing required tags: "+strings.Join(missing, ", "))
+		}
+	}
+
+	for _, region := range policy.ForbiddenRegions {
+		if strings.EqualFold(fn.Region, region) {
+			add("region", fmt.Sprintf("region %s is forbidden", fn.Region))
+			break
+		}
+	}
+
+	return violations
+}
+
+// matchesAny reports whether name equals or matches the glob of any pattern
+func matchesAny(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok || pattern == name {
+			return true
+		}
+	}
+	return false
+}
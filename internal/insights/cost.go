@@ -0,0 +1,84 @@
+package insights
+
+import "math"
+
+const (
+	// pricePerGBSecond is the on-demand Lambda compute price (x86, us-east-1)
+	pricePerGBSecond = 0.0000166667
+	// pricePerRequest is the Lambda request price ($0.20 per million)
+	pricePerRequest = 0.20 / 1_000_000
+)
+
+// DurationBucket counts invocations whose billed duration is at most UpperMs
+type DurationBucket struct {
+	Label   string
+	UpperMs float64
+	Count   int
+}
+
+// durationBuckets are the histogram bounds for billed duration
+var durationBuckets = []DurationBucket{
+	{Label: "≤100ms", UpperMs: 100},
+	{Label: "≤250ms", UpperMs: 250},
+	{Label: "≤500ms", UpperMs: 500},
+	{Label: "≤1s", UpperMs: 1000},
+	{Label: "≤3s", UpperMs: 3000},
+	{Label: "≤10s", UpperMs: 10000},
+	{Label: ">10s", UpperMs: math.Inf(1)},
+}
+
+// CostSummary is the billed duration distribution and estimated cost of a
+// set of invocations
+type CostSummary struct {
+	Invocations      int
+	AvgBilledMs      float64
+	P50BilledMs      float64
+	P90BilledMs      float64
+	P99BilledMs      float64
+	GBSeconds        float64
+	TotalUSD         float64
+	PerInvocationUSD float64
+	Histogram        []DurationBucket
+}
+
+// AnalyzeCost computes the billed duration distribution and estimated cost
+// from REPORT lines using each invocation's own memory size
+func AnalyzeCost(reports []Report) (CostSummary, bool) {
+	if len(reports) == 0 {
+		return CostSummary{}, false
+	}
+
+	s := CostSummary{
+		Invocations: len(reports),
+		Histogram:   append([]DurationBucket(nil), durationBuckets...),
+	}
+	billed := make([]float64, 0, len(reports))
+	total := 0.0
+	for _, r := range reports {
+		billed = append(billed, r.BilledDuration)
+		total += r.BilledDuration
+		s.GBSeconds += r.BilledDuration / 1000 * float64(r.MemorySizeMB) / 1024
+
+		for i := range s.Histogram {
+			if r.BilledDuration <= s.Histogram[i].UpperMs {
+				s.Histogram[i].Count++
+				break
+			}
+		}
+	}
+
+	s.AvgBilledMs = total / float64(len(reports))
+	s.P50BilledMs = percentile(billed, 50)
+	s.P90BilledMs = percentile(billed, 90)
+	s.P99BilledMs = percentile(billed, 99)
+	s.TotalUSD = s.GBSeconds*pricePerGBSecond + float64(len(reports))*pricePerRequest
+	s.PerInvocationUSD = s.TotalUSD / float64(len(reports))
+	return s, true
+}
+
+// EstimateCost estimates the on-demand cost of invocations that ran for a
+// total of durationMs with the given memory size
+func EstimateCost(invocations, durationMs float64, memoryMB int32) float64 {
+	gbSeconds := durationMs / 1000 * float64(memoryMB) / 1024
+	return gbSeconds*pricePerGBSecond + invocations*pricePerRequest
+}
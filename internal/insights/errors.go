@@ -0,0 +1,100 @@
+package insights
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"f6n/internal/provider"
+)
+
+// ErrorGroup is a cluster of similar error log entries
+type ErrorGroup struct {
+	Signature string // normalized message shared by the group
+	Sample    string // most recent raw message in the group
+	Count     int
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+var (
+	// exceptionLineRe finds the line of a stack trace naming the exception
+	exceptionLineRe = regexp.MustCompile(`\b[A-Za-z_.]*(Error|Exception|Fault)\b`)
+
+	// normalizers replace the variable parts of a message so similar errors match
+	normalizers = []struct {
+		re          *regexp.Regexp
+		replacement string
+	}{
+		{regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`), "<uuid>"},
+		{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?Z?`), "<time>"},
+		{regexp.MustCompile(`"[^"]*"`), `"<str>"`},
+		{regexp.MustCompile(`'[^']*'`), `'<str>'`},
+		{regexp.MustCompile(`\b0x[0-9a-fA-F]+\b|\b[0-9a-fA-F]{12,}\b`), "<hex>"},
+		{regexp.MustCompile(`\d+(\.\d+)?`), "<n>"},
+		{regexp.MustCompile(`\s+`), " "},
+	}
+)
+
+// maxSignatureLen keeps signatures readable in the view
+const maxSignatureLen = 200
+
+// ErrorSignature reduces an error message to a stable signature: the line
+// naming the exception (or the first line) with IDs, numbers and quoted
+// values replaced by placeholders
+func ErrorSignature(message string) string {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	line := lines[0]
+	// Python puts the exception last, Java/Node first; prefer the last match
+	for i := len(lines) - 1; i >= 0; i-- {
+		if exceptionLineRe.MatchString(lines[i]) {
+			line = lines[i]
+			break
+		}
+	}
+
+	for _, n := range normalizers {
+		line = n.re.ReplaceAllString(line, n.replacement)
+	}
+	line = strings.TrimSpace(line)
+	if len(line) > maxSignatureLen {
+		line = line[:maxSignatureLen] + "…"
+	}
+	return line
+}
+
+// GroupErrors clusters log entries by error signature, ordered by count
+// (most frequent first) and then by most recent occurrence
+func GroupErrors(entries []provider.LogEntry) []ErrorGroup {
+	groups := map[string]*ErrorGroup{}
+	for _, entry := range entries {
+		sig := ErrorSignature(entry.Message)
+		g, ok := groups[sig]
+		if !ok {
+			g = &ErrorGroup{Signature: sig, FirstSeen: entry.Timestamp, LastSeen: entry.Timestamp, Sample: entry.Message}
+			groups[sig] = g
+		}
+
+		g.Count++
+		if entry.Timestamp.Before(g.FirstSeen) {
+			g.FirstSeen = entry.Timestamp
+		}
+		if !entry.Timestamp.Before(g.LastSeen) {
+			g.LastSeen = entry.Timestamp
+			g.Sample = entry.Message
+		}
+	}
+
+	result := make([]ErrorGroup, 0, len(groups))
+	for _, g := range groups {
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].LastSeen.After(result[j].LastSeen)
+	})
+	return result
+}
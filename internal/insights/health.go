@@ -0,0 +1,130 @@
+package insights
+
+import (
+	"fmt"
+	"strings"
+
+	"f6n/internal/provider"
+)
+
+const (
+	// warnErrorRate and critErrorRate are the error rate thresholds for health
+	warnErrorRate = 0.01
+	critErrorRate = 0.05
+)
+
+// HealthLevel is the severity of a health check, ordered from best to worst
+type HealthLevel int
+
+const (
+	HealthUnknown HealthLevel = iota
+	HealthOK
+	HealthWarn
+	HealthCrit
+)
+
+// String returns the label used in the Health column
+func (l HealthLevel) String() string {
+	switch l {
+	case HealthOK:
+		return "OK"
+	case HealthWarn:
+		return "Warn"
+	case HealthCrit:
+		return "Crit"
+	default:
+		return "?"
+	}
+}
+
+// Icon returns the status icon for a level
+func (l HealthLevel) Icon() string {
+	switch l {
+	case HealthOK:
+		return "✅"
+	case HealthWarn:
+		return "⚠️"
+	case HealthCrit:
+		return "🔴"
+	default:
+		return "…"
+	}
+}
+
+// HealthCheck is one input of the health summary
+type HealthCheck struct {
+	Name   string
+	Level  HealthLevel
+	Detail string
+}
+
+// Health is the combined health of a function; Level is the worst check
+type Health struct {
+	Level  HealthLevel
+	Checks []HealthCheck
+}
+
+func (h *Health) add(name string, level HealthLevel, detail string) {
+	h.Checks = append(h.Checks, HealthCheck{Name: name, Level: level, Detail: detail})
+	if level > h.Level {
+		h.Level = level
+	}
+}
+
+// AssessHealth combines the function state, last update status, recent error
+// rate and firing alarms into a single health level. stats is nil when no
+// invocation statistics are available.
+func AssessHealth(fn provider.FunctionInfo, stats *provider.FunctionStats, firingAlarms []string) Health {
+	var h Health
+
+	if fn.State != "" {
+		level := HealthOK
+		switch strings.ToUpper(fn.State) {
+		case "FAILED", "OFFLINE":
+			level = HealthCrit
+		case "PENDING", "INACTIVE", "DEPLOYING", "DEPLOY_IN_PROGRESS", "DELETE_IN_PROGRESS", "UNKNOWN", "NOT READY":
+			level = HealthWarn
+		}
+		h.add("State", level, withReason(fn.State, fn.StateReason))
+	}
+
+	if fn.LastUpdateStatus != "" {
+		level := HealthOK
+		switch strings.ToUpper(fn.LastUpdateStatus) {
+		case "FAILED":
+			level = HealthCrit
+		case "INPROGRESS":
+			level = HealthWarn
+		}
+		h.add("Last update", level, withReason(fn.LastUpdateStatus, fn.LastUpdateStatusReason))
+	}
+
+	if stats != nil && stats.Invocations > 0 {
+		rate := stats.ErrorRate()
+		level := HealthOK
+		switch {
+		case rate >= critErrorRate:
+			level = HealthCrit
+		case rate >= warnErrorRate:
+			level = HealthWarn
+		}
+		h.add("Error rate", level, fmt.Sprintf("%.2f%% (%.0f of %.0f invocations)", rate*100, stats.Errors, stats.Invocations))
+	}
+
+	if firingAlarms != nil {
+		if len(firingAlarms) > 0 {
+			h.add("Alarms", HealthCrit, "in ALARM: "+strings.Join(firingAlarms, ", "))
+		} else {
+			h.add("Alarms", HealthOK, "none firing")
+		}
+	}
+
+	return h
+}
+
+func withReason(status, reason string) string {
+	if reason == "" {
+		return status
+	}
+	return status + " — " + reason
+}
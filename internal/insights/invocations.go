@@ -0,0 +1,139 @@
+package insights
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"f6n/internal/provider"
+)
+
+// Statuses of an invocation
+const (
+	InvocationOK      = "ok"
+	InvocationError   = "error"
+	InvocationTimeout = "timeout"
+	InvocationRunning = "running" // started, without a REPORT or end line among the logs read
+)
+
+// Invocation is a function run reconstructed from its log lines
+type Invocation struct {
+	RequestID string // Lambda request ID, or GCP execution ID
+	Start     time.Time
+	Duration  float64 // ms, 0 when unknown
+	Report    *Report // Lambda REPORT line, nil when missing or on GCP
+	Status    string
+	Entries   []provider.LogEntry // All the lines of the invocation, oldest first
+}
+
+var (
+	// Lambda writes these lines around every invocation
+	startRe = regexp.MustCompile(`^START RequestId: (\S+)`)
+	endRe   = regexp.MustCompile(`^END RequestId: (\S+)`)
+	// Runtimes tab-separate the request ID in the lines they write, e.g.
+	// "2024-01-02T03:04:05.678Z\t<request id>\tINFO\tmessage"
+	tabbedRequestRe = regexp.MustCompile(`^\S+\t([0-9a-f-]{36})\t`)
+	// GCP 1st gen ends an execution with e.g.
+	// "Function execution took 123 ms, finished with status: 'crash'"
+	gcpFinishedRe = regexp.MustCompile(`^Function execution took (\d+) ms, finished with status(?: code)?: '?([^']+)'?`)
+)
+
+// GroupInvocations groups log lines by the invocation they belong to, newest
+// first. Lambda lines are grouped by the START/END/REPORT lines of their log
+// stream and the request ID runtimes prefix lines with; GCP lines by their
+// execution_id label. Lines outside any invocation are left out.
+func GroupInvocations(entries []provider.LogEntry) []Invocation {
+	sorted := append([]provider.LogEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+
+	byID := map[string]*Invocation{}
+	var order []string
+	current := map[string]string{} // open request ID by log stream
+	get := func(id string, at time.Time) *Invocation {
+		inv, ok := byID[id]
+		if !ok {
+			inv = &Invocation{RequestID: id, Start: at, Status: InvocationRunning}
+			byID[id] = inv
+			order = append(order, id)
+		}
+		return inv
+	}
+
+	for _, entry := range sorted {
+		message := strings.TrimSpace(entry.Message)
+		stream := entry.Labels["logStream"]
+
+		var id string
+		switch {
+		case entry.Labels["execution_id"] != "":
+			id = entry.Labels["execution_id"]
+		case startRe.MatchString(message):
+			id = startRe.FindStringSubmatch(message)[1]
+			current[stream] = id
+		case endRe.MatchString(message):
+			id = endRe.FindStringSubmatch(message)[1]
+		case strings.HasPrefix(message, ReportMarker):
+			if report, ok := ParseReport(entry); ok {
+				id = report.RequestID
+				inv := get(id, entry.Timestamp)
+				inv.Report = &report
+				inv.Duration = report.Duration
+				delete(current, stream)
+			}
+		case tabbedRequestRe.MatchString(message):
+			id = tabbedRequestRe.FindStringSubmatch(message)[1]
+		default:
+			id = current[stream]
+		}
+		if id == "" {
+			continue
+		}
+
+		inv := get(id, entry.Timestamp)
+		inv.Entries = append(inv.Entries, entry)
+		if m := gcpFinishedRe.FindStringSubmatch(message); m != nil {
+			inv.Duration, _ = strconv.ParseFloat(m[1], 64)
+			inv.Status = gcpStatus(m[2])
+		}
+	}
+
+	invocations := make([]Invocation, 0, len(order))
+	for _, id := range order {
+		inv := byID[id]
+		if inv.Report != nil {
+			inv.Status = lambdaStatus(*inv)
+		}
+		invocations = append(invocations, *inv)
+	}
+	sort.SliceStable(invocations, func(i, j int) bool { return invocations[i].Start.After(invocations[j].Start) })
+	return invocations
+}
+
+// lambdaStatus derives the outcome of a finished Lambda invocation from its lines
+func lambdaStatus(inv Invocation) string {
+	status := InvocationOK
+	for _, entry := range inv.Entries {
+		switch {
+		case strings.Contains(entry.Message, "Task timed out"), strings.Contains(entry.Message, "Status: timeout"):
+			return InvocationTimeout
+		case entry.Severity == "ERROR", entry.Severity == "CRITICAL",
+			strings.Contains(entry.Message, `"errorType"`), strings.Contains(entry.Message, "Status: error"):
+			status = InvocationError
+		}
+	}
+	return status
+}
+
+// gcpStatus maps the finished status of a GCP execution, e.g. ok, crash,
+// timeout or error
+func gcpStatus(status string) string {
+	switch status {
+	case "ok", "200":
+		return InvocationOK
+	case "timeout":
+		return InvocationTimeout
+	}
+	return InvocationError
+}
@@ -0,0 +1,121 @@
+package insights
+
+import (
+	"fmt"
+	"math"
+
+	"f6n/internal/provider"
+)
+
+const (
+	// underProvisionedRatio flags functions whose peak usage nears the limit
+	underProvisionedRatio = 0.9
+	// overProvisionedRatio flags functions whose p95 usage is far below the limit
+	overProvisionedRatio = 0.4
+	// minLambdaMemoryMB is the smallest memory size worth suggesting
+	minLambdaMemoryMB = 128
+	// memoryStepMB rounds suggestions to a tidy size
+	memoryStepMB = 64
+	// memoryChartBuckets is the number of points kept for the utilization chart
+	memoryChartBuckets = 8
+)
+
+// Provisioning classifies how well a function's memory matches its usage
+type Provisioning string
+
+const (
+	WellSized        Provisioning = "well sized"
+	UnderProvisioned Provisioning = "under-provisioned"
+	OverProvisioned  Provisioning = "over-provisioned"
+)
+
+// MemoryUtilization summarizes memory usage across invocations
+type MemoryUtilization struct {
+	ConfiguredMB int
+	Samples      int
+	MaxUsedMB    float64
+	AvgUsedMB    float64
+	P95UsedMB    float64
+	Points       []provider.MetricDataPoint // peak utilization (% of configured) over time
+	Provisioning Provisioning
+	SuggestedMB  int // 0 when no change is suggested
+}
+
+// Suggestion returns a human readable right-sizing recommendation
+func (u MemoryUtilization) Suggestion() string {
+	switch u.Provisioning {
+	case UnderProvisioned:
+		return fmt.Sprintf("Peak usage is %.0f%% of %d MB; raise memory to %d MB to avoid out-of-memory failures.",
+			u.MaxUsedMB/float64(u.ConfiguredMB)*100, u.ConfiguredMB, u.SuggestedMB)
+	case OverProvisioned:
+		return fmt.Sprintf("p95 usage is only %.0f%% of %d MB; consider %d MB (memory also scales CPU, so check duration afterwards).",
+			u.P95UsedMB/float64(u.ConfiguredMB)*100, u.ConfiguredMB, u.SuggestedMB)
+	default:
+		return "Memory matches observed usage."
+	}
+}
+
+// AnalyzeMemory computes memory utilization from REPORT lines. configuredMB is
+// used when the reports don't carry a memory size.
+func AnalyzeMemory(reports []Report, configuredMB int) (MemoryUtilization, bool) {
+	if len(reports) == 0 {
+		return MemoryUtilization{}, false
+	}
+
+	// The most recent report reflects the current configuration
+	if size := reports[len(reports)-1].MemorySizeMB; size > 0 {
+		configuredMB = size
+	}
+	if configuredMB <= 0 {
+		return MemoryUtilization{}, false
+	}
+
+	u := MemoryUtilization{ConfiguredMB: configuredMB, Samples: len(reports)}
+	used := make([]float64, 0, len(reports))
+	points := make([]provider.MetricDataPoint, 0, len(reports))
+	total := 0.0
+	for _, r := range reports {
+		mb := float64(r.MaxMemoryUsedMB)
+		used = append(used, mb)
+		total += mb
+		if mb > u.MaxUsedMB {
+			u.MaxUsedMB = mb
+		}
+		size := r.MemorySizeMB
+		if size <= 0 {
+			size = configuredMB
+		}
+		points = append(points, provider.MetricDataPoint{Timestamp: r.Timestamp, Value: mb / float64(size) * 100})
+	}
+	u.AvgUsedMB = total / float64(len(reports))
+	u.P95UsedMB = percentile(used, 95)
+	u.Points = bucketMax(points, memoryChartBuckets)
+
+	limit := float64(configuredMB)
+	switch {
+	case u.MaxUsedMB >= limit*underProvisionedRatio:
+		u.Provisioning = UnderProvisioned
+		u.SuggestedMB = roundUpMemory(u.MaxUsedMB * 1.25)
+		if u.SuggestedMB <= configuredMB {
+			u.SuggestedMB = configuredMB + memoryStepMB
+		}
+	case u.P95UsedMB < limit*overProvisionedRatio && configuredMB > minLambdaMemoryMB:
+		u.Provisioning = OverProvisioned
+		u.SuggestedMB = roundUpMemory(u.P95UsedMB * 1.5)
+		if u.SuggestedMB >= configuredMB {
+			u.Provisioning, u.SuggestedMB = WellSized, 0
+		}
+	default:
+		u.Provisioning = WellSized
+	}
+	return u, true
+}
+
+// roundUpMemory rounds a size up to the next memory step, never below the minimum
+func roundUpMemory(mb float64) int {
+	size := int(math.Ceil(mb/memoryStepMB)) * memoryStepMB
+	if size < minLambdaMemoryMB {
+		size = minLambdaMemoryMB
+	}
+	return size
+}
@@ -0,0 +1,71 @@
+package insights
+
+import (
+	"strings"
+
+	"f6n/internal/provider"
+)
+
+// Ownership is who a function belongs to, according to the conventional
+// owner, team and service tags (AWS) or labels (GCP)
+type Ownership struct {
+	Owner   string
+	Team    string
+	Service string
+}
+
+// FunctionOwnership reads the ownership tags of a function. Tag keys are
+// matched regardless of case, e.g. Owner or TEAM.
+func FunctionOwnership(fn provider.FunctionInfo) Ownership {
+	var o Ownership
+	for key, value := range fn.Tags {
+		switch strings.ToLower(key) {
+		case "owner":
+			o.Owner = value
+		case "team":
+			o.Team = value
+		case "service":
+			o.Service = value
+		}
+	}
+	return o
+}
+
+// Primary returns who to ask about the function first: the owner, else the
+// team, else the service; empty when the function has no ownership tags
+func (o Ownership) Primary() string {
+	switch {
+	case o.Owner != "":
+		return o.Owner
+	case o.Team != "":
+		return o.Team
+	}
+	return o.Service
+}
+
+// Matches reports whether the owner, team or service contains text,
+// ignoring case
+func (o Ownership) Matches(text string) bool {
+	text = strings.ToLower(text)
+	for _, value := range []string{o.Owner, o.Team, o.Service} {
+		if value != "" && strings.Contains(strings.ToLower(value), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the ownership tags set, e.g. "owner jane, team payments"
+func (o Ownership) String() string {
+	var parts []string
+	if o.Owner != "" {
+		parts = append(parts, "owner "+o.Owner)
+	}
+	if o.Team != "" {
+		parts = append(parts, "team "+o.Team)
+	}
+	if o.Service != "" {
+		parts = append(parts, "service "+o.Service)
+	}
+	return strings.Join(parts, ", ")
+}
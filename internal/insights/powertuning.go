@@ -0,0 +1,111 @@
+package insights
+
+import (
+	"math"
+	"strings"
+
+	"f6n/internal/provider"
+)
+
+// PowerTuningResult is the measured duration and estimated cost of a
+// function's invocations at one memory size
+type PowerTuningResult struct {
+	MemoryMB          int32
+	Invocations       int
+	Errors            int // invocations the function failed
+	ColdStarts        int
+	AvgDurationMs     float64 // of the warm invocations, or all when every one was cold
+	AvgBilledMs       float64
+	MaxMemoryUsedMB   int
+	CostPerMillionUSD float64 // a million invocations at AvgBilledMs
+}
+
+// PowerTuningPicks are the indexes of the cheapest, fastest and balanced
+// results, -1 when no result was measured
+type PowerTuningPicks struct {
+	Cheapest int
+	Fastest  int
+	Balanced int // lowest sum of the cost and duration, each scaled to 0-1
+}
+
+// ReportFromLog returns the REPORT line at the end of an invocation's log
+func ReportFromLog(log string) (Report, bool) {
+	lines := strings.Split(strings.TrimSpace(log), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if report, ok := ParseReport(provider.LogEntry{Message: lines[i]}); ok {
+			return report, true
+		}
+	}
+	return Report{}, false
+}
+
+// MeasurePower summarizes the REPORT lines of the invocations run at
+// memoryMB. The first invocation after a memory change starts a new
+// environment, so cold starts are left out of the averages unless every
+// invocation was one.
+func MeasurePower(memoryMB int32, reports []Report, errors int) PowerTuningResult {
+	result := PowerTuningResult{MemoryMB: memoryMB, Invocations: len(reports), Errors: errors}
+	warm := make([]Report, 0, len(reports))
+	for _, r := range reports {
+		if r.InitDuration > 0 {
+			result.ColdStarts++
+		} else {
+			warm = append(warm, r)
+		}
+		result.MaxMemoryUsedMB = max(result.MaxMemoryUsedMB, r.MaxMemoryUsedMB)
+	}
+	if len(warm) == 0 {
+		warm = reports
+	}
+	if len(warm) == 0 {
+		return result
+	}
+
+	for _, r := range warm {
+		result.AvgDurationMs += r.Duration
+		result.AvgBilledMs += r.BilledDuration
+	}
+	result.AvgDurationMs /= float64(len(warm))
+	result.AvgBilledMs /= float64(len(warm))
+	result.CostPerMillionUSD = EstimateCost(1_000_000, result.AvgBilledMs*1_000_000, memoryMB)
+	return result
+}
+
+// PickPowerTuning picks the cheapest, the fastest and the best balanced of
+// the memory sizes with measured invocations
+func PickPowerTuning(results []PowerTuningResult) PowerTuningPicks {
+	picks := PowerTuningPicks{Cheapest: -1, Fastest: -1, Balanced: -1}
+	minCost, maxCost := math.Inf(1), math.Inf(-1)
+	minDuration, maxDuration := math.Inf(1), math.Inf(-1)
+	for i, r := range results {
+		if r.Invocations == 0 {
+			continue
+		}
+		if picks.Cheapest < 0 || r.CostPerMillionUSD < results[picks.Cheapest].CostPerMillionUSD {
+			picks.Cheapest = i
+		}
+		if picks.Fastest < 0 || r.AvgDurationMs < results[picks.Fastest].AvgDurationMs {
+			picks.Fastest = i
+		}
+		minCost, maxCost = math.Min(minCost, r.CostPerMillionUSD), math.Max(maxCost, r.CostPerMillionUSD)
+		minDuration, maxDuration = math.Min(minDuration, r.AvgDurationMs), math.Max(maxDuration, r.AvgDurationMs)
+	}
+
+	scale := func(v, lo, hi float64) float64 {
+		if hi <= lo {
+			return 0
+		}
+		return (v - lo) / (hi - lo)
+	}
+	best := math.Inf(1)
+	for i, r := range results {
+		if r.Invocations == 0 {
+			continue
+		}
+		score := scale(r.CostPerMillionUSD, minCost, maxCost) + scale(r.AvgDurationMs, minDuration, maxDuration)
+		if score < best {
+			best, picks.Balanced = score, i
+		}
+	}
+	return picks
+}
@@ -0,0 +1,120 @@
+package insights
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"f6n/internal/provider"
+)
+
+// ReportMarker is the prefix of the summary line Lambda writes after every invocation
+const ReportMarker = "REPORT RequestId:"
+
+// Report holds the fields of a Lambda REPORT log line
+type Report struct {
+	Timestamp       time.Time
+	RequestID       string
+	Duration        float64 // ms
+	BilledDuration  float64 // ms
+	InitDuration    float64 // ms, only set on cold starts
+	MemorySizeMB    int
+	MaxMemoryUsedMB int
+}
+
+// reportFieldRe matches the "Name: value unit" pairs of a REPORT line
+var reportFieldRe = regexp.MustCompile(`(RequestId|Duration|Billed Duration|Init Duration|Memory Size|Max Memory Used):\s*([^\s]+)`)
+
+// ParseReport parses a REPORT line such as
+// "REPORT RequestId: abc Duration: 12.3 ms Billed Duration: 13 ms Memory Size: 128 MB Max Memory Used: 70 MB"
+func ParseReport(entry provider.LogEntry) (Report, bool) {
+	message := strings.TrimSpace(entry.Message)
+	if !strings.HasPrefix(message, ReportMarker) {
+		return Report{}, false
+	}
+
+	report := Report{Timestamp: entry.Timestamp}
+	for _, m := range reportFieldRe.FindAllStringSubmatch(message, -1) {
+		value := m[2]
+		switch m[1] {
+		case "RequestId":
+			report.RequestID = value
+		case "Duration":
+			report.Duration, _ = strconv.ParseFloat(value, 64)
+		case "Billed Duration":
+			report.BilledDuration, _ = strconv.ParseFloat(value, 64)
+		case "Init Duration":
+			report.InitDuration, _ = strconv.ParseFloat(value, 64)
+		case "Memory Size":
+			report.MemorySizeMB, _ = strconv.Atoi(value)
+		case "Max Memory Used":
+			report.MaxMemoryUsedMB, _ = strconv.Atoi(value)
+		}
+	}
+	return report, report.MemorySizeMB > 0
+}
+
+// ParseReports extracts the REPORT lines from log entries, oldest first
+func ParseReports(entries []provider.LogEntry) []Report {
+	var reports []Report
+	for _, entry := range entries {
+		if report, ok := ParseReport(entry); ok {
+			reports = append(reports, report)
+		}
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].Timestamp.Before(reports[j].Timestamp)
+	})
+	return reports
+}
+
+// percentile returns the p-th percentile (0-100) of values using nearest rank
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// bucketMax splits points into n equal time buckets and keeps the maximum of
+// each non-empty bucket, so long series fit in the small terminal charts
+func bucketMax(points []provider.MetricDataPoint, n int) []provider.MetricDataPoint {
+	if len(points) <= n || n < 1 {
+		return points
+	}
+
+	start, end := points[0].Timestamp, points[len(points)-1].Timestamp
+	span := end.Sub(start)
+	if span <= 0 {
+		return points[len(points)-n:]
+	}
+
+	buckets := make([]*provider.MetricDataPoint, n)
+	for _, p := range points {
+		i := int(float64(p.Timestamp.Sub(start)) / float64(span) * float64(n))
+		if i >= n {
+			i = n - 1
+		}
+		if buckets[i] == nil || p.Value > buckets[i].Value {
+			point := p
+			buckets[i] = &point
+		}
+	}
+
+	var result []provider.MetricDataPoint
+	for _, b := range buckets {
+		if b != nil {
+			result = append(result, *b)
+		}
+	}
+	return result
+}
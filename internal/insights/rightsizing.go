@@ -0,0 +1,128 @@
+package insights
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+)
+
+const (
+	// rightSizingFunctions is the number of most expensive functions whose
+	// memory usage is read for recommendations
+	rightSizingFunctions = 25
+	// rightSizingReports caps the REPORT lines read per function
+	rightSizingReports = 1000
+	// rightSizingWorkers bounds the log reads running at once
+	rightSizingWorkers = 4
+	// month is the period savings are projected over
+	month = 30 * 24 * time.Hour
+)
+
+// RightSizing is a memory recommendation for a function, with its cost at
+// the current and the recommended memory size projected to a month
+type RightSizing struct {
+	Function           string
+	Memory             MemoryUtilization
+	MonthlyInvocations float64
+	AvgDurationMs      float64
+	MonthlyCostUSD     float64
+	ProjectedCostUSD   float64 // at Memory.SuggestedMB, or the current cost when no change is suggested
+}
+
+// MonthlySavingsUSD returns what the recommendation saves per month,
+// negative when memory should be raised
+func (r RightSizing) MonthlySavingsUSD() float64 {
+	return r.MonthlyCostUSD - r.ProjectedCostUSD
+}
+
+// RecommendMemory combines the memory usage of a function's invocations
+// with its invocation volume and duration over window into a recommendation.
+// The projected cost assumes the duration stays the same: memory also sets
+// the CPU share, so functions bound by CPU may run longer with less of it.
+func RecommendMemory(fn provider.FunctionInfo, stats provider.FunctionStats, reports []Report, window time.Duration) (RightSizing, bool) {
+	memory, ok := AnalyzeMemory(reports, int(fn.Memory))
+	if !ok || stats.Invocations == 0 || window <= 0 {
+		return RightSizing{}, false
+	}
+
+	scale := float64(month) / float64(window)
+	r := RightSizing{
+		Function:           fn.Name,
+		Memory:             memory,
+		MonthlyInvocations: stats.Invocations * scale,
+		AvgDurationMs:      stats.TotalDurationMs / stats.Invocations,
+	}
+	r.MonthlyCostUSD = EstimateCost(r.MonthlyInvocations, stats.TotalDurationMs*scale, int32(memory.ConfiguredMB))
+	r.ProjectedCostUSD = r.MonthlyCostUSD
+	if memory.SuggestedMB > 0 {
+		r.ProjectedCostUSD = EstimateCost(r.MonthlyInvocations, stats.TotalDurationMs*scale, int32(memory.SuggestedMB))
+	}
+	return r, true
+}
+
+// CollectRightSizing recommends memory sizes for the most expensive invoked
+// functions, reading the memory used by their invocations since the start
+// of window from their REPORT log lines. Functions without REPORT lines,
+// such as GCP functions, get no recommendation. The result is sorted by
+// monthly savings, the functions needing more memory last.
+func CollectRightSizing(ctx context.Context, prov provider.Provider, functions []provider.FunctionInfo, stats map[string]provider.FunctionStats, window time.Duration) []RightSizing {
+	candidates := make([]provider.FunctionInfo, 0, len(functions))
+	for _, fn := range functions {
+		if stats[fn.Name].Invocations > 0 {
+			candidates = append(candidates, fn)
+		}
+	}
+	cost := func(fn provider.FunctionInfo) float64 {
+		s := stats[fn.Name]
+		return EstimateCost(s.Invocations, s.TotalDurationMs, fn.Memory)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return cost(candidates[i]) > cost(candidates[j]) })
+	if len(candidates) > rightSizingFunctions {
+		candidates = candidates[:rightSizingFunctions]
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results []RightSizing
+	)
+	since := time.Now().Add(-window)
+	sem := make(chan struct{}, rightSizingWorkers)
+	for _, fn := range candidates {
+		wg.Add(1)
+		go func(fn provider.FunctionInfo) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			entries, err := prov.GetFunctionLogs(ctx, fn.Name, provider.LogQuery{
+				Limit: rightSizingReports,
+				Since: since,
+				Text:  ReportMarker,
+			})
+			if err != nil {
+				logger.Logger.Printf("Error reading invocation reports of %s: %v", fn.Name, err)
+				return
+			}
+			if r, ok := RecommendMemory(fn, stats[fn.Name], ParseReports(entries), window); ok {
+				mu.Lock()
+				results = append(results, r)
+				mu.Unlock()
+			}
+		}(fn)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		si, sj := results[i].MonthlySavingsUSD(), results[j].MonthlySavingsUSD()
+		if si != sj {
+			return si > sj
+		}
+		return results[i].Function < results[j].Function
+	})
+	return results
+}
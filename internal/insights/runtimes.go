@@ -0,0 +1,62 @@
+package insights
+
+import (
+	"strings"
+	"time"
+)
+
+// deprecationNotice is how far ahead a runtime deprecation is flagged
+const deprecationNotice = 180 * 24 * time.Hour
+
+// runtimeDeprecations lists the dates on which Lambda and Cloud Functions
+// runtimes were (or will be) deprecated, from the providers' published
+// runtime support schedules
+var runtimeDeprecations = map[string]string{
+	// AWS Lambda
+	"nodejs12.x":    "2023-03-31",
+	"nodejs14.x":    "2023-12-04",
+	"nodejs16.x":    "2024-06-12",
+	"nodejs18.x":    "2025-09-01",
+	"python3.7":     "2023-12-04",
+	"python3.8":     "2024-10-14",
+	"python3.9":     "2025-12-15",
+	"java8":         "2024-01-08",
+	"go1.x":         "2024-01-08",
+	"provided":      "2024-01-08",
+	"ruby2.7":       "2023-12-07",
+	"ruby3.2":       "2026-03-31",
+	"dotnetcore3.1": "2023-04-03",
+	"dotnet6":       "2024-12-20",
+	// Cloud Functions
+	"nodejs10": "2024-01-30",
+	"nodejs12": "2024-01-30",
+	"nodejs14": "2024-01-30",
+	"nodejs16": "2024-01-30",
+	"python37": "2024-01-30",
+	"python38": "2024-10-14",
+	"go113":    "2024-01-30",
+	"go116":    "2024-01-30",
+	"ruby26":   "2024-01-30",
+	"ruby27":   "2024-01-30",
+}
+
+// RuntimeDeprecation describes a runtime that is deprecated or about to be
+type RuntimeDeprecation struct {
+	Runtime    string
+	Date       time.Time
+	Deprecated bool // false when the deprecation is still ahead
+}
+
+// CheckRuntime reports whether a runtime is deprecated as of now or will be
+// within deprecationNotice
+func CheckRuntime(runtime string, now time.Time) (RuntimeDeprecation, bool) {
+	date, ok := runtimeDeprecations[strings.ToLower(runtime)]
+	if !ok {
+		return RuntimeDeprecation{}, false
+	}
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil || t.Sub(now) > deprecationNotice {
+		return RuntimeDeprecation{}, false
+	}
+	return RuntimeDeprecation{Runtime: runtime, Date: t, Deprecated: !t.After(now)}, true
+}
@@ -0,0 +1,57 @@
+package insights
+
+import (
+	"context"
+	"time"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+)
+
+// CollectStats returns invocation statistics for the given functions, using
+// the provider's batch API when available and the per-function metrics
+// otherwise
+func CollectStats(ctx context.Context, prov provider.Provider, functions []provider.FunctionInfo, since time.Time) (map[string]provider.FunctionStats, error) {
+	names := make([]string, 0, len(functions))
+	for _, fn := range functions {
+		names = append(names, fn.Name)
+	}
+
+	if reporter, ok := provider.As[provider.StatsReporter](prov); ok {
+		return reporter.GetFunctionStats(ctx, names, since)
+	}
+
+	stats := make(map[string]provider.FunctionStats, len(names))
+	for _, name := range names {
+		metrics, err := prov.GetFunctionMetrics(ctx, name, since, time.Now())
+		if err != nil {
+			logger.Logger.Printf("Error fetching metrics for %s: %v", name, err)
+			continue
+		}
+		stats[name] = statsFromMetrics(metrics)
+	}
+	return stats, nil
+}
+
+// statsFromMetrics approximates FunctionStats from metric time series, whose
+// duration points are per-interval averages
+func statsFromMetrics(metrics *provider.FunctionMetrics) provider.FunctionStats {
+	var s provider.FunctionStats
+	for _, point := range metrics.Invocations.DataPoints {
+		s.Invocations += point.Value
+	}
+	for _, point := range metrics.Errors.DataPoints {
+		s.Errors += point.Value
+	}
+
+	total := 0.0
+	for _, point := range metrics.Duration.DataPoints {
+		total += point.Value
+		s.MaxDurationMs = max(s.MaxDurationMs, point.Value)
+	}
+	s.P99DurationMs = s.MaxDurationMs
+	if n := len(metrics.Duration.DataPoints); n > 0 {
+		s.TotalDurationMs = total / float64(n) * s.Invocations
+	}
+	return s
+}
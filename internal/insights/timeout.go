@@ -0,0 +1,41 @@
+package insights
+
+// TimeoutRiskRatio is the share of the configured timeout above which a
+// function is considered at risk of timing out
+const TimeoutRiskRatio = 0.8
+
+// TimeoutRisk compares a function's peak duration with its timeout
+type TimeoutRisk struct {
+	PeakMs    float64
+	TimeoutMs float64
+}
+
+// Ratio returns the peak duration as a fraction of the timeout
+func (r TimeoutRisk) Ratio() float64 {
+	if r.TimeoutMs <= 0 {
+		return 0
+	}
+	return r.PeakMs / r.TimeoutMs
+}
+
+// AtRisk reports whether the peak duration is within TimeoutRiskRatio of the timeout
+func (r TimeoutRisk) AtRisk() bool {
+	return r.TimeoutMs > 0 && r.Ratio() >= TimeoutRiskRatio
+}
+
+// AssessTimeout builds a TimeoutRisk from a peak duration in milliseconds and
+// a timeout in seconds
+func AssessTimeout(peakMs float64, timeoutSeconds int32) TimeoutRisk {
+	return TimeoutRisk{PeakMs: peakMs, TimeoutMs: float64(timeoutSeconds) * 1000}
+}
+
+// PeakDuration returns the longest duration among REPORT lines in milliseconds
+func PeakDuration(reports []Report) float64 {
+	peak := 0.0
+	for _, r := range reports {
+		if r.Duration > peak {
+			peak = r.Duration
+		}
+	}
+	return peak
+}
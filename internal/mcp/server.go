@@ -0,0 +1,154 @@
+// Package mcp serves the provider operations as a Model Context Protocol
+// server over stdio, so AI assistants can list functions, read logs and fetch
+// metrics through f6n's credentials.
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/version"
+)
+
+// protocolVersions are the MCP revisions this server speaks, newest first
+var protocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"` // absent on notifications
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Server is an MCP server for a provider
+type Server struct {
+	provider provider.Provider
+	tools    []tool
+
+	mu  sync.Mutex // serializes writes to out
+	out io.Writer
+}
+
+// New creates an MCP server for a provider
+func New(prov provider.Provider) *Server {
+	s := &Server{provider: prov}
+	s.tools = s.registerTools()
+	return s
+}
+
+// Serve reads newline-delimited JSON-RPC messages from in and writes responses
+// to out until in is closed or ctx is cancelled
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	s.out = out
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		var req request
+		if err := json.Unmarshal(line, &req); err != nil {
+			s.write(response{ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}})
+			continue
+		}
+		if req.JSONRPC != "2.0" || req.Method == "" {
+			s.write(response{ID: idOrNull(req.ID), Error: &rpcError{Code: codeInvalidRequest, Message: "invalid JSON-RPC 2.0 request"}})
+			continue
+		}
+
+		result, rpcErr := s.handle(ctx, req)
+		if req.ID == nil {
+			continue // notifications get no response
+		}
+		s.write(response{ID: req.ID, Result: result, Error: rpcErr})
+	}
+	return scanner.Err()
+}
+
+// handle dispatches a request to its method
+func (s *Server) handle(ctx context.Context, req request) (any, *rpcError) {
+	logger.Logger.Printf("MCP request: %s", req.Method)
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		protocol := protocolVersions[0]
+		if slices.Contains(protocolVersions, params.ProtocolVersion) {
+			protocol = params.ProtocolVersion
+		}
+		return map[string]any{
+			"protocolVersion": protocol,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]string{"name": "f6n", "version": version.Version},
+			"instructions": fmt.Sprintf("Tools for %s serverless functions in %s. Use list_functions to discover function names.",
+				s.provider.GetProviderName(), s.provider.GetRegion()),
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		return map[string]any{"tools": s.tools}, nil
+	case "tools/call":
+		return s.callTool(ctx, req.Params)
+	case "notifications/initialized", "notifications/cancelled":
+		return nil, nil
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+	}
+}
+
+func (s *Server) write(resp response) {
+	resp.JSONRPC = "2.0"
+	data, err := json.Marshal(resp)
+	if err != nil {
+		logger.Logger.Printf("Error encoding MCP response: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.out.Write(append(data, '\n')); err != nil {
+		logger.Logger.Printf("Error writing MCP response: %v", err)
+	}
+}
+
+func idOrNull(id json.RawMessage) json.RawMessage {
+	if id == nil {
+		return json.RawMessage("null")
+	}
+	return id
+}
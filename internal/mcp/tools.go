@@ -0,0 +1,169 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/provider"
+)
+
+const (
+	// defaultLogLimit and maxLogLimit bound the log entries returned to the assistant
+	defaultLogLimit = 100
+	maxLogLimit     = 1000
+	// defaultMetricsWindow applies when get_metrics doesn't set since
+	defaultMetricsWindow = time.Hour
+)
+
+// tool is an MCP tool definition with its handler
+type tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+	handler     func(ctx context.Context, args toolArgs) (any, error)
+}
+
+// toolArgs are the arguments of every tool; each tool uses a subset
+type toolArgs struct {
+	Name     string `json:"name"`
+	Limit    int    `json:"limit"`
+	Since    string `json:"since"`
+	Severity string `json:"severity"`
+	Text     string `json:"text"`
+}
+
+func schema(required []string, properties map[string]any) map[string]any {
+	s := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+var nameProperty = map[string]any{"type": "string", "description": "Function name, as returned by list_functions"}
+
+func (s *Server) registerTools() []tool {
+	return []tool{
+		{
+			Name:        "list_functions",
+			Description: "List the serverless functions with runtime, memory, timeout and state.",
+			InputSchema: schema(nil, map[string]any{}),
+			handler: func(ctx context.Context, _ toolArgs) (any, error) {
+				return s.provider.ListFunctions(ctx)
+			},
+		},
+		{
+			Name:        "get_function",
+			Description: "Get the configuration of a function, including handler, role and environment variables.",
+			InputSchema: schema([]string{"name"}, map[string]any{"name": nameProperty}),
+			handler: func(ctx context.Context, args toolArgs) (any, error) {
+				return s.provider.GetFunction(ctx, args.Name)
+			},
+		},
+		{
+			Name:        "get_logs",
+			Description: "Read recent log entries of a function, optionally filtered by minimum severity and text.",
+			InputSchema: schema([]string{"name"}, map[string]any{
+				"name":     nameProperty,
+				"limit":    map[string]any{"type": "integer", "description": fmt.Sprintf("Maximum entries (default %d, at most %d)", defaultLogLimit, maxLogLimit)},
+				"since":    map[string]any{"type": "string", "description": "Lookback window, e.g. 30m or 6h"},
+				"severity": map[string]any{"type": "string", "description": "Minimum severity, e.g. WARNING or ERROR"},
+				"text":     map[string]any{"type": "string", "description": "Text the message must contain"},
+			}),
+			handler: s.getLogs,
+		},
+		{
+			Name:        "get_metrics",
+			Description: "Fetch metric time series of a function: invocations, duration, errors, throttles, memory and concurrent executions.",
+			InputSchema: schema([]string{"name"}, map[string]any{
+				"name":  nameProperty,
+				"since": map[string]any{"type": "string", "description": "Window to fetch, e.g. 1h or 24h (default 1h)"},
+			}),
+			handler: s.getMetrics,
+		},
+	}
+}
+
+func (s *Server) getLogs(ctx context.Context, args toolArgs) (any, error) {
+	query := provider.LogQuery{
+		Limit:       defaultLogLimit,
+		MinSeverity: strings.ToUpper(args.Severity),
+		Text:        args.Text,
+	}
+	if args.Limit > 0 {
+		query.Limit = min(args.Limit, maxLogLimit)
+	}
+	if args.Since != "" {
+		since, err := time.ParseDuration(args.Since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid since %q (use e.g. 30m or 6h)", args.Since)
+		}
+		query.Since = time.Now().Add(-since)
+	}
+	return s.provider.GetFunctionLogs(ctx, args.Name, query)
+}
+
+func (s *Server) getMetrics(ctx context.Context, args toolArgs) (any, error) {
+	window := defaultMetricsWindow
+	if args.Since != "" {
+		d, err := time.ParseDuration(args.Since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid since %q (use e.g. 1h)", args.Since)
+		}
+		window = d
+	}
+	end := time.Now()
+	return s.provider.GetFunctionMetrics(ctx, args.Name, end.Add(-window), end)
+}
+
+// callTool runs a tool. Tool failures are reported in the result with
+// isError so the assistant can see them; protocol errors are JSON-RPC errors.
+func (s *Server) callTool(ctx context.Context, raw json.RawMessage) (any, *rpcError) {
+	var params struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	var t *tool
+	for i := range s.tools {
+		if s.tools[i].Name == params.Name {
+			t = &s.tools[i]
+		}
+	}
+	if t == nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool %q", params.Name)}
+	}
+
+	var args toolArgs
+	if len(params.Arguments) > 0 {
+		if err := json.Unmarshal(params.Arguments, &args); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("invalid arguments: %v", err)}
+		}
+	}
+	if required, _ := t.InputSchema["required"].([]string); len(required) > 0 && args.Name == "" {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "missing required argument \"name\""}
+	}
+
+	result, err := t.handler(ctx, args)
+	if err != nil {
+		return textResult(err.Error(), true), nil
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return textResult(fmt.Sprintf("failed to encode result: %v", err), true), nil
+	}
+	return textResult(string(data), false), nil
+}
+
+func textResult(text string, isError bool) map[string]any {
+	return map[string]any{
+		"content": []map[string]string{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
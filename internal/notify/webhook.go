@@ -0,0 +1,80 @@
+// Package notify delivers watch-mode notifications to a webhook.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Notification describes an anomaly detected on a function
+type Notification struct {
+	Provider string `json:"provider"`
+	Function string `json:"function"`
+	Kind     string `json:"kind"`
+	Summary  string `json:"summary"`
+}
+
+// Text renders the notification as a single chat line
+func (n Notification) Text() string {
+	return fmt.Sprintf(":rotating_light: f6n: *%s* (%s) %s", n.Function, strings.ToUpper(n.Provider), n.Summary)
+}
+
+// Webhook posts notifications as JSON to a URL. Slack incoming webhooks get a
+// plain {"text": ...} message; other endpoints also get the structured fields.
+type Webhook struct {
+	url   string
+	slack bool
+	http  *http.Client
+}
+
+// NewWebhook creates a webhook notifier for the given URL
+func NewWebhook(rawURL string) (*Webhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook URL %q", rawURL)
+	}
+	return &Webhook{
+		url:   rawURL,
+		slack: u.Host == "hooks.slack.com",
+		http:  &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Send posts a notification
+func (w *Webhook) Send(ctx context.Context, n Notification) error {
+	var payload any = struct {
+		Text string `json:"text"`
+		Notification
+	}{n.Text(), n}
+	if w.slack {
+		payload = map[string]string{"text": n.Text()}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post notification: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"f6n/internal/aws"
+	"f6n/internal/logger"
+
+	awstypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+const (
+	// inlineCodeLimit is the largest package GetFunctionCode fetches to show
+	// the handler, the size up to which the Lambda console edits code inline
+	inlineCodeLimit = 3 << 20
+	// inlineFileLimit is the largest handler file shown
+	inlineFileLimit = 512 << 10
+)
+
+// handlerExtensions are the source file extensions of the interpreted
+// runtimes whose handler can be shown, by runtime prefix
+var handlerExtensions = map[string][]string{
+	"python": {".py"},
+	"nodejs": {".js", ".mjs", ".cjs", ".ts"},
+	"ruby":   {".rb"},
+}
+
+// GetFunctionCode shows the handler file of functions packaged as a zip for
+// an interpreted runtime, read from the package in memory. Larger packages,
+// compiled runtimes and container images get where the code is instead.
+func (p *AWSProvider) GetFunctionCode(ctx context.Context, name string) (string, error) {
+	output, err := p.client.GetFunction(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	config := output.Configuration
+	if config != nil && config.PackageType == awstypes.PackageTypeImage {
+		image := ""
+		if output.Code != nil {
+			image = getString(output.Code.ImageUri)
+		}
+		return fmt.Sprintf("Container image function: its code is in the image %s\n\nPress 'esc' to go back.", image), nil
+	}
+	if output.Code == nil || output.Code.Location == nil {
+		return "Code location not available", nil
+	}
+	location := *output.Code.Location
+	if config == nil {
+		return codeLocationNote(location, "the function's configuration is not available"), nil
+	}
+
+	runtime, handler := string(config.Runtime), getString(config.Handler)
+	extensions := runtimeExtensions(runtime)
+	if extensions == nil {
+		return codeLocationNote(location, fmt.Sprintf("the %s runtime runs compiled code", orUnknown(runtime))), nil
+	}
+	if config.CodeSize > inlineCodeLimit {
+		return codeLocationNote(location, fmt.Sprintf("the package is larger than %d MB", inlineCodeLimit>>20)), nil
+	}
+
+	data, err := p.client.FetchCodePackage(ctx, location, inlineCodeLimit)
+	if errors.Is(err, aws.ErrPackageTooLarge) {
+		return codeLocationNote(location, fmt.Sprintf("the package is larger than %d MB", inlineCodeLimit>>20)), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to open code package: %w", err)
+	}
+
+	file := findHandlerFile(archive, handler, extensions)
+	if file == nil {
+		logger.Logger.Printf("Handler %s of %s not found in its package", handler, name)
+		return fmt.Sprintf("━━━ %s ━━━\n\nThe handler %s was not found in the package, which holds:\n\n%s",
+			name, handler, strings.Join(packageFiles(archive), "\n")), nil
+	}
+	if file.UncompressedSize64 > inlineFileLimit {
+		return codeLocationNote(location, fmt.Sprintf("%s is larger than %d KB", file.Name, inlineFileLimit>>10)), nil
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s from the code package: %w", file.Name, err)
+	}
+	defer rc.Close()
+	source, err := io.ReadAll(io.LimitReader(rc, inlineFileLimit))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s from the code package: %w", file.Name, err)
+	}
+	return fmt.Sprintf("━━━ %s (%s, handler %s) ━━━\n\n%s", file.Name, runtime, handler, source), nil
+}
+
+// UpdateHandlerCode replaces the handler file GetFunctionCode shows with
+// source in the function's package, keeping the other files, and deploys the
+// package
+func (p *AWSProvider) UpdateHandlerCode(ctx context.Context, name, source, revision string) error {
+	output, err := p.client.GetFunction(ctx, name)
+	if err != nil {
+		return err
+	}
+	config := output.Configuration
+	if config == nil || config.PackageType == awstypes.PackageTypeImage || output.Code == nil || output.Code.Location == nil {
+		return fmt.Errorf("the code of %s is not a zip package that can be edited", name)
+	}
+	runtime, handler := string(config.Runtime), getString(config.Handler)
+	extensions := runtimeExtensions(runtime)
+	if extensions == nil {
+		return fmt.Errorf("the code of %s can't be edited: the %s runtime runs compiled code", name, orUnknown(runtime))
+	}
+
+	data, err := p.client.FetchCodePackage(ctx, *output.Code.Location, inlineCodeLimit)
+	if errors.Is(err, aws.ErrPackageTooLarge) {
+		return fmt.Errorf("the code of %s can't be edited: the package is larger than %d MB", name, inlineCodeLimit>>20)
+	}
+	if err != nil {
+		return err
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return fmt.Errorf("failed to open code package: %w", err)
+	}
+	file := findHandlerFile(archive, handler, extensions)
+	if file == nil {
+		return fmt.Errorf("handler %s of %s not found in its package", handler, name)
+	}
+	pkg, err := replacePackageFile(archive, file, []byte(source))
+	if err != nil {
+		return fmt.Errorf("failed to build code package: %w", err)
+	}
+	logger.Logger.Printf("Deploying %s with %s edited (%d bytes)", name, file.Name, len(pkg))
+	return p.client.UpdateFunctionCode(ctx, name, pkg, revision)
+}
+
+// replacePackageFile copies archive with the content of file replaced,
+// keeping its name, mode and the other files as they are
+func replacePackageFile(archive *zip.Reader, file *zip.File, content []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range archive.File {
+		if f != file {
+			if err := w.Copy(f); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		header := f.FileHeader
+		header.CRC32, header.CompressedSize64, header.UncompressedSize64 = 0, 0, 0
+		fw, err := w.CreateHeader(&header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fw.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// runtimeExtensions returns the handler file extensions of a runtime, nil
+// for compiled runtimes such as java, dotnet and provided (Go, Rust)
+func runtimeExtensions(runtime string) []string {
+	for prefix, extensions := range handlerExtensions {
+		if strings.HasPrefix(runtime, prefix) {
+			return extensions
+		}
+	}
+	return nil
+}
+
+// findHandlerFile finds the file a handler such as "app.lambda_handler",
+// "src/index.handler" or "pkg.module.handler" (Python) names in the package
+func findHandlerFile(archive *zip.Reader, handler string, extensions []string) *zip.File {
+	module := handler
+	if i := strings.LastIndex(handler, "."); i > 0 {
+		module = handler[:i]
+	}
+	dir, base := path.Split(module)
+	candidates := []string{dir + base, dir + strings.ReplaceAll(base, ".", "/")}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[strings.TrimPrefix(f.Name, "./")] = f
+	}
+	for _, candidate := range candidates {
+		for _, ext := range extensions {
+			if f, ok := files[candidate+ext]; ok {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+// packageFiles lists the files of a package, sorted
+func packageFiles(archive *zip.Reader) []string {
+	var names []string
+	for _, f := range archive.File {
+		if !f.FileInfo().IsDir() {
+			names = append(names, f.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// codeLocationNote tells where the code is when it is not shown, and why
+func codeLocationNote(location, reason string) string {
+	return fmt.Sprintf("The code is not shown because %s.\n\nCode location: %s\n\nThe link expires 10 minutes after it was fetched.", reason, location)
+}
+
+// orUnknown returns s, or "unknown" when it is empty
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"f6n/internal/logger"
+)
+
+// GetTrafficShifts returns the CodeDeploy deployments in progress on the
+// function's aliases, such as SAM gradual deployments: how much traffic the
+// new version gets, and the alarms that roll the deployment back. Reading
+// them needs codedeploy:ListDeployments, BatchGetDeployments,
+// ListDeploymentTargets, BatchGetDeploymentTargets and GetDeploymentGroup.
+func (p *AWSProvider) GetTrafficShifts(ctx context.Context, fn FunctionInfo) ([]TrafficShift, error) {
+	if p.deployClient == nil {
+		return nil, fmt.Errorf("gradual deployments need a CodeDeploy client")
+	}
+	ids, err := p.deployClient.ActiveDeploymentIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []TrafficShift{}, nil
+	}
+	deployments, err := p.deployClient.GetDeployments(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	region := p.deployClient.Region()
+	shifts := []TrafficShift{}
+	for _, d := range deployments {
+		if d.ComputePlatform != "Lambda" {
+			continue
+		}
+		targets, err := p.deployClient.LambdaTargets(ctx, d.ID)
+		if err != nil {
+			logger.Logger.Printf("Error getting the targets of deployment %s: %v", d.ID, err)
+			continue
+		}
+		for _, target := range targets {
+			// The target names the function by name or ARN
+			if target.FunctionName != fn.Name && !strings.HasSuffix(target.FunctionName, ":function:"+fn.Name) {
+				continue
+			}
+			shift := TrafficShift{
+				ID:             d.ID,
+				Status:         d.Status,
+				Config:         d.Config,
+				Alias:          target.Alias,
+				CurrentVersion: target.CurrentVersion,
+				TargetVersion:  target.TargetVersion,
+				TargetWeight:   target.TargetWeight,
+				Started:        d.Created,
+				Error:          d.Error,
+				URL:            fmt.Sprintf("https://%s.console.aws.amazon.com/codesuite/codedeploy/deployments/%s?region=%s", region, d.ID, region),
+			}
+			p.addRollbackSettings(ctx, &shift, d.Application, d.DeploymentGroup)
+			shifts = append(shifts, shift)
+		}
+	}
+	return shifts, nil
+}
+
+// addRollbackSettings adds the rollback alarms of a deployment's group, with
+// their current state, and the events rolling it back. Errors are logged and
+// leave them out.
+func (p *AWSProvider) addRollbackSettings(ctx context.Context, shift *TrafficShift, application, group string) {
+	settings, err := p.deployClient.GetDeploymentGroupSettings(ctx, application, group)
+	if err != nil {
+		logger.Logger.Printf("Error getting deployment group %s: %v", group, err)
+		return
+	}
+	if settings.AutoRollback {
+		shift.AutoRollback = settings.RollbackEvents
+	}
+	if !settings.AlarmsEnabled || len(settings.Alarms) == 0 {
+		return
+	}
+
+	states := map[string]string{}
+	alarms, err := p.cwClient.DescribeAlarmsNamed(ctx, settings.Alarms)
+	if err != nil {
+		logger.Logger.Printf("Error describing the rollback alarms of %s: %v", group, err)
+	}
+	for _, alarm := range alarms {
+		states[alarm.Name] = alarm.StateValue
+	}
+	for _, name := range settings.Alarms {
+		shift.Alarms = append(shift.Alarms, RollbackAlarm{Name: name, State: states[name]})
+	}
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"f6n/internal/aws"
+)
+
+// GetConcurrency returns the function's concurrent executions with its
+// reserved or account limit and provisioned concurrency utilization
+func (p *AWSProvider) GetConcurrency(ctx context.Context, functionName string, startTime, endTime time.Time) (*ConcurrencyInfo, error) {
+	info := &ConcurrencyInfo{}
+
+	reserved, err := p.client.GetReservedConcurrency(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+	if reserved != nil {
+		info.Limit, info.LimitSource = *reserved, "reserved"
+	} else {
+		settings, err := p.client.GetAccountSettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if settings.AccountLimit != nil {
+			info.Limit = settings.AccountLimit.ConcurrentExecutions
+		}
+		info.LimitSource = "account"
+	}
+
+	configs, err := p.client.ListProvisionedConcurrencyConfigs(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+
+	queries := []aws.MetricDataQuery{aws.LambdaMetricQuery("c", functionName, "ConcurrentExecutions", "Maximum", 60)}
+	for i, cfg := range configs {
+		info.Provisioned += getInt32(cfg.AllocatedProvisionedConcurrentExecutions)
+		// Provisioned concurrency metrics are reported per alias/version as "function:qualifier"
+		arn := getString(cfg.FunctionArn)
+		qualifier := arn[strings.LastIndex(arn, ":")+1:]
+		queries = append(queries, aws.MetricDataQuery{
+			ID:            fmt.Sprintf("p%d", i),
+			MetricName:    "ProvisionedConcurrencyUtilization",
+			Dimensions:    map[string]string{"FunctionName": functionName, "Resource": functionName + ":" + qualifier},
+			Stat:          "Maximum",
+			PeriodSeconds: 60,
+		})
+	}
+
+	results, err := p.cwClient.GetMetricData(ctx, queries, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	utilization := map[time.Time]float64{}
+	for _, r := range results {
+		for i, ts := range r.Timestamps {
+			if i >= len(r.Values) {
+				break
+			}
+			if r.ID == "c" {
+				info.Executions = append(info.Executions, MetricDataPoint{Timestamp: ts, Value: r.Values[i]})
+			} else {
+				utilization[ts] = max(utilization[ts], r.Values[i])
+			}
+		}
+	}
+	for ts, v := range utilization {
+		info.ProvisionedUtilization = append(info.ProvisionedUtilization, MetricDataPoint{Timestamp: ts, Value: v})
+	}
+	sortPoints(info.Executions)
+	sortPoints(info.ProvisionedUtilization)
+
+	return info, nil
+}
+
+// sortPoints orders metric data points by time
+func sortPoints(points []MetricDataPoint) {
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Timestamp.Before(points[j].Timestamp)
+	})
+}
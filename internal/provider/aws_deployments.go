@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"f6n/internal/aws"
+	"f6n/internal/logger"
+)
+
+// deployHistoryLookback is how far back CloudTrail's event history goes
+const deployHistoryLookback = 90 * 24 * time.Hour
+
+// deployEvents are the Lambda API calls that deploy a function. CloudTrail
+// names them with the API version appended, e.g. UpdateFunctionCode20150331v2.
+var deployEvents = []string{"UpdateFunctionCode", "UpdateFunctionConfiguration", "CreateFunction"}
+
+// trailRecord is the part of a CloudTrail record describing a deployment
+type trailRecord struct {
+	UserIdentity struct {
+		ARN       string `json:"arn"`
+		InvokedBy string `json:"invokedBy"`
+	} `json:"userIdentity"`
+	ErrorCode         string         `json:"errorCode"`
+	ErrorMessage      string         `json:"errorMessage"`
+	RequestParameters map[string]any `json:"requestParameters"`
+	ResponseElements  struct {
+		CodeSha256 string `json:"codeSha256"`
+		Version    string `json:"version"`
+	} `json:"responseElements"`
+}
+
+// ListDeployments returns who updated the function's code or configuration,
+// and when, from the last 90 days of CloudTrail's event history. The most
+// recent successful code update is the code the function runs now.
+func (p *AWSProvider) ListDeployments(ctx context.Context, fn FunctionInfo) ([]Deployment, error) {
+	if p.trailClient == nil {
+		return nil, fmt.Errorf("deploy history needs a CloudTrail client")
+	}
+	isDeploy := func(e aws.TrailEvent) bool {
+		return deployEventName(e.Name) != ""
+	}
+	since := time.Now().Add(-deployHistoryLookback)
+	events, err := p.trailClient.LookupResourceEvents(ctx, fn.Name, since, maxDeployments, isDeploy)
+	if err == nil && len(events) == 0 && fn.ARN != "" {
+		// Some events name the function by its ARN
+		events, err = p.trailClient.LookupResourceEvents(ctx, fn.ARN, since, maxDeployments, isDeploy)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	region := fn.Region
+	if region == "" {
+		region = p.trailClient.Region()
+	}
+	deployments := make([]Deployment, 0, len(events))
+	currentFound := false
+	for _, e := range events {
+		var record trailRecord
+		if err := json.Unmarshal([]byte(e.Detail), &record); err != nil {
+			logger.Logger.Printf("Error parsing CloudTrail event %s: %v", e.ID, err)
+		}
+		name := deployEventName(e.Name)
+		deployment := Deployment{
+			ID:      e.ID,
+			Status:  "SUCCESS",
+			Change:  describeChange(name, record),
+			Started: e.Time,
+			Actor:   cmp.Or(record.UserIdentity.ARN, e.Username),
+			URL:     fmt.Sprintf("https://%s.console.aws.amazon.com/cloudtrailv2/home?region=%s#/events/%s", region, region, e.ID),
+		}
+		if name != "UpdateFunctionConfiguration" {
+			deployment.Source = codeSource(record)
+		}
+		if record.UserIdentity.InvokedBy != "" {
+			deployment.Actor += " via " + record.UserIdentity.InvokedBy
+		}
+		if record.ErrorCode != "" {
+			deployment.Status = "FAILED"
+			deployment.Change += fmt.Sprintf(": %s %s", record.ErrorCode, record.ErrorMessage)
+		} else if name != "UpdateFunctionConfiguration" && !currentFound {
+			deployment.Current, currentFound = true, true
+		}
+		deployments = append(deployments, deployment)
+	}
+	sortDeployments(deployments)
+	return deployments, nil
+}
+
+// deployEventName returns the deploying API call an event records, without
+// its version suffix, or "" for other events
+func deployEventName(eventName string) string {
+	for _, name := range deployEvents {
+		if strings.HasPrefix(eventName, name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// describeChange summarizes what a deployment changed, e.g. "code, version 7
+// published" or "configuration: memorySize 1024, timeout 30, environment"
+func describeChange(name string, record trailRecord) string {
+	switch name {
+	case "CreateFunction":
+		return "function created"
+	case "UpdateFunctionCode":
+		change := "code"
+		if v := record.ResponseElements.Version; v != "" && v != "$LATEST" {
+			change += fmt.Sprintf(", version %s published", v)
+		}
+		return change
+	}
+
+	var changed []string
+	for key, value := range record.RequestParameters {
+		switch value := value.(type) {
+		case string, float64, bool:
+			if key == "functionName" || key == "revisionId" {
+				continue
+			}
+			changed = append(changed, fmt.Sprintf("%s %v", key, value))
+		default:
+			// Objects such as the environment are redacted or too long to show
+			changed = append(changed, key)
+		}
+	}
+	if len(changed) == 0 {
+		return "configuration"
+	}
+	slices.Sort(changed)
+	return "configuration: " + strings.Join(changed, ", ")
+}
+
+// codeSource describes the code a deployment uploaded: the S3 object or
+// container image, and the SHA-256 of the package
+func codeSource(record trailRecord) string {
+	var parts []string
+	params := record.RequestParameters
+	if code, ok := params["code"].(map[string]any); ok {
+		// CreateFunction nests the location in code
+		params = code
+	}
+	if bucket, _ := params["s3Bucket"].(string); bucket != "" {
+		object := fmt.Sprintf("s3://%s/%v", bucket, params["s3Key"])
+		if version, _ := params["s3ObjectVersion"].(string); version != "" {
+			object += "#" + version
+		}
+		parts = append(parts, object)
+	}
+	if image, _ := params["imageUri"].(string); image != "" {
+		parts = append(parts, image)
+	}
+	if sha := record.ResponseElements.CodeSha256; sha != "" {
+		parts = append(parts, "sha256 "+sha)
+	}
+	return strings.Join(parts, ", ")
+}
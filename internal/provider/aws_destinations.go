@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"context"
+	"time"
+)
+
+// GetAsyncInvokeConfig reads the destinations and retry settings of the
+// function's asynchronous invocations. It returns an empty configuration when
+// none is set.
+func (p *AWSProvider) GetAsyncInvokeConfig(ctx context.Context, functionName string) (*AsyncInvokeConfig, error) {
+	out, err := p.client.GetFunctionEventInvokeConfig(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+	if out == nil {
+		return &AsyncInvokeConfig{}, nil
+	}
+
+	config := &AsyncInvokeConfig{MaxRetries: out.MaximumRetryAttempts}
+	if out.MaximumEventAgeInSeconds != nil {
+		config.MaxEventAge = time.Duration(*out.MaximumEventAgeInSeconds) * time.Second
+	}
+	if dest := out.DestinationConfig; dest != nil {
+		if dest.OnSuccess != nil && getString(dest.OnSuccess.Destination) != "" {
+			config.Destinations = append(config.Destinations, AsyncDestination{Condition: "OnSuccess", Target: getString(dest.OnSuccess.Destination)})
+		}
+		if dest.OnFailure != nil && getString(dest.OnFailure.Destination) != "" {
+			config.Destinations = append(config.Destinations, AsyncDestination{Condition: "OnFailure", Target: getString(dest.OnFailure.Destination)})
+		}
+	}
+	return config, nil
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"f6n/internal/aws"
+)
+
+// lambdaInsightsLayer is the name of the CloudWatch Lambda Insights extension layer
+const lambdaInsightsLayer = ":layer:LambdaInsightsExtension"
+
+// GetEnhancedMetrics reads the CPU, memory and network metrics of the
+// CloudWatch Lambda Insights extension. Only the extension layer is set when
+// the function doesn't have it attached.
+func (p *AWSProvider) GetEnhancedMetrics(ctx context.Context, functionName string, startTime, endTime time.Time) (*EnhancedMetrics, error) {
+	config, err := p.client.GetFunctionConfiguration(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &EnhancedMetrics{}
+	for _, layer := range config.Layers {
+		if arn := getString(layer.Arn); strings.Contains(arn, lambdaInsightsLayer) {
+			metrics.Extension = arn
+			break
+		}
+	}
+	if metrics.Extension == "" {
+		return metrics, nil
+	}
+
+	query := func(id, metric, stat string) aws.MetricDataQuery {
+		return aws.MetricDataQuery{
+			ID:            id,
+			Namespace:     "LambdaInsights",
+			MetricName:    metric,
+			Dimensions:    map[string]string{"function_name": functionName},
+			Stat:          stat,
+			PeriodSeconds: 60,
+		}
+	}
+	results, err := p.cwClient.GetMetricData(ctx, []aws.MetricDataQuery{
+		query("cpu", "cpu_total_time", "Average"),
+		query("memory", "memory_utilization", "Maximum"),
+		query("network", "total_network", "Sum"),
+	}, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range results {
+		var points []MetricDataPoint
+		for i, ts := range r.Timestamps {
+			if i < len(r.Values) {
+				points = append(points, MetricDataPoint{Timestamp: ts, Value: r.Values[i]})
+			}
+		}
+		sortPoints(points)
+		switch r.ID {
+		case "cpu":
+			metrics.CPUTime = points
+		case "memory":
+			metrics.Memory = points
+		case "network":
+			metrics.Network = points
+		}
+	}
+	return metrics, nil
+}
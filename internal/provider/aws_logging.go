@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"f6n/internal/aws"
+
+	awstypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+// logRetentionDays are the retention periods CloudWatch Logs accepts
+var logRetentionDays = []int32{1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1096, 1827, 2192, 2557, 2922, 3288, 3653}
+
+// convertLoggingConfig converts the logging configuration of a function, nil
+// when Lambda returned none
+func convertLoggingConfig(cfg *awstypes.LoggingConfig) *LoggingConfig {
+	if cfg == nil {
+		return nil
+	}
+	return &LoggingConfig{
+		Format:           string(cfg.LogFormat),
+		ApplicationLevel: string(cfg.ApplicationLogLevel),
+		SystemLevel:      string(cfg.SystemLogLevel),
+		LogGroup:         getString(cfg.LogGroup),
+	}
+}
+
+// UpdateLoggingConfig changes the log format and levels of a function with
+// the advanced logging controls. Levels only apply to JSON logs, so they are
+// left out when switching to text.
+func (p *AWSProvider) UpdateLoggingConfig(ctx context.Context, name string, cfg LoggingConfig) error {
+	config := awstypes.LoggingConfig{LogFormat: awstypes.LogFormat(cfg.Format)}
+	switch config.LogFormat {
+	case awstypes.LogFormatJson:
+		config.ApplicationLogLevel = awstypes.ApplicationLogLevel(cfg.ApplicationLevel)
+		config.SystemLogLevel = awstypes.SystemLogLevel(cfg.SystemLevel)
+	case awstypes.LogFormatText:
+	default:
+		return fmt.Errorf("log format must be JSON or Text, not %q", cfg.Format)
+	}
+	// Without it, the function would go back to its default log group
+	if cfg.LogGroup != "" {
+		config.LogGroup = &cfg.LogGroup
+	}
+	return p.client.UpdateLoggingConfig(ctx, name, config)
+}
+
+// functionLogGroup returns the log group a function logs to: the one of its
+// logging configuration, or /aws/lambda/<name> by default
+func functionLogGroup(fn FunctionInfo) string {
+	if fn.Logging != nil && fn.Logging.LogGroup != "" {
+		return fn.Logging.LogGroup
+	}
+	return lambdaLogGroup(fn.Name)
+}
+
+// GetLogRetention returns the retention and size of the function's log group
+func (p *AWSProvider) GetLogRetention(ctx context.Context, fn FunctionInfo) (*LogRetention, error) {
+	group, err := p.logsClient.DescribeLogGroup(ctx, functionLogGroup(fn))
+	if errors.Is(err, aws.ErrLogGroupNotFound) {
+		return &LogRetention{LogGroup: functionLogGroup(fn), Choices: logRetentionDays, Missing: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &LogRetention{
+		LogGroup:    group.Name,
+		Days:        group.RetentionDays,
+		StoredBytes: group.StoredBytes,
+		Choices:     logRetentionDays,
+	}, nil
+}
+
+// SetLogRetention sets the retention of the function's log group
+func (p *AWSProvider) SetLogRetention(ctx context.Context, fn FunctionInfo, days int32) error {
+	if days != 0 && !slices.Contains(logRetentionDays, days) {
+		return fmt.Errorf("CloudWatch Logs does not support a retention of %d days", days)
+	}
+	return p.logsClient.SetRetention(ctx, functionLogGroup(fn), days)
+}
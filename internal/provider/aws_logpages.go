@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// awsLogPageWindow is how far back a page of older logs looks at a time
+const awsLogPageWindow = 24 * time.Hour
+
+// awsLogPageMaxWindows bounds the empty windows skipped looking for older
+// logs, beyond which there are taken to be none
+const awsLogPageMaxWindows = 7
+
+// GetOlderFunctionLogs pages back through the function's CloudWatch Logs
+// group. FilterLogEvents tokens only page forward in time, so the token is
+// the time the next older page ends at, in Unix milliseconds: a window is
+// read back from it until entries turn up.
+func (p *AWSProvider) GetOlderFunctionLogs(ctx context.Context, name string, query LogQuery, before time.Time, token string) (*LogPage, error) {
+	end := before
+	if token != "" {
+		ms, err := strconv.ParseInt(token, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log page token %q", token)
+		}
+		end = time.UnixMilli(ms)
+	}
+	query.Since = time.Time{}
+
+	for window := 0; window < awsLogPageMaxWindows; window++ {
+		start := end.Add(-awsLogPageWindow)
+		// The end time of FilterLogEvents is inclusive
+		entries, err := p.filterFunctionLogs(ctx, name, query, start, end.Add(-time.Millisecond))
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) == 0 {
+			end = start
+			continue
+		}
+
+		// A full page may leave older entries in the window; otherwise the
+		// next page starts before it
+		next := start
+		if query.Limit > 0 && len(entries) >= query.Limit {
+			next = entries[0].Timestamp
+		}
+		return &LogPage{Entries: entries, Next: strconv.FormatInt(next.UnixMilli(), 10)}, nil
+	}
+	return &LogPage{}, nil
+}
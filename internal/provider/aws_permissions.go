@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// awsActionPermissions are the IAM actions each f6n action needs. Lambda
+// actions are simulated on the function, the others on any resource.
+var awsActionPermissions = map[Action][]string{
+	ActionUpdateEnvironment: {"lambda:UpdateFunctionConfiguration"},
+	ActionDownloadCode:      {"lambda:GetFunction"},
+	ActionReplayEvents:      {"lambda:InvokeFunction", "sqs:ReceiveMessage", "sqs:DeleteMessage"},
+	ActionCreateAlarm:       {"cloudwatch:PutMetricAlarm"},
+	ActionUpdateLogging:     {"lambda:UpdateFunctionConfiguration"},
+	ActionSetLogRetention:   {"logs:PutRetentionPolicy"},
+	ActionUpdateDescription: {"lambda:UpdateFunctionConfiguration"},
+	ActionPowerTune:         {"lambda:UpdateFunctionConfiguration", "lambda:GetFunction", "lambda:InvokeFunction"},
+	ActionUpdateCode:        {"lambda:GetFunction", "lambda:UpdateFunctionCode"},
+}
+
+// CheckPermissions simulates the IAM policies of the caller for the actions
+// f6n offers on a Lambda function. Simulating requires
+// iam:SimulatePrincipalPolicy, and iam:GetRole for assumed roles.
+func (p *AWSProvider) CheckPermissions(ctx context.Context, fn FunctionInfo) (map[Action][]string, error) {
+	if p.iamClient == nil {
+		return nil, fmt.Errorf("permission checks need an IAM client")
+	}
+	principal, err := p.simulationPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if principal == "" {
+		// The account root user is allowed everything
+		return nil, nil
+	}
+
+	var onFunction, onAny []string
+	for _, actions := range awsActionPermissions {
+		for _, action := range actions {
+			if strings.HasPrefix(action, "lambda:") {
+				onFunction = append(onFunction, action)
+			} else {
+				onAny = append(onAny, action)
+			}
+		}
+	}
+	allowed, err := p.iamClient.SimulatePrincipalPolicy(ctx, principal, onFunction, fn.ARN)
+	if err != nil {
+		return nil, err
+	}
+	others, err := p.iamClient.SimulatePrincipalPolicy(ctx, principal, onAny, "*")
+	if err != nil {
+		return nil, err
+	}
+	for action, ok := range others {
+		allowed[action] = ok
+	}
+
+	denied := make(map[Action][]string)
+	for action, permissions := range awsActionPermissions {
+		for _, permission := range permissions {
+			if !allowed[permission] {
+				denied[action] = append(denied[action], permission)
+			}
+		}
+	}
+	return denied, nil
+}
+
+// simulationPrincipal returns the user or role whose policies apply to the
+// caller: the role of an assumed-role session, looked up for its path. It is
+// empty for the account root user.
+func (p *AWSProvider) simulationPrincipal(ctx context.Context) (string, error) {
+	arn, err := p.stsClient.GetCallerARN(ctx)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasSuffix(arn, ":root") {
+		return "", nil
+	}
+	// arn:aws:sts::123456789012:assumed-role/RoleName/session
+	if _, session, ok := strings.Cut(arn, ":assumed-role/"); ok {
+		role, _, _ := strings.Cut(session, "/")
+		return p.iamClient.GetRoleARN(ctx, role)
+	}
+	return arn, nil
+}
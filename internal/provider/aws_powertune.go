@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+)
+
+// Lambda memory sizes, in MB
+const (
+	minLambdaMemoryMB = 128
+	maxLambdaMemoryMB = 10240
+)
+
+// SetMemory changes the function's memory size and waits for the update to
+// finish. Changing it also changes the CPU share of the function.
+func (p *AWSProvider) SetMemory(ctx context.Context, functionName string, memoryMB int32) error {
+	if memoryMB < minLambdaMemoryMB || memoryMB > maxLambdaMemoryMB {
+		return fmt.Errorf("Lambda memory must be between %d and %d MB, not %d", minLambdaMemoryMB, maxLambdaMemoryMB, memoryMB)
+	}
+	return p.client.UpdateMemory(ctx, functionName, memoryMB)
+}
+
+// InvokeWithLog invokes a function synchronously, returning the last 4 KB of
+// its log, which end with the invocation's REPORT line
+func (p *AWSProvider) InvokeWithLog(ctx context.Context, functionName string, payload []byte) (*InvokeResult, error) {
+	out, log, err := p.client.InvokeWithLog(ctx, functionName, payload)
+	if err != nil {
+		return nil, err
+	}
+	requestID, _ := awsmiddleware.GetRequestIDMetadata(out.ResultMetadata)
+	return &InvokeResult{
+		Payload:       string(out.Payload),
+		FunctionError: getString(out.FunctionError),
+		ExecutionID:   requestID,
+		Log:           log,
+	}, nil
+}
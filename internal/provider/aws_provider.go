@@ -3,24 +3,61 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"f6n/internal/aws"
+	"f6n/internal/logger"
 
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	awstypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
 )
 
+// AWSClients bundles the service clients used by the AWS provider
+type AWSClients struct {
+	Lambda     *aws.LambdaClient
+	STS        *aws.StsClient
+	CloudWatch *aws.CloudWatchClient
+	Logs       *aws.LogsClient
+	SQS        *aws.SQSClient
+	IAM        *aws.IAMClient
+	Tagging    *aws.TaggingClient
+	S3         *aws.S3Client
+	CloudTrail *aws.CloudTrailClient
+	CodeDeploy *aws.CodeDeployClient
+}
+
 // AWSProvider implements the Provider interface for AWS Lambda
 type AWSProvider struct {
-	client    *aws.LambdaClient
-	stsClient *aws.StsClient
+	client       *aws.LambdaClient
+	stsClient    *aws.StsClient
+	cwClient     *aws.CloudWatchClient
+	logsClient   *aws.LogsClient
+	sqsClient    *aws.SQSClient
+	iamClient    *aws.IAMClient
+	tagClient    *aws.TaggingClient
+	s3Client     *aws.S3Client
+	trailClient  *aws.CloudTrailClient
+	deployClient *aws.CodeDeployClient
 }
 
 // NewAWSProvider creates a new AWS provider
-func NewAWSProvider(client *aws.LambdaClient, stsClient *aws.StsClient) *AWSProvider {
+func NewAWSProvider(clients AWSClients) *AWSProvider {
 	return &AWSProvider{
-		client:    client,
-		stsClient: stsClient,
+		client:       clients.Lambda,
+		stsClient:    clients.STS,
+		cwClient:     clients.CloudWatch,
+		logsClient:   clients.Logs,
+		sqsClient:    clients.SQS,
+		iamClient:    clients.IAM,
+		tagClient:    clients.Tagging,
+		s3Client:     clients.S3,
+		trailClient:  clients.CloudTrail,
+		deployClient: clients.CodeDeploy,
 	}
 }
 
@@ -38,9 +75,55 @@ func (p *AWSProvider) GetAccountID(ctx context.Context) (string, error) {
 	return p.stsClient.GetAccountID(ctx)
 }
 
+// ConsoleURL returns the function's page in the AWS Lambda console
+func (p *AWSProvider) ConsoleURL(fn FunctionInfo) string {
+	region := fn.Region
+	if region == "" {
+		region = p.client.Region()
+	}
+	return fmt.Sprintf("https://%s.console.aws.amazon.com/lambda/home?region=%s#/functions/%s",
+		region, region, url.PathEscape(fn.Name))
+}
+
+// References returns the function's ARN, log group and function URL, if it has one
+func (p *AWSProvider) References(ctx context.Context, fn FunctionInfo) ([]FunctionReference, error) {
+	refs := []FunctionReference{
+		{Label: "ARN", Value: fn.ARN},
+		{Label: "Log group", Value: lambdaLogGroup(fn.Name)},
+	}
+	config, err := p.client.GetFunctionURLConfig(ctx, fn.Name)
+	if err != nil {
+		return refs, err
+	}
+	if config != nil {
+		refs = append(refs, FunctionReference{Label: "URL", Value: getString(config.FunctionUrl)})
+	}
+	return refs, nil
+}
+
+// UpdateEnvironment replaces the function's environment variables. Lambda
+// has no secret references, so secrets must be empty.
+func (p *AWSProvider) UpdateEnvironment(ctx context.Context, name string, env, secrets map[string]string, revision string) error {
+	if len(secrets) > 0 {
+		return fmt.Errorf("secret references are not supported on AWS Lambda")
+	}
+	return p.client.UpdateEnvironment(ctx, name, env, revision)
+}
+
+// maxLambdaDescription is the longest description Lambda accepts
+const maxLambdaDescription = 256
+
+// UpdateDescription changes the function's description
+func (p *AWSProvider) UpdateDescription(ctx context.Context, name, description string) error {
+	if n := utf8.RuneCountInString(description); n > maxLambdaDescription {
+		return fmt.Errorf("Lambda descriptions are at most %d characters, not %d", maxLambdaDescription, n)
+	}
+	return p.client.UpdateDescription(ctx, name, description)
+}
+
 // ListFunctions lists all Lambda functions
 func (p *AWSProvider) ListFunctions(ctx context.Context) ([]FunctionInfo, error) {
-	functions, err := p.client.ListFunctionsWithFallback(ctx)
+	functions, err := p.client.ListFunctions(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -50,6 +133,21 @@ func (p *AWSProvider) ListFunctions(ctx context.Context) ([]FunctionInfo, error)
 		result = append(result, convertAWSFunction(fn, p.client.Region()))
 	}
 
+	// Tags are optional: without them only tag-based checks are skipped
+	if p.tagClient != nil {
+		tags, err := p.tagClient.GetLambdaFunctionTags(ctx)
+		if err != nil {
+			logger.Logger.Printf("Error fetching function tags: %v", err)
+			return result, nil
+		}
+		for i := range result {
+			result[i].Tags = tags[result[i].ARN]
+			if result[i].Tags == nil {
+				result[i].Tags = map[string]string{}
+			}
+		}
+	}
+
 	return result, nil
 }
 
@@ -65,12 +163,21 @@ func (p *AWSProvider) GetFunction(ctx context.Context, name string) (*FunctionIn
 		Runtime:      string(output.Runtime),
 		Memory:       getInt32(output.MemorySize),
 		Timeout:      getInt32(output.Timeout),
+		CodeSize:     output.CodeSize,
 		Handler:      getString(output.Handler),
 		LastModified: getString(output.LastModified),
+		Modified:     parseLambdaTime(getString(output.LastModified)),
 		ARN:          getString(output.FunctionArn),
 		Description:  getString(output.Description),
 		Role:         getString(output.Role),
 		Region:       p.client.Region(),
+
+		State:                  string(output.State),
+		StateReason:            getString(output.StateReason),
+		LastUpdateStatus:       string(output.LastUpdateStatus),
+		LastUpdateStatusReason: getString(output.LastUpdateStatusReason),
+		Revision:               getString(output.RevisionId),
+		Logging:                convertLoggingConfig(output.LoggingConfig),
 	}
 
 	if output.Environment != nil {
@@ -80,28 +187,54 @@ func (p *AWSProvider) GetFunction(ctx context.Context, name string) (*FunctionIn
 	return info, nil
 }
 
-// GetFunctionCode gets the code/source for a function
-func (p *AWSProvider) GetFunctionCode(ctx context.Context, name string) (string, error) {
-	output, err := p.client.GetFunction(ctx, name)
-	if err != nil {
-		return "", err
+// GetFunctionLogs gets logs for a function from its CloudWatch Logs group
+func (p *AWSProvider) GetFunctionLogs(ctx context.Context, name string, query LogQuery) ([]LogEntry, error) {
+	since := query.Since
+	if since.IsZero() {
+		since = time.Now().Add(-24 * time.Hour)
+	}
+
+	return p.filterFunctionLogs(ctx, name, query, since, time.Time{})
+}
+
+// filterFunctionLogs returns the most recent query.Limit entries matching
+// query that were logged between start and end (zero for now)
+func (p *AWSProvider) filterFunctionLogs(ctx context.Context, name string, query LogQuery, start, end time.Time) ([]LogEntry, error) {
+	input := aws.FilterLogEventsInput{
+		LogGroupName: lambdaLogGroup(name),
+		StartTime:    start,
+		EndTime:      end,
+	}
+	if query.Text != "" {
+		input.FilterPattern = strconv.Quote(query.Text)
+	}
+	// Severity is derived client-side, so only limit server results when it isn't filtered
+	if query.MinSeverity == "" {
+		input.Limit = query.Limit
 	}
 
-	if output.Code != nil && output.Code.Location != nil {
-		return fmt.Sprintf("Code location: %s\\n\\nNote: Download the code from the S3 location above to view it.", *output.Code.Location), nil
+	events, err := p.logsClient.FilterLogEvents(ctx, input)
+	if err != nil {
+		return nil, err
 	}
 
-	return "Code location not available", nil
-}
+	entries := make([]LogEntry, 0, len(events))
+	for _, event := range events {
+		entry := LogEntry{
+			Timestamp: event.Timestamp,
+			Severity:  lambdaLogSeverity(event.Message),
+			Message:   strings.TrimRight(event.Message, "\n"),
+			Labels:    map[string]string{"logStream": event.LogStreamName},
+		}
+		if query.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
 
-// GetFunctionLogs gets logs for a function (placeholder)
-func (p *AWSProvider) GetFunctionLogs(ctx context.Context, name string, limit int) ([]string, error) {
-	// TODO: Implement CloudWatch Logs integration
-	return []string{
-		"CloudWatch Logs integration coming soon...",
-		fmt.Sprintf("Function: %s", name),
-		"Use AWS Console or AWS CLI to view logs for now",
-	}, nil
+	if query.Limit > 0 && len(entries) > query.Limit {
+		entries = entries[len(entries)-query.Limit:]
+	}
+	return entries, nil
 }
 
 // StreamFunctionLogs streams logs for a function in real-time (placeholder)
@@ -125,11 +258,37 @@ func (p *AWSProvider) StreamFunctionLogs(ctx context.Context, functionName strin
 	return logChan, errChan
 }
 
-// GetFunctionMetrics retrieves metrics for a Lambda function (placeholder)
+// metricsQueries lists the CloudWatch metrics behind FunctionMetrics, keyed
+// by the query ID
+var metricsQueries = []struct {
+	id, metric, stat, unit, description string
+}{
+	{"invocations", "Invocations", "Sum", "count", "Number of function invocations"},
+	{"duration", "Duration", "Average", "ms", "Average function execution duration"},
+	{"errors", "Errors", "Sum", "count", "Number of invocations that failed"},
+	{"throttles", "Throttles", "Sum", "count", "Number of throttled invocation requests"},
+	{"concurrency", "ConcurrentExecutions", "Maximum", "count", "Highest number of instances running at once"},
+}
+
+// metricsPoints is about the number of data points per series in FunctionMetrics
+const metricsPoints = 60
+
+// GetFunctionMetrics reads the function's CloudWatch metrics over the time
+// range. Memory use is only published by Lambda Insights and is left empty.
 func (p *AWSProvider) GetFunctionMetrics(ctx context.Context, functionName string, startTime, endTime time.Time) (*FunctionMetrics, error) {
-	// TODO: Implement CloudWatch metrics integration
+	// Periods are multiples of a minute
+	period := int32(endTime.Sub(startTime).Seconds()) / metricsPoints
+	period = (period/60 + 1) * 60
+
+	queries := make([]aws.MetricDataQuery, 0, len(metricsQueries))
+	for _, q := range metricsQueries {
+		queries = append(queries, aws.LambdaMetricQuery(q.id, functionName, q.metric, q.stat, period))
+	}
+	results, err := p.cwClient.GetMetricData(ctx, queries, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metrics of %s: %w", functionName, err)
+	}
 
-	// Create sample metrics data for now
 	metrics := &FunctionMetrics{
 		FunctionName: functionName,
 		TimeRange: struct {
@@ -137,39 +296,27 @@ func (p *AWSProvider) GetFunctionMetrics(ctx context.Context, functionName strin
 			End   time.Time
 		}{Start: startTime, End: endTime},
 	}
-
-	// Generate sample data points
-	now := time.Now()
-	samplePoints := []MetricDataPoint{
-		{Timestamp: now.Add(-1 * time.Hour), Value: 10},
-		{Timestamp: now.Add(-45 * time.Minute), Value: 15},
-		{Timestamp: now.Add(-30 * time.Minute), Value: 8},
-		{Timestamp: now.Add(-15 * time.Minute), Value: 12},
-		{Timestamp: now, Value: 6},
-	}
-
-	metrics.Invocations = MetricData{
-		MetricName:  "Invocations",
-		Unit:        "count",
-		Description: "Number of function invocations (sample data)",
-		DataPoints:  samplePoints,
+	series := map[string]*MetricData{
+		"invocations": &metrics.Invocations,
+		"duration":    &metrics.Duration,
+		"errors":      &metrics.Errors,
+		"throttles":   &metrics.Throttles,
+		"concurrency": &metrics.ConcurrentExecutions,
 	}
-
-	durationPoints := []MetricDataPoint{
-		{Timestamp: now.Add(-1 * time.Hour), Value: 250.5},
-		{Timestamp: now.Add(-45 * time.Minute), Value: 180.2},
-		{Timestamp: now.Add(-30 * time.Minute), Value: 320.8},
-		{Timestamp: now.Add(-15 * time.Minute), Value: 195.3},
-		{Timestamp: now, Value: 275.1},
+	for _, q := range metricsQueries {
+		*series[q.id] = MetricData{MetricName: q.metric, Unit: q.unit, Description: q.description}
 	}
-
-	metrics.Duration = MetricData{
-		MetricName:  "Duration",
-		Unit:        "ms",
-		Description: "Average function execution duration (sample data)",
-		DataPoints:  durationPoints,
+	for _, r := range results {
+		data, ok := series[r.ID]
+		if !ok {
+			continue
+		}
+		for i, v := range r.Values {
+			if i < len(r.Timestamps) {
+				data.DataPoints = append(data.DataPoints, MetricDataPoint{Timestamp: r.Timestamps[i], Value: v})
+			}
+		}
 	}
-
 	return metrics, nil
 }
 
@@ -182,6 +329,20 @@ func (p *AWSProvider) GetEndpoints(ctx context.Context, name string) ([]string,
 	}, nil
 }
 
+// Invoke invokes a function synchronously with the given payload
+func (p *AWSProvider) Invoke(ctx context.Context, functionName string, payload []byte) (*InvokeResult, error) {
+	out, err := p.client.Invoke(ctx, functionName, payload)
+	if err != nil {
+		return nil, err
+	}
+	requestID, _ := awsmiddleware.GetRequestIDMetadata(out.ResultMetadata)
+	return &InvokeResult{
+		Payload:       string(out.Payload),
+		FunctionError: getString(out.FunctionError),
+		ExecutionID:   requestID,
+	}, nil
+}
+
 // DownloadFunctionCode downloads the function code to a local path (placeholder)
 func (p *AWSProvider) DownloadFunctionCode(ctx context.Context, name, destination string) error {
 	// Add logging to track AWS download attempts
@@ -191,20 +352,171 @@ func (p *AWSProvider) DownloadFunctionCode(ctx context.Context, name, destinatio
 	return fmt.Errorf("AWS Lambda code download not yet implemented")
 }
 
+// CreateAlarm creates a CloudWatch alarm on one of the function's metrics
+func (p *AWSProvider) CreateAlarm(ctx context.Context, functionName string, spec AlarmSpec) error {
+	comparisons := map[string]string{
+		">=": "GreaterThanOrEqualToThreshold",
+		">":  "GreaterThanThreshold",
+		"<=": "LessThanOrEqualToThreshold",
+		"<":  "LessThanThreshold",
+	}
+	operator, ok := comparisons[spec.Comparison]
+	if !ok {
+		return fmt.Errorf("unsupported comparison %q", spec.Comparison)
+	}
+
+	input := aws.MetricAlarmInput{
+		AlarmName:          spec.Name,
+		FunctionName:       functionName,
+		MetricName:         spec.MetricName,
+		Statistic:          spec.Statistic,
+		ComparisonOperator: operator,
+		Threshold:          spec.Threshold,
+		EvaluationPeriods:  spec.EvaluationPeriods,
+		PeriodSeconds:      spec.PeriodSeconds,
+	}
+	if spec.TopicARN != "" {
+		input.AlarmActions = []string{spec.TopicARN}
+	}
+
+	return p.cwClient.PutMetricAlarm(ctx, input)
+}
+
+// GetFiringAlarms returns the alarms in ALARM state on AWS/Lambda metrics by function name
+func (p *AWSProvider) GetFiringAlarms(ctx context.Context) (map[string][]string, error) {
+	alarms, err := p.cwClient.DescribeAlarmsInState(ctx, "ALARM")
+	if err != nil {
+		return nil, err
+	}
+
+	firing := map[string][]string{}
+	for _, alarm := range alarms {
+		if fn := alarm.Dimensions["FunctionName"]; alarm.Namespace == "AWS/Lambda" && fn != "" {
+			firing[fn] = append(firing[fn], alarm.Name)
+		}
+	}
+	return firing, nil
+}
+
+// statsQueries lists the CloudWatch statistics behind FunctionStats, keyed
+// by the query ID prefix
+var statsQueries = []struct {
+	prefix, metric, stat string
+}{
+	{"i", "Invocations", "Sum"},
+	{"e", "Errors", "Sum"},
+	{"x", "Duration", "Maximum"},
+	{"p", "Duration", "p99"},
+	{"s", "Duration", "Sum"},
+}
+
+// GetFunctionStats returns invocation statistics per function since the given time
+func (p *AWSProvider) GetFunctionStats(ctx context.Context, names []string, since time.Time) (map[string]FunctionStats, error) {
+	end := time.Now()
+	// A single period covering the whole window yields one value per statistic
+	period := int32(end.Sub(since).Seconds())
+	period = (period/60 + 1) * 60
+
+	queries := make([]aws.MetricDataQuery, 0, len(names)*len(statsQueries))
+	for i, name := range names {
+		for _, q := range statsQueries {
+			queries = append(queries, aws.LambdaMetricQuery(fmt.Sprintf("%s%d", q.prefix, i), name, q.metric, q.stat, period))
+		}
+	}
+
+	results, err := p.cwClient.GetMetricData(ctx, queries, since, end)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]FunctionStats, len(names))
+	for _, r := range results {
+		if len(r.ID) < 2 || len(r.Values) == 0 {
+			continue
+		}
+		i, err := strconv.Atoi(r.ID[1:])
+		if err != nil || i >= len(names) {
+			continue
+		}
+
+		s := stats[names[i]]
+		for _, v := range r.Values {
+			switch r.ID[0] {
+			case 'i':
+				s.Invocations += v
+			case 'e':
+				s.Errors += v
+			case 's':
+				s.TotalDurationMs += v
+			case 'x':
+				s.MaxDurationMs = max(s.MaxDurationMs, v)
+			case 'p':
+				s.P99DurationMs = max(s.P99DurationMs, v)
+			}
+		}
+		stats[names[i]] = s
+	}
+	return stats, nil
+}
+
 // Helper functions
 
+// lambdaLogGroup returns the default CloudWatch Logs group of a function
+func lambdaLogGroup(functionName string) string {
+	return "/aws/lambda/" + functionName
+}
+
+// lambdaLevelRe matches the level field written by the Lambda runtimes, e.g.
+// "<timestamp>\t<request id>\tERROR\t..." (Node.js), "[ERROR]\t..." (Python)
+// or {"level":"ERROR",...} (JSON log format)
+var lambdaLevelRe = regexp.MustCompile(`^\[(TRACE|DEBUG|INFO|WARN|WARNING|ERROR|FATAL|CRITICAL)\]|\t(TRACE|DEBUG|INFO|WARN|WARNING|ERROR|FATAL)\t|"level"\s*:\s*"(TRACE|DEBUG|INFO|WARN|WARNING|ERROR|FATAL)"`)
+
+// lambdaLogSeverity derives a severity from a Lambda log line
+func lambdaLogSeverity(message string) string {
+	if m := lambdaLevelRe.FindStringSubmatch(message); m != nil {
+		level := m[1] + m[2] + m[3]
+		switch level {
+		case "TRACE":
+			return "DEBUG"
+		case "FATAL":
+			return "CRITICAL"
+		}
+		return level
+	}
+
+	switch {
+	case strings.HasPrefix(message, "START RequestId:"), strings.HasPrefix(message, "END RequestId:"),
+		strings.HasPrefix(message, "REPORT RequestId:"), strings.HasPrefix(message, "INIT_START"):
+		return "INFO"
+	case strings.Contains(message, "Task timed out"), strings.Contains(message, "Runtime.ExitError"),
+		strings.Contains(message, `"errorType"`):
+		return "ERROR"
+	}
+	return "DEFAULT"
+}
+
 func convertAWSFunction(fn awstypes.FunctionConfiguration, region string) FunctionInfo {
 	info := FunctionInfo{
 		Name:         getString(fn.FunctionName),
 		Runtime:      string(fn.Runtime),
 		Memory:       getInt32(fn.MemorySize),
 		Timeout:      getInt32(fn.Timeout),
+		CodeSize:     fn.CodeSize,
 		Handler:      getString(fn.Handler),
 		LastModified: getString(fn.LastModified),
+		Modified:     parseLambdaTime(getString(fn.LastModified)),
 		ARN:          getString(fn.FunctionArn),
 		Description:  getString(fn.Description),
 		Role:         getString(fn.Role),
 		Region:       region,
+		KMSKeyARN:    getString(fn.KMSKeyArn),
+
+		State:                  string(fn.State),
+		StateReason:            getString(fn.StateReason),
+		LastUpdateStatus:       string(fn.LastUpdateStatus),
+		LastUpdateStatusReason: getString(fn.LastUpdateStatusReason),
+		Revision:               getString(fn.RevisionId),
+		Logging:                convertLoggingConfig(fn.LoggingConfig),
 	}
 
 	if fn.Environment != nil {
@@ -214,6 +526,16 @@ func convertAWSFunction(fn awstypes.FunctionConfiguration, region string) Functi
 	return info
 }
 
+// parseLambdaTime parses a Lambda timestamp such as
+// 2024-09-15T10:30:00.000+0000, zero when it is not one
+func parseLambdaTime(s string) time.Time {
+	t, err := time.Parse("2006-01-02T15:04:05.000-0700", s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
 func getString(s *string) string {
 	if s == nil {
 		return ""
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"f6n/internal/aws"
+)
+
+const (
+	// failedEventsPeekLimit caps the messages read from each failure queue
+	failedEventsPeekLimit = 20
+	// failedEventsVisibility hides peeked messages while the user picks one
+	failedEventsVisibility = 120
+)
+
+// failureQueues returns the SQS dead-letter queue and on-failure destination
+// configured for a function
+func (p *AWSProvider) failureQueues(ctx context.Context, functionName string) ([]string, error) {
+	var queues []string
+
+	cfg, err := p.client.GetFunctionConfiguration(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.DeadLetterConfig != nil && isSQSArn(getString(cfg.DeadLetterConfig.TargetArn)) {
+		queues = append(queues, getString(cfg.DeadLetterConfig.TargetArn))
+	}
+
+	invokeCfg, err := p.client.GetFunctionEventInvokeConfig(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+	if invokeCfg != nil && invokeCfg.DestinationConfig != nil && invokeCfg.DestinationConfig.OnFailure != nil {
+		if dest := getString(invokeCfg.DestinationConfig.OnFailure.Destination); isSQSArn(dest) && !contains(queues, dest) {
+			queues = append(queues, dest)
+		}
+	}
+	return queues, nil
+}
+
+// ListFailedEvents peeks at the messages in the function's failure queues
+func (p *AWSProvider) ListFailedEvents(ctx context.Context, functionName string) ([]FailedEvent, error) {
+	queues, err := p.failureQueues(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+	if len(queues) == 0 {
+		return nil, fmt.Errorf("%s has no SQS dead-letter queue or on-failure destination", functionName)
+	}
+
+	var events []FailedEvent
+	for _, queueARN := range queues {
+		queueURL, err := p.sqsClient.QueueURL(ctx, queueARN)
+		if err != nil {
+			return nil, err
+		}
+		messages, err := p.sqsClient.PeekMessages(ctx, queueURL, failedEventsPeekLimit, failedEventsVisibility)
+		if err != nil {
+			return nil, err
+		}
+		for _, msg := range messages {
+			events = append(events, convertFailedEvent(queueARN, msg))
+		}
+	}
+	return events, nil
+}
+
+// ReplayFailedEvent re-invokes the function with the event payload and deletes the message on success
+func (p *AWSProvider) ReplayFailedEvent(ctx context.Context, functionName string, event FailedEvent) error {
+	out, err := p.client.Invoke(ctx, functionName, []byte(event.Payload))
+	if err != nil {
+		return err
+	}
+	if out.FunctionError != nil {
+		return fmt.Errorf("function returned %s: %s", *out.FunctionError, strings.TrimSpace(string(out.Payload)))
+	}
+
+	queueURL, err := p.sqsClient.QueueURL(ctx, event.Source)
+	if err != nil {
+		return err
+	}
+	return p.sqsClient.DeleteMessage(ctx, queueURL, event.Handle)
+}
+
+// convertFailedEvent extracts the original payload from a failure queue message.
+// Dead-letter queues hold the raw event, while on-failure destinations wrap it
+// in an invocation record under "requestPayload".
+func convertFailedEvent(queueARN string, msg aws.SQSMessage) FailedEvent {
+	event := FailedEvent{
+		ID:      msg.MessageID,
+		Source:  queueARN,
+		Payload: msg.Body,
+		Handle:  msg.ReceiptHandle,
+	}
+
+	if ms, err := strconv.ParseInt(msg.Attributes["SentTimestamp"], 10, 64); err == nil {
+		event.SentAt = time.UnixMilli(ms)
+	}
+
+	var record struct {
+		RequestContext json.RawMessage `json:"requestContext"`
+		RequestPayload json.RawMessage `json:"requestPayload"`
+	}
+	if json.Unmarshal([]byte(event.Payload), &record) == nil && record.RequestContext != nil && record.RequestPayload != nil {
+		event.Payload = string(record.RequestPayload)
+	}
+	return event
+}
+
+func isSQSArn(arn string) bool {
+	return strings.HasPrefix(arn, "arn:aws:sqs:")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,327 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"f6n/internal/secrets"
+)
+
+// stringList decodes IAM policy fields that hold a string or a list of strings
+type stringList []string
+
+func (l *stringList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*l = stringList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*l = list
+	return nil
+}
+
+// policyPrincipal decodes "*" or a map such as {"AWS": [...], "Service": "..."}
+type policyPrincipal map[string]stringList
+
+func (p *policyPrincipal) UnmarshalJSON(data []byte) error {
+	var wildcard string
+	if err := json.Unmarshal(data, &wildcard); err == nil {
+		*p = policyPrincipal{"*": {wildcard}}
+		return nil
+	}
+	var m map[string]stringList
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*p = m
+	return nil
+}
+
+type policyStatement struct {
+	Sid       string
+	Effect    string
+	Principal policyPrincipal
+	Action    stringList
+	NotAction stringList
+	Resource  stringList
+	Condition map[string]map[string]json.RawMessage
+}
+
+type policyDocument struct {
+	Statement []policyStatement
+}
+
+// parsePolicy decodes an IAM policy document, whose Statement may be a single object
+func parsePolicy(document string) (*policyDocument, error) {
+	var doc policyDocument
+	if err := json.Unmarshal([]byte(document), &doc); err == nil {
+		return &doc, nil
+	}
+	var single struct{ Statement policyStatement }
+	if err := json.Unmarshal([]byte(document), &single); err != nil {
+		return nil, fmt.Errorf("failed to parse policy document: %w", err)
+	}
+	return &policyDocument{Statement: []policyStatement{single.Statement}}, nil
+}
+
+func (s policyStatement) hasCondition(key string) bool {
+	for _, conditions := range s.Condition {
+		for k := range conditions {
+			if strings.EqualFold(k, key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// conditionValues returns the values of a condition key under any operator,
+// e.g. the ARNs of aws:SourceArn
+func (s policyStatement) conditionValues(key string) []string {
+	var values []string
+	for _, conditions := range s.Condition {
+		for k, raw := range conditions {
+			var list stringList
+			if strings.EqualFold(k, key) && json.Unmarshal(raw, &list) == nil {
+				values = append(values, list...)
+			}
+		}
+	}
+	return values
+}
+
+// AuditPosture checks the function URL, resource policy, execution role and
+// environment encryption of a Lambda function
+func (p *AWSProvider) AuditPosture(ctx context.Context, fn FunctionInfo) (*PostureReport, error) {
+	report := &PostureReport{}
+
+	report.Checks = append(report.Checks, "Function URL authentication")
+	authType, err := p.client.GetFunctionURLAuthType(ctx, fn.Name)
+	if err != nil {
+		return nil, err
+	}
+	if authType == "NONE" {
+		report.Findings = append(report.Findings, PostureFinding{
+			Severity:    "HIGH",
+			Title:       "Public function URL without authentication",
+			Detail:      "The function URL has auth type NONE",
+			Explanation: "Anyone on the internet who knows the URL can invoke the function, and every invocation is billed to you.",
+			Remediation: "Set the URL auth type to AWS_IAM, or authenticate requests in the function code and front it with API Gateway or CloudFront for throttling.",
+		})
+	}
+
+	report.Checks = append(report.Checks, "Resource policy principals")
+	policy, err := p.client.GetPolicy(ctx, fn.Name)
+	if err != nil {
+		return nil, err
+	}
+	if policy != "" {
+		findings, err := auditResourcePolicy(policy)
+		if err != nil {
+			return nil, err
+		}
+		report.Findings = append(report.Findings, findings...)
+	}
+
+	if p.iamClient != nil && fn.Role != "" {
+		report.Checks = append(report.Checks, "Execution role permissions")
+		findings, err := p.auditExecutionRole(ctx, fn.Role)
+		if err != nil {
+			return nil, err
+		}
+		report.Findings = append(report.Findings, findings...)
+	}
+
+	report.Checks = append(report.Checks, "Environment variable encryption")
+	report.Findings = append(report.Findings, auditEnvironmentEncryption(fn,
+		"Environment variables are encrypted at rest with the AWS managed key, so anyone allowed lambda:GetFunctionConfiguration can read them in plain text.",
+		"Store secrets in Secrets Manager or SSM Parameter Store and read them at runtime, or encrypt the variables with a customer managed KMS key.")...)
+
+	return report, nil
+}
+
+// auditResourcePolicy flags statements that let any principal invoke the function
+func auditResourcePolicy(policy string) ([]PostureFinding, error) {
+	doc, err := parsePolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []PostureFinding
+	for _, s := range doc.Statement {
+		if !strings.EqualFold(s.Effect, "Allow") {
+			continue
+		}
+		// Public function URLs are reported by the function URL check
+		if s.hasCondition("lambda:FunctionUrlAuthType") {
+			continue
+		}
+
+		wildcard := contains(s.Principal["*"], "*") || contains(s.Principal["AWS"], "*")
+		switch {
+		case wildcard && len(s.Condition) == 0:
+			findings = append(findings, PostureFinding{
+				Severity:    "HIGH",
+				Title:       "Resource policy allows any principal",
+				Detail:      fmt.Sprintf("Statement %q allows %s to principal *", s.Sid, strings.Join(s.Action, ", ")),
+				Explanation: "Any AWS account, and depending on the action anyone, can invoke or manage the function.",
+				Remediation: "Restrict the principal to specific accounts or services, or add aws:SourceAccount / aws:SourceArn conditions.",
+			})
+		case len(s.Principal["Service"]) > 0 && !s.hasCondition("aws:SourceArn") && !s.hasCondition("aws:SourceAccount"):
+			findings = append(findings, PostureFinding{
+				Severity:    "LOW",
+				Title:       "Service principal without a source condition",
+				Detail:      fmt.Sprintf("Statement %q allows %s without aws:SourceArn or aws:SourceAccount", s.Sid, strings.Join(s.Principal["Service"], ", ")),
+				Explanation: "Resources of that service in other accounts could trigger the function (the confused deputy problem).",
+				Remediation: "Add an aws:SourceArn or aws:SourceAccount condition to the permission.",
+			})
+		}
+	}
+	return findings, nil
+}
+
+// broadManagedPolicies are AWS managed policies granting far more than a function needs
+var broadManagedPolicies = map[string]string{
+	"AdministratorAccess": "CRITICAL",
+	"PowerUserAccess":     "HIGH",
+	"IAMFullAccess":       "HIGH",
+}
+
+// auditExecutionRole flags administrator, full-access and wildcard permissions
+func (p *AWSProvider) auditExecutionRole(ctx context.Context, roleARN string) ([]PostureFinding, error) {
+	roleName := roleARN[strings.LastIndex(roleARN, "/")+1:]
+	var findings []PostureFinding
+
+	attached, err := p.iamClient.ListAttachedRolePolicies(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range attached {
+		if strings.HasPrefix(policy.ARN, "arn:aws:iam::aws:policy/") {
+			severity, broad := broadManagedPolicies[policy.Name]
+			if !broad && strings.HasSuffix(policy.Name, "FullAccess") {
+				severity, broad = "MEDIUM", true
+			}
+			if broad {
+				findings = append(findings, PostureFinding{
+					Severity:    severity,
+					Title:       "Overly broad execution role",
+					Detail:      fmt.Sprintf("Role %s has the AWS managed policy %s attached", roleName, policy.Name),
+					Explanation: "Code running in the function, including a compromised dependency, can use every permission of its role.",
+					Remediation: "Replace the policy with one granting only the actions and resources the function uses.",
+				})
+			}
+			continue
+		}
+
+		document, err := p.iamClient.GetManagedPolicyDocument(ctx, policy.ARN)
+		if err != nil {
+			return nil, err
+		}
+		found, err := auditRolePolicy(roleName, policy.Name, document)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, found...)
+	}
+
+	inline, err := p.iamClient.ListRolePolicies(ctx, roleName)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range inline {
+		document, err := p.iamClient.GetRolePolicy(ctx, roleName, name)
+		if err != nil {
+			return nil, err
+		}
+		found, err := auditRolePolicy(roleName, name, document)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, found...)
+	}
+	return findings, nil
+}
+
+// auditRolePolicy flags statements allowing wildcard actions on every resource
+func auditRolePolicy(roleName, policyName, document string) ([]PostureFinding, error) {
+	doc, err := parsePolicy(document)
+	if err != nil {
+		return nil, fmt.Errorf("policy %s: %w", policyName, err)
+	}
+
+	var findings []PostureFinding
+	for _, s := range doc.Statement {
+		if !strings.EqualFold(s.Effect, "Allow") || !contains(s.Resource, "*") {
+			continue
+		}
+
+		severity := ""
+		var actions []string
+		for _, action := range s.Action {
+			switch {
+			case action == "*":
+				severity = "CRITICAL"
+			case strings.HasPrefix(strings.ToLower(action), "iam:") && (strings.HasSuffix(action, ":*") || strings.EqualFold(action, "iam:PassRole")):
+				if severity != "CRITICAL" {
+					severity = "HIGH"
+				}
+			case strings.HasSuffix(action, ":*"):
+				if severity == "" {
+					severity = "MEDIUM"
+				}
+			default:
+				continue
+			}
+			actions = append(actions, action)
+		}
+		if len(s.NotAction) > 0 && severity == "" {
+			severity = "MEDIUM"
+			actions = append(actions, "NotAction "+strings.Join(s.NotAction, ", "))
+		}
+		if severity == "" {
+			continue
+		}
+
+		findings = append(findings, PostureFinding{
+			Severity:    severity,
+			Title:       "Wildcard permissions in execution role",
+			Detail:      fmt.Sprintf("Policy %s of role %s allows %s on resource *", policyName, roleName, strings.Join(actions, ", ")),
+			Explanation: "Wildcard actions on every resource let the function's code reach far beyond the resources it uses.",
+			Remediation: "List the specific actions the function calls and scope Resource to the ARNs it uses.",
+		})
+	}
+	return findings, nil
+}
+
+// auditEnvironmentEncryption flags secret-looking environment variables that
+// are not protected by a customer-managed key
+func auditEnvironmentEncryption(fn FunctionInfo, explanation, remediation string) []PostureFinding {
+	if fn.KMSKeyARN != "" {
+		return nil
+	}
+	var names []string
+	for name, value := range fn.Environment {
+		if value != "" && secrets.IsSensitiveName(name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	return []PostureFinding{{
+		Severity:    "MEDIUM",
+		Title:       "Secrets in environment variables without a customer-managed key",
+		Detail:      "Variables: " + strings.Join(names, ", "),
+		Explanation: explanation,
+		Remediation: remediation,
+	}}
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/aws"
+	"f6n/internal/logger"
+)
+
+// ListEventSourceMappings lists the function's event source mappings, with
+// the backlog of SQS queues, and the S3 bucket notifications invoking it
+func (p *AWSProvider) ListEventSourceMappings(ctx context.Context, functionName string) ([]EventSourceMapping, error) {
+	configs, err := p.client.ListEventSourceMappings(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings := make([]EventSourceMapping, 0, len(configs))
+	var queries []aws.MetricDataQuery
+	for i, cfg := range configs {
+		mapping := EventSourceMapping{
+			ID:        getString(cfg.UUID),
+			SourceARN: getString(cfg.EventSourceArn),
+			State:     getString(cfg.State),
+			BatchSize: getInt32(cfg.BatchSize),
+		}
+
+		if isSQSArn(mapping.SourceARN) {
+			backlog, err := p.queueBacklog(ctx, mapping.SourceARN)
+			if err != nil {
+				// Keep listing the mapping even if the queue can't be read
+				logger.Logger.Printf("Error reading backlog of %s: %v", mapping.SourceARN, err)
+			} else {
+				mapping.Backlog = backlog
+				queries = append(queries, aws.MetricDataQuery{
+					ID:            fmt.Sprintf("q%d", i),
+					Namespace:     "AWS/SQS",
+					MetricName:    "ApproximateAgeOfOldestMessage",
+					Dimensions:    map[string]string{"QueueName": mapping.SourceARN[strings.LastIndex(mapping.SourceARN, ":")+1:]},
+					Stat:          "Maximum",
+					PeriodSeconds: 60,
+				})
+			}
+		}
+		mappings = append(mappings, mapping)
+	}
+
+	if len(queries) > 0 {
+		p.addOldestMessageAges(ctx, mappings, queries)
+	}
+
+	notifications, err := p.s3Triggers(ctx, functionName)
+	if err != nil {
+		// The mappings are still worth showing
+		logger.Logger.Printf("Error reading S3 notifications of %s: %v", functionName, err)
+	}
+	return append(mappings, notifications...), nil
+}
+
+// s3Triggers lists the bucket notifications invoking the function. S3 can
+// only invoke a function its resource policy allows, so the buckets are the
+// aws:SourceArn conditions of the statements granting s3.amazonaws.com.
+func (p *AWSProvider) s3Triggers(ctx context.Context, functionName string) ([]EventSourceMapping, error) {
+	document, err := p.client.GetPolicy(ctx, functionName)
+	if err != nil || document == "" {
+		return nil, err
+	}
+	policy, err := parsePolicy(document)
+	if err != nil {
+		return nil, err
+	}
+
+	var buckets []string
+	for _, s := range policy.Statement {
+		if !contains(s.Principal["Service"], "s3.amazonaws.com") {
+			continue
+		}
+		for _, arn := range s.conditionValues("aws:SourceArn") {
+			// arn:<partition>:s3:::<bucket>
+			parts := strings.SplitN(arn, ":", 6)
+			if len(parts) == 6 && parts[2] == "s3" && !strings.ContainsAny(parts[5], "*?/") && !contains(buckets, parts[5]) {
+				buckets = append(buckets, parts[5])
+			}
+		}
+	}
+
+	var mappings []EventSourceMapping
+	for _, bucket := range buckets {
+		notifications, err := p.s3Client.GetLambdaNotifications(ctx, bucket)
+		if err != nil {
+			logger.Logger.Printf("Error reading notifications of bucket %s: %v", bucket, err)
+			continue
+		}
+		for _, n := range notifications {
+			// arn:aws:lambda:<region>:<account>:function:<name>[:<qualifier>]
+			parts := strings.Split(n.FunctionARN, ":")
+			if len(parts) < 7 || parts[6] != functionName {
+				continue
+			}
+			mapping := EventSourceMapping{
+				ID:        n.ID,
+				SourceARN: "arn:aws:s3:::" + bucket,
+				EventType: strings.Join(n.Events, ", "),
+			}
+			if n.Prefix != "" {
+				mapping.Filters = append(mapping.Filters, "prefix="+n.Prefix)
+			}
+			if n.Suffix != "" {
+				mapping.Filters = append(mapping.Filters, "suffix="+n.Suffix)
+			}
+			mappings = append(mappings, mapping)
+		}
+	}
+	return mappings, nil
+}
+
+// queueBacklog reads the approximate message counts of an SQS queue
+func (p *AWSProvider) queueBacklog(ctx context.Context, queueARN string) (*QueueBacklog, error) {
+	queueURL, err := p.sqsClient.QueueURL(ctx, queueARN)
+	if err != nil {
+		return nil, err
+	}
+	depth, err := p.sqsClient.GetQueueDepth(ctx, queueURL)
+	if err != nil {
+		return nil, err
+	}
+	return &QueueBacklog{Messages: depth.Visible + depth.Delayed, InFlight: depth.InFlight}, nil
+}
+
+// addOldestMessageAges fills in the age of the oldest message from the
+// latest ApproximateAgeOfOldestMessage datapoint; SQS doesn't expose it as
+// a queue attribute
+func (p *AWSProvider) addOldestMessageAges(ctx context.Context, mappings []EventSourceMapping, queries []aws.MetricDataQuery) {
+	end := time.Now()
+	results, err := p.cwClient.GetMetricData(ctx, queries, end.Add(-15*time.Minute), end)
+	if err != nil {
+		logger.Logger.Printf("Error reading queue ages: %v", err)
+		return
+	}
+
+	for _, r := range results {
+		var i int
+		if _, err := fmt.Sscanf(r.ID, "q%d", &i); err != nil || i >= len(mappings) || mappings[i].Backlog == nil {
+			continue
+		}
+		if n := len(r.Values); n > 0 {
+			mappings[i].Backlog.OldestAge = time.Duration(r.Values[n-1]) * time.Second
+		}
+	}
+}
@@ -0,0 +1,527 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	demoRegion  = "us-east-1"
+	demoAccount = "123456789012"
+	// demoStreamInterval is how often the demo's live log stream emits an entry
+	demoStreamInterval = 2 * time.Second
+	// demoMetricPoints is the number of points in each demo metric series
+	demoMetricPoints = 60
+)
+
+// demoFunction is a sample function served by the DemoProvider
+type demoFunction struct {
+	info      FunctionInfo
+	code      map[string]string // file name to content
+	rate      float64           // invocations per minute
+	errorRate float64           // fraction of invocations that fail
+	duration  float64           // typical duration in ms
+	logs      []string          // messages the function logs, picked at random
+}
+
+// demoFunctions are the sample functions of the demo account
+var demoFunctions = []demoFunction{
+	{
+		info: FunctionInfo{
+			Name:         "user-authentication-service",
+			Runtime:      "nodejs20.x",
+			Memory:       512,
+			Timeout:      30,
+			Handler:      "index.handler",
+			LastModified: "2024-09-15T10:30:00.000+0000",
+			Description:  "Handles user authentication and JWT token generation",
+			Role:         "arn:aws:iam::" + demoAccount + ":role/lambda-exec-role",
+			Environment:  map[string]string{"TOKEN_TTL": "3600", "USER_TABLE": "users"},
+			Tags:         map[string]string{"team": "identity", "env": "demo"},
+		},
+		code: map[string]string{
+			"index.js": `const jwt = require('./jwt');
+
+exports.handler = async (event) => {
+  const { username, password } = JSON.parse(event.body || '{}');
+  if (!username || !password) {
+    return { statusCode: 400, body: 'missing credentials' };
+  }
+  const token = jwt.sign({ sub: username }, Number(process.env.TOKEN_TTL));
+  return { statusCode: 200, body: JSON.stringify({ token }) };
+};
+`,
+			"jwt.js": `const crypto = require('crypto');
+
+exports.sign = (claims, ttl) => {
+  const payload = { ...claims, exp: Math.floor(Date.now() / 1000) + ttl };
+  return Buffer.from(JSON.stringify(payload)).toString('base64url') + '.' +
+    crypto.randomBytes(16).toString('hex');
+};
+`,
+		},
+		rate: 120, errorRate: 0.002, duration: 45,
+		logs: []string{"Issued token for user %d", "Login attempt for user %d", "Token refreshed for user %d"},
+	},
+	{
+		info: FunctionInfo{
+			Name:         "payment-processor",
+			Runtime:      "python3.12",
+			Memory:       1024,
+			Timeout:      60,
+			Handler:      "app.lambda_handler",
+			LastModified: "2024-09-20T14:22:00.000+0000",
+			Description:  "Processes payment transactions via Stripe API",
+			Role:         "arn:aws:iam::" + demoAccount + ":role/payment-lambda-role",
+			Environment:  map[string]string{"STRIPE_API_KEY": "sk_test_demo", "CURRENCY": "usd"},
+			Tags:         map[string]string{"team": "payments", "env": "demo"},
+		},
+		code: map[string]string{
+			"app.py": `import json
+import os
+
+import stripe
+
+stripe.api_key = os.environ["STRIPE_API_KEY"]
+
+
+def lambda_handler(event, context):
+    order = json.loads(event["body"])
+    charge = stripe.Charge.create(
+        amount=order["amount"],
+        currency=os.environ.get("CURRENCY", "usd"),
+        source=order["token"],
+    )
+    return {"statusCode": 200, "body": json.dumps({"charge": charge["id"]})}
+`,
+			"requirements.txt": "stripe==7.0.0\n",
+		},
+		rate: 40, errorRate: 0.04, duration: 850,
+		logs: []string{"Charged order %d", "Retrying charge for order %d", "ERROR Card declined for order %d"},
+	},
+	{
+		info: FunctionInfo{
+			Name:         "email-notification-sender",
+			Runtime:      "nodejs18.x",
+			Memory:       256,
+			Timeout:      15,
+			Handler:      "index.sendEmail",
+			LastModified: "2024-09-18T08:45:00.000+0000",
+			Description:  "Sends email notifications using SES",
+			Role:         "arn:aws:iam::" + demoAccount + ":role/email-lambda-role",
+			Environment:  map[string]string{"FROM_ADDRESS": "noreply@example.com"},
+			Tags:         map[string]string{"team": "growth", "env": "demo"},
+		},
+		code: map[string]string{
+			"index.js": `const { SESClient, SendEmailCommand } = require('@aws-sdk/client-ses');
+
+const ses = new SESClient({});
+
+exports.sendEmail = async (event) => {
+  for (const record of event.Records) {
+    const message = JSON.parse(record.body);
+    await ses.send(new SendEmailCommand({
+      Source: process.env.FROM_ADDRESS,
+      Destination: { ToAddresses: [message.to] },
+      Message: { Subject: { Data: message.subject }, Body: { Text: { Data: message.text } } },
+    }));
+  }
+};
+`,
+		},
+		rate: 15, errorRate: 0.01, duration: 180,
+		logs: []string{"Sent email %d", "Queued email %d", "WARN SES throttled email %d, retrying"},
+	},
+	{
+		info: FunctionInfo{
+			Name:         "data-analytics-processor",
+			Runtime:      "python3.12",
+			Memory:       2048,
+			Timeout:      300,
+			Handler:      "analytics.process",
+			LastModified: "2024-09-22T16:10:00.000+0000",
+			Description:  "Processes large datasets for analytics dashboard",
+			Role:         "arn:aws:iam::" + demoAccount + ":role/analytics-lambda-role",
+			Tags:         map[string]string{"team": "data", "env": "demo"},
+		},
+		code: map[string]string{
+			"analytics.py": `import json
+
+
+def process(event, context):
+    rows = event.get("rows", [])
+    totals = {}
+    for row in rows:
+        totals[row["metric"]] = totals.get(row["metric"], 0) + row["value"]
+    print(json.dumps({"processed": len(rows)}))
+    return totals
+`,
+		},
+		rate: 2, errorRate: 0, duration: 42000,
+		logs: []string{"Processed batch %d", "Aggregated %d rows"},
+	},
+	{
+		info: FunctionInfo{
+			Name:         "image-resizer",
+			Runtime:      "nodejs20.x",
+			Memory:       1536,
+			Timeout:      45,
+			Handler:      "resize.handler",
+			LastModified: "2024-09-10T12:00:00.000+0000",
+			Description:  "Resizes and optimizes images for S3 storage",
+			Role:         "arn:aws:iam::" + demoAccount + ":role/image-lambda-role",
+			Tags:         map[string]string{"team": "media"},
+		},
+		code: map[string]string{
+			"resize.js": `const sharp = require('sharp');
+
+exports.handler = async (event) => {
+  const image = Buffer.from(event.image, 'base64');
+  const resized = await sharp(image).resize(800).webp().toBuffer();
+  return { size: resized.length, image: resized.toString('base64') };
+};
+`,
+		},
+		rate: 25, errorRate: 0.006, duration: 1200,
+		logs: []string{"Resized image %d", "Image %d already optimized"},
+	},
+}
+
+// DemoProvider serves a fixed set of sample functions with generated logs,
+// metrics and code, so f6n can be explored without a cloud account
+type DemoProvider struct {
+	functions map[string]demoFunction
+	names     []string
+}
+
+// NewDemoProvider creates the demo provider
+func NewDemoProvider() *DemoProvider {
+	p := &DemoProvider{functions: make(map[string]demoFunction, len(demoFunctions))}
+	for _, fn := range demoFunctions {
+		fn.info.ARN = fmt.Sprintf("arn:aws:lambda:%s:%s:function:%s", demoRegion, demoAccount, fn.info.Name)
+		fn.info.Region = demoRegion
+		fn.info.State = "Active"
+		fn.info.LastUpdateStatus = "Successful"
+		fn.info.Modified = parseLambdaTime(fn.info.LastModified)
+		p.functions[fn.info.Name] = fn
+		p.names = append(p.names, fn.info.Name)
+	}
+	return p
+}
+
+// function looks up a demo function by name
+func (p *DemoProvider) function(name string) (demoFunction, error) {
+	fn, ok := p.functions[name]
+	if !ok {
+		return demoFunction{}, fmt.Errorf("function %s not found", name)
+	}
+	return fn, nil
+}
+
+// demoRand returns a random source seeded by the function name and seed, so
+// the same function and time yield the same values
+func demoRand(name string, seed int64) *rand.Rand {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return rand.New(rand.NewSource(int64(h.Sum64()) ^ seed))
+}
+
+func (p *DemoProvider) GetProviderName() CloudProvider {
+	return AWS
+}
+
+func (p *DemoProvider) GetRegion() string {
+	return demoRegion
+}
+
+func (p *DemoProvider) GetAccountID(ctx context.Context) (string, error) {
+	return demoAccount, nil
+}
+
+func (p *DemoProvider) ListFunctions(ctx context.Context) ([]FunctionInfo, error) {
+	functions := make([]FunctionInfo, 0, len(p.names))
+	for _, name := range p.names {
+		functions = append(functions, p.functions[name].info)
+	}
+	return functions, nil
+}
+
+func (p *DemoProvider) GetFunction(ctx context.Context, name string) (*FunctionInfo, error) {
+	fn, err := p.function(name)
+	if err != nil {
+		return nil, err
+	}
+	info := fn.info
+	return &info, nil
+}
+
+// GetFunctionCode returns the function's handler file
+func (p *DemoProvider) GetFunctionCode(ctx context.Context, name string) (string, error) {
+	fn, err := p.function(name)
+	if err != nil {
+		return "", err
+	}
+	file := fn.info.Handler[:strings.LastIndex(fn.info.Handler, ".")]
+	for path, content := range fn.code {
+		if strings.TrimSuffix(path, filepath.Ext(path)) == file {
+			return content, nil
+		}
+	}
+	return "", fmt.Errorf("handler of %s not found", name)
+}
+
+// DownloadFunctionCode writes the function's source files to destination
+func (p *DemoProvider) DownloadFunctionCode(ctx context.Context, name, destination string) error {
+	fn, err := p.function(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(destination, 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+	for path, content := range fn.code {
+		if err := os.WriteFile(filepath.Join(destination, path), []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// demoLogEntry generates the log line of the n-th invocation at ts
+func demoLogEntry(fn demoFunction, r *rand.Rand, ts time.Time) LogEntry {
+	severity := "INFO"
+	message := fmt.Sprintf(fn.logs[r.Intn(len(fn.logs))], 1000+r.Intn(9000))
+	switch {
+	case r.Float64() < fn.errorRate*5:
+		severity, message = "ERROR", fmt.Sprintf("ERROR Task timed out after %d.00 seconds", fn.info.Timeout)
+	case strings.HasPrefix(message, "ERROR "):
+		severity = "ERROR"
+	case strings.HasPrefix(message, "WARN "):
+		severity = "WARN"
+	}
+	return LogEntry{
+		Timestamp: ts,
+		Severity:  severity,
+		Message:   message,
+		Labels:    map[string]string{"logStream": ts.Format("2006/01/02") + "/[$LATEST]demo"},
+	}
+}
+
+// GetFunctionLogs generates log entries, one every few minutes back from now
+func (p *DemoProvider) GetFunctionLogs(ctx context.Context, name string, query LogQuery) ([]LogEntry, error) {
+	fn, err := p.function(name)
+	if err != nil {
+		return nil, err
+	}
+	since := query.Since
+	if since.IsZero() {
+		since = time.Now().Add(-24 * time.Hour)
+	}
+
+	// Round to the minute so that refreshing doesn't reshuffle the entries
+	now := time.Now().Truncate(time.Minute)
+	r := demoRand(name, now.Unix())
+	var entries []LogEntry
+	for ts := now.Add(-2 * time.Hour); !ts.After(now); ts = ts.Add(time.Duration(1+r.Intn(4)) * time.Minute) {
+		if ts.Before(since) {
+			continue
+		}
+		if entry := demoLogEntry(fn, r, ts); query.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if query.Limit > 0 && len(entries) > query.Limit {
+		entries = entries[len(entries)-query.Limit:]
+	}
+	return entries, nil
+}
+
+// demoLogHistory is how far back the older demo logs go
+const demoLogHistory = 7 * 24 * time.Hour
+
+// GetOlderFunctionLogs generates the entries of the two hours before the
+// token, a time in Unix milliseconds, or before; back to a week ago
+func (p *DemoProvider) GetOlderFunctionLogs(ctx context.Context, name string, query LogQuery, before time.Time, token string) (*LogPage, error) {
+	fn, err := p.function(name)
+	if err != nil {
+		return nil, err
+	}
+	end := before
+	if token != "" {
+		ms, err := strconv.ParseInt(token, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log page token %q", token)
+		}
+		end = time.UnixMilli(ms)
+	}
+	if end.Before(time.Now().Add(-demoLogHistory)) {
+		return &LogPage{}, nil
+	}
+	query.Since = time.Time{}
+
+	start := end.Add(-2 * time.Hour)
+	r := demoRand(name, start.Unix())
+	var entries []LogEntry
+	for ts := start; ts.Before(end); ts = ts.Add(time.Duration(1+r.Intn(4)) * time.Minute) {
+		if entry := demoLogEntry(fn, r, ts); query.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	next := start
+	if query.Limit > 0 && len(entries) > query.Limit {
+		entries = entries[len(entries)-query.Limit:]
+		next = entries[0].Timestamp
+	}
+	return &LogPage{Entries: entries, Next: strconv.FormatInt(next.UnixMilli(), 10)}, nil
+}
+
+// StreamFunctionLogs emits a generated entry every couple of seconds until
+// the context is done
+func (p *DemoProvider) StreamFunctionLogs(ctx context.Context, name string) (<-chan LogEntry, <-chan error) {
+	logChan := make(chan LogEntry)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(logChan)
+		defer close(errChan)
+
+		fn, err := p.function(name)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		r := demoRand(name, time.Now().UnixNano())
+		ticker := time.NewTicker(demoStreamInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ts := <-ticker.C:
+				select {
+				case logChan <- demoLogEntry(fn, r, ts):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return logChan, errChan
+}
+
+// GetFunctionMetrics generates metric series over the window following a
+// daily traffic curve
+func (p *DemoProvider) GetFunctionMetrics(ctx context.Context, name string, startTime, endTime time.Time) (*FunctionMetrics, error) {
+	fn, err := p.function(name)
+	if err != nil {
+		return nil, err
+	}
+	metrics := &FunctionMetrics{FunctionName: name}
+	metrics.TimeRange.Start, metrics.TimeRange.End = startTime, endTime
+
+	step := endTime.Sub(startTime) / demoMetricPoints
+	if step <= 0 {
+		return metrics, nil
+	}
+	r := demoRand(name, startTime.Truncate(time.Hour).Unix())
+	var invocations, duration, errs, throttles, memory, concurrency []MetricDataPoint
+	for ts := startTime.Add(step); !ts.After(endTime); ts = ts.Add(step) {
+		hour := float64(ts.Hour()) + float64(ts.Minute())/60
+		load := 0.6 + 0.4*math.Sin((hour-8)/24*2*math.Pi) + 0.2*r.Float64()
+		count := math.Round(fn.rate * step.Minutes() * load)
+		failed := math.Round(count * fn.errorRate * (0.5 + r.Float64()))
+		avgDuration := fn.duration * (0.8 + 0.4*r.Float64())
+
+		invocations = append(invocations, MetricDataPoint{Timestamp: ts, Value: count})
+		duration = append(duration, MetricDataPoint{Timestamp: ts, Value: math.Round(avgDuration*10) / 10})
+		errs = append(errs, MetricDataPoint{Timestamp: ts, Value: failed})
+		throttles = append(throttles, MetricDataPoint{Timestamp: ts, Value: 0})
+		memory = append(memory, MetricDataPoint{Timestamp: ts, Value: float64(fn.info.Memory) * (0.35 + 0.3*load) * 1024 * 1024})
+		concurrency = append(concurrency, MetricDataPoint{Timestamp: ts, Value: math.Ceil(fn.rate / 60 * fn.duration / 1000 * load)})
+	}
+
+	metrics.Invocations = MetricData{MetricName: "Invocations", Unit: "count", Description: "Number of function invocations (demo data)", DataPoints: invocations}
+	metrics.Duration = MetricData{MetricName: "Duration", Unit: "ms", Description: "Average function execution duration (demo data)", DataPoints: duration}
+	metrics.Errors = MetricData{MetricName: "Errors", Unit: "count", Description: "Failed invocations (demo data)", DataPoints: errs}
+	metrics.Throttles = MetricData{MetricName: "Throttles", Unit: "count", Description: "Throttled invocations (demo data)", DataPoints: throttles}
+	metrics.Memory = MetricData{MetricName: "Memory Usage", Unit: "bytes", Description: "Memory used (demo data)", DataPoints: memory}
+	metrics.ConcurrentExecutions = MetricData{MetricName: "ConcurrentExecutions", Unit: "count", Description: "Maximum concurrent executions (demo data)", DataPoints: concurrency}
+	return metrics, nil
+}
+
+func (p *DemoProvider) GetEndpoints(ctx context.Context, name string) ([]string, error) {
+	if _, err := p.function(name); err != nil {
+		return nil, err
+	}
+	return []string{fmt.Sprintf("https://demo.execute-api.%s.amazonaws.com/prod/%s", demoRegion, name)}, nil
+}
+
+// GetFunctionStats sums the generated metrics since the given time
+func (p *DemoProvider) GetFunctionStats(ctx context.Context, names []string, since time.Time) (map[string]FunctionStats, error) {
+	stats := make(map[string]FunctionStats, len(names))
+	now := time.Now()
+	for _, name := range names {
+		metrics, err := p.GetFunctionMetrics(ctx, name, since, now)
+		if err != nil {
+			continue
+		}
+		var s FunctionStats
+		for i, point := range metrics.Invocations.DataPoints {
+			d := metrics.Duration.DataPoints[i].Value
+			s.Invocations += point.Value
+			s.Errors += metrics.Errors.DataPoints[i].Value
+			s.TotalDurationMs += point.Value * d
+			s.MaxDurationMs = math.Max(s.MaxDurationMs, d*1.5)
+		}
+		s.P99DurationMs = s.MaxDurationMs * 0.9
+		stats[name] = s
+	}
+	return stats, nil
+}
+
+// GetFiringAlarms reports an error alarm on the function that fails the most
+func (p *DemoProvider) GetFiringAlarms(ctx context.Context) (map[string][]string, error) {
+	return map[string][]string{"payment-processor": {"payment-processor-errors"}}, nil
+}
+
+// Invoke echoes the payload back
+func (p *DemoProvider) Invoke(ctx context.Context, functionName string, payload []byte) (*InvokeResult, error) {
+	if _, err := p.function(functionName); err != nil {
+		return nil, err
+	}
+	return &InvokeResult{
+		Payload:     fmt.Sprintf(`{"statusCode":200,"body":%q}`, payload),
+		ExecutionID: fmt.Sprintf("demo-%d", time.Now().UnixNano()),
+	}, nil
+}
+
+// ListEventSourceMappings gives the email sender a queue trigger
+func (p *DemoProvider) ListEventSourceMappings(ctx context.Context, functionName string) ([]EventSourceMapping, error) {
+	if functionName != "email-notification-sender" {
+		return nil, nil
+	}
+	return []EventSourceMapping{{
+		ID:        "demo-email-queue",
+		SourceARN: fmt.Sprintf("arn:aws:sqs:%s:%s:email-queue", demoRegion, demoAccount),
+		State:     "Enabled",
+		BatchSize: 10,
+		Backlog:   &QueueBacklog{Messages: 12, InFlight: 3, OldestAge: 45 * time.Second},
+	}}, nil
+}
+
+// References lists the function's ARN and log group
+func (p *DemoProvider) References(ctx context.Context, fn FunctionInfo) ([]FunctionReference, error) {
+	return []FunctionReference{
+		{Label: "ARN", Value: fn.ARN},
+		{Label: "Log group", Value: "/aws/lambda/" + fn.Name},
+	}, nil
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"f6n/internal/apicalls"
+
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// countedOptions extends the client options to count each API call for the
+// rate-limit indicator: with an authorized HTTP client for the REST services,
+// and with an interceptor for the gRPC ones (Cloud Logging and Monitoring).
+// gRPC clients reject an HTTP client, hence the two sets.
+func countedOptions(ctx context.Context, opts []option.ClientOption) (rest, grpcOpts []option.ClientOption, err error) {
+	client, _, err := htransport.NewClient(ctx, append([]option.ClientOption{option.WithScopes(CloudPlatformScope)}, opts...)...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create GCP HTTP client: %w", err)
+	}
+	client.Transport = apicalls.Transport(client.Transport, func(resp *http.Response) bool {
+		return resp.StatusCode == http.StatusTooManyRequests
+	})
+
+	rest = append(slices.Clone(opts), option.WithHTTPClient(client))
+	grpcOpts = append(slices.Clone(opts), option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(countGRPCCall)))
+	return rest, grpcOpts, nil
+}
+
+// countGRPCCall counts a gRPC call, which quota limits fail with RESOURCE_EXHAUSTED
+func countGRPCCall(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	apicalls.Record(status.Code(err) == codes.ResourceExhausted)
+	return err
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"f6n/internal/logger"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// CloudPlatformScope is the OAuth scope f6n requests for GCP APIs
+const CloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// ADCLoginCommand creates or refreshes the Application Default Credentials
+const ADCLoginCommand = "gcloud auth application-default login"
+
+// CredentialsError reports missing or expired Application Default Credentials
+type CredentialsError struct {
+	Err     error
+	Expired bool // the credentials exist but were rejected
+}
+
+func (e *CredentialsError) Error() string {
+	if e.Expired {
+		return fmt.Sprintf("GCP Application Default Credentials are expired or revoked (%v); run `%s`", e.Err, ADCLoginCommand)
+	}
+	return fmt.Sprintf("GCP Application Default Credentials not found (%v); run `%s`", e.Err, ADCLoginCommand)
+}
+
+func (e *CredentialsError) Unwrap() error {
+	return e.Err
+}
+
+// CheckGCPCredentials finds the Application Default Credentials and fetches
+// a token, so that missing or expired credentials are reported before any API
+// call fails. Network errors are left to the API calls.
+func CheckGCPCredentials(ctx context.Context) error {
+	creds, err := google.FindDefaultCredentials(ctx, CloudPlatformScope)
+	if err != nil {
+		return &CredentialsError{Err: err}
+	}
+	if _, err := creds.TokenSource.Token(); err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) || strings.Contains(err.Error(), "invalid_grant") {
+			return &CredentialsError{Err: err, Expired: true}
+		}
+		logger.Logger.Printf("Could not fetch a GCP token, continuing: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"f6n/internal/logger"
+
+	"google.golang.org/api/cloudbuild/v1"
+)
+
+// maxBuildsScanned bounds the builds looked through per location, as builds
+// of other functions and of other sources share the list
+const maxBuildsScanned = 300
+
+// errEnoughBuilds stops paging through the builds
+var errEnoughBuilds = errors.New("enough builds")
+
+// ListDeployments returns the recent Cloud Build builds of a function, the
+// builds Cloud Functions runs on each deployment: 2nd gen functions build in
+// the function's region, 1st gen ones mostly in the global location. Builds
+// are recognised by the source Cloud Functions uploads to its gcf-* buckets,
+// and by the build ID of the current deployment.
+func (p *GCPProvider) ListDeployments(ctx context.Context, fn FunctionInfo) ([]Deployment, error) {
+	region := fn.Region
+	if region == "" {
+		region = p.region
+	}
+	current := p.currentBuild(ctx, fmt.Sprintf("projects/%s/locations/%s/functions/%s", p.projectID, region, fn.Name))
+
+	service, err := cloudbuild.NewService(ctx, p.restOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cloud Build client: %w", err)
+	}
+
+	source := regexp.MustCompile(`^` + regexp.QuoteMeta(fn.Name) + `(-[0-9a-f-]{36})?/`)
+	deployments := []Deployment{}
+	seen := map[string]bool{}
+	for _, location := range []string{region, "global"} {
+		scanned := 0
+		parent := fmt.Sprintf("projects/%s/locations/%s", p.projectID, location)
+		err := service.Projects.Locations.Builds.List(parent).PageSize(100).Pages(ctx, func(resp *cloudbuild.ListBuildsResponse) error {
+			for _, build := range resp.Builds {
+				scanned++
+				if seen[build.Id] || !(build.Id == current || matchesFunctionSource(build, source)) {
+					continue
+				}
+				seen[build.Id] = true
+				deployments = append(deployments, buildDeployment(build, current))
+			}
+			if scanned >= maxBuildsScanned || len(deployments) >= maxDeployments {
+				return errEnoughBuilds
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errEnoughBuilds) {
+			if len(deployments) > 0 {
+				logger.Logger.Printf("Error listing Cloud Build builds in %s: %v", location, err)
+				continue
+			}
+			return nil, fmt.Errorf("failed to list Cloud Build builds: %w", err)
+		}
+	}
+
+	// The current build can be older than the builds scanned
+	if current != "" && !seen[current] {
+		build, err := service.Projects.Locations.Builds.Get(fmt.Sprintf("projects/%s/locations/%s/builds/%s", p.projectID, region, current)).Context(ctx).Do()
+		if err != nil {
+			build, err = service.Projects.Builds.Get(p.projectID, current).Context(ctx).Do()
+		}
+		if err != nil {
+			logger.Logger.Printf("Error getting build %s of %s: %v", current, fn.Name, err)
+		} else {
+			deployments = append(deployments, buildDeployment(build, current))
+		}
+	}
+
+	sortDeployments(deployments)
+	if len(deployments) > maxDeployments {
+		deployments = deployments[:maxDeployments]
+	}
+	return deployments, nil
+}
+
+// currentBuild returns the ID of the build the function was last deployed
+// with, empty when it can't be found
+func (p *GCPProvider) currentBuild(ctx context.Context, name string) string {
+	function, err := p.functionV2(ctx, name)
+	if err == nil {
+		if function.BuildConfig == nil {
+			return ""
+		}
+		// projects/<number>/locations/<region>/builds/<id>
+		build := function.BuildConfig.Build
+		return build[strings.LastIndex(build, "/")+1:]
+	}
+	logger.Logger.Printf("Falling back to the v1 API for the build of %s: %v", name, err)
+
+	v1, err := p.client.Projects.Locations.Functions.Get(name).Context(ctx).Do()
+	if err != nil {
+		logger.Logger.Printf("Error getting function %s: %v", name, err)
+		return ""
+	}
+	return v1.BuildId
+}
+
+// matchesFunctionSource reports whether a build builds the source Cloud
+// Functions uploaded for the function: gcf-v2-sources-*/<name>/... for 2nd
+// gen, gcf-sources-*/<name>-<uuid>/... for 1st gen functions
+func matchesFunctionSource(build *cloudbuild.Build, source *regexp.Regexp) bool {
+	storage := buildStorageSource(build)
+	return storage != nil && strings.HasPrefix(storage.Bucket, "gcf-") && source.MatchString(storage.Object)
+}
+
+// buildStorageSource returns the Cloud Storage source of a build, nil when
+// it was built from a repository
+func buildStorageSource(build *cloudbuild.Build) *cloudbuild.StorageSource {
+	if build.SourceProvenance != nil && build.SourceProvenance.ResolvedStorageSource != nil {
+		return build.SourceProvenance.ResolvedStorageSource
+	}
+	if build.Source != nil {
+		return build.Source.StorageSource
+	}
+	return nil
+}
+
+// buildDeployment describes a build as a deployment, with the source version
+// it built: the archive uploaded with its generation, or the commit
+func buildDeployment(build *cloudbuild.Build, current string) Deployment {
+	deployment := Deployment{
+		ID:       build.Id,
+		Status:   build.Status,
+		Started:  parseRFC3339(build.StartTime),
+		Finished: parseRFC3339(build.FinishTime),
+		URL:      build.LogUrl,
+		Current:  build.Id == current,
+	}
+	if deployment.Started.IsZero() {
+		// Queued builds haven't started yet
+		deployment.Started = parseRFC3339(build.CreateTime)
+	}
+
+	var repo *cloudbuild.RepoSource
+	if build.SourceProvenance != nil {
+		repo = build.SourceProvenance.ResolvedRepoSource
+	}
+	if repo == nil && build.Source != nil {
+		repo = build.Source.RepoSource
+	}
+	switch storage := buildStorageSource(build); {
+	case storage != nil:
+		deployment.Source = fmt.Sprintf("gs://%s/%s", storage.Bucket, storage.Object)
+		if storage.Generation != 0 {
+			deployment.Source += fmt.Sprintf("#%d", storage.Generation)
+		}
+	case repo != nil:
+		deployment.Source = repo.RepoName
+		if repo.CommitSha != "" {
+			deployment.Source += "@" + repo.CommitSha
+		}
+	}
+	return deployment
+}
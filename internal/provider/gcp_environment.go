@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"f6n/internal/logger"
+
+	"google.golang.org/api/cloudfunctions/v1"
+	cloudfunctionsv2 "google.golang.org/api/cloudfunctions/v2"
+	"google.golang.org/api/googleapi"
+)
+
+// UpdateEnvironment replaces the function's environment variables and secret
+// references with a patch limited to those fields. The deployment it starts
+// runs in the background; the function's state shows its progress. The
+// patch can't be made conditional, so revision is not used.
+func (p *GCPProvider) UpdateEnvironment(ctx context.Context, name string, env, secrets map[string]string, revision string) error {
+	resource := fmt.Sprintf("projects/%s/locations/%s/functions/%s", p.projectID, p.region, name)
+
+	var secretVars []*cloudfunctions.SecretEnvVar
+	for _, key := range sortedKeys(secrets) {
+		project, secret, version, err := p.parseSecretRef(secrets[key])
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		secretVars = append(secretVars, &cloudfunctions.SecretEnvVar{Key: key, ProjectId: project, Secret: secret, Version: version})
+	}
+
+	_, err := p.client.Projects.Locations.Functions.Patch(resource, &cloudfunctions.CloudFunction{
+		EnvironmentVariables:       env,
+		SecretEnvironmentVariables: secretVars,
+	}).UpdateMask("environmentVariables,secretEnvironmentVariables").Context(ctx).Do()
+	if err == nil {
+		return nil
+	}
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+		return fmt.Errorf("failed to update environment of %s: %w", name, err)
+	}
+
+	// 2nd gen functions are only known to the v2 API
+	logger.Logger.Printf("Function %s not found by the v1 API, patching with v2", name)
+	service, err := cloudfunctionsv2.NewService(ctx, p.restOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to create Cloud Functions v2 client: %w", err)
+	}
+	var secretVarsV2 []*cloudfunctionsv2.SecretEnvVar
+	for _, v := range secretVars {
+		secretVarsV2 = append(secretVarsV2, &cloudfunctionsv2.SecretEnvVar{Key: v.Key, ProjectId: v.ProjectId, Secret: v.Secret, Version: v.Version})
+	}
+	_, err = service.Projects.Locations.Functions.Patch(resource, &cloudfunctionsv2.Function{
+		ServiceConfig: &cloudfunctionsv2.ServiceConfig{
+			EnvironmentVariables:       env,
+			SecretEnvironmentVariables: secretVarsV2,
+		},
+	}).UpdateMask("serviceConfig.environmentVariables,serviceConfig.secretEnvironmentVariables").Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to update environment of %s: %w", name, err)
+	}
+	return nil
+}
+
+// UpdateDescription changes the function's description with a patch limited
+// to it. Like other patches, it starts a deployment.
+func (p *GCPProvider) UpdateDescription(ctx context.Context, name, description string) error {
+	resource := fmt.Sprintf("projects/%s/locations/%s/functions/%s", p.projectID, p.region, name)
+	_, err := p.client.Projects.Locations.Functions.Patch(resource, &cloudfunctions.CloudFunction{
+		Description:     description,
+		ForceSendFields: []string{"Description"},
+	}).UpdateMask("description").Context(ctx).Do()
+	if err == nil {
+		return nil
+	}
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusNotFound {
+		return fmt.Errorf("failed to update description of %s: %w", name, err)
+	}
+
+	// 2nd gen functions are only known to the v2 API
+	service, err := cloudfunctionsv2.NewService(ctx, p.restOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to create Cloud Functions v2 client: %w", err)
+	}
+	_, err = service.Projects.Locations.Functions.Patch(resource, &cloudfunctionsv2.Function{
+		Description:     description,
+		ForceSendFields: []string{"Description"},
+	}).UpdateMask("description").Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to update description of %s: %w", name, err)
+	}
+	return nil
+}
+
+// secretRef formats a secret environment variable as a Secret Manager resource name
+func secretRef(v *cloudfunctions.SecretEnvVar) string {
+	version := v.Version
+	if version == "" {
+		version = "latest"
+	}
+	return fmt.Sprintf("projects/%s/secrets/%s/versions/%s", v.ProjectId, v.Secret, version)
+}
+
+// parseSecretRef splits projects/P/secrets/S/versions/V. The shorthands S and
+// S:V refer to a secret of the provider's project, at the latest version
+// unless V is given.
+func (p *GCPProvider) parseSecretRef(ref string) (project, secret, version string, err error) {
+	parts := strings.Split(ref, "/")
+	switch {
+	case len(parts) == 6 && parts[0] == "projects" && parts[2] == "secrets" && parts[4] == "versions":
+		project, secret, version = parts[1], parts[3], parts[5]
+	case len(parts) == 1:
+		project = p.projectID
+		secret, version, _ = strings.Cut(ref, ":")
+		if version == "" {
+			version = "latest"
+		}
+	default:
+		return "", "", "", fmt.Errorf("invalid secret reference %q (expected projects/P/secrets/S/versions/V or S[:V])", ref)
+	}
+	if project == "" || secret == "" || version == "" {
+		return "", "", "", fmt.Errorf("invalid secret reference %q", ref)
+	}
+	return project, secret, version, nil
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"cloud.google.com/go/logging/logadmin"
+	"google.golang.org/api/iterator"
+)
+
+// gcpLogRetention is how long Cloud Logging keeps entries by default, the
+// furthest back a page of older logs looks
+const gcpLogRetention = 30 * 24 * time.Hour
+
+// defaultLogPageSize is the page size when the query sets no limit
+const defaultLogPageSize = 200
+
+// GetOlderFunctionLogs pages back through the function's Cloud Logging
+// entries, newest first, with the page tokens of the Logging API
+func (p *GCPProvider) GetOlderFunctionLogs(ctx context.Context, functionName string, query LogQuery, before time.Time, token string) (*LogPage, error) {
+	adminClient, err := logadmin.NewClient(ctx, p.projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logging client: %w", err)
+	}
+	defer adminClient.Close()
+
+	// The filter must stay the same from page to page for the token to apply
+	filter := buildGCPLogFilter(functionName, before.Add(-gcpLogRetention), query) +
+		fmt.Sprintf("\ntimestamp<%q", before.UTC().Format(time.RFC3339Nano))
+	iter := adminClient.Entries(ctx, logadmin.Filter(filter), logadmin.NewestFirst())
+
+	pageSize := query.Limit
+	if pageSize <= 0 {
+		pageSize = defaultLogPageSize
+	}
+	var page []*logging.Entry
+	next, err := iterator.NewPager(iter, pageSize, token).NextPage(&page)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch older log entries: %w", err)
+	}
+
+	entries := make([]LogEntry, 0, len(page))
+	for _, entry := range page {
+		entries = append(entries, LogEntry{
+			Timestamp: entry.Timestamp,
+			Severity:  entry.Severity.String(),
+			Message:   payloadMessage(entry.Payload),
+			Labels:    entry.Labels,
+		})
+	}
+	slices.Reverse(entries)
+	return &LogPage{Entries: entries, Next: next}, nil
+}
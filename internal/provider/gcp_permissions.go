@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/cloudfunctions/v1"
+)
+
+// gcpActionPermissions are the IAM permissions each f6n action needs on a
+// Cloud Function
+var gcpActionPermissions = map[Action]string{
+	ActionUpdateEnvironment: "cloudfunctions.functions.update",
+	ActionDownloadCode:      "cloudfunctions.functions.sourceCodeGet",
+	ActionUpdateDescription: "cloudfunctions.functions.update",
+}
+
+// CheckPermissions asks Cloud Functions which of the permissions f6n's
+// actions need the caller holds on the function
+func (p *GCPProvider) CheckPermissions(ctx context.Context, fn FunctionInfo) (map[Action][]string, error) {
+	permissions := make([]string, 0, len(gcpActionPermissions))
+	for _, permission := range gcpActionPermissions {
+		permissions = append(permissions, permission)
+	}
+	resp, err := p.client.Projects.Locations.Functions.TestIamPermissions(fn.ARN,
+		&cloudfunctions.TestIamPermissionsRequest{Permissions: permissions}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to test permissions on %s: %w", fn.Name, err)
+	}
+
+	held := make(map[string]bool, len(resp.Permissions))
+	for _, permission := range resp.Permissions {
+		held[permission] = true
+	}
+	denied := make(map[Action][]string)
+	for action, permission := range gcpActionPermissions {
+		if !held[permission] {
+			denied[action] = []string{permission}
+		}
+	}
+	return denied, nil
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"google.golang.org/api/cloudresourcemanager/v3"
+)
+
+// ListProjects lists the active projects the caller can access
+func (p *GCPProvider) ListProjects(ctx context.Context) ([]ProjectInfo, error) {
+	service, err := cloudresourcemanager.NewService(ctx, p.restOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Resource Manager client: %w", err)
+	}
+
+	var projects []ProjectInfo
+	err = service.Projects.Search().Query("state:ACTIVE").Pages(ctx, func(resp *cloudresourcemanager.SearchProjectsResponse) error {
+		for _, project := range resp.Projects {
+			projects = append(projects, ProjectInfo{ID: project.ProjectId, Name: project.DisplayName})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search projects: %w", err)
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].ID < projects[j].ID })
+	return projects, nil
+}
+
+// WithProject returns a provider for another project in the same region,
+// with the same credentials
+func (p *GCPProvider) WithProject(projectID string) (Provider, error) {
+	return NewGCPProvider(projectID, p.region, p.clientOpts...)
+}
@@ -6,8 +6,12 @@ import (
 	"f6n/internal/logger"
 	"fmt"
 	"io"
+	"maps"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +22,8 @@ import (
 	"google.golang.org/api/cloudfunctions/v1"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -26,7 +32,9 @@ type GCPProvider struct {
 	projectID  string
 	region     string
 	client     *cloudfunctions.Service
-	clientOpts []option.ClientOption
+	clientOpts []option.ClientOption // as given, to switch projects with
+	restOpts   []option.ClientOption // for REST clients, counting API calls
+	grpcOpts   []option.ClientOption // for gRPC clients, counting API calls
 }
 
 // NewGCPProvider creates a new GCP provider
@@ -36,7 +44,11 @@ func NewGCPProvider(projectID, region string, opts ...option.ClientOption) (*GCP
 	}
 
 	ctx := context.Background()
-	client, err := cloudfunctions.NewService(ctx, opts...)
+	restOpts, grpcOpts, err := countedOptions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	client, err := cloudfunctions.NewService(ctx, restOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Cloud Functions client: %w", err)
 	}
@@ -46,6 +58,8 @@ func NewGCPProvider(projectID, region string, opts ...option.ClientOption) (*GCP
 		region:     region,
 		client:     client,
 		clientOpts: opts,
+		restOpts:   restOpts,
+		grpcOpts:   grpcOpts,
 	}, nil
 }
 
@@ -63,6 +77,30 @@ func (p *GCPProvider) GetAccountID(ctx context.Context) (string, error) {
 	return p.projectID, nil
 }
 
+// ConsoleURL returns the function's page in the Google Cloud console
+func (p *GCPProvider) ConsoleURL(fn FunctionInfo) string {
+	region := fn.Region
+	if region == "" {
+		region = p.region
+	}
+	return fmt.Sprintf("https://console.cloud.google.com/functions/details/%s/%s?project=%s",
+		url.PathEscape(region), url.PathEscape(fn.Name), url.QueryEscape(p.projectID))
+}
+
+// References returns the function's resource name, Cloud Logging filter and
+// HTTPS trigger URL, if it has one
+func (p *GCPProvider) References(ctx context.Context, fn FunctionInfo) ([]FunctionReference, error) {
+	refs := []FunctionReference{
+		{Label: "Resource name", Value: fn.ARN},
+		{Label: "Log filter", Value: fmt.Sprintf(`resource.type="cloud_function" resource.labels.function_name="%s"`, fn.Name)},
+	}
+	urls, _ := p.functionTargets(ctx, fn.ARN)
+	if len(urls) > 0 {
+		refs = append(refs, FunctionReference{Label: "URL", Value: urls[0]})
+	}
+	return refs, nil
+}
+
 // ListFunctions lists all Cloud Functions (using dummy data for now)
 func (p *GCPProvider) ListFunctions(ctx context.Context) ([]FunctionInfo, error) {
 	parent := fmt.Sprintf("projects/%s/locations/%s", p.projectID, p.region)
@@ -84,21 +122,52 @@ func (p *GCPProvider) ListFunctions(ctx context.Context) ([]FunctionInfo, error)
 			timeout = 0
 		}
 
+		// Labels are the tags of Cloud Functions
+		labels := f.Labels
+		if labels == nil {
+			labels = map[string]string{}
+		}
+
+		var secrets map[string]string
+		for _, v := range f.SecretEnvironmentVariables {
+			if secrets == nil {
+				secrets = make(map[string]string)
+			}
+			secrets[v.Key] = secretRef(v)
+		}
+
 		functions = append(functions, FunctionInfo{
-			Name:         f.Name[strings.LastIndex(f.Name, "/")+1:],
-			Runtime:      f.Runtime,
-			Memory:       int32(f.AvailableMemoryMb),
-			Timeout:      int32(timeout.Seconds()),
-			LastModified: lastModified.Format("2006-01-02 15:04:05"),
-			ARN:          f.Name,
-			Description:  f.Description,
-			Region:       p.region,
+			Name:              f.Name[strings.LastIndex(f.Name, "/")+1:],
+			Runtime:           f.Runtime,
+			Memory:            int32(f.AvailableMemoryMb),
+			Timeout:           int32(timeout.Seconds()),
+			LastModified:      f.UpdateTime,
+			Modified:          lastModified,
+			ARN:               f.Name,
+			Description:       f.Description,
+			Handler:           f.EntryPoint,
+			Role:              f.ServiceAccountEmail,
+			Environment:       f.EnvironmentVariables,
+			Region:            p.region,
+			KMSKeyARN:         f.KmsKeyName,
+			Tags:              labels,
+			State:             f.Status,
+			SecretEnvironment: secrets,
+			Revision:          formatVersionID(f.VersionId),
 		})
 	}
 
 	return functions, nil
 }
 
+// formatVersionID formats the version of a function, empty when unknown
+func formatVersionID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
+
 // GetFunction gets details about a specific function
 func (p *GCPProvider) GetFunction(ctx context.Context, name string) (*FunctionInfo, error) {
 	// TODO: Implement real GCP Cloud Functions API integration
@@ -233,7 +302,7 @@ func (p *GCPProvider) downloadFromGCS(ctx context.Context, gsURL, destination st
 
 	// Create Cloud Storage client
 	logger.Logger.Printf("Creating GCS client with authentication options...")
-	client, err := storage.NewClient(ctx, p.clientOpts...)
+	client, err := storage.NewClient(ctx, p.restOpts...)
 	if err != nil {
 		logger.Logger.Printf("Failed to create GCS client: %v", err)
 		return fmt.Errorf("failed to create storage client: %w", err)
@@ -386,13 +455,22 @@ func writeConfigInfo(info *strings.Builder, function *cloudfunctions.CloudFuncti
 		info.WriteString(fmt.Sprintf("  Min Instances: %d\n", function.MinInstances))
 	}
 	info.WriteString("\n")
+	if len(function.Labels) > 0 {
+		info.WriteString("Labels:\n")
+		for _, k := range slices.Sorted(maps.Keys(function.Labels)) {
+			info.WriteString(fmt.Sprintf("  %s: %s\n", k, function.Labels[k]))
+		}
+		info.WriteString("\n")
+	}
 }
 
+// writeEnvVars lists the environment variables with their values masked, as
+// they may hold secrets; the details reveal them one at a time
 func writeEnvVars(info *strings.Builder, function *cloudfunctions.CloudFunction) {
 	if len(function.EnvironmentVariables) > 0 {
-		info.WriteString("Environment Variables:\n")
-		for k, v := range function.EnvironmentVariables {
-			info.WriteString(fmt.Sprintf("  %s: %s\n", k, v))
+		info.WriteString("Environment Variables (values hidden, reveal them in the details with v):\n")
+		for _, k := range slices.Sorted(maps.Keys(function.EnvironmentVariables)) {
+			info.WriteString(fmt.Sprintf("  %s: ********\n", k))
 		}
 		info.WriteString("\n")
 	}
@@ -417,7 +495,7 @@ func writeDownloadInstructions(info *strings.Builder, function *cloudfunctions.C
 }
 
 // GetFunctionLogs gets logs for a function
-func (p *GCPProvider) GetFunctionLogs(ctx context.Context, functionName string, limit int) ([]string, error) {
+func (p *GCPProvider) GetFunctionLogs(ctx context.Context, functionName string, query LogQuery) ([]LogEntry, error) {
 	// Create logging client
 	adminClient, err := logadmin.NewClient(ctx, p.projectID)
 	if err != nil {
@@ -425,25 +503,19 @@ func (p *GCPProvider) GetFunctionLogs(ctx context.Context, functionName string,
 	}
 	defer adminClient.Close()
 
-	// Build filter for Cloud Function logs
-	// Cloud Functions log to resources.type="cloud_function" with function_name label
-	filter := fmt.Sprintf(`resource.type="cloud_function"
-resource.labels.function_name="%s"
-timestamp>="%s"`,
-		functionName,
-		time.Now().Add(-24*time.Hour).Format(time.RFC3339), // Last 24 hours
-	)
+	since := query.Since
+	if since.IsZero() {
+		since = time.Now().Add(-24 * time.Hour) // Last 24 hours
+	}
 
 	// Query logs
 	iter := adminClient.Entries(ctx,
-		logadmin.Filter(filter),
+		logadmin.Filter(buildGCPLogFilter(functionName, since, query)),
 		logadmin.NewestFirst(),
 	)
 
-	var logs []string
-	count := 0
-
-	for count < limit {
+	var logs []LogEntry
+	for query.Limit <= 0 || len(logs) < query.Limit {
 		entry, err := iter.Next()
 		if err == iterator.Done {
 			break
@@ -452,30 +524,65 @@ timestamp>="%s"`,
 			return nil, fmt.Errorf("failed to fetch log entry: %w", err)
 		}
 
-		// Format log entry
-		timestamp := entry.Timestamp.Format("2006-01-02 15:04:05")
-		severity := entry.Severity.String()
-
-		var message string
-		switch payload := entry.Payload.(type) {
-		case string:
-			message = payload
-		default:
-			message = fmt.Sprintf("%v", payload)
-		}
-
-		logLine := fmt.Sprintf("[%s] %s: %s", timestamp, severity, message)
-		logs = append(logs, logLine)
-		count++
+		logs = append(logs, LogEntry{
+			Timestamp: entry.Timestamp,
+			Severity:  entry.Severity.String(),
+			Message:   payloadMessage(entry.Payload),
+			Labels:    entry.Labels,
+		})
 	}
 
 	if len(logs) == 0 {
-		return []string{fmt.Sprintf("No logs found for function: %s (last 24 hours)", functionName)}, nil
+		return []LogEntry{{
+			Timestamp: time.Now(),
+			Severity:  "INFO",
+			Message:   fmt.Sprintf("No logs found for function: %s since %s", functionName, since.Format("2006-01-02 15:04")),
+		}}, nil
 	}
 
+	// Read newest first to keep the most recent entries; return them oldest first
+	slices.Reverse(logs)
 	return logs, nil
 }
 
+// buildGCPLogFilter builds a Cloud Logging filter for a function's logs.
+// Cloud Functions log to resource.type="cloud_function" with a function_name label.
+func buildGCPLogFilter(functionName string, since time.Time, query LogQuery) string {
+	filter := fmt.Sprintf(`resource.type="cloud_function"
+resource.labels.function_name="%s"
+timestamp>="%s"`,
+		functionName,
+		since.Format(time.RFC3339),
+	)
+
+	if query.MinSeverity != "" {
+		severity := strings.ToUpper(query.MinSeverity)
+		if severity == "WARN" {
+			// Cloud Logging only knows WARNING
+			severity = "WARNING"
+		}
+		filter += fmt.Sprintf("\nseverity>=%s", severity)
+	}
+	if query.Text != "" {
+		filter += "\n" + strconv.Quote(query.Text)
+	}
+	return filter
+}
+
+// payloadMessage renders a log entry payload as text. Structured (jsonPayload
+// and protoPayload) entries are encoded as JSON so they can be filtered by field.
+func payloadMessage(payload interface{}) string {
+	switch p := payload.(type) {
+	case string:
+		return p
+	case proto.Message:
+		if data, err := protojson.Marshal(p); err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprintf("%v", payload)
+}
+
 // StreamFunctionLogs streams logs for a function in real-time
 func (p *GCPProvider) StreamFunctionLogs(ctx context.Context, functionName string) (<-chan LogEntry, <-chan error) {
 	logChan := make(chan LogEntry, 100) // Buffer to prevent blocking
@@ -488,7 +595,7 @@ func (p *GCPProvider) StreamFunctionLogs(ctx context.Context, functionName strin
 		logger.Logger.Printf("Starting log streaming for function: %s", functionName)
 
 		// Create logging client
-		adminClient, err := logadmin.NewClient(ctx, p.projectID, p.clientOpts...)
+		adminClient, err := logadmin.NewClient(ctx, p.projectID, p.grpcOpts...)
 		if err != nil {
 			errChan <- fmt.Errorf("failed to create logging client: %w", err)
 			return
@@ -537,18 +644,10 @@ timestamp>="%s"`,
 					}
 
 					// Create LogEntry
-					var message string
-					switch payload := entry.Payload.(type) {
-					case string:
-						message = payload
-					default:
-						message = fmt.Sprintf("%v", payload)
-					}
-
 					logEntry := LogEntry{
 						Timestamp: entry.Timestamp,
 						Severity:  entry.Severity.String(),
-						Message:   message,
+						Message:   payloadMessage(entry.Payload),
 						Labels:    entry.Labels,
 					}
 
@@ -579,7 +678,7 @@ func (p *GCPProvider) GetFunctionMetrics(ctx context.Context, functionName strin
 	logger.Logger.Printf("Fetching metrics for function: %s", functionName)
 
 	// Create monitoring client
-	client, err := monitoring.NewMetricClient(ctx, p.clientOpts...)
+	client, err := monitoring.NewMetricClient(ctx, p.grpcOpts...)
 	if err != nil {
 		logger.Logger.Printf("Failed to create monitoring client: %v, falling back to sample data", err)
 		return p.generateSampleMetrics(functionName, startTime, endTime), nil
@@ -755,6 +854,27 @@ func (p *GCPProvider) fetchMetricData(ctx context.Context, client *monitoring.Me
 	return dataPoints, nil
 }
 
+// Invoke calls a function synchronously with the given payload. Cloud Functions
+// limits such calls to 16 invocations per 100 seconds.
+func (p *GCPProvider) Invoke(ctx context.Context, functionName string, payload []byte) (*InvokeResult, error) {
+	fn, err := p.GetFunction(ctx, functionName)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Projects.Locations.Functions.Call(fn.ARN, &cloudfunctions.CallFunctionRequest{
+		Data: string(payload),
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to call function %s: %w", functionName, err)
+	}
+	return &InvokeResult{
+		Payload:       resp.Result,
+		FunctionError: resp.Error,
+		ExecutionID:   resp.ExecutionId,
+	}, nil
+}
+
 // GetEndpoints gets endpoints associated with a function
 func (p *GCPProvider) GetEndpoints(ctx context.Context, name string) ([]string, error) {
 	// TODO: Implement real endpoint discovery
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/logger"
+
+	"google.golang.org/api/cloudscheduler/v1"
+)
+
+// ListSchedules finds the Cloud Scheduler jobs in the function's region that
+// call its HTTP trigger or publish to the Pub/Sub topic that triggers it
+func (p *GCPProvider) ListSchedules(ctx context.Context, fn FunctionInfo) ([]ScheduledJob, error) {
+	region := fn.Region
+	if region == "" {
+		region = p.region
+	}
+	urls, topics := p.functionTargets(ctx, fmt.Sprintf("projects/%s/locations/%s/functions/%s", p.projectID, region, fn.Name))
+	if len(urls) == 0 && len(topics) == 0 {
+		return []ScheduledJob{}, nil
+	}
+
+	service, err := cloudscheduler.NewService(ctx, p.restOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cloud Scheduler client: %w", err)
+	}
+
+	jobs := []ScheduledJob{}
+	parent := fmt.Sprintf("projects/%s/locations/%s", p.projectID, region)
+	err = service.Projects.Locations.Jobs.List(parent).Pages(ctx, func(resp *cloudscheduler.ListJobsResponse) error {
+		for _, job := range resp.Jobs {
+			target := ""
+			switch {
+			case job.HttpTarget != nil && matchesURL(job.HttpTarget.Uri, urls):
+				target = job.HttpTarget.Uri
+			case job.PubsubTarget != nil && contains(topics, job.PubsubTarget.TopicName):
+				target = job.PubsubTarget.TopicName
+			default:
+				continue
+			}
+			jobs = append(jobs, ScheduledJob{
+				Name:        job.Name[strings.LastIndex(job.Name, "/")+1:],
+				Schedule:    job.Schedule,
+				TimeZone:    job.TimeZone,
+				State:       job.State,
+				Target:      target,
+				Description: job.Description,
+				NextRun:     parseRFC3339(job.ScheduleTime),
+				LastAttempt: parseRFC3339(job.LastAttemptTime),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Cloud Scheduler jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// functionTargets returns the HTTPS URLs and Pub/Sub topics that invoke a
+// function. Lookup errors leave the lists empty.
+func (p *GCPProvider) functionTargets(ctx context.Context, name string) (urls, topics []string) {
+	function, err := p.functionV2(ctx, name)
+	if err == nil {
+		if function.Url != "" {
+			urls = append(urls, function.Url)
+		}
+		if function.ServiceConfig != nil && function.ServiceConfig.Uri != "" {
+			urls = append(urls, function.ServiceConfig.Uri)
+		}
+		if function.EventTrigger != nil && function.EventTrigger.PubsubTopic != "" {
+			topics = append(topics, function.EventTrigger.PubsubTopic)
+		}
+		return urls, topics
+	}
+	logger.Logger.Printf("Falling back to the v1 API for the targets of %s: %v", name, err)
+
+	v1, err := p.client.Projects.Locations.Functions.Get(name).Context(ctx).Do()
+	if err != nil {
+		logger.Logger.Printf("Error getting function %s: %v", name, err)
+		return nil, nil
+	}
+	if v1.HttpsTrigger != nil && v1.HttpsTrigger.Url != "" {
+		urls = append(urls, v1.HttpsTrigger.Url)
+	}
+	if v1.EventTrigger != nil && strings.Contains(v1.EventTrigger.Resource, "/topics/") {
+		topics = append(topics, v1.EventTrigger.Resource)
+	}
+	return urls, topics
+}
+
+// matchesURL reports whether uri calls one of the base URLs, with or without
+// a path or query
+func matchesURL(uri string, urls []string) bool {
+	for _, base := range urls {
+		base = strings.TrimSuffix(base, "/")
+		if uri == base || strings.HasPrefix(uri, base+"/") || strings.HasPrefix(uri, base+"?") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRFC3339 parses an API timestamp, returning the zero time when unset
+func parseRFC3339(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
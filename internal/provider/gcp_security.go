@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// AuditPosture checks the invoker bindings, HTTPS enforcement, service account
+// and environment encryption of a Cloud Function
+func (p *GCPProvider) AuditPosture(ctx context.Context, fn FunctionInfo) (*PostureReport, error) {
+	report := &PostureReport{}
+
+	function, err := p.client.Projects.Locations.Functions.Get(fn.ARN).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get function %s: %w", fn.Name, err)
+	}
+
+	report.Checks = append(report.Checks, "Invoker bindings")
+	policy, err := p.client.Projects.Locations.Functions.GetIamPolicy(fn.ARN).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get IAM policy of %s: %w", fn.Name, err)
+	}
+	ingress := ""
+	if function.IngressSettings != "" && function.IngressSettings != "ALLOW_ALL" {
+		ingress = fmt.Sprintf(" Ingress is limited to %s, which reduces but does not remove the exposure.", function.IngressSettings)
+	}
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			switch member {
+			case "allUsers":
+				report.Findings = append(report.Findings, PostureFinding{
+					Severity:    "HIGH",
+					Title:       "Function invokable by allUsers",
+					Detail:      fmt.Sprintf("%s is granted to allUsers", binding.Role),
+					Explanation: "Anyone on the internet can call the function without authenticating, and every invocation is billed to you." + ingress,
+					Remediation: "Remove the allUsers binding and grant roles/cloudfunctions.invoker to the callers' service accounts, or put the function behind API Gateway or IAP.",
+				})
+			case "allAuthenticatedUsers":
+				report.Findings = append(report.Findings, PostureFinding{
+					Severity:    "MEDIUM",
+					Title:       "Function invokable by allAuthenticatedUsers",
+					Detail:      fmt.Sprintf("%s is granted to allAuthenticatedUsers", binding.Role),
+					Explanation: "Any Google account, not only accounts of your organization, can call the function." + ingress,
+					Remediation: "Grant roles/cloudfunctions.invoker to specific users, groups or service accounts instead.",
+				})
+			}
+		}
+	}
+
+	if function.HttpsTrigger != nil {
+		report.Checks = append(report.Checks, "HTTPS enforcement")
+		if function.HttpsTrigger.SecurityLevel == "SECURE_OPTIONAL" {
+			report.Findings = append(report.Findings, PostureFinding{
+				Severity:    "LOW",
+				Title:       "Plain HTTP allowed",
+				Detail:      "The HTTP trigger has security level SECURE_OPTIONAL",
+				Explanation: "Requests and responses sent over plain HTTP can be read and modified in transit.",
+				Remediation: "Redeploy with --security-level=secure-always so HTTP requests are redirected to HTTPS.",
+			})
+		}
+	}
+
+	report.Checks = append(report.Checks, "Service account")
+	if sa := function.ServiceAccountEmail; strings.HasSuffix(sa, "-compute@developer.gserviceaccount.com") || strings.HasSuffix(sa, "@appspot.gserviceaccount.com") {
+		report.Findings = append(report.Findings, PostureFinding{
+			Severity:    "MEDIUM",
+			Title:       "Runs as a default service account",
+			Detail:      "Service account: " + sa,
+			Explanation: "Default service accounts are granted the Editor role on the project, so the function's code can modify most resources in it.",
+			Remediation: "Deploy with --service-account set to a dedicated account holding only the roles the function needs.",
+		})
+	}
+
+	report.Checks = append(report.Checks, "Environment variable encryption")
+	report.Findings = append(report.Findings, auditEnvironmentEncryption(fn,
+		"Environment variables are stored with Google-managed encryption and are readable in plain text by anyone allowed to view the function.",
+		"Move secrets to Secret Manager and expose them with --set-secrets, or encrypt the function with a customer-managed key (--kms-key).")...)
+
+	return report, nil
+}
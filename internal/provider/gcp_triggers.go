@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"f6n/internal/logger"
+
+	cloudfunctionsv2 "google.golang.org/api/cloudfunctions/v2"
+)
+
+// ListEventSourceMappings resolves the event trigger of a Cloud Function: the
+// Pub/Sub topic, Cloud Storage bucket or Eventarc trigger with its event type
+// and filters. HTTP functions have none.
+func (p *GCPProvider) ListEventSourceMappings(ctx context.Context, functionName string) ([]EventSourceMapping, error) {
+	name := fmt.Sprintf("projects/%s/locations/%s/functions/%s", p.projectID, p.region, functionName)
+
+	// 2nd gen functions are triggered through Eventarc, which only the v2 API describes
+	mappings, err := p.eventarcTriggers(ctx, name)
+	if err == nil {
+		return mappings, nil
+	}
+	logger.Logger.Printf("Falling back to the v1 API for the trigger of %s: %v", functionName, err)
+
+	function, err := p.client.Projects.Locations.Functions.Get(name).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get function %s: %w", functionName, err)
+	}
+	trigger := function.EventTrigger
+	if trigger == nil {
+		return []EventSourceMapping{}, nil
+	}
+
+	mapping := EventSourceMapping{
+		SourceARN: gcsBucketURL(trigger.Resource),
+		State:     function.Status,
+		EventType: trigger.EventType,
+		Retry:     "RETRY_POLICY_DO_NOT_RETRY",
+	}
+	if trigger.FailurePolicy != nil && trigger.FailurePolicy.Retry != nil {
+		mapping.Retry = "RETRY_POLICY_RETRY"
+	}
+	return []EventSourceMapping{mapping}, nil
+}
+
+// eventarcTriggers reads the event trigger of a function from the v2 API
+func (p *GCPProvider) eventarcTriggers(ctx context.Context, name string) ([]EventSourceMapping, error) {
+	function, err := p.functionV2(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	trigger := function.EventTrigger
+	if trigger == nil {
+		return []EventSourceMapping{}, nil
+	}
+
+	mapping := EventSourceMapping{
+		SourceARN: trigger.Trigger,
+		State:     function.State,
+		EventType: trigger.EventType,
+		Trigger:   trigger.Trigger,
+		Retry:     trigger.RetryPolicy,
+	}
+	for _, filter := range trigger.EventFilters {
+		if filter.Operator != "" {
+			mapping.Filters = append(mapping.Filters, fmt.Sprintf("%s %s %s", filter.Attribute, filter.Operator, filter.Value))
+		} else {
+			mapping.Filters = append(mapping.Filters, filter.Attribute+"="+filter.Value)
+		}
+		if filter.Attribute == "bucket" && filter.Operator == "" {
+			mapping.SourceARN = "gs://" + filter.Value
+		}
+	}
+	if trigger.PubsubTopic != "" {
+		mapping.SourceARN = trigger.PubsubTopic
+	}
+	return []EventSourceMapping{mapping}, nil
+}
+
+// functionV2 gets a function from the v2 API, which also describes 2nd gen functions
+func (p *GCPProvider) functionV2(ctx context.Context, name string) (*cloudfunctionsv2.Function, error) {
+	service, err := cloudfunctionsv2.NewService(ctx, p.restOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cloud Functions v2 client: %w", err)
+	}
+	return service.Projects.Locations.Functions.Get(name).Context(ctx).Do()
+}
+
+// gcsBucketURL turns a 1st gen bucket resource (projects/_/buckets/b) into
+// gs://b, leaving other resources unchanged
+func gcsBucketURL(resource string) string {
+	if bucket, ok := strings.CutPrefix(resource, "projects/_/buckets/"); ok {
+		return "gs://" + bucket
+	}
+	return resource
+}
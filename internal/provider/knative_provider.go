@@ -0,0 +1,721 @@
+package provider
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"f6n/internal/apicalls"
+	"f6n/internal/logger"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// knativeServiceLabel is the label Knative sets on the revisions and pods
+	// of a service
+	knativeServiceLabel = "serving.knative.dev/service"
+	// knativeUserContainer is the name of a revision's container when its
+	// spec gives none
+	knativeUserContainer = "user-container"
+	// knativeLogLimit is the number of log lines read when the query sets none
+	knativeLogLimit = 100
+	// knativePodPoll is how often a log stream looks for new pods, Knative
+	// starting and stopping them as it scales
+	knativePodPoll = 5 * time.Second
+)
+
+// Knative autoscaling annotations, set on a service's revision template
+const (
+	knativeTargetAnnotation   = "autoscaling.knative.dev/target"
+	knativeMetricAnnotation   = "autoscaling.knative.dev/metric"
+	knativeMinScaleAnnotation = "autoscaling.knative.dev/min-scale"
+	knativeMaxScaleAnnotation = "autoscaling.knative.dev/max-scale"
+	knativeClassAnnotation    = "autoscaling.knative.dev/class"
+)
+
+var (
+	knativeServicesResource  = schema.GroupVersionResource{Group: "serving.knative.dev", Version: "v1", Resource: "services"}
+	knativeRevisionsResource = schema.GroupVersionResource{Group: "serving.knative.dev", Version: "v1", Resource: "revisions"}
+)
+
+// KnativeProvider implements Provider for Knative Serving services, read
+// from the cluster of a kubeconfig context. Services are the functions and
+// revisions their deployments.
+type KnativeProvider struct {
+	context   string
+	namespace string
+	dynamic   dynamic.Interface
+	clientset kubernetes.Interface
+}
+
+// knativeService is the part of a serving.knative.dev/v1 Service f6n reads
+type knativeService struct {
+	Metadata metav1.ObjectMeta `json:"metadata"`
+	Spec     struct {
+		Template struct {
+			Metadata metav1.ObjectMeta   `json:"metadata"`
+			Spec     knativeRevisionSpec `json:"spec"`
+		} `json:"template"`
+	} `json:"spec"`
+	Status struct {
+		URL     string `json:"url"`
+		Address *struct {
+			URL string `json:"url"`
+		} `json:"address"`
+		LatestCreatedRevisionName string             `json:"latestCreatedRevisionName"`
+		LatestReadyRevisionName   string             `json:"latestReadyRevisionName"`
+		Conditions                []knativeCondition `json:"conditions"`
+		Traffic                   []knativeTraffic   `json:"traffic"`
+	} `json:"status"`
+}
+
+// knativeRevision is the part of a serving.knative.dev/v1 Revision f6n reads
+type knativeRevision struct {
+	Metadata metav1.ObjectMeta   `json:"metadata"`
+	Spec     knativeRevisionSpec `json:"spec"`
+	Status   struct {
+		Conditions        []knativeCondition `json:"conditions"`
+		ActualReplicas    int32              `json:"actualReplicas"`
+		DesiredReplicas   int32              `json:"desiredReplicas"`
+		ContainerStatuses []struct {
+			Name        string `json:"name"`
+			ImageDigest string `json:"imageDigest"`
+		} `json:"containerStatuses"`
+	} `json:"status"`
+}
+
+// knativeRevisionSpec is a revision's pod spec with Knative's own fields
+type knativeRevisionSpec struct {
+	ContainerConcurrency *int64             `json:"containerConcurrency"`
+	TimeoutSeconds       *int64             `json:"timeoutSeconds"`
+	Containers           []corev1.Container `json:"containers"`
+}
+
+type knativeCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"` // True, False or Unknown
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// knativeTraffic is a route of a service's traffic to a revision
+type knativeTraffic struct {
+	RevisionName   string `json:"revisionName"`
+	Percent        *int64 `json:"percent"`
+	Tag            string `json:"tag"`
+	LatestRevision bool   `json:"latestRevision"`
+}
+
+// NewKnativeProvider creates a provider for the Knative services of
+// namespace, in the cluster of the kubeconfig context. An empty kubeconfig
+// reads KUBECONFIG or ~/.kube/config, an empty context the current one and an
+// empty namespace the context's.
+func NewKnativeProvider(kubeconfig, kubeContext, namespace string) (*KnativeProvider, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{CurrentContext: kubeContext})
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the kubeconfig: %w", err)
+	}
+	restConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return apicalls.Transport(rt, func(resp *http.Response) bool {
+			return resp.StatusCode == http.StatusTooManyRequests
+		})
+	})
+	if namespace == "" {
+		if namespace, _, err = clientConfig.Namespace(); err != nil {
+			return nil, fmt.Errorf("failed to read the namespace of the kubeconfig context: %w", err)
+		}
+	}
+	if kubeContext == "" {
+		if raw, err := clientConfig.RawConfig(); err == nil {
+			kubeContext = raw.CurrentContext
+		}
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the Kubernetes client: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the Kubernetes client: %w", err)
+	}
+	return &KnativeProvider{
+		context:   cmp.Or(kubeContext, restConfig.Host),
+		namespace: namespace,
+		dynamic:   dynamicClient,
+		clientset: clientset,
+	}, nil
+}
+
+func (p *KnativeProvider) GetProviderName() CloudProvider {
+	return Knative
+}
+
+// GetRegion returns the namespace, Kubernetes having no regions
+func (p *KnativeProvider) GetRegion() string {
+	return p.namespace
+}
+
+// GetAccountID returns the kubeconfig context
+func (p *KnativeProvider) GetAccountID(ctx context.Context) (string, error) {
+	return p.context, nil
+}
+
+// fromUnstructured decodes a Knative object into one of the structs above
+func fromUnstructured(obj *unstructured.Unstructured, out any) error {
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, out); err != nil {
+		return fmt.Errorf("failed to decode %s %s: %w", obj.GetKind(), obj.GetName(), err)
+	}
+	return nil
+}
+
+// ListFunctions lists the services of the namespace, with the replicas of
+// their latest revisions
+func (p *KnativeProvider) ListFunctions(ctx context.Context) ([]FunctionInfo, error) {
+	list, err := p.dynamic.Resource(knativeServicesResource).Namespace(p.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logger.Logger.Printf("Error listing Knative services: %v", err)
+		return nil, fmt.Errorf("failed to list Knative services: %w", err)
+	}
+	revisions, err := p.revisions(ctx, "")
+	if err != nil {
+		// The services alone still make a list
+		logger.Logger.Printf("Error listing Knative revisions: %v", err)
+	}
+	byName := make(map[string]knativeRevision, len(revisions))
+	for _, revision := range revisions {
+		byName[revision.Metadata.Name] = revision
+	}
+
+	functions := make([]FunctionInfo, 0, len(list.Items))
+	for i := range list.Items {
+		var service knativeService
+		if err := fromUnstructured(&list.Items[i], &service); err != nil {
+			logger.Logger.Printf("Skipping Knative service: %v", err)
+			continue
+		}
+		var latest *knativeRevision
+		if revision, ok := byName[service.Status.LatestCreatedRevisionName]; ok {
+			latest = &revision
+		}
+		functions = append(functions, p.functionInfo(service, latest))
+	}
+	slices.SortFunc(functions, func(a, b FunctionInfo) int { return strings.Compare(a.Name, b.Name) })
+	return functions, nil
+}
+
+// GetFunction gets a service with its latest revision
+func (p *KnativeProvider) GetFunction(ctx context.Context, name string) (*FunctionInfo, error) {
+	service, err := p.service(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	var latest *knativeRevision
+	if service.Status.LatestCreatedRevisionName != "" {
+		obj, err := p.dynamic.Resource(knativeRevisionsResource).Namespace(p.namespace).Get(ctx, service.Status.LatestCreatedRevisionName, metav1.GetOptions{})
+		var revision knativeRevision
+		if err == nil {
+			err = fromUnstructured(obj, &revision)
+		}
+		if err == nil {
+			latest = &revision
+		} else {
+			logger.Logger.Printf("Error reading revision %s: %v", service.Status.LatestCreatedRevisionName, err)
+		}
+	}
+	info := p.functionInfo(*service, latest)
+	return &info, nil
+}
+
+// service reads a Knative service
+func (p *KnativeProvider) service(ctx context.Context, name string) (*knativeService, error) {
+	obj, err := p.dynamic.Resource(knativeServicesResource).Namespace(p.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Knative service %s: %w", name, err)
+	}
+	var service knativeService
+	if err := fromUnstructured(obj, &service); err != nil {
+		return nil, err
+	}
+	return &service, nil
+}
+
+// revisions lists the revisions of a service, or of the namespace when
+// service is empty
+func (p *KnativeProvider) revisions(ctx context.Context, service string) ([]knativeRevision, error) {
+	options := metav1.ListOptions{}
+	if service != "" {
+		options.LabelSelector = knativeServiceLabel + "=" + service
+	}
+	list, err := p.dynamic.Resource(knativeRevisionsResource).Namespace(p.namespace).List(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	revisions := make([]knativeRevision, 0, len(list.Items))
+	for i := range list.Items {
+		var revision knativeRevision
+		if err := fromUnstructured(&list.Items[i], &revision); err != nil {
+			logger.Logger.Printf("Skipping Knative revision: %v", err)
+			continue
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// container returns the container serving a revision's requests
+func (spec knativeRevisionSpec) container() corev1.Container {
+	for _, c := range spec.Containers {
+		if len(c.Ports) > 0 {
+			return c
+		}
+	}
+	if len(spec.Containers) > 0 {
+		return spec.Containers[0]
+	}
+	return corev1.Container{}
+}
+
+// readyCondition maps the Ready condition of a service or revision to a
+// state and its reason
+func readyCondition(conditions []knativeCondition) (state, reason string) {
+	for _, c := range conditions {
+		if c.Type != "Ready" {
+			continue
+		}
+		switch c.Status {
+		case "True":
+			state = "Ready"
+		case "False":
+			state = "Failed"
+		default:
+			state = "Deploying"
+		}
+		return state, strings.TrimSpace(cmp.Or(c.Message, c.Reason))
+	}
+	return "Unknown", ""
+}
+
+// functionInfo maps a service: the image's name is the runtime, the labels
+// the tags and the Ready condition the state. The latest revision, when
+// read, gives the replicas and when the service last changed.
+func (p *KnativeProvider) functionInfo(service knativeService, latest *knativeRevision) FunctionInfo {
+	template := service.Spec.Template
+	container := template.Spec.container()
+	image := container.Image
+	if before, _, ok := strings.Cut(image, "@"); ok {
+		image = before
+	}
+
+	info := FunctionInfo{
+		Name:        service.Metadata.Name,
+		Runtime:     image[strings.LastIndex(image, "/")+1:],
+		ARN:         cmp.Or(service.Metadata.Namespace, p.namespace) + "/" + service.Metadata.Name,
+		Region:      cmp.Or(service.Metadata.Namespace, p.namespace),
+		Tags:        service.Metadata.Labels,
+		Environment: map[string]string{},
+		Revision:    service.Status.LatestCreatedRevisionName,
+		Scaling:     knativeScaling(template.Metadata.Annotations, template.Spec.ContainerConcurrency),
+	}
+	if info.Tags == nil {
+		info.Tags = map[string]string{}
+	}
+	for _, env := range container.Env {
+		if env.ValueFrom == nil {
+			info.Environment[env.Name] = env.Value
+		}
+	}
+	if memory := container.Resources.Limits.Memory(); !memory.IsZero() {
+		info.Memory = int32(memory.Value() >> 20)
+	} else if memory := container.Resources.Requests.Memory(); !memory.IsZero() {
+		info.Memory = int32(memory.Value() >> 20)
+	}
+	if template.Spec.TimeoutSeconds != nil {
+		info.Timeout = int32(*template.Spec.TimeoutSeconds)
+	}
+	info.State, info.StateReason = readyCondition(service.Status.Conditions)
+
+	modified := service.Metadata.CreationTimestamp.Time
+	if latest != nil {
+		info.Replicas = latest.Status.DesiredReplicas
+		info.AvailableReplicas = latest.Status.ActualReplicas
+		if info.State == "Ready" && info.StateReason == "" {
+			info.StateReason = fmt.Sprintf("%d/%d replicas", latest.Status.ActualReplicas, latest.Status.DesiredReplicas)
+		}
+		modified = cmp.Or(latest.Metadata.CreationTimestamp.Time, modified)
+	}
+	if !modified.IsZero() {
+		info.Modified = modified
+		info.LastModified = modified.Format(time.RFC3339)
+	}
+	return info
+}
+
+// knativeScaling reads the concurrency limit and autoscaling annotations of
+// a revision template
+func knativeScaling(annotations map[string]string, containerConcurrency *int64) *ScalingConfig {
+	scaling := &ScalingConfig{
+		Target:   annotations[knativeTargetAnnotation],
+		Metric:   annotations[knativeMetricAnnotation],
+		MinScale: annotations[knativeMinScaleAnnotation],
+		MaxScale: annotations[knativeMaxScaleAnnotation],
+		Class:    annotations[knativeClassAnnotation],
+	}
+	if containerConcurrency != nil {
+		scaling.ContainerConcurrency = *containerConcurrency
+	}
+	return scaling
+}
+
+// GetFunctionCode describes the service's image and configuration: its code
+// is built into the image rather than uploaded
+func (p *KnativeProvider) GetFunctionCode(ctx context.Context, name string) (string, error) {
+	service, err := p.service(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	template := service.Spec.Template
+	container := template.Spec.container()
+
+	var info strings.Builder
+	info.WriteString("━━━ Code Information ━━━\n\n")
+	info.WriteString(fmt.Sprintf("Image: %s\n", container.Image))
+	if len(container.Command) > 0 || len(container.Args) > 0 {
+		info.WriteString(fmt.Sprintf("Command: %s\n", strings.Join(append(slices.Clone(container.Command), container.Args...), " ")))
+	}
+	info.WriteString(fmt.Sprintf("Latest revision: %s (ready: %s)\n", cmp.Or(service.Status.LatestCreatedRevisionName, "-"), cmp.Or(service.Status.LatestReadyRevisionName, "-")))
+	if service.Status.URL != "" {
+		info.WriteString(fmt.Sprintf("URL: %s\n", service.Status.URL))
+	}
+	info.WriteString("\n")
+
+	if len(container.Resources.Requests) > 0 || len(container.Resources.Limits) > 0 {
+		info.WriteString("Resources:\n")
+		for _, resources := range []struct {
+			label string
+			list  corev1.ResourceList
+		}{{"Requests", container.Resources.Requests}, {"Limits", container.Resources.Limits}} {
+			if len(resources.list) > 0 {
+				info.WriteString(fmt.Sprintf("  %s: memory %s, cpu %s\n", resources.label, quantityOrDash(resources.list, corev1.ResourceMemory), quantityOrDash(resources.list, corev1.ResourceCPU)))
+			}
+		}
+		info.WriteString("\n")
+	}
+	if len(container.Env) > 0 {
+		info.WriteString("Environment Variables (values hidden, reveal them in the details with v):\n")
+		for _, env := range container.Env {
+			switch {
+			case env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil:
+				info.WriteString(fmt.Sprintf("  %s: from secret %s/%s\n", env.Name, env.ValueFrom.SecretKeyRef.Name, env.ValueFrom.SecretKeyRef.Key))
+			case env.ValueFrom != nil && env.ValueFrom.ConfigMapKeyRef != nil:
+				info.WriteString(fmt.Sprintf("  %s: from config map %s/%s\n", env.Name, env.ValueFrom.ConfigMapKeyRef.Name, env.ValueFrom.ConfigMapKeyRef.Key))
+			default:
+				info.WriteString(fmt.Sprintf("  %s: ********\n", env.Name))
+			}
+		}
+		info.WriteString("\n")
+	}
+	if len(template.Metadata.Annotations) > 0 {
+		info.WriteString("Revision Annotations:\n")
+		for _, k := range slices.Sorted(maps.Keys(template.Metadata.Annotations)) {
+			info.WriteString(fmt.Sprintf("  %s: %s\n", k, template.Metadata.Annotations[k]))
+		}
+		info.WriteString("\n")
+	}
+
+	info.WriteString("To get the code:\n")
+	info.WriteString(fmt.Sprintf("1. Pull the image: docker pull %s\n", container.Image))
+	info.WriteString(fmt.Sprintf("2. Or describe the service: kubectl get ksvc %s --namespace %s -o yaml\n", name, p.namespace))
+	return info.String(), nil
+}
+
+// quantityOrDash formats a resource of a list, "-" when it is not set
+func quantityOrDash(list corev1.ResourceList, name corev1.ResourceName) string {
+	if quantity, ok := list[name]; ok {
+		return quantity.String()
+	}
+	return "-"
+}
+
+// DownloadFunctionCode is not supported: Knative services are container
+// images, pulled with a container runtime
+func (p *KnativeProvider) DownloadFunctionCode(ctx context.Context, name, destination string) error {
+	service, err := p.service(ctx, name)
+	if err != nil {
+		return err
+	}
+	image := service.Spec.Template.Spec.container().Image
+	return fmt.Errorf("the code of %s is built into the image %s: pull it with docker pull %s", name, image, image)
+}
+
+// pods lists the running pods of a service with the name of their serving
+// container
+func (p *KnativeProvider) pods(ctx context.Context, name string) ([]corev1.Pod, string, error) {
+	pods, err := p.clientset.CoreV1().Pods(p.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: knativeServiceLabel + "=" + name,
+		FieldSelector: "status.phase=Running",
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list the pods of %s: %w", name, err)
+	}
+	container := knativeUserContainer
+	if service, err := p.service(ctx, name); err == nil {
+		container = cmp.Or(service.Spec.Template.Spec.container().Name, knativeUserContainer)
+	}
+	return pods.Items, container, nil
+}
+
+// GetFunctionLogs reads the latest log lines of the service's running pods.
+// A service scaled to zero has none.
+func (p *KnativeProvider) GetFunctionLogs(ctx context.Context, name string, query LogQuery) ([]LogEntry, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = knativeLogLimit
+	}
+	pods, container, err := p.pods(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the logs of %s: %w", name, err)
+	}
+
+	var entries []LogEntry
+	for _, pod := range pods {
+		tail := int64(limit)
+		options := &corev1.PodLogOptions{Container: container, Timestamps: true, TailLines: &tail}
+		if !query.Since.IsZero() {
+			options.SinceTime = &metav1.Time{Time: query.Since}
+		}
+		stream, err := p.clientset.CoreV1().Pods(p.namespace).GetLogs(pod.Name, options).Stream(ctx)
+		if err != nil {
+			logger.Logger.Printf("Error reading the logs of pod %s: %v", pod.Name, err)
+			continue
+		}
+		err = readPodLogs(stream, pod.Name, func(entry LogEntry) bool {
+			if query.Matches(entry) {
+				entries = append(entries, entry)
+			}
+			return true
+		})
+		stream.Close()
+		if err != nil {
+			logger.Logger.Printf("Error reading the logs of pod %s: %v", pod.Name, err)
+		}
+	}
+	slices.SortStableFunc(entries, func(a, b LogEntry) int { return a.Timestamp.Compare(b.Timestamp) })
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	return entries, nil
+}
+
+// StreamFunctionLogs follows the logs of the service's pods from now on,
+// picking up the pods Knative starts while it scales
+func (p *KnativeProvider) StreamFunctionLogs(ctx context.Context, name string) (<-chan LogEntry, <-chan error) {
+	logChan := make(chan LogEntry, 100)
+	errChan := make(chan error, 1)
+	since := metav1.Now()
+
+	go func() {
+		var wg sync.WaitGroup
+		defer close(errChan)
+		defer close(logChan)
+		defer wg.Wait()
+
+		following := map[string]bool{}
+		ticker := time.NewTicker(knativePodPoll)
+		defer ticker.Stop()
+		for {
+			pods, container, err := p.pods(ctx, name)
+			if err != nil {
+				if ctx.Err() == nil {
+					errChan <- fmt.Errorf("failed to stream the logs of %s: %w", name, err)
+				}
+				return
+			}
+			for _, pod := range pods {
+				if following[pod.Name] {
+					continue
+				}
+				following[pod.Name] = true
+				wg.Add(1)
+				go func(pod string) {
+					defer wg.Done()
+					p.followPod(ctx, pod, container, since, logChan)
+				}(pod.Name)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return logChan, errChan
+}
+
+// followPod sends a pod's log lines since a time to logChan until the pod
+// stops or ctx is done
+func (p *KnativeProvider) followPod(ctx context.Context, pod, container string, since metav1.Time, logChan chan<- LogEntry) {
+	options := &corev1.PodLogOptions{Container: container, Timestamps: true, Follow: true, SinceTime: &since}
+	stream, err := p.clientset.CoreV1().Pods(p.namespace).GetLogs(pod, options).Stream(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Logger.Printf("Error following the logs of pod %s: %v", pod, err)
+		}
+		return
+	}
+	defer stream.Close()
+	err = readPodLogs(stream, pod, func(entry LogEntry) bool {
+		select {
+		case logChan <- entry:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+	if err != nil && ctx.Err() == nil && !errors.Is(err, io.EOF) {
+		logger.Logger.Printf("Log stream of pod %s ended: %v", pod, err)
+	}
+}
+
+// readPodLogs passes the timestamped lines of a pod's logs to emit until it
+// returns false
+func readPodLogs(body io.Reader, pod string, emit func(LogEntry) bool) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		stamp, text, _ := strings.Cut(scanner.Text(), " ")
+		timestamp, err := time.Parse(time.RFC3339Nano, stamp)
+		if err != nil {
+			timestamp, text = time.Now(), scanner.Text()
+		}
+		entry := LogEntry{
+			Timestamp: timestamp,
+			Severity:  inferSeverity(text),
+			Message:   text,
+			Labels:    map[string]string{"pod": pod},
+		}
+		if !emit(entry) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// GetFunctionMetrics is not supported: Knative exports its request metrics
+// to the cluster's Prometheus or OpenTelemetry collector
+func (p *KnativeProvider) GetFunctionMetrics(ctx context.Context, name string, startTime, endTime time.Time) (*FunctionMetrics, error) {
+	return nil, fmt.Errorf("metrics are not available from the Kubernetes API: query the cluster's Prometheus for revision_app_request_count")
+}
+
+// GetEndpoints returns the service's public URL and its cluster-local one
+func (p *KnativeProvider) GetEndpoints(ctx context.Context, name string) ([]string, error) {
+	service, err := p.service(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	var urls []string
+	if service.Status.URL != "" {
+		urls = append(urls, service.Status.URL)
+	}
+	if service.Status.Address != nil && service.Status.Address.URL != "" && service.Status.Address.URL != service.Status.URL {
+		urls = append(urls, service.Status.Address.URL)
+	}
+	return urls, nil
+}
+
+// ListDeployments lists the service's revisions, most recent first. The
+// revisions serving traffic are current, with their share as the change.
+func (p *KnativeProvider) ListDeployments(ctx context.Context, fn FunctionInfo) ([]Deployment, error) {
+	service, err := p.service(ctx, fn.Name)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := p.revisions(ctx, fn.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the revisions of %s: %w", fn.Name, err)
+	}
+
+	traffic := map[string][]string{}
+	for _, route := range service.Status.Traffic {
+		revision := route.RevisionName
+		if revision == "" && route.LatestRevision {
+			revision = service.Status.LatestReadyRevisionName
+		}
+		if route.Percent != nil && *route.Percent > 0 {
+			traffic[revision] = append(traffic[revision], fmt.Sprintf("traffic %d%%", *route.Percent))
+		}
+		if route.Tag != "" {
+			traffic[revision] = append(traffic[revision], "tag "+route.Tag)
+		}
+	}
+
+	deployments := make([]Deployment, 0, len(revisions))
+	for _, revision := range revisions {
+		status, reason := readyCondition(revision.Status.Conditions)
+		changes := traffic[revision.Metadata.Name]
+		if status == "Failed" && reason != "" {
+			changes = append(changes, reason)
+		}
+		source := revision.Spec.container().Image
+		for _, c := range revision.Status.ContainerStatuses {
+			if c.ImageDigest != "" {
+				source = c.ImageDigest
+				break
+			}
+		}
+		deployments = append(deployments, Deployment{
+			ID:      revision.Metadata.Name,
+			Status:  strings.ToUpper(status),
+			Change:  strings.Join(changes, ", "),
+			Started: revision.Metadata.CreationTimestamp.Time,
+			Source:  source,
+			Actor:   revision.Metadata.Annotations["serving.knative.dev/creator"],
+			Current: slices.ContainsFunc(service.Status.Traffic, func(route knativeTraffic) bool {
+				return route.Percent != nil && *route.Percent > 0 && (route.RevisionName == revision.Metadata.Name ||
+					(route.RevisionName == "" && route.LatestRevision && revision.Metadata.Name == service.Status.LatestReadyRevisionName))
+			}),
+		})
+	}
+	slices.SortFunc(deployments, func(a, b Deployment) int { return b.Started.Compare(a.Started) })
+	return deployments, nil
+}
+
+// References returns the service's resource, image and URL
+func (p *KnativeProvider) References(ctx context.Context, fn FunctionInfo) ([]FunctionReference, error) {
+	refs := []FunctionReference{{Label: "Service", Value: fn.ARN}}
+	service, err := p.service(ctx, fn.Name)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			logger.Logger.Printf("Error reading service %s: %v", fn.Name, err)
+		}
+		return refs, nil
+	}
+	refs = append(refs, FunctionReference{Label: "Image", Value: service.Spec.Template.Spec.container().Image})
+	if service.Status.URL != "" {
+		refs = append(refs, FunctionReference{Label: "URL", Value: service.Status.URL})
+	}
+	return refs, nil
+}
@@ -0,0 +1,597 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"f6n/internal/logger"
+)
+
+// profileSeparator joins a function name and the profile it was listed with.
+// Function names cannot contain it, and "orders@dev" sorts next to
+// "orders@prod" so the same function lines up across environments.
+const profileSeparator = "@"
+
+// MultiProvider lists the functions of several credential profiles (e.g. one
+// per environment) as one inventory. Function names are qualified with their
+// profile, as in "orders@prod", and calls are routed to the provider of that
+// profile with the plain name.
+type MultiProvider struct {
+	profiles  []string
+	providers map[string]Provider
+}
+
+// NewMultiProvider combines providers by profile name, listing them in the
+// order given
+func NewMultiProvider(profiles []string, providers []Provider) *MultiProvider {
+	m := &MultiProvider{profiles: profiles, providers: make(map[string]Provider, len(providers))}
+	for i, profile := range profiles {
+		m.providers[profile] = providers[i]
+	}
+	return m
+}
+
+// Supports reports whether the provider of any profile satisfies has
+func (m *MultiProvider) Supports(has func(Provider) bool) bool {
+	for _, p := range m.providers {
+		if has(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Profiles returns the profile names in the order they were given
+func (m *MultiProvider) Profiles() []string {
+	return m.profiles
+}
+
+// QualifiedName returns the name of a function listed with profile
+func QualifiedName(name, profile string) string {
+	return name + profileSeparator + profile
+}
+
+// PlainName returns the name of fn in its cloud account, without the profile
+// a MultiProvider qualifies it with
+func PlainName(fn FunctionInfo) string {
+	if fn.Profile == "" {
+		return fn.Name
+	}
+	return strings.TrimSuffix(fn.Name, profileSeparator+fn.Profile)
+}
+
+// resolve splits a qualified function name into the provider of its profile
+// and the plain name
+func (m *MultiProvider) resolve(name string) (Provider, string, error) {
+	i := strings.LastIndex(name, profileSeparator)
+	if i < 0 {
+		return nil, "", fmt.Errorf("function %q is not qualified with a profile (expected name%sprofile)", name, profileSeparator)
+	}
+	p, ok := m.providers[name[i+1:]]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown profile %q for function %q", name[i+1:], name[:i])
+	}
+	return p, name[:i], nil
+}
+
+// resolveInfo returns the provider of fn and a copy of it with the plain name
+func (m *MultiProvider) resolveInfo(fn FunctionInfo) (Provider, FunctionInfo, error) {
+	p, name, err := m.resolve(fn.Name)
+	fn.Name = name
+	return p, fn, err
+}
+
+// GetProviderName returns the provider type shared by the profiles
+func (m *MultiProvider) GetProviderName() CloudProvider {
+	return m.providers[m.profiles[0]].GetProviderName()
+}
+
+// GetRegion returns the regions of the profiles, deduplicated
+func (m *MultiProvider) GetRegion() string {
+	var regions []string
+	seen := make(map[string]bool)
+	for _, profile := range m.profiles {
+		region := m.providers[profile].GetRegion()
+		if !seen[region] {
+			seen[region] = true
+			regions = append(regions, region)
+		}
+	}
+	return strings.Join(regions, ",")
+}
+
+// GetAccountID returns the account of each profile, as "dev=123456789012, prod=..."
+func (m *MultiProvider) GetAccountID(ctx context.Context) (string, error) {
+	accounts := make([]string, len(m.profiles))
+	errs := make([]error, len(m.profiles))
+	m.each(func(i int, p Provider) {
+		accounts[i], errs[i] = p.GetAccountID(ctx)
+	})
+
+	var parts []string
+	for i, profile := range m.profiles {
+		if errs[i] != nil {
+			logger.Logger.Printf("Error getting account ID of profile %s: %v", profile, errs[i])
+			continue
+		}
+		parts = append(parts, profile+"="+accounts[i])
+	}
+	if len(parts) == 0 {
+		return "", errors.Join(errs...)
+	}
+	return strings.Join(parts, ", "), nil
+}
+
+// each calls fn concurrently for every profile's provider
+func (m *MultiProvider) each(fn func(i int, p Provider)) {
+	var wg sync.WaitGroup
+	for i, profile := range m.profiles {
+		wg.Add(1)
+		go func(i int, p Provider) {
+			defer wg.Done()
+			fn(i, p)
+		}(i, m.providers[profile])
+	}
+	wg.Wait()
+}
+
+// ListFunctions lists the functions of all profiles concurrently. Profiles
+// that fail are logged and left out; it only fails when every profile does.
+func (m *MultiProvider) ListFunctions(ctx context.Context) ([]FunctionInfo, error) {
+	results := make([][]FunctionInfo, len(m.profiles))
+	errs := make([]error, len(m.profiles))
+	m.each(func(i int, p Provider) {
+		results[i], errs[i] = p.ListFunctions(ctx)
+	})
+
+	var functions []FunctionInfo
+	failed := 0
+	for i, profile := range m.profiles {
+		if errs[i] != nil {
+			failed++
+			logger.Logger.Printf("Error listing functions of profile %s: %v", profile, errs[i])
+			errs[i] = fmt.Errorf("profile %s: %w", profile, errs[i])
+			continue
+		}
+		for _, fn := range results[i] {
+			fn.Name = QualifiedName(fn.Name, profile)
+			fn.Profile = profile
+			functions = append(functions, fn)
+		}
+	}
+	if failed == len(m.profiles) {
+		return nil, errors.Join(errs...)
+	}
+
+	sort.SliceStable(functions, func(i, j int) bool { return functions[i].Name < functions[j].Name })
+	return functions, nil
+}
+
+// GetFunction gets a function from the provider of its profile
+func (m *MultiProvider) GetFunction(ctx context.Context, name string) (*FunctionInfo, error) {
+	p, plain, err := m.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	fn, err := p.GetFunction(ctx, plain)
+	if err != nil {
+		return nil, err
+	}
+	fn.Name = name
+	fn.Profile = name[len(plain)+len(profileSeparator):]
+	return fn, nil
+}
+
+func (m *MultiProvider) GetFunctionCode(ctx context.Context, name string) (string, error) {
+	p, plain, err := m.resolve(name)
+	if err != nil {
+		return "", err
+	}
+	return p.GetFunctionCode(ctx, plain)
+}
+
+func (m *MultiProvider) DownloadFunctionCode(ctx context.Context, name, destination string) error {
+	p, plain, err := m.resolve(name)
+	if err != nil {
+		return err
+	}
+	return p.DownloadFunctionCode(ctx, plain, destination)
+}
+
+func (m *MultiProvider) GetFunctionLogs(ctx context.Context, name string, query LogQuery) ([]LogEntry, error) {
+	p, plain, err := m.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetFunctionLogs(ctx, plain, query)
+}
+
+func (m *MultiProvider) GetOlderFunctionLogs(ctx context.Context, name string, query LogQuery, before time.Time, token string) (*LogPage, error) {
+	p, plain, err := m.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	pager, ok := As[LogPager](p)
+	if !ok {
+		return nil, unsupported("paging through older logs", p)
+	}
+	return pager.GetOlderFunctionLogs(ctx, plain, query, before, token)
+}
+
+func (m *MultiProvider) StreamFunctionLogs(ctx context.Context, name string) (<-chan LogEntry, <-chan error) {
+	p, plain, err := m.resolve(name)
+	if err != nil {
+		errCh := make(chan error, 1)
+		errCh <- err
+		close(errCh)
+		return nil, errCh
+	}
+	return p.StreamFunctionLogs(ctx, plain)
+}
+
+func (m *MultiProvider) GetFunctionMetrics(ctx context.Context, name string, startTime, endTime time.Time) (*FunctionMetrics, error) {
+	p, plain, err := m.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := p.GetFunctionMetrics(ctx, plain, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	metrics.FunctionName = name
+	return metrics, nil
+}
+
+func (m *MultiProvider) GetEndpoints(ctx context.Context, name string) ([]string, error) {
+	p, plain, err := m.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetEndpoints(ctx, plain)
+}
+
+// unsupported is the error of an optional capability a profile's provider lacks
+func unsupported(capability string, p Provider) error {
+	return fmt.Errorf("%s is not supported for %s", capability, strings.ToUpper(string(p.GetProviderName())))
+}
+
+func (m *MultiProvider) CreateAlarm(ctx context.Context, functionName string, spec AlarmSpec) error {
+	p, plain, err := m.resolve(functionName)
+	if err != nil {
+		return err
+	}
+	manager, ok := As[AlarmManager](p)
+	if !ok {
+		return unsupported("creating alarms", p)
+	}
+	return manager.CreateAlarm(ctx, plain, spec)
+}
+
+// GetFunctionStats asks each profile for the statistics of its functions
+func (m *MultiProvider) GetFunctionStats(ctx context.Context, names []string, since time.Time) (map[string]FunctionStats, error) {
+	byProfile := make([][]string, len(m.profiles))
+	index := make(map[string]int, len(m.profiles))
+	for i, profile := range m.profiles {
+		index[profile] = i
+	}
+	for _, name := range names {
+		if i := strings.LastIndex(name, profileSeparator); i >= 0 {
+			if j, ok := index[name[i+1:]]; ok {
+				byProfile[j] = append(byProfile[j], name[:i])
+			}
+		}
+	}
+
+	results := make([]map[string]FunctionStats, len(m.profiles))
+	errs := make([]error, len(m.profiles))
+	m.each(func(i int, p Provider) {
+		reporter, ok := As[StatsReporter](p)
+		if !ok || len(byProfile[i]) == 0 {
+			return
+		}
+		results[i], errs[i] = reporter.GetFunctionStats(ctx, byProfile[i], since)
+	})
+
+	stats := make(map[string]FunctionStats)
+	for i, profile := range m.profiles {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("profile %s: %w", profile, errs[i])
+		}
+		for name, s := range results[i] {
+			stats[QualifiedName(name, profile)] = s
+		}
+	}
+	return stats, nil
+}
+
+// GetFiringAlarms merges the firing alarms of all profiles
+func (m *MultiProvider) GetFiringAlarms(ctx context.Context) (map[string][]string, error) {
+	results := make([]map[string][]string, len(m.profiles))
+	errs := make([]error, len(m.profiles))
+	m.each(func(i int, p Provider) {
+		if reporter, ok := As[AlarmStateReporter](p); ok {
+			results[i], errs[i] = reporter.GetFiringAlarms(ctx)
+		}
+	})
+
+	firing := make(map[string][]string)
+	for i, profile := range m.profiles {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("profile %s: %w", profile, errs[i])
+		}
+		for name, alarms := range results[i] {
+			firing[QualifiedName(name, profile)] = alarms
+		}
+	}
+	return firing, nil
+}
+
+func (m *MultiProvider) Invoke(ctx context.Context, functionName string, payload []byte) (*InvokeResult, error) {
+	p, plain, err := m.resolve(functionName)
+	if err != nil {
+		return nil, err
+	}
+	invoker, ok := As[Invoker](p)
+	if !ok {
+		return nil, unsupported("invocation", p)
+	}
+	return invoker.Invoke(ctx, plain, payload)
+}
+
+func (m *MultiProvider) SetMemory(ctx context.Context, functionName string, memoryMB int32) error {
+	p, plain, err := m.resolve(functionName)
+	if err != nil {
+		return err
+	}
+	tuner, ok := As[PowerTuner](p)
+	if !ok {
+		return unsupported("power tuning", p)
+	}
+	return tuner.SetMemory(ctx, plain, memoryMB)
+}
+
+func (m *MultiProvider) InvokeWithLog(ctx context.Context, functionName string, payload []byte) (*InvokeResult, error) {
+	p, plain, err := m.resolve(functionName)
+	if err != nil {
+		return nil, err
+	}
+	tuner, ok := As[PowerTuner](p)
+	if !ok {
+		return nil, unsupported("power tuning", p)
+	}
+	return tuner.InvokeWithLog(ctx, plain, payload)
+}
+
+func (m *MultiProvider) ListFailedEvents(ctx context.Context, functionName string) ([]FailedEvent, error) {
+	p, plain, err := m.resolve(functionName)
+	if err != nil {
+		return nil, err
+	}
+	replayer, ok := As[FailedEventReplayer](p)
+	if !ok {
+		return nil, unsupported("failed event replay", p)
+	}
+	return replayer.ListFailedEvents(ctx, plain)
+}
+
+func (m *MultiProvider) ReplayFailedEvent(ctx context.Context, functionName string, event FailedEvent) error {
+	p, plain, err := m.resolve(functionName)
+	if err != nil {
+		return err
+	}
+	replayer, ok := As[FailedEventReplayer](p)
+	if !ok {
+		return unsupported("failed event replay", p)
+	}
+	return replayer.ReplayFailedEvent(ctx, plain, event)
+}
+
+func (m *MultiProvider) ListEventSourceMappings(ctx context.Context, functionName string) ([]EventSourceMapping, error) {
+	p, plain, err := m.resolve(functionName)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := As[TriggerLister](p)
+	if !ok {
+		return nil, unsupported("listing triggers", p)
+	}
+	return lister.ListEventSourceMappings(ctx, plain)
+}
+
+func (m *MultiProvider) ListSchedules(ctx context.Context, fn FunctionInfo) ([]ScheduledJob, error) {
+	p, fn, err := m.resolveInfo(fn)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := As[ScheduleLister](p)
+	if !ok {
+		return nil, unsupported("listing schedules", p)
+	}
+	return lister.ListSchedules(ctx, fn)
+}
+
+func (m *MultiProvider) GetTrafficShifts(ctx context.Context, fn FunctionInfo) ([]TrafficShift, error) {
+	p, fn, err := m.resolveInfo(fn)
+	if err != nil {
+		return nil, err
+	}
+	reader, ok := As[TrafficShiftReader](p)
+	if !ok {
+		return nil, unsupported("reading gradual deployments", p)
+	}
+	return reader.GetTrafficShifts(ctx, fn)
+}
+
+func (m *MultiProvider) ListDeployments(ctx context.Context, fn FunctionInfo) ([]Deployment, error) {
+	p, fn, err := m.resolveInfo(fn)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := As[DeploymentLister](p)
+	if !ok {
+		return nil, unsupported("listing deployments", p)
+	}
+	return lister.ListDeployments(ctx, fn)
+}
+
+func (m *MultiProvider) GetAsyncInvokeConfig(ctx context.Context, functionName string) (*AsyncInvokeConfig, error) {
+	p, plain, err := m.resolve(functionName)
+	if err != nil {
+		return nil, err
+	}
+	reader, ok := As[AsyncConfigReader](p)
+	if !ok {
+		return nil, unsupported("reading invocation destinations", p)
+	}
+	return reader.GetAsyncInvokeConfig(ctx, plain)
+}
+
+func (m *MultiProvider) UpdateEnvironment(ctx context.Context, name string, env, secrets map[string]string, revision string) error {
+	p, plain, err := m.resolve(name)
+	if err != nil {
+		return err
+	}
+	updater, ok := As[EnvironmentUpdater](p)
+	if !ok {
+		return unsupported("editing environment variables", p)
+	}
+	return updater.UpdateEnvironment(ctx, plain, env, secrets, revision)
+}
+
+func (m *MultiProvider) UpdateHandlerCode(ctx context.Context, name, source, revision string) error {
+	p, plain, err := m.resolve(name)
+	if err != nil {
+		return err
+	}
+	updater, ok := As[CodeUpdater](p)
+	if !ok {
+		return unsupported("editing code", p)
+	}
+	return updater.UpdateHandlerCode(ctx, plain, source, revision)
+}
+
+func (m *MultiProvider) UpdateDescription(ctx context.Context, name, description string) error {
+	p, plain, err := m.resolve(name)
+	if err != nil {
+		return err
+	}
+	updater, ok := As[DescriptionUpdater](p)
+	if !ok {
+		return unsupported("editing descriptions", p)
+	}
+	return updater.UpdateDescription(ctx, plain, description)
+}
+
+func (m *MultiProvider) UpdateLoggingConfig(ctx context.Context, name string, cfg LoggingConfig) error {
+	p, plain, err := m.resolve(name)
+	if err != nil {
+		return err
+	}
+	updater, ok := As[LoggingConfigUpdater](p)
+	if !ok {
+		return unsupported("changing log levels", p)
+	}
+	return updater.UpdateLoggingConfig(ctx, plain, cfg)
+}
+
+func (m *MultiProvider) GetLogRetention(ctx context.Context, fn FunctionInfo) (*LogRetention, error) {
+	p, fn, err := m.resolveInfo(fn)
+	if err != nil {
+		return nil, err
+	}
+	manager, ok := As[LogRetentionManager](p)
+	if !ok {
+		return nil, unsupported("log retention", p)
+	}
+	return manager.GetLogRetention(ctx, fn)
+}
+
+func (m *MultiProvider) SetLogRetention(ctx context.Context, fn FunctionInfo, days int32) error {
+	p, fn, err := m.resolveInfo(fn)
+	if err != nil {
+		return err
+	}
+	manager, ok := As[LogRetentionManager](p)
+	if !ok {
+		return unsupported("log retention", p)
+	}
+	return manager.SetLogRetention(ctx, fn, days)
+}
+
+func (m *MultiProvider) GetConcurrency(ctx context.Context, functionName string, startTime, endTime time.Time) (*ConcurrencyInfo, error) {
+	p, plain, err := m.resolve(functionName)
+	if err != nil {
+		return nil, err
+	}
+	reporter, ok := As[ConcurrencyReporter](p)
+	if !ok {
+		return nil, unsupported("concurrency metrics", p)
+	}
+	return reporter.GetConcurrency(ctx, plain, startTime, endTime)
+}
+
+func (m *MultiProvider) GetEnhancedMetrics(ctx context.Context, functionName string, startTime, endTime time.Time) (*EnhancedMetrics, error) {
+	p, plain, err := m.resolve(functionName)
+	if err != nil {
+		return nil, err
+	}
+	reporter, ok := As[EnhancedMetricsReporter](p)
+	if !ok {
+		return nil, unsupported("enhanced metrics", p)
+	}
+	return reporter.GetEnhancedMetrics(ctx, plain, startTime, endTime)
+}
+
+func (m *MultiProvider) AuditPosture(ctx context.Context, fn FunctionInfo) (*PostureReport, error) {
+	p, fn, err := m.resolveInfo(fn)
+	if err != nil {
+		return nil, err
+	}
+	auditor, ok := As[PostureAuditor](p)
+	if !ok {
+		return nil, unsupported("security audits", p)
+	}
+	return auditor.AuditPosture(ctx, fn)
+}
+
+func (m *MultiProvider) CheckPermissions(ctx context.Context, fn FunctionInfo) (map[Action][]string, error) {
+	p, fn, err := m.resolveInfo(fn)
+	if err != nil {
+		return nil, err
+	}
+	checker, ok := As[PermissionChecker](p)
+	if !ok {
+		return nil, unsupported("permission checks", p)
+	}
+	return checker.CheckPermissions(ctx, fn)
+}
+
+func (m *MultiProvider) References(ctx context.Context, fn FunctionInfo) ([]FunctionReference, error) {
+	p, fn, err := m.resolveInfo(fn)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := As[ReferenceLister](p)
+	if !ok {
+		return nil, unsupported("copying references", p)
+	}
+	return lister.References(ctx, fn)
+}
+
+// ConsoleURL links to the function's page in the console of its profile's
+// provider, or returns an empty string when it has none
+func (m *MultiProvider) ConsoleURL(fn FunctionInfo) string {
+	p, fn, err := m.resolveInfo(fn)
+	if err != nil {
+		return ""
+	}
+	if linker, ok := As[ConsoleLinker](p); ok {
+		return linker.ConsoleURL(fn)
+	}
+	return ""
+}
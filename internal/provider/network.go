@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IsNetworkError reports whether err comes from failing to reach the cloud
+// provider, e.g. no connection or DNS, rather than from the provider
+// rejecting the call
+func IsNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	return status.Code(err) == codes.Unavailable
+}
@@ -0,0 +1,515 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"f6n/internal/apicalls"
+	"f6n/internal/logger"
+)
+
+const (
+	// OpenFaaSDefaultNamespace is the namespace functions are deployed to
+	// unless another one is given
+	OpenFaaSDefaultNamespace = "openfaas-fn"
+	// openFaaSLogLimit is the number of log lines read when the query sets none
+	openFaaSLogLimit = 100
+	// openFaaSMaxResponse caps the size of an invocation response kept
+	openFaaSMaxResponse = 1 << 20
+)
+
+// Levels inferred from container log lines, which carry none
+var (
+	logErrorRe = regexp.MustCompile(`(?i)\b(error|fatal|panic|exception|traceback)\b`)
+	logWarnRe  = regexp.MustCompile(`(?i)\bwarn(ing)?\b`)
+)
+
+// OpenFaaSAuth holds the gateway credentials: basic auth, or a bearer token
+// taking precedence over it
+type OpenFaaSAuth struct {
+	Username string
+	Password string
+	Token    string
+}
+
+// OpenFaaSProvider implements Provider against the REST API of an OpenFaaS
+// gateway, for functions on on-premises or self-managed clusters
+type OpenFaaSProvider struct {
+	gateway   *url.URL
+	namespace string
+	auth      OpenFaaSAuth
+	client    *http.Client
+}
+
+// openFaaSFunction is a function status returned by the gateway
+type openFaaSFunction struct {
+	Name              string             `json:"name"`
+	Image             string             `json:"image"`
+	Namespace         string             `json:"namespace"`
+	EnvProcess        string             `json:"envProcess"`
+	EnvVars           map[string]string  `json:"envVars"`
+	Labels            map[string]string  `json:"labels"`
+	Annotations       map[string]string  `json:"annotations"`
+	Secrets           []string           `json:"secrets"`
+	Limits            *openFaaSResources `json:"limits"`
+	Requests          *openFaaSResources `json:"requests"`
+	Replicas          int32              `json:"replicas"`
+	AvailableReplicas int32              `json:"availableReplicas"`
+	InvocationCount   float64            `json:"invocationCount"`
+	CreatedAt         time.Time          `json:"createdAt"`
+}
+
+// openFaaSResources are the memory and CPU limits or requests of a function
+type openFaaSResources struct {
+	Memory string `json:"memory"`
+	CPU    string `json:"cpu"`
+}
+
+// openFaaSLogLine is a line of the gateway's newline-delimited log stream
+type openFaaSLogLine struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	Instance  string    `json:"instance"`
+	Timestamp time.Time `json:"timestamp"`
+	Text      string    `json:"text"`
+}
+
+// NewOpenFaaSProvider creates a provider for the gateway at gatewayURL, e.g.
+// http://127.0.0.1:8080, listing the functions of namespace
+func NewOpenFaaSProvider(gatewayURL, namespace string, auth OpenFaaSAuth) (*OpenFaaSProvider, error) {
+	gateway, err := url.Parse(strings.TrimSuffix(gatewayURL, "/"))
+	if err != nil || gateway.Host == "" || (gateway.Scheme != "http" && gateway.Scheme != "https") {
+		return nil, fmt.Errorf("invalid OpenFaaS gateway URL %q, e.g. http://127.0.0.1:8080", gatewayURL)
+	}
+	if namespace == "" {
+		namespace = OpenFaaSDefaultNamespace
+	}
+	transport := apicalls.Transport(http.DefaultTransport, func(resp *http.Response) bool {
+		return resp.StatusCode == http.StatusTooManyRequests
+	})
+	return &OpenFaaSProvider{
+		gateway:   gateway,
+		namespace: namespace,
+		auth:      auth,
+		client:    &http.Client{Transport: transport},
+	}, nil
+}
+
+func (p *OpenFaaSProvider) GetProviderName() CloudProvider {
+	return OpenFaaS
+}
+
+// GetRegion returns the namespace, OpenFaaS having no regions
+func (p *OpenFaaSProvider) GetRegion() string {
+	return p.namespace
+}
+
+// GetAccountID returns the gateway's host
+func (p *OpenFaaSProvider) GetAccountID(ctx context.Context) (string, error) {
+	return p.gateway.Host, nil
+}
+
+// endpoint returns the URL of a gateway path with its query
+func (p *OpenFaaSProvider) endpoint(path string, query url.Values) string {
+	u := *p.gateway
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// authorize adds the gateway credentials to a request
+func (p *OpenFaaSProvider) authorize(req *http.Request) {
+	switch {
+	case p.auth.Token != "":
+		req.Header.Set("Authorization", "Bearer "+p.auth.Token)
+	case p.auth.Password != "":
+		req.SetBasicAuth(p.auth.Username, p.auth.Password)
+	}
+}
+
+// do sends a request to the gateway with its credentials, returning the
+// response when its status is 2xx
+func (p *OpenFaaSProvider) do(ctx context.Context, method, path string, query url.Values, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, p.endpoint(path, query), body)
+	if err != nil {
+		return nil, err
+	}
+	p.authorize(req)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the OpenFaaS gateway: %w", err)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return nil, fmt.Errorf("the OpenFaaS gateway rejected the credentials (401): set OPENFAAS_PASSWORD or OPENFAAS_TOKEN")
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("not found on the OpenFaaS gateway: %s", strings.TrimSpace(string(message)))
+	}
+	return nil, fmt.Errorf("OpenFaaS gateway returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+}
+
+// getJSON decodes the response to a GET of a gateway path
+func (p *OpenFaaSProvider) getJSON(ctx context.Context, path string, query url.Values, out any) error {
+	resp, err := p.do(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode the OpenFaaS gateway response: %w", err)
+	}
+	return nil
+}
+
+// ListFunctions lists the functions of the namespace
+func (p *OpenFaaSProvider) ListFunctions(ctx context.Context) ([]FunctionInfo, error) {
+	var statuses []openFaaSFunction
+	if err := p.getJSON(ctx, "/system/functions", url.Values{"namespace": {p.namespace}}, &statuses); err != nil {
+		logger.Logger.Printf("Error listing OpenFaaS functions: %v", err)
+		return nil, fmt.Errorf("failed to list functions: %w", err)
+	}
+	functions := make([]FunctionInfo, 0, len(statuses))
+	for _, status := range statuses {
+		functions = append(functions, p.functionInfo(status))
+	}
+	slices.SortFunc(functions, func(a, b FunctionInfo) int { return strings.Compare(a.Name, b.Name) })
+	return functions, nil
+}
+
+// GetFunction gets a function's status
+func (p *OpenFaaSProvider) GetFunction(ctx context.Context, name string) (*FunctionInfo, error) {
+	status, err := p.function(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	info := p.functionInfo(*status)
+	return &info, nil
+}
+
+// function reads a function's status from the gateway
+func (p *OpenFaaSProvider) function(ctx context.Context, name string) (*openFaaSFunction, error) {
+	var status openFaaSFunction
+	if err := p.getJSON(ctx, "/system/function/"+url.PathEscape(name), url.Values{"namespace": {p.namespace}}, &status); err != nil {
+		return nil, fmt.Errorf("failed to get function %s: %w", name, err)
+	}
+	return &status, nil
+}
+
+// functionInfo maps a function status: the image's name is the runtime, the
+// process the handler, the labels the tags, and the replicas the state
+func (p *OpenFaaSProvider) functionInfo(status openFaaSFunction) FunctionInfo {
+	info := FunctionInfo{
+		Name:              status.Name,
+		Runtime:           status.Image[strings.LastIndex(status.Image, "/")+1:],
+		Handler:           status.EnvProcess,
+		ARN:               status.Name + "." + cmp.Or(status.Namespace, p.namespace),
+		Environment:       status.EnvVars,
+		Region:            cmp.Or(status.Namespace, p.namespace),
+		Tags:              status.Labels,
+		Description:       status.Annotations["com.openfaas.description"],
+		Replicas:          status.Replicas,
+		AvailableReplicas: status.AvailableReplicas,
+		InvocationCount:   int64(status.InvocationCount),
+		StateReason:       fmt.Sprintf("%d/%d replicas available", status.AvailableReplicas, status.Replicas),
+	}
+	if info.Tags == nil {
+		info.Tags = map[string]string{}
+	}
+	if status.Limits != nil {
+		info.Memory = parseMemoryQuantityMB(status.Limits.Memory)
+	}
+	if info.Memory == 0 && status.Requests != nil {
+		info.Memory = parseMemoryQuantityMB(status.Requests.Memory)
+	}
+	// of-watchdog stops the handler after exec_timeout, the classic watchdog
+	// after write_timeout
+	for _, key := range []string{"exec_timeout", "write_timeout"} {
+		if d, err := parseOpenFaaSDuration(status.EnvVars[key]); err == nil {
+			info.Timeout = int32(d.Seconds())
+			break
+		}
+	}
+	switch {
+	case status.AvailableReplicas > 0:
+		info.State = "Ready"
+	case status.Replicas == 0:
+		info.State = "Scaled to zero"
+	default:
+		info.State = "Not ready"
+	}
+	if !status.CreatedAt.IsZero() && status.CreatedAt.Year() > 1 {
+		info.Modified = status.CreatedAt
+		info.LastModified = status.CreatedAt.Format(time.RFC3339)
+	}
+	return info
+}
+
+// parseOpenFaaSDuration reads a watchdog timeout: a duration such as 30s, or
+// seconds
+func parseOpenFaaSDuration(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// parseMemoryQuantityMB reads a Kubernetes memory quantity, e.g. 128Mi, 1G
+// or bytes, in MB; 0 when it is empty or invalid
+func parseMemoryQuantityMB(quantity string) int32 {
+	quantity = strings.TrimSpace(quantity)
+	units := []struct {
+		suffix string
+		bytes  float64
+	}{
+		{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40},
+		{"k", 1e3}, {"K", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12},
+	}
+	multiplier := 1.0
+	for _, unit := range units {
+		if number, ok := strings.CutSuffix(quantity, unit.suffix); ok {
+			quantity, multiplier = number, unit.bytes
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(quantity, 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return int32(n * multiplier / (1 << 20))
+}
+
+// GetFunctionCode describes the function's image and configuration: its code
+// is built into the image rather than uploaded
+func (p *OpenFaaSProvider) GetFunctionCode(ctx context.Context, name string) (string, error) {
+	status, err := p.function(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	var info strings.Builder
+	info.WriteString("━━━ Code Information ━━━\n\n")
+	info.WriteString(fmt.Sprintf("Image: %s\n", status.Image))
+	if status.EnvProcess != "" {
+		info.WriteString(fmt.Sprintf("Process: %s\n", status.EnvProcess))
+	}
+	info.WriteString(fmt.Sprintf("Replicas: %d/%d available\n\n", status.AvailableReplicas, status.Replicas))
+
+	if status.Limits != nil || status.Requests != nil {
+		info.WriteString("Resources:\n")
+		if status.Requests != nil {
+			info.WriteString(fmt.Sprintf("  Requests: memory %s, cpu %s\n", cmp.Or(status.Requests.Memory, "-"), cmp.Or(status.Requests.CPU, "-")))
+		}
+		if status.Limits != nil {
+			info.WriteString(fmt.Sprintf("  Limits: memory %s, cpu %s\n", cmp.Or(status.Limits.Memory, "-"), cmp.Or(status.Limits.CPU, "-")))
+		}
+		info.WriteString("\n")
+	}
+	if len(status.EnvVars) > 0 {
+		info.WriteString("Environment Variables (values hidden, reveal them in the details with v):\n")
+		for _, k := range slices.Sorted(maps.Keys(status.EnvVars)) {
+			info.WriteString(fmt.Sprintf("  %s: ********\n", k))
+		}
+		info.WriteString("\n")
+	}
+	if len(status.Secrets) > 0 {
+		info.WriteString(fmt.Sprintf("Secrets: %s\n\n", strings.Join(status.Secrets, ", ")))
+	}
+	if len(status.Annotations) > 0 {
+		info.WriteString("Annotations:\n")
+		for _, k := range slices.Sorted(maps.Keys(status.Annotations)) {
+			info.WriteString(fmt.Sprintf("  %s: %s\n", k, status.Annotations[k]))
+		}
+		info.WriteString("\n")
+	}
+
+	info.WriteString("To get the code:\n")
+	info.WriteString(fmt.Sprintf("1. Pull the image: docker pull %s\n", status.Image))
+	info.WriteString(fmt.Sprintf("2. Or describe it with faas-cli: faas-cli describe %s --namespace %s\n", name, p.namespace))
+	return info.String(), nil
+}
+
+// DownloadFunctionCode is not supported: OpenFaaS functions are container
+// images, pulled with a container runtime
+func (p *OpenFaaSProvider) DownloadFunctionCode(ctx context.Context, name, destination string) error {
+	status, err := p.function(ctx, name)
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("the code of %s is built into the image %s: pull it with docker pull %s", name, status.Image, status.Image)
+}
+
+// GetFunctionLogs reads the function's latest log lines
+func (p *OpenFaaSProvider) GetFunctionLogs(ctx context.Context, name string, query LogQuery) ([]LogEntry, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = openFaaSLogLimit
+	}
+	params := url.Values{"name": {name}, "namespace": {p.namespace}, "follow": {"false"}, "tail": {strconv.Itoa(limit)}}
+	if !query.Since.IsZero() {
+		params.Set("since", query.Since.UTC().Format(time.RFC3339))
+	}
+	resp, err := p.do(ctx, http.MethodGet, "/system/logs", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the logs of %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	var entries []LogEntry
+	err = readOpenFaaSLogs(resp.Body, func(entry LogEntry) bool {
+		if query.Matches(entry) {
+			entries = append(entries, entry)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the logs of %s: %w", name, err)
+	}
+	slices.SortStableFunc(entries, func(a, b LogEntry) int { return a.Timestamp.Compare(b.Timestamp) })
+	return entries, nil
+}
+
+// StreamFunctionLogs follows the function's logs from now on
+func (p *OpenFaaSProvider) StreamFunctionLogs(ctx context.Context, name string) (<-chan LogEntry, <-chan error) {
+	logChan := make(chan LogEntry, 100)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(logChan)
+		defer close(errChan)
+
+		params := url.Values{"name": {name}, "namespace": {p.namespace}, "follow": {"true"}, "since": {time.Now().UTC().Format(time.RFC3339)}}
+		resp, err := p.do(ctx, http.MethodGet, "/system/logs", params, nil)
+		if err != nil {
+			errChan <- fmt.Errorf("failed to stream the logs of %s: %w", name, err)
+			return
+		}
+		defer resp.Body.Close()
+
+		err = readOpenFaaSLogs(resp.Body, func(entry LogEntry) bool {
+			select {
+			case logChan <- entry:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		if err != nil && ctx.Err() == nil {
+			errChan <- fmt.Errorf("log stream of %s ended: %w", name, err)
+		}
+	}()
+
+	return logChan, errChan
+}
+
+// readOpenFaaSLogs passes the lines of a log response to emit until it
+// returns false
+func readOpenFaaSLogs(body io.Reader, emit func(LogEntry) bool) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var line openFaaSLogLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue
+		}
+		entry := LogEntry{
+			Timestamp: line.Timestamp,
+			Severity:  inferSeverity(line.Text),
+			Message:   line.Text,
+			Labels:    map[string]string{"instance": line.Instance},
+		}
+		if !emit(entry) {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+// inferSeverity infers the severity of a log line from its words
+func inferSeverity(text string) string {
+	switch {
+	case logErrorRe.MatchString(text):
+		return "ERROR"
+	case logWarnRe.MatchString(text):
+		return "WARNING"
+	default:
+		return "INFO"
+	}
+}
+
+// GetFunctionMetrics is not supported: the gateway only reports the
+// invocation count since the function was deployed, shown in the details.
+// Its Prometheus keeps the history.
+func (p *OpenFaaSProvider) GetFunctionMetrics(ctx context.Context, name string, startTime, endTime time.Time) (*FunctionMetrics, error) {
+	return nil, fmt.Errorf("metrics history is not available from the OpenFaaS gateway: query its Prometheus for gateway_function_invocation_total")
+}
+
+// GetEndpoints returns the function's synchronous and asynchronous URLs
+func (p *OpenFaaSProvider) GetEndpoints(ctx context.Context, name string) ([]string, error) {
+	path := url.PathEscape(name + "." + p.namespace)
+	return []string{
+		p.endpoint("/function/"+path, nil),
+		p.endpoint("/async-function/"+path, nil),
+	}, nil
+}
+
+// Invoke calls the function synchronously through the gateway. A function
+// answering with an error status is a function error, not a failed call.
+func (p *OpenFaaSProvider) Invoke(ctx context.Context, functionName string, payload []byte) (*InvokeResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint("/function/"+url.PathEscape(functionName+"."+p.namespace), nil), bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	p.authorize(req)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call function %s: %w", functionName, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, openFaaSMaxResponse))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response of %s: %w", functionName, err)
+	}
+
+	result := &InvokeResult{Payload: string(body), ExecutionID: resp.Header.Get("X-Call-Id")}
+	if resp.StatusCode >= 400 {
+		result.FunctionError = resp.Status
+	}
+	return result, nil
+}
+
+// ConsoleURL returns the gateway's web UI
+func (p *OpenFaaSProvider) ConsoleURL(fn FunctionInfo) string {
+	return p.endpoint("/ui/", nil)
+}
+
+// References returns the function's name with its namespace, image and URL
+func (p *OpenFaaSProvider) References(ctx context.Context, fn FunctionInfo) ([]FunctionReference, error) {
+	refs := []FunctionReference{{Label: "Name", Value: fn.ARN}}
+	if status, err := p.function(ctx, fn.Name); err == nil {
+		refs = append(refs, FunctionReference{Label: "Image", Value: status.Image})
+	} else if !errors.Is(err, context.Canceled) {
+		logger.Logger.Printf("Error reading the image of %s: %v", fn.Name, err)
+	}
+	urls, _ := p.GetEndpoints(ctx, fn.Name)
+	return append(refs, FunctionReference{Label: "URL", Value: urls[0]}), nil
+}
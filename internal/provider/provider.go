@@ -2,67 +2,158 @@ package provider
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"time"
 )
 
 // LogEntry represents a single log entry
 type LogEntry struct {
-	Timestamp time.Time
-	Severity  string
-	Message   string
-	Labels    map[string]string
+	Timestamp time.Time         `json:"timestamp"`
+	Severity  string            `json:"severity"`
+	Message   string            `json:"message"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// LogQuery narrows a log fetch by time window, severity and message text
+type LogQuery struct {
+	Limit       int
+	Since       time.Time // zero means the provider's default lookback
+	MinSeverity string    // e.g. "ERROR"; empty matches every severity
+	Text        string    // text the message must contain
+}
+
+// severityRanks orders log severities using Cloud Logging's scale
+var severityRanks = map[string]int{
+	"DEFAULT":   0,
+	"DEBUG":     100,
+	"INFO":      200,
+	"NOTICE":    300,
+	"WARN":      400,
+	"WARNING":   400,
+	"ERROR":     500,
+	"CRITICAL":  600,
+	"ALERT":     700,
+	"EMERGENCY": 800,
+}
+
+// SeverityRank returns the numeric rank of a severity name (unknown names rank as DEFAULT)
+func SeverityRank(severity string) int {
+	return severityRanks[strings.ToUpper(strings.TrimSpace(severity))]
+}
+
+// Matches reports whether a log entry satisfies the query's severity, text and time constraints
+func (q LogQuery) Matches(entry LogEntry) bool {
+	if q.MinSeverity != "" && SeverityRank(entry.Severity) < SeverityRank(q.MinSeverity) {
+		return false
+	}
+	if q.Text != "" && !strings.Contains(strings.ToLower(entry.Message), strings.ToLower(q.Text)) {
+		return false
+	}
+	if !q.Since.IsZero() && !entry.Timestamp.IsZero() && entry.Timestamp.Before(q.Since) {
+		return false
+	}
+	return true
 }
 
 // MetricDataPoint represents a single metric data point
 type MetricDataPoint struct {
-	Timestamp time.Time
-	Value     float64
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
 }
 
 // MetricData represents metrics for a function
 type MetricData struct {
-	MetricName  string
-	Unit        string
-	DataPoints  []MetricDataPoint
-	Description string
+	MetricName  string            `json:"metricName"`
+	Unit        string            `json:"unit"`
+	DataPoints  []MetricDataPoint `json:"dataPoints"`
+	Description string            `json:"description,omitempty"`
 }
 
 // FunctionMetrics represents comprehensive metrics for a function
 type FunctionMetrics struct {
-	FunctionName string
+	FunctionName string `json:"functionName"`
 	TimeRange    struct {
 		Start time.Time
 		End   time.Time
-	}
-	Invocations          MetricData
-	Duration             MetricData
-	Errors               MetricData
-	Throttles            MetricData
-	Memory               MetricData
-	ConcurrentExecutions MetricData
+	} `json:"timeRange"`
+	Invocations          MetricData `json:"invocations"`
+	Duration             MetricData `json:"duration"`
+	Errors               MetricData `json:"errors"`
+	Throttles            MetricData `json:"throttles"`
+	Memory               MetricData `json:"memory"`
+	ConcurrentExecutions MetricData `json:"concurrentExecutions"`
 }
 
 // CloudProvider represents the cloud provider type
 type CloudProvider string
 
 const (
-	AWS CloudProvider = "aws"
-	GCP CloudProvider = "gcp"
+	AWS      CloudProvider = "aws"
+	GCP      CloudProvider = "gcp"
+	OpenFaaS CloudProvider = "openfaas"
+	Knative  CloudProvider = "knative"
 )
 
 // FunctionInfo represents generic function information across providers
 type FunctionInfo struct {
-	Name         string
-	Runtime      string
-	Memory       int32
-	Timeout      int32
-	Handler      string
-	LastModified string
-	ARN          string // AWS ARN or GCP resource name
-	Description  string
-	Role         string
-	Environment  map[string]string
-	Region       string // AWS region or GCP location
+	Name         string            `json:"name"`
+	Runtime      string            `json:"runtime"`
+	Memory       int32             `json:"memory"`
+	Timeout      int32             `json:"timeout"`
+	CodeSize     int64             `json:"codeSize,omitempty"` // bytes of the deployment package, AWS only
+	Handler      string            `json:"handler,omitempty"`
+	LastModified string            `json:"lastModified,omitempty"`
+	ARN          string            `json:"arn"` // AWS ARN or GCP resource name
+	Description  string            `json:"description,omitempty"`
+	Role         string            `json:"role,omitempty"`
+	Environment  map[string]string `json:"environment,omitempty"`
+	Region       string            `json:"region"`              // AWS region or GCP location
+	KMSKeyARN    string            `json:"kmsKeyArn,omitempty"` // customer-managed key encrypting the environment
+	Tags         map[string]string `json:"tags,omitempty"`      // nil when the provider did not load tags
+	Profile      string            `json:"profile,omitempty"`   // credentials profile, set when listing several
+	// Lifecycle state, e.g. Active/Pending/Failed (AWS) or ACTIVE/OFFLINE (GCP)
+	State                  string `json:"state,omitempty"`
+	StateReason            string `json:"stateReason,omitempty"`
+	LastUpdateStatus       string `json:"lastUpdateStatus,omitempty"` // AWS only: Successful, InProgress or Failed
+	LastUpdateStatusReason string `json:"lastUpdateStatusReason,omitempty"`
+	// GCP Secret Manager references by variable, as projects/P/secrets/S/versions/V
+	SecretEnvironment map[string]string `json:"secretEnvironment,omitempty"`
+	// Changes on every update: the RevisionId (AWS) or versionId (GCP)
+	Revision string `json:"revision,omitempty"`
+	// LastModified parsed; LastModified keeps the provider's format, which differs
+	// between AWS and GCP. Zero when it could not be parsed.
+	Modified time.Time `json:"modified,omitzero"`
+	// Log format and levels, AWS only (advanced logging controls)
+	Logging *LoggingConfig `json:"logging,omitempty"`
+	// Replicas desired and ready (OpenFaaS and Knative), and invocations
+	// since the function was deployed (OpenFaaS only)
+	Replicas          int32 `json:"replicas,omitempty"`
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	InvocationCount   int64 `json:"invocationCount,omitempty"`
+	// Concurrency and autoscaling settings, Knative only
+	Scaling *ScalingConfig `json:"scaling,omitempty"`
+}
+
+// LoggingConfig is the format of a function's logs and the levels below
+// which its log lines are dropped
+type LoggingConfig struct {
+	Format           string `json:"format"`                     // JSON or Text
+	ApplicationLevel string `json:"applicationLevel,omitempty"` // TRACE to FATAL, JSON format only
+	SystemLevel      string `json:"systemLevel,omitempty"`      // DEBUG, INFO or WARN, JSON format only
+	LogGroup         string `json:"logGroup,omitempty"`
+}
+
+// ScalingConfig holds the concurrency limit and autoscaling annotations of a
+// Knative revision; the annotations are empty when left to the cluster's
+// defaults
+type ScalingConfig struct {
+	ContainerConcurrency int64  `json:"containerConcurrency"` // requests per replica at once, 0 for unlimited
+	Target               string `json:"target,omitempty"`     // the autoscaler's soft target per replica
+	Metric               string `json:"metric,omitempty"`     // concurrency, rps, cpu or memory
+	MinScale             string `json:"minScale,omitempty"`
+	MaxScale             string `json:"maxScale,omitempty"`
+	Class                string `json:"class,omitempty"` // e.g. kpa.autoscaling.knative.dev
 }
 
 // Provider defines the interface for cloud function providers
@@ -74,8 +165,410 @@ type Provider interface {
 	GetFunction(ctx context.Context, name string) (*FunctionInfo, error)
 	GetFunctionCode(ctx context.Context, name string) (string, error)
 	DownloadFunctionCode(ctx context.Context, name, destination string) error
-	GetFunctionLogs(ctx context.Context, name string, limit int) ([]string, error)
+	GetFunctionLogs(ctx context.Context, name string, query LogQuery) ([]LogEntry, error) // in chronological order
 	StreamFunctionLogs(ctx context.Context, name string) (<-chan LogEntry, <-chan error)
 	GetFunctionMetrics(ctx context.Context, name string, startTime, endTime time.Time) (*FunctionMetrics, error)
 	GetEndpoints(ctx context.Context, name string) ([]string, error)
 }
+
+// CapabilityReporter is implemented by providers wrapping others, such as
+// the MultiProvider and RecordingProvider. They implement the optional
+// interfaces to forward them, whether or not the providers they wrap do.
+type CapabilityReporter interface {
+	// Supports reports whether a provider it wraps satisfies has
+	Supports(has func(Provider) bool) bool
+}
+
+// As returns p as the optional interface T, e.g. Invoker, when it provides
+// it. Unlike a type assertion, it asks wrappers whether the providers they
+// wrap implement T, so that features they can't serve are not offered.
+func As[T any](p Provider) (T, bool) {
+	t, ok := p.(T)
+	if !ok {
+		return t, false
+	}
+	if reporter, ok := p.(CapabilityReporter); ok {
+		has := func(wrapped Provider) bool {
+			_, ok := As[T](wrapped)
+			return ok
+		}
+		if !reporter.Supports(has) {
+			var none T
+			return none, false
+		}
+	}
+	return t, true
+}
+
+// AlarmSpec describes a metric alarm to create for a function
+type AlarmSpec struct {
+	Name              string
+	MetricName        string // e.g. Errors, Throttles, Duration
+	Statistic         string // Sum, Average, Maximum, Minimum or SampleCount
+	Comparison        string // one of >=, >, <=, <
+	Threshold         float64
+	EvaluationPeriods int32
+	PeriodSeconds     int32
+	TopicARN          string // optional notification target
+}
+
+// AlarmManager is implemented by providers that can create metric alarms
+type AlarmManager interface {
+	CreateAlarm(ctx context.Context, functionName string, spec AlarmSpec) error
+}
+
+// FunctionStats are aggregate invocation statistics for a function over a window
+type FunctionStats struct {
+	Invocations     float64
+	Errors          float64
+	MaxDurationMs   float64
+	P99DurationMs   float64
+	TotalDurationMs float64 // sum of all invocation durations
+}
+
+// ErrorRate returns errors as a fraction of invocations
+func (s FunctionStats) ErrorRate() float64 {
+	if s.Invocations == 0 {
+		return 0
+	}
+	return s.Errors / s.Invocations
+}
+
+// StatsReporter is implemented by providers that can report invocation
+// statistics for many functions in a single request
+type StatsReporter interface {
+	// GetFunctionStats returns statistics per function name; functions
+	// without invocations may be omitted
+	GetFunctionStats(ctx context.Context, names []string, since time.Time) (map[string]FunctionStats, error)
+}
+
+// AlarmStateReporter is implemented by providers that can report firing alarms
+type AlarmStateReporter interface {
+	// GetFiringAlarms returns the names of alarms in ALARM state by function name
+	GetFiringAlarms(ctx context.Context) (map[string][]string, error)
+}
+
+// InvokeResult is the outcome of a synchronous invocation
+type InvokeResult struct {
+	Payload       string `json:"payload"`
+	FunctionError string `json:"functionError,omitempty"` // set when the function itself failed
+	ExecutionID   string `json:"executionId,omitempty"`
+	Log           string `json:"log,omitempty"` // end of the invocation's log, from PowerTuner.InvokeWithLog
+}
+
+// Invoker is implemented by providers that can invoke a function synchronously
+type Invoker interface {
+	Invoke(ctx context.Context, functionName string, payload []byte) (*InvokeResult, error)
+}
+
+// PowerTuner is implemented by providers that can run a function at other
+// memory sizes to measure its duration and cost at each
+type PowerTuner interface {
+	// SetMemory changes the function's memory size, returning once the
+	// update is done. An update still in progress is waited for first.
+	SetMemory(ctx context.Context, functionName string, memoryMB int32) error
+	// InvokeWithLog invokes the function synchronously, returning the end of
+	// the invocation's log with its REPORT line
+	InvokeWithLog(ctx context.Context, functionName string, payload []byte) (*InvokeResult, error)
+}
+
+// FailedEvent is a message from a function's dead-letter or on-failure queue
+type FailedEvent struct {
+	ID      string
+	Source  string // queue the message was read from
+	SentAt  time.Time
+	Payload string // original invocation payload
+	Handle  string // provider-specific handle used to delete the message
+}
+
+// FailedEventReplayer is implemented by providers that can re-invoke a
+// function with events from its dead-letter or failure destination queue
+type FailedEventReplayer interface {
+	ListFailedEvents(ctx context.Context, functionName string) ([]FailedEvent, error)
+	// ReplayFailedEvent invokes the function with the event payload and
+	// deletes the message from its queue when the invocation succeeds
+	ReplayFailedEvent(ctx context.Context, functionName string, event FailedEvent) error
+}
+
+// QueueBacklog describes the messages waiting in a trigger queue
+type QueueBacklog struct {
+	Messages  int64
+	InFlight  int64
+	OldestAge time.Duration // zero when unknown
+}
+
+// EventSourceMapping is a queue, stream, topic or bucket that triggers a function
+type EventSourceMapping struct {
+	ID        string
+	SourceARN string // queue, stream or S3 bucket ARN, Pub/Sub topic, gs:// bucket or Eventarc trigger
+	State     string // e.g. Enabled, Disabled, Creating
+	BatchSize int32
+	Backlog   *QueueBacklog // set for queue sources
+	EventType string        // set for S3 notifications and GCP event triggers
+	Filters   []string      // S3 key filters or Eventarc event filters, e.g. "bucket=my-bucket"
+	Trigger   string        // Eventarc trigger delivering the events
+	Retry     string        // retry policy on failure, e.g. RETRY_POLICY_RETRY
+}
+
+// TriggerLister is implemented by providers that can list a function's event source mappings
+type TriggerLister interface {
+	ListEventSourceMappings(ctx context.Context, functionName string) ([]EventSourceMapping, error)
+}
+
+// ScheduledJob is a scheduler job that invokes a function on a schedule
+type ScheduledJob struct {
+	Name        string
+	Schedule    string // cron expression
+	TimeZone    string
+	State       string // e.g. ENABLED, PAUSED
+	Target      string // URL or topic the job sends to
+	Description string
+	NextRun     time.Time // zero when unknown
+	LastAttempt time.Time // zero when the job never ran
+}
+
+// ScheduleLister is implemented by providers that can find the scheduler jobs targeting a function
+type ScheduleLister interface {
+	ListSchedules(ctx context.Context, fn FunctionInfo) ([]ScheduledJob, error)
+}
+
+// Deployment is a past deployment of a function's code or configuration
+type Deployment struct {
+	ID       string // e.g. the Cloud Build ID or CloudTrail event ID
+	Status   string // e.g. SUCCESS, FAILURE, WORKING
+	Change   string // what was changed, when the deployment is not a build, e.g. memory 512 MB
+	Started  time.Time
+	Finished time.Time // zero while the deployment runs, or when it is a single API call
+	Source   string    // source version deployed, e.g. gs://bucket/object#generation, a commit or a code SHA-256
+	Actor    string    // who deployed, when known
+	URL      string    // where the deployment's logs or record can be read
+	Current  bool      // the deployment the function runs now
+}
+
+// DeploymentLister is implemented by providers that can list a function's
+// recent deployments, most recent first
+type DeploymentLister interface {
+	ListDeployments(ctx context.Context, fn FunctionInfo) ([]Deployment, error)
+}
+
+// TrafficShift is a gradual deployment shifting an alias's traffic to a new
+// version, e.g. a CodeDeploy canary or linear deployment
+type TrafficShift struct {
+	ID             string
+	Status         string // e.g. InProgress, Ready
+	Config         string // e.g. CodeDeployDefault.LambdaCanary10Percent5Minutes
+	Alias          string
+	CurrentVersion string
+	TargetVersion  string
+	TargetWeight   float64 // share of the alias's traffic on the target version, 0 to 1
+	Started        time.Time
+	Error          string
+	Alarms         []RollbackAlarm // alarms stopping the deployment when they fire
+	AutoRollback   []string        // events rolling the deployment back, empty when it isn't
+	URL            string          // the deployment in the console
+}
+
+// RollbackAlarm is an alarm watched by a gradual deployment
+type RollbackAlarm struct {
+	Name  string
+	State string // OK, ALARM or INSUFFICIENT_DATA; empty when unknown
+}
+
+// TrafficShiftReader is implemented by providers that can report the gradual
+// deployments in progress on a function, empty when there are none
+type TrafficShiftReader interface {
+	GetTrafficShifts(ctx context.Context, fn FunctionInfo) ([]TrafficShift, error)
+}
+
+// maxDeployments is the number of deployments ListDeployments returns
+const maxDeployments = 20
+
+// sortDeployments orders deployments from the most recent
+func sortDeployments(deployments []Deployment) {
+	slices.SortStableFunc(deployments, func(a, b Deployment) int {
+		return b.Started.Compare(a.Started)
+	})
+}
+
+// AsyncDestination is where the outcome of an asynchronous invocation is sent
+type AsyncDestination struct {
+	Condition string // OnSuccess or OnFailure
+	Target    string // destination ARN
+}
+
+// AsyncInvokeConfig holds the retry and routing settings of asynchronous invocations
+type AsyncInvokeConfig struct {
+	Destinations []AsyncDestination
+	MaxRetries   *int32        // nil when the default applies
+	MaxEventAge  time.Duration // 0 when the default applies
+}
+
+// AsyncConfigReader is implemented by providers that route asynchronous
+// invocations to destinations
+type AsyncConfigReader interface {
+	GetAsyncInvokeConfig(ctx context.Context, functionName string) (*AsyncInvokeConfig, error)
+}
+
+// LogPage is a page of a function's logs, in chronological order
+type LogPage struct {
+	Entries []LogEntry
+	Next    string // Token of the page before this one, empty when there are no older logs
+}
+
+// LogPager is implemented by providers that can page back through a
+// function's logs, beyond the entries GetFunctionLogs returns.
+// GetOlderFunctionLogs returns up to query.Limit entries logged before
+// before; token, when set, is the Next of the previous page fetched with the
+// same query and before. query.Since is ignored.
+type LogPager interface {
+	GetOlderFunctionLogs(ctx context.Context, name string, query LogQuery, before time.Time, token string) (*LogPage, error)
+}
+
+// EnvironmentUpdater is implemented by providers that can replace a
+// function's environment variables. secrets maps variables to Secret Manager
+// references and is only supported on GCP. revision, when set, is the
+// FunctionInfo.Revision the edit started from: providers that can make the
+// update conditional fail it when the function changed since.
+type EnvironmentUpdater interface {
+	UpdateEnvironment(ctx context.Context, name string, env, secrets map[string]string, revision string) error
+}
+
+// CodeUpdater is implemented by providers that can replace the handler file
+// GetFunctionCode shows with source and deploy it. revision is as for
+// EnvironmentUpdater.
+type CodeUpdater interface {
+	UpdateHandlerCode(ctx context.Context, name, source, revision string) error
+}
+
+// DescriptionUpdater is implemented by providers that can change a
+// function's description
+type DescriptionUpdater interface {
+	UpdateDescription(ctx context.Context, name, description string) error
+}
+
+// LoggingConfigUpdater is implemented by providers that can change the log
+// format and levels of a function, e.g. to turn debug logs on for a while
+type LoggingConfigUpdater interface {
+	UpdateLoggingConfig(ctx context.Context, name string, cfg LoggingConfig) error
+}
+
+// LogRetention is how long a function's logs are kept and the space they take
+type LogRetention struct {
+	LogGroup    string
+	Days        int32 // 0 when the logs never expire
+	StoredBytes int64
+	Choices     []int32 // The numbers of days the retention can be set to
+	Missing     bool    // No logs yet: the log group is created on the first invocation
+}
+
+// LogRetentionManager is implemented by providers that can show and change
+// how long a function's logs are kept. Days 0 keeps them forever.
+type LogRetentionManager interface {
+	GetLogRetention(ctx context.Context, fn FunctionInfo) (*LogRetention, error)
+	SetLogRetention(ctx context.Context, fn FunctionInfo, days int32) error
+}
+
+// ProjectInfo is a project the caller can access
+type ProjectInfo struct {
+	ID   string
+	Name string
+}
+
+// ProjectSwitcher is implemented by providers scoped to a project that can
+// be rebuilt for another one
+type ProjectSwitcher interface {
+	ListProjects(ctx context.Context) ([]ProjectInfo, error)
+	WithProject(projectID string) (Provider, error)
+}
+
+// ConcurrencyInfo is a function's concurrency usage against its limits
+type ConcurrencyInfo struct {
+	Executions  []MetricDataPoint // maximum concurrent executions per period
+	Limit       int32             // reserved concurrency, or the account limit when none is reserved
+	LimitSource string            // "reserved" or "account"
+	Provisioned int32             // allocated provisioned concurrency, 0 when not configured
+	// ProvisionedUtilization is the maximum share (0-1) of provisioned concurrency in use per period
+	ProvisionedUtilization []MetricDataPoint
+}
+
+// ConcurrencyReporter is implemented by providers that expose concurrency limits and usage
+type ConcurrencyReporter interface {
+	GetConcurrency(ctx context.Context, functionName string, startTime, endTime time.Time) (*ConcurrencyInfo, error)
+}
+
+// EnhancedMetrics are the per-period metrics of an enhanced monitoring
+// extension such as CloudWatch Lambda Insights
+type EnhancedMetrics struct {
+	Extension string            // ARN of the extension layer, empty when it is not attached
+	CPUTime   []MetricDataPoint // average CPU time per invocation, in ms
+	Memory    []MetricDataPoint // maximum memory utilization, in % of the configured memory
+	Network   []MetricDataPoint // bytes received and sent
+}
+
+// EnhancedMetricsReporter is implemented by providers that can read enhanced monitoring metrics
+type EnhancedMetricsReporter interface {
+	GetEnhancedMetrics(ctx context.Context, functionName string, startTime, endTime time.Time) (*EnhancedMetrics, error)
+}
+
+// PostureFinding is a security misconfiguration found by a posture audit
+type PostureFinding struct {
+	Severity    string `json:"severity"` // CRITICAL, HIGH, MEDIUM or LOW
+	Title       string `json:"title"`
+	Detail      string `json:"detail"`      // what was found
+	Explanation string `json:"explanation"` // why it matters
+	Remediation string `json:"remediation"`
+}
+
+// PostureReport is the outcome of a security posture audit of a function
+type PostureReport struct {
+	Checks   []string         `json:"checks"` // names of the checks that ran
+	Findings []PostureFinding `json:"findings"`
+}
+
+// PostureAuditor is implemented by providers that can audit the access and
+// encryption settings of a function
+type PostureAuditor interface {
+	AuditPosture(ctx context.Context, fn FunctionInfo) (*PostureReport, error)
+}
+
+// Action is a change f6n can make to a function, whose permissions can be
+// probed before it is offered
+type Action string
+
+const (
+	ActionUpdateEnvironment Action = "update-environment"
+	ActionDownloadCode      Action = "download-code"
+	ActionReplayEvents      Action = "replay-events"
+	ActionCreateAlarm       Action = "create-alarm"
+	ActionUpdateLogging     Action = "update-logging"
+	ActionSetLogRetention   Action = "set-log-retention"
+	ActionPowerTune         Action = "power-tune"
+	ActionUpdateDescription Action = "update-description"
+	ActionUpdateCode        Action = "update-code"
+)
+
+// PermissionChecker is implemented by providers that can probe the caller's
+// permissions, so that the actions they would deny are not offered
+type PermissionChecker interface {
+	// CheckPermissions returns the actions the credentials may not perform on
+	// fn with the permissions they lack, e.g. lambda:UpdateFunctionConfiguration
+	CheckPermissions(ctx context.Context, fn FunctionInfo) (map[Action][]string, error)
+}
+
+// FunctionReference is an identifier of a function to paste into other tools
+type FunctionReference struct {
+	Label string // e.g. "ARN", "Log group", "URL"
+	Value string
+}
+
+// ReferenceLister is implemented by providers that can list a function's
+// identifiers: its ARN or resource name, where its logs are and its URL
+type ReferenceLister interface {
+	References(ctx context.Context, fn FunctionInfo) ([]FunctionReference, error)
+}
+
+// ConsoleLinker is implemented by providers that can link to a function's
+// page in the cloud console
+type ConsoleLinker interface {
+	ConsoleURL(fn FunctionInfo) string
+}
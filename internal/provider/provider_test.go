@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// basicProvider implements Provider and none of the optional interfaces
+type basicProvider struct {
+	Provider
+}
+
+func TestAsAsksWrappers(t *testing.T) {
+	demo := NewDemoProvider()
+	basic := basicProvider{demo}
+	record := filepath.Join(t.TempDir(), "recording.json")
+
+	tests := []struct {
+		name string
+		p    Provider
+		want bool
+	}{
+		{"provider", demo, true},
+		{"provider without it", basic, false},
+		{"multi", NewMultiProvider([]string{"dev", "prod"}, []Provider{basic, demo}), true},
+		{"multi without it", NewMultiProvider([]string{"dev"}, []Provider{basic}), false},
+		{"recording", NewRecordingProvider(demo, record), true},
+		{"recording without it", NewRecordingProvider(basic, record), false},
+		{"recording of multi without it", NewRecordingProvider(NewMultiProvider([]string{"dev"}, []Provider{basic}), record), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := As[Invoker](tt.p); ok != tt.want {
+				t.Errorf("As[Invoker] = %v, want %v", ok, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayOffersNoChanges(t *testing.T) {
+	replay := NewReplayProvider(&Recording{Provider: AWS, Responses: map[string]RecordedResponse{}})
+	if _, ok := As[EnvironmentUpdater](replay); ok {
+		t.Error("a replay offers environment updates")
+	}
+	if _, ok := As[CodeUpdater](replay); ok {
+		t.Error("a replay offers code updates")
+	}
+	if _, ok := As[TriggerLister](replay); !ok {
+		t.Error("a replay doesn't serve recorded triggers")
+	}
+}
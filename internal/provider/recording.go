@@ -0,0 +1,725 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"f6n/internal/logger"
+)
+
+const (
+	// recordedStreamEntries caps the streamed log entries kept per function
+	recordedStreamEntries = 200
+	// recordingWriteDelay is how long responses are gathered before the
+	// recording file is written with them
+	recordingWriteDelay = 2 * time.Second
+)
+
+// Recording is a snapshot of provider responses, written by a
+// RecordingProvider and served back by a ReplayProvider
+type Recording struct {
+	Provider  CloudProvider               `json:"provider"`
+	Region    string                      `json:"region"`
+	Recorded  time.Time                   `json:"recorded"`
+	Responses map[string]RecordedResponse `json:"responses"` // by call, e.g. "GetFunction/orders"
+}
+
+// RecordedResponse is the result or the error of a provider call
+type RecordedResponse struct {
+	Value json.RawMessage `json:"value,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// callKey identifies a recorded call by method and function name
+func callKey(method, name string) string {
+	if name == "" {
+		return method
+	}
+	return method + "/" + name
+}
+
+// LoadRecording reads a recording written with --record
+func LoadRecording(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	return ParseRecording(data)
+}
+
+// ParseRecording decodes a recording
+func ParseRecording(data []byte) (*Recording, error) {
+	var rec Recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("invalid recording: %w", err)
+	}
+	if rec.Provider == "" {
+		return nil, fmt.Errorf("invalid recording: no provider set")
+	}
+	return &rec, nil
+}
+
+// RecordingProvider passes calls through to a provider and saves each
+// response to a recording file. The file is written a moment after new
+// responses come in, gathering those of a burst of calls, and by
+// FlushRecordings when f6n exits.
+type RecordingProvider struct {
+	Provider
+	*recorder
+}
+
+// recorder holds the recording a RecordingProvider writes, shared with the
+// providers of the projects switched to
+type recorder struct {
+	path string
+
+	mu    sync.Mutex
+	rec   Recording
+	timer *time.Timer // pending write, nil when the file is up to date
+}
+
+// recorders are the recorders created, written by FlushRecordings
+var recorders struct {
+	sync.Mutex
+	list []*recorder
+}
+
+// NewRecordingProvider records the responses of p to the file at path
+func NewRecordingProvider(p Provider, path string) *RecordingProvider {
+	r := &recorder{
+		path: path,
+		rec: Recording{
+			Provider:  p.GetProviderName(),
+			Region:    p.GetRegion(),
+			Recorded:  time.Now().UTC(),
+			Responses: make(map[string]RecordedResponse),
+		},
+	}
+	recorders.Lock()
+	recorders.list = append(recorders.list, r)
+	recorders.Unlock()
+	return &RecordingProvider{Provider: p, recorder: r}
+}
+
+// Supports reports whether the recorded provider satisfies has
+func (r *RecordingProvider) Supports(has func(Provider) bool) bool {
+	return has(r.Provider)
+}
+
+// FlushRecordings writes the responses not written yet of every recording
+func FlushRecordings() {
+	recorders.Lock()
+	defer recorders.Unlock()
+	for _, r := range recorders.list {
+		r.flush()
+	}
+}
+
+// flush writes the recording file when responses are waiting to be written
+func (r *recorder) flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.timer == nil {
+		return
+	}
+	r.timer.Stop()
+	r.timer = nil
+	data, err := json.MarshalIndent(r.rec, "", "  ")
+	if err == nil {
+		err = os.WriteFile(r.path, data, 0o600)
+	}
+	if err != nil {
+		logger.Logger.Printf("Error writing recording %s: %v", r.path, err)
+	}
+}
+
+// save stores a response under key, to be written with the others shortly
+func (r *recorder) save(key string, value any, err error) {
+	var resp RecordedResponse
+	if err != nil {
+		resp.Error = err.Error()
+	} else {
+		data, mErr := json.Marshal(value)
+		if mErr != nil {
+			logger.Logger.Printf("Error recording %s: %v", key, mErr)
+			return
+		}
+		resp.Value = data
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rec.Responses[key] = resp
+	if r.timer == nil {
+		r.timer = time.AfterFunc(recordingWriteDelay, r.flush)
+	}
+}
+
+// recordCall runs call and records its response under key
+func recordCall[T any](r *RecordingProvider, key string, call func() (T, error)) (T, error) {
+	value, err := call()
+	r.save(key, value, err)
+	return value, err
+}
+
+func (r *RecordingProvider) GetAccountID(ctx context.Context) (string, error) {
+	return recordCall(r, callKey("GetAccountID", ""), func() (string, error) {
+		return r.Provider.GetAccountID(ctx)
+	})
+}
+
+func (r *RecordingProvider) ListFunctions(ctx context.Context) ([]FunctionInfo, error) {
+	return recordCall(r, callKey("ListFunctions", ""), func() ([]FunctionInfo, error) {
+		return r.Provider.ListFunctions(ctx)
+	})
+}
+
+func (r *RecordingProvider) GetFunction(ctx context.Context, name string) (*FunctionInfo, error) {
+	return recordCall(r, callKey("GetFunction", name), func() (*FunctionInfo, error) {
+		return r.Provider.GetFunction(ctx, name)
+	})
+}
+
+func (r *RecordingProvider) GetFunctionCode(ctx context.Context, name string) (string, error) {
+	return recordCall(r, callKey("GetFunctionCode", name), func() (string, error) {
+		return r.Provider.GetFunctionCode(ctx, name)
+	})
+}
+
+// GetFunctionLogs records the logs of unfiltered fetches; a replay filters
+// them by the query
+func (r *RecordingProvider) GetFunctionLogs(ctx context.Context, name string, query LogQuery) ([]LogEntry, error) {
+	entries, err := r.Provider.GetFunctionLogs(ctx, name, query)
+	if query.MinSeverity == "" && query.Text == "" {
+		r.save(callKey("GetFunctionLogs", name), entries, err)
+	}
+	return entries, err
+}
+
+// GetOlderFunctionLogs passes older logs through unrecorded: a replay shows
+// the logs of the recording only
+func (r *RecordingProvider) GetOlderFunctionLogs(ctx context.Context, name string, query LogQuery, before time.Time, token string) (*LogPage, error) {
+	pager, ok := As[LogPager](r.Provider)
+	if !ok {
+		return nil, unsupported("paging through older logs", r.Provider)
+	}
+	return pager.GetOlderFunctionLogs(ctx, name, query, before, token)
+}
+
+// StreamFunctionLogs records the first streamed entries
+func (r *RecordingProvider) StreamFunctionLogs(ctx context.Context, name string) (<-chan LogEntry, <-chan error) {
+	entries, errs := r.Provider.StreamFunctionLogs(ctx, name)
+	if entries == nil {
+		return entries, errs
+	}
+	out := make(chan LogEntry)
+	go func() {
+		defer close(out)
+		var recorded []LogEntry
+		for entry := range entries {
+			if len(recorded) < recordedStreamEntries {
+				recorded = append(recorded, entry)
+				r.save(callKey("StreamFunctionLogs", name), recorded, nil)
+			}
+			select {
+			case out <- entry:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, errs
+}
+
+func (r *RecordingProvider) GetFunctionMetrics(ctx context.Context, name string, startTime, endTime time.Time) (*FunctionMetrics, error) {
+	return recordCall(r, callKey("GetFunctionMetrics", name), func() (*FunctionMetrics, error) {
+		return r.Provider.GetFunctionMetrics(ctx, name, startTime, endTime)
+	})
+}
+
+func (r *RecordingProvider) GetEndpoints(ctx context.Context, name string) ([]string, error) {
+	return recordCall(r, callKey("GetEndpoints", name), func() ([]string, error) {
+		return r.Provider.GetEndpoints(ctx, name)
+	})
+}
+
+func (r *RecordingProvider) GetFunctionStats(ctx context.Context, names []string, since time.Time) (map[string]FunctionStats, error) {
+	reporter, ok := As[StatsReporter](r.Provider)
+	if !ok {
+		return nil, unsupported("invocation statistics", r.Provider)
+	}
+	return recordCall(r, callKey("GetFunctionStats", ""), func() (map[string]FunctionStats, error) {
+		return reporter.GetFunctionStats(ctx, names, since)
+	})
+}
+
+func (r *RecordingProvider) GetFiringAlarms(ctx context.Context) (map[string][]string, error) {
+	reporter, ok := As[AlarmStateReporter](r.Provider)
+	if !ok {
+		return nil, unsupported("alarm states", r.Provider)
+	}
+	return recordCall(r, callKey("GetFiringAlarms", ""), func() (map[string][]string, error) {
+		return reporter.GetFiringAlarms(ctx)
+	})
+}
+
+func (r *RecordingProvider) CreateAlarm(ctx context.Context, functionName string, spec AlarmSpec) error {
+	manager, ok := As[AlarmManager](r.Provider)
+	if !ok {
+		return unsupported("creating alarms", r.Provider)
+	}
+	return manager.CreateAlarm(ctx, functionName, spec)
+}
+
+func (r *RecordingProvider) Invoke(ctx context.Context, functionName string, payload []byte) (*InvokeResult, error) {
+	invoker, ok := As[Invoker](r.Provider)
+	if !ok {
+		return nil, unsupported("invocation", r.Provider)
+	}
+	return recordCall(r, callKey("Invoke", functionName), func() (*InvokeResult, error) {
+		return invoker.Invoke(ctx, functionName, payload)
+	})
+}
+
+func (r *RecordingProvider) SetMemory(ctx context.Context, functionName string, memoryMB int32) error {
+	tuner, ok := As[PowerTuner](r.Provider)
+	if !ok {
+		return unsupported("power tuning", r.Provider)
+	}
+	return tuner.SetMemory(ctx, functionName, memoryMB)
+}
+
+func (r *RecordingProvider) InvokeWithLog(ctx context.Context, functionName string, payload []byte) (*InvokeResult, error) {
+	tuner, ok := As[PowerTuner](r.Provider)
+	if !ok {
+		return nil, unsupported("power tuning", r.Provider)
+	}
+	return tuner.InvokeWithLog(ctx, functionName, payload)
+}
+
+func (r *RecordingProvider) ListFailedEvents(ctx context.Context, functionName string) ([]FailedEvent, error) {
+	replayer, ok := As[FailedEventReplayer](r.Provider)
+	if !ok {
+		return nil, unsupported("failed event replay", r.Provider)
+	}
+	return recordCall(r, callKey("ListFailedEvents", functionName), func() ([]FailedEvent, error) {
+		return replayer.ListFailedEvents(ctx, functionName)
+	})
+}
+
+func (r *RecordingProvider) ReplayFailedEvent(ctx context.Context, functionName string, event FailedEvent) error {
+	replayer, ok := As[FailedEventReplayer](r.Provider)
+	if !ok {
+		return unsupported("failed event replay", r.Provider)
+	}
+	return replayer.ReplayFailedEvent(ctx, functionName, event)
+}
+
+func (r *RecordingProvider) ListEventSourceMappings(ctx context.Context, functionName string) ([]EventSourceMapping, error) {
+	lister, ok := As[TriggerLister](r.Provider)
+	if !ok {
+		return nil, unsupported("listing triggers", r.Provider)
+	}
+	return recordCall(r, callKey("ListEventSourceMappings", functionName), func() ([]EventSourceMapping, error) {
+		return lister.ListEventSourceMappings(ctx, functionName)
+	})
+}
+
+func (r *RecordingProvider) ListSchedules(ctx context.Context, fn FunctionInfo) ([]ScheduledJob, error) {
+	lister, ok := As[ScheduleLister](r.Provider)
+	if !ok {
+		return nil, unsupported("listing schedules", r.Provider)
+	}
+	return recordCall(r, callKey("ListSchedules", fn.Name), func() ([]ScheduledJob, error) {
+		return lister.ListSchedules(ctx, fn)
+	})
+}
+
+func (r *RecordingProvider) GetTrafficShifts(ctx context.Context, fn FunctionInfo) ([]TrafficShift, error) {
+	reader, ok := As[TrafficShiftReader](r.Provider)
+	if !ok {
+		return nil, unsupported("reading gradual deployments", r.Provider)
+	}
+	return recordCall(r, callKey("GetTrafficShifts", fn.Name), func() ([]TrafficShift, error) {
+		return reader.GetTrafficShifts(ctx, fn)
+	})
+}
+
+func (r *RecordingProvider) ListDeployments(ctx context.Context, fn FunctionInfo) ([]Deployment, error) {
+	lister, ok := As[DeploymentLister](r.Provider)
+	if !ok {
+		return nil, unsupported("listing deployments", r.Provider)
+	}
+	return recordCall(r, callKey("ListDeployments", fn.Name), func() ([]Deployment, error) {
+		return lister.ListDeployments(ctx, fn)
+	})
+}
+
+func (r *RecordingProvider) GetAsyncInvokeConfig(ctx context.Context, functionName string) (*AsyncInvokeConfig, error) {
+	reader, ok := As[AsyncConfigReader](r.Provider)
+	if !ok {
+		return nil, unsupported("reading invocation destinations", r.Provider)
+	}
+	return recordCall(r, callKey("GetAsyncInvokeConfig", functionName), func() (*AsyncInvokeConfig, error) {
+		return reader.GetAsyncInvokeConfig(ctx, functionName)
+	})
+}
+
+func (r *RecordingProvider) UpdateEnvironment(ctx context.Context, name string, env, secrets map[string]string, revision string) error {
+	updater, ok := As[EnvironmentUpdater](r.Provider)
+	if !ok {
+		return unsupported("editing environment variables", r.Provider)
+	}
+	return updater.UpdateEnvironment(ctx, name, env, secrets, revision)
+}
+
+func (r *RecordingProvider) UpdateHandlerCode(ctx context.Context, name, source, revision string) error {
+	updater, ok := As[CodeUpdater](r.Provider)
+	if !ok {
+		return unsupported("editing code", r.Provider)
+	}
+	return updater.UpdateHandlerCode(ctx, name, source, revision)
+}
+
+func (r *RecordingProvider) UpdateLoggingConfig(ctx context.Context, name string, cfg LoggingConfig) error {
+	updater, ok := As[LoggingConfigUpdater](r.Provider)
+	if !ok {
+		return unsupported("changing log levels", r.Provider)
+	}
+	return updater.UpdateLoggingConfig(ctx, name, cfg)
+}
+
+func (r *RecordingProvider) GetLogRetention(ctx context.Context, fn FunctionInfo) (*LogRetention, error) {
+	manager, ok := As[LogRetentionManager](r.Provider)
+	if !ok {
+		return nil, unsupported("log retention", r.Provider)
+	}
+	return recordCall(r, callKey("GetLogRetention", fn.Name), func() (*LogRetention, error) {
+		return manager.GetLogRetention(ctx, fn)
+	})
+}
+
+func (r *RecordingProvider) SetLogRetention(ctx context.Context, fn FunctionInfo, days int32) error {
+	manager, ok := As[LogRetentionManager](r.Provider)
+	if !ok {
+		return unsupported("log retention", r.Provider)
+	}
+	return manager.SetLogRetention(ctx, fn, days)
+}
+
+func (r *RecordingProvider) ListProjects(ctx context.Context) ([]ProjectInfo, error) {
+	switcher, ok := As[ProjectSwitcher](r.Provider)
+	if !ok {
+		return nil, unsupported("switching projects", r.Provider)
+	}
+	return recordCall(r, callKey("ListProjects", ""), func() ([]ProjectInfo, error) {
+		return switcher.ListProjects(ctx)
+	})
+}
+
+// WithProject records the project switched to in the same recording, its
+// responses replacing those of the same calls in the previous project
+func (r *RecordingProvider) WithProject(projectID string) (Provider, error) {
+	switcher, ok := As[ProjectSwitcher](r.Provider)
+	if !ok {
+		return nil, unsupported("switching projects", r.Provider)
+	}
+	p, err := switcher.WithProject(projectID)
+	if err != nil {
+		return nil, err
+	}
+	return &RecordingProvider{Provider: p, recorder: r.recorder}, nil
+}
+
+func (r *RecordingProvider) UpdateDescription(ctx context.Context, name, description string) error {
+	updater, ok := As[DescriptionUpdater](r.Provider)
+	if !ok {
+		return unsupported("editing descriptions", r.Provider)
+	}
+	return updater.UpdateDescription(ctx, name, description)
+}
+
+func (r *RecordingProvider) GetConcurrency(ctx context.Context, functionName string, startTime, endTime time.Time) (*ConcurrencyInfo, error) {
+	reporter, ok := As[ConcurrencyReporter](r.Provider)
+	if !ok {
+		return nil, unsupported("concurrency metrics", r.Provider)
+	}
+	return recordCall(r, callKey("GetConcurrency", functionName), func() (*ConcurrencyInfo, error) {
+		return reporter.GetConcurrency(ctx, functionName, startTime, endTime)
+	})
+}
+
+func (r *RecordingProvider) GetEnhancedMetrics(ctx context.Context, functionName string, startTime, endTime time.Time) (*EnhancedMetrics, error) {
+	reporter, ok := As[EnhancedMetricsReporter](r.Provider)
+	if !ok {
+		return nil, unsupported("enhanced metrics", r.Provider)
+	}
+	return recordCall(r, callKey("GetEnhancedMetrics", functionName), func() (*EnhancedMetrics, error) {
+		return reporter.GetEnhancedMetrics(ctx, functionName, startTime, endTime)
+	})
+}
+
+func (r *RecordingProvider) AuditPosture(ctx context.Context, fn FunctionInfo) (*PostureReport, error) {
+	auditor, ok := As[PostureAuditor](r.Provider)
+	if !ok {
+		return nil, unsupported("security audits", r.Provider)
+	}
+	return recordCall(r, callKey("AuditPosture", fn.Name), func() (*PostureReport, error) {
+		return auditor.AuditPosture(ctx, fn)
+	})
+}
+
+func (r *RecordingProvider) CheckPermissions(ctx context.Context, fn FunctionInfo) (map[Action][]string, error) {
+	checker, ok := As[PermissionChecker](r.Provider)
+	if !ok {
+		return nil, unsupported("permission checks", r.Provider)
+	}
+	return recordCall(r, callKey("CheckPermissions", fn.Name), func() (map[Action][]string, error) {
+		return checker.CheckPermissions(ctx, fn)
+	})
+}
+
+func (r *RecordingProvider) References(ctx context.Context, fn FunctionInfo) ([]FunctionReference, error) {
+	lister, ok := As[ReferenceLister](r.Provider)
+	if !ok {
+		return nil, unsupported("copying references", r.Provider)
+	}
+	return recordCall(r, callKey("References", fn.Name), func() ([]FunctionReference, error) {
+		return lister.References(ctx, fn)
+	})
+}
+
+// ConsoleURL records the link so that a replay can show it
+func (r *RecordingProvider) ConsoleURL(fn FunctionInfo) string {
+	linker, ok := As[ConsoleLinker](r.Provider)
+	if !ok {
+		return ""
+	}
+	url := linker.ConsoleURL(fn)
+	r.save(callKey("ConsoleURL", fn.Name), url, nil)
+	return url
+}
+
+// errReadOnlyReplay is returned by changes made during a replay
+var errReadOnlyReplay = errors.New("not available when replaying a recording")
+
+// ReplayProvider serves the responses of a recording, without credentials
+// or network access. Calls that were not recorded fail, and it implements
+// none of the interfaces that only make changes.
+type ReplayProvider struct {
+	rec *Recording
+}
+
+// NewReplayProvider serves the responses of rec
+func NewReplayProvider(rec *Recording) *ReplayProvider {
+	return &ReplayProvider{rec: rec}
+}
+
+// replayCall decodes the response recorded under key
+func replayCall[T any](p *ReplayProvider, key string) (T, error) {
+	var value T
+	resp, ok := p.rec.Responses[key]
+	if !ok {
+		return value, fmt.Errorf("%s was not recorded", key)
+	}
+	if resp.Error != "" {
+		return value, errors.New(resp.Error)
+	}
+	if err := json.Unmarshal(resp.Value, &value); err != nil {
+		return value, fmt.Errorf("invalid recorded response for %s: %w", key, err)
+	}
+	return value, nil
+}
+
+func (p *ReplayProvider) GetProviderName() CloudProvider {
+	return p.rec.Provider
+}
+
+func (p *ReplayProvider) GetRegion() string {
+	return p.rec.Region
+}
+
+func (p *ReplayProvider) GetAccountID(ctx context.Context) (string, error) {
+	return replayCall[string](p, callKey("GetAccountID", ""))
+}
+
+func (p *ReplayProvider) ListFunctions(ctx context.Context) ([]FunctionInfo, error) {
+	return replayCall[[]FunctionInfo](p, callKey("ListFunctions", ""))
+}
+
+// GetFunction falls back to the listed details of functions that were not
+// opened while recording
+func (p *ReplayProvider) GetFunction(ctx context.Context, name string) (*FunctionInfo, error) {
+	if _, ok := p.rec.Responses[callKey("GetFunction", name)]; ok {
+		return replayCall[*FunctionInfo](p, callKey("GetFunction", name))
+	}
+	functions, err := p.ListFunctions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range functions {
+		if functions[i].Name == name {
+			return &functions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("function %s was not recorded", name)
+}
+
+func (p *ReplayProvider) GetFunctionCode(ctx context.Context, name string) (string, error) {
+	return replayCall[string](p, callKey("GetFunctionCode", name))
+}
+
+func (p *ReplayProvider) DownloadFunctionCode(ctx context.Context, name, destination string) error {
+	return fmt.Errorf("downloading code is %w", errReadOnlyReplay)
+}
+
+// GetFunctionLogs filters the recorded logs by the query's severity and
+// text. The time window is ignored: the recording is a snapshot.
+func (p *ReplayProvider) GetFunctionLogs(ctx context.Context, name string, query LogQuery) ([]LogEntry, error) {
+	recorded, err := replayCall[[]LogEntry](p, callKey("GetFunctionLogs", name))
+	if err != nil {
+		return nil, err
+	}
+	query.Since = time.Time{}
+	var entries []LogEntry
+	for _, entry := range recorded {
+		if query.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if query.Limit > 0 && len(entries) > query.Limit {
+		entries = entries[len(entries)-query.Limit:]
+	}
+	return entries, nil
+}
+
+// GetOlderFunctionLogs returns no entries: a recording holds no logs older
+// than those it fetched
+func (p *ReplayProvider) GetOlderFunctionLogs(ctx context.Context, name string, query LogQuery, before time.Time, token string) (*LogPage, error) {
+	return &LogPage{}, nil
+}
+
+// StreamFunctionLogs sends the recorded stream, then stays open until the
+// context is done, as a quiet live stream would
+func (p *ReplayProvider) StreamFunctionLogs(ctx context.Context, name string) (<-chan LogEntry, <-chan error) {
+	entries := make(chan LogEntry)
+	errs := make(chan error, 1)
+	recorded, err := replayCall[[]LogEntry](p, callKey("StreamFunctionLogs", name))
+	if err != nil {
+		// Fall back to the fetched logs of the function
+		recorded, err = replayCall[[]LogEntry](p, callKey("GetFunctionLogs", name))
+	}
+	go func() {
+		defer close(entries)
+		defer close(errs)
+		if err != nil {
+			errs <- err
+			return
+		}
+		for _, entry := range recorded {
+			select {
+			case entries <- entry:
+			case <-ctx.Done():
+				return
+			}
+		}
+		<-ctx.Done()
+	}()
+	return entries, errs
+}
+
+func (p *ReplayProvider) GetFunctionMetrics(ctx context.Context, name string, startTime, endTime time.Time) (*FunctionMetrics, error) {
+	return replayCall[*FunctionMetrics](p, callKey("GetFunctionMetrics", name))
+}
+
+func (p *ReplayProvider) GetEndpoints(ctx context.Context, name string) ([]string, error) {
+	return replayCall[[]string](p, callKey("GetEndpoints", name))
+}
+
+func (p *ReplayProvider) GetFunctionStats(ctx context.Context, names []string, since time.Time) (map[string]FunctionStats, error) {
+	return replayCall[map[string]FunctionStats](p, callKey("GetFunctionStats", ""))
+}
+
+func (p *ReplayProvider) GetFiringAlarms(ctx context.Context) (map[string][]string, error) {
+	return replayCall[map[string][]string](p, callKey("GetFiringAlarms", ""))
+}
+
+// Invoke returns the response recorded for the function, whatever the payload
+func (p *ReplayProvider) Invoke(ctx context.Context, functionName string, payload []byte) (*InvokeResult, error) {
+	return replayCall[*InvokeResult](p, callKey("Invoke", functionName))
+}
+
+func (p *ReplayProvider) ListFailedEvents(ctx context.Context, functionName string) ([]FailedEvent, error) {
+	return replayCall[[]FailedEvent](p, callKey("ListFailedEvents", functionName))
+}
+
+func (p *ReplayProvider) ReplayFailedEvent(ctx context.Context, functionName string, event FailedEvent) error {
+	return fmt.Errorf("replaying failed events is %w", errReadOnlyReplay)
+}
+
+func (p *ReplayProvider) ListEventSourceMappings(ctx context.Context, functionName string) ([]EventSourceMapping, error) {
+	return replayCall[[]EventSourceMapping](p, callKey("ListEventSourceMappings", functionName))
+}
+
+func (p *ReplayProvider) ListSchedules(ctx context.Context, fn FunctionInfo) ([]ScheduledJob, error) {
+	return replayCall[[]ScheduledJob](p, callKey("ListSchedules", fn.Name))
+}
+
+func (p *ReplayProvider) GetTrafficShifts(ctx context.Context, fn FunctionInfo) ([]TrafficShift, error) {
+	return replayCall[[]TrafficShift](p, callKey("GetTrafficShifts", fn.Name))
+}
+
+func (p *ReplayProvider) ListDeployments(ctx context.Context, fn FunctionInfo) ([]Deployment, error) {
+	return replayCall[[]Deployment](p, callKey("ListDeployments", fn.Name))
+}
+
+func (p *ReplayProvider) GetAsyncInvokeConfig(ctx context.Context, functionName string) (*AsyncInvokeConfig, error) {
+	return replayCall[*AsyncInvokeConfig](p, callKey("GetAsyncInvokeConfig", functionName))
+}
+
+func (p *ReplayProvider) GetLogRetention(ctx context.Context, fn FunctionInfo) (*LogRetention, error) {
+	return replayCall[*LogRetention](p, callKey("GetLogRetention", fn.Name))
+}
+
+func (p *ReplayProvider) SetLogRetention(ctx context.Context, fn FunctionInfo, days int32) error {
+	return fmt.Errorf("changing log retention is %w", errReadOnlyReplay)
+}
+
+func (p *ReplayProvider) ListProjects(ctx context.Context) ([]ProjectInfo, error) {
+	return replayCall[[]ProjectInfo](p, callKey("ListProjects", ""))
+}
+
+// WithProject fails: a replay serves the recorded project only
+func (p *ReplayProvider) WithProject(projectID string) (Provider, error) {
+	return nil, fmt.Errorf("switching projects is %w", errReadOnlyReplay)
+}
+
+func (p *ReplayProvider) GetConcurrency(ctx context.Context, functionName string, startTime, endTime time.Time) (*ConcurrencyInfo, error) {
+	return replayCall[*ConcurrencyInfo](p, callKey("GetConcurrency", functionName))
+}
+
+func (p *ReplayProvider) GetEnhancedMetrics(ctx context.Context, functionName string, startTime, endTime time.Time) (*EnhancedMetrics, error) {
+	return replayCall[*EnhancedMetrics](p, callKey("GetEnhancedMetrics", functionName))
+}
+
+func (p *ReplayProvider) AuditPosture(ctx context.Context, fn FunctionInfo) (*PostureReport, error) {
+	return replayCall[*PostureReport](p, callKey("AuditPosture", fn.Name))
+}
+
+func (p *ReplayProvider) CheckPermissions(ctx context.Context, fn FunctionInfo) (map[Action][]string, error) {
+	return replayCall[map[Action][]string](p, callKey("CheckPermissions", fn.Name))
+}
+
+func (p *ReplayProvider) References(ctx context.Context, fn FunctionInfo) ([]FunctionReference, error) {
+	return replayCall[[]FunctionReference](p, callKey("References", fn.Name))
+}
+
+func (p *ReplayProvider) ConsoleURL(fn FunctionInfo) string {
+	url, _ := replayCall[string](p, callKey("ConsoleURL", fn.Name))
+	return url
+}
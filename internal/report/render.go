@@ -0,0 +1,249 @@
+package report
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Formats lists the supported output formats
+var Formats = []string{"markdown", "html"}
+
+var funcs = map[string]any{
+	"upper":  strings.ToUpper,
+	"usd":    func(v float64) string { return fmt.Sprintf("$%.2f", v) },
+	"pct":    func(v float64) string { return fmt.Sprintf("%.2f%%", v*100) },
+	"num":    func(v float64) string { return fmt.Sprintf("%.0f", v) },
+	"ms":     func(v float64) string { return fmt.Sprintf("%.0f ms", v) },
+	"date":   func(t time.Time) string { return t.Format("2006-01-02") },
+	"time":   func(t time.Time) string { return t.Format("2006-01-02 15:04 MST") },
+	"window": formatWindow,
+	"cell":   mdCell,
+}
+
+// formatWindow renders a window as e.g. "24h" or "7d"
+func formatWindow(d time.Duration) string {
+	if d >= 24*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return d.String()
+}
+
+// mdCell makes a value safe for a Markdown table cell
+func mdCell(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// CheckFormat returns an error for an unsupported output format
+func CheckFormat(format string) error {
+	switch strings.ToLower(format) {
+	case "markdown", "md", "html":
+		return nil
+	}
+	return fmt.Errorf("unknown report format %q (expected %s)", format, strings.Join(Formats, " or "))
+}
+
+// Render writes the report in the given format
+func Render(w io.Writer, r *Report, format string) error {
+	if err := CheckFormat(format); err != nil {
+		return err
+	}
+	if strings.EqualFold(format, "html") {
+		return htmlTemplate.Execute(w, r)
+	}
+	return markdownTemplate.Execute(w, r)
+}
+
+var markdownTemplate = template.Must(template.New("markdown").Funcs(funcs).Parse(`# Serverless Function Report
+
+| | |
+|---|---|
+| Provider | {{upper .Provider}} |
+| Account | {{.Account}} |
+| Region | {{.Region}} |
+| Generated | {{time .GeneratedAt}} |
+| Window | last {{window .Window}} |
+
+## Summary
+
+- **Functions:** {{len .Functions}}
+- **Invocations:** {{num .Invocations}} ({{num .ErrorCount}} errors)
+- **Estimated cost:** {{usd .CostUSD}} over the last {{window .Window}}, about {{usd .MonthlyCostUSD}} per month
+{{- if .StatsError}}
+- **Note:** invocation stats are unavailable: {{.StatsError}}
+{{- end}}
+
+Costs are on-demand compute and request estimates; they exclude free tier,
+provisioned concurrency, data transfer and other services.
+
+## Runtimes
+
+| Runtime | Functions |
+|---|---:|
+{{- range .Runtimes}}
+| {{cell .Runtime}} | {{.Count}} |
+{{- end}}
+
+## Deprecations
+{{if .Deprecations}}
+| Function | Runtime | Deprecation |
+|---|---|---|
+{{- range .Deprecations}}
+| {{cell .Function}} | {{cell .Runtime}} | {{if .Deprecated}}deprecated since {{date .Date}}{{else}}deprecated on {{date .Date}}{{end}} |
+{{- end}}
+{{else}}
+No functions run on deprecated runtimes.
+{{end}}
+## Functions
+
+| Function | Runtime | Memory | Timeout | State | Invocations | Errors | Error rate | p99 | Est. cost |
+|---|---|---:|---:|---|---:|---:|---:|---:|---:|
+{{- range .Functions}}
+| {{cell .Name}} | {{cell .Runtime}} | {{.Memory}} MB | {{.Timeout}} s | {{cell .State}} | {{num .Invocations}} | {{num .Errors}} | {{pct .ErrorRate}} | {{ms .P99Ms}} | {{usd .CostUSD}} |
+{{- end}}
+
+## Errors
+{{if .Errors}}{{range .Errors}}
+### {{.Function}}
+
+{{num .Errors}} errors ({{pct .ErrorRate}} of invocations)
+{{if .LogsError}}
+Error logs unavailable: {{.LogsError}}
+{{else if .Groups}}
+| Count | Last seen | Error |
+|---:|---|---|
+{{- range .Groups}}
+| {{.Count}} | {{time .LastSeen}} | {{cell .Signature}} |
+{{- end}}
+{{else}}
+No error log entries found.
+{{end}}{{end}}{{else if .StatsError}}
+Error summary unavailable without invocation stats.
+{{else}}
+No errors in the last {{window .Window}}.
+{{end}}
+## Right-sizing
+{{if .RightSizing}}
+Memory recommendations from the memory used by recent invocations, with costs
+projected to a month. Lowering memory could save about {{usd .SavingsUSD}} per month,
+assuming durations stay the same: memory also sets the CPU share.
+
+| Function | Memory | p95 used | Max used | Invocations/month | Avg duration | Recommended | Cost/month | Savings/month |
+|---|---:|---:|---:|---:|---:|---|---:|---:|
+{{- range .RightSizing}}
+| {{cell .Function}} | {{.Memory.ConfiguredMB}} MB | {{num .Memory.P95UsedMB}} MB | {{num .Memory.MaxUsedMB}} MB | {{num .MonthlyInvocations}} | {{ms .AvgDurationMs}} | {{if .Memory.SuggestedMB}}{{.Memory.SuggestedMB}} MB ({{.Memory.Provisioning}}){{else}}keep{{end}} | {{usd .MonthlyCostUSD}} | {{usd .MonthlySavingsUSD}} |
+{{- end}}
+{{else}}
+No recommendations: they need invoked functions with Lambda REPORT log lines in the last {{window .Window}}.
+{{end}}`))
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("html").Funcs(funcs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Serverless Function Report - {{upper .Provider}} {{.Region}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2328; }
+h1, h2 { color: #07646b; }
+table { border-collapse: collapse; margin: 1rem 0; }
+th, td { border: 1px solid #d0d7de; padding: 0.3rem 0.6rem; text-align: left; }
+th { background: #f6f8fa; }
+td.num { text-align: right; font-variant-numeric: tabular-nums; }
+.warn { color: #9a6700; }
+.crit { color: #cf222e; }
+.note { color: #656d76; font-size: 0.9em; }
+</style>
+</head>
+<body>
+<h1>Serverless Function Report</h1>
+<table>
+<tr><th>Provider</th><td>{{upper .Provider}}</td></tr>
+<tr><th>Account</th><td>{{.Account}}</td></tr>
+<tr><th>Region</th><td>{{.Region}}</td></tr>
+<tr><th>Generated</th><td>{{time .GeneratedAt}}</td></tr>
+<tr><th>Window</th><td>last {{window .Window}}</td></tr>
+</table>
+
+<h2>Summary</h2>
+<ul>
+<li><strong>Functions:</strong> {{len .Functions}}</li>
+<li><strong>Invocations:</strong> {{num .Invocations}} ({{num .ErrorCount}} errors)</li>
+<li><strong>Estimated cost:</strong> {{usd .CostUSD}} over the last {{window .Window}}, about {{usd .MonthlyCostUSD}} per month</li>
+{{- if .StatsError}}
+<li class="warn"><strong>Note:</strong> invocation stats are unavailable: {{.StatsError}}</li>
+{{- end}}
+</ul>
+<p class="note">Costs are on-demand compute and request estimates; they exclude free tier, provisioned concurrency, data transfer and other services.</p>
+
+<h2>Runtimes</h2>
+<table>
+<tr><th>Runtime</th><th>Functions</th></tr>
+{{- range .Runtimes}}
+<tr><td>{{.Runtime}}</td><td class="num">{{.Count}}</td></tr>
+{{- end}}
+</table>
+
+<h2>Deprecations</h2>
+{{- if .Deprecations}}
+<table>
+<tr><th>Function</th><th>Runtime</th><th>Deprecation</th></tr>
+{{- range .Deprecations}}
+<tr><td>{{.Function}}</td><td>{{.Runtime}}</td>{{if .Deprecated}}<td class="crit">deprecated since {{date .Date}}</td>{{else}}<td class="warn">deprecated on {{date .Date}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No functions run on deprecated runtimes.</p>
+{{- end}}
+
+<h2>Functions</h2>
+<table>
+<tr><th>Function</th><th>Runtime</th><th>Memory</th><th>Timeout</th><th>State</th><th>Invocations</th><th>Errors</th><th>Error rate</th><th>p99</th><th>Est. cost</th></tr>
+{{- range .Functions}}
+<tr><td>{{.Name}}</td><td>{{.Runtime}}</td><td class="num">{{.Memory}} MB</td><td class="num">{{.Timeout}} s</td><td>{{.State}}</td><td class="num">{{num .Invocations}}</td><td class="num">{{num .Errors}}</td><td class="num">{{pct .ErrorRate}}</td><td class="num">{{ms .P99Ms}}</td><td class="num">{{usd .CostUSD}}</td></tr>
+{{- end}}
+</table>
+
+<h2>Errors</h2>
+{{- if .Errors}}
+{{- range .Errors}}
+<h3>{{.Function}}</h3>
+<p>{{num .Errors}} errors ({{pct .ErrorRate}} of invocations)</p>
+{{- if .LogsError}}
+<p class="warn">Error logs unavailable: {{.LogsError}}</p>
+{{- else if .Groups}}
+<table>
+<tr><th>Count</th><th>Last seen</th><th>Error</th></tr>
+{{- range .Groups}}
+<tr><td class="num">{{.Count}}</td><td>{{time .LastSeen}}</td><td><code>{{.Signature}}</code></td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No error log entries found.</p>
+{{- end}}
+{{- end}}
+{{- else if .StatsError}}
+<p class="warn">Error summary unavailable without invocation stats.</p>
+{{- else}}
+<p>No errors in the last {{window .Window}}.</p>
+{{- end}}
+
+<h2>Right-sizing</h2>
+{{- if .RightSizing}}
+<p>Memory recommendations from the memory used by recent invocations, with costs projected to a month. Lowering memory could save about <strong>{{usd .SavingsUSD}}</strong> per month.</p>
+<table>
+<tr><th>Function</th><th>Memory</th><th>p95 used</th><th>Max used</th><th>Invocations/month</th><th>Avg duration</th><th>Recommended</th><th>Cost/month</th><th>Savings/month</th></tr>
+{{- range .RightSizing}}
+<tr><td>{{.Function}}</td><td class="num">{{.Memory.ConfiguredMB}} MB</td><td class="num">{{num .Memory.P95UsedMB}} MB</td><td class="num">{{num .Memory.MaxUsedMB}} MB</td><td class="num">{{num .MonthlyInvocations}}</td><td class="num">{{ms .AvgDurationMs}}</td>{{if eq .Memory.Provisioning "under-provisioned"}}<td class="crit">{{.Memory.SuggestedMB}} MB (under-provisioned)</td>{{else if .Memory.SuggestedMB}}<td>{{.Memory.SuggestedMB}} MB</td>{{else}}<td>keep</td>{{end}}<td class="num">{{usd .MonthlyCostUSD}}</td><td class="num">{{usd .MonthlySavingsUSD}}</td></tr>
+{{- end}}
+</table>
+<p class="note">Savings assume durations stay the same: memory also sets the CPU share, so check CPU-bound functions after lowering it.</p>
+{{- else}}
+<p>No recommendations: they need invoked functions with Lambda REPORT log lines in the last {{window .Window}}.</p>
+{{- end}}
+</body>
+</html>
+`))
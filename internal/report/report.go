@@ -0,0 +1,180 @@
+// Package report builds inventory reports of a provider's functions for
+// sharing with a team or an auditor.
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"f6n/internal/insights"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+)
+
+const (
+	// errorFunctions is the number of functions whose errors are summarized
+	errorFunctions = 5
+	// errorGroupsPerFunction is the number of error groups shown per function
+	errorGroupsPerFunction = 3
+	// errorLogLimit caps the error log entries read per function
+	errorLogLimit = 500
+)
+
+// Report is the data behind a rendered report
+type Report struct {
+	GeneratedAt    time.Time
+	Provider       string
+	Region         string
+	Account        string
+	Window         time.Duration
+	Functions      []Function
+	Runtimes       []RuntimeCount
+	Deprecations   []Deprecation
+	Errors         []FunctionErrors
+	RightSizing    []insights.RightSizing
+	Invocations    float64
+	ErrorCount     float64
+	CostUSD        float64 // estimated cost over Window
+	MonthlyCostUSD float64 // CostUSD projected to 30 days
+	SavingsUSD     float64 // monthly savings of the memory reductions in RightSizing
+	StatsError     string  // why invocation stats are missing, if they are
+}
+
+// Function is a row of the inventory
+type Function struct {
+	provider.FunctionInfo
+	Invocations float64
+	Errors      float64
+	ErrorRate   float64
+	P99Ms       float64
+	CostUSD     float64
+}
+
+// RuntimeCount is the number of functions on a runtime
+type RuntimeCount struct {
+	Runtime string
+	Count   int
+}
+
+// Deprecation is a function on a deprecated or soon deprecated runtime
+type Deprecation struct {
+	Function string
+	insights.RuntimeDeprecation
+}
+
+// FunctionErrors summarizes the recent errors of a function
+type FunctionErrors struct {
+	Function  string
+	Errors    float64
+	ErrorRate float64
+	Groups    []insights.ErrorGroup
+	LogsError string // why the error logs couldn't be read, if they couldn't
+}
+
+// Build collects the report data for the functions of a provider over window
+func Build(ctx context.Context, prov provider.Provider, window time.Duration) (*Report, error) {
+	functions, err := prov.ListFunctions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list functions: %w", err)
+	}
+
+	now := time.Now()
+	r := &Report{
+		GeneratedAt: now,
+		Provider:    string(prov.GetProviderName()),
+		Region:      prov.GetRegion(),
+		Window:      window,
+	}
+	if account, err := prov.GetAccountID(ctx); err == nil {
+		r.Account = account
+	}
+
+	stats, err := insights.CollectStats(ctx, prov, functions, now.Add(-window))
+	if err != nil {
+		logger.Logger.Printf("Report: error fetching function stats: %v", err)
+		r.StatsError = err.Error()
+	}
+
+	runtimes := map[string]int{}
+	for _, fn := range functions {
+		s := stats[fn.Name]
+		row := Function{
+			FunctionInfo: fn,
+			Invocations:  s.Invocations,
+			Errors:       s.Errors,
+			ErrorRate:    s.ErrorRate(),
+			P99Ms:        s.P99DurationMs,
+			CostUSD:      insights.EstimateCost(s.Invocations, s.TotalDurationMs, fn.Memory),
+		}
+		r.Functions = append(r.Functions, row)
+		r.Invocations += row.Invocations
+		r.ErrorCount += row.Errors
+		r.CostUSD += row.CostUSD
+
+		runtimes[fn.Runtime]++
+		if dep, ok := insights.CheckRuntime(fn.Runtime, now); ok {
+			r.Deprecations = append(r.Deprecations, Deprecation{Function: fn.Name, RuntimeDeprecation: dep})
+		}
+	}
+	if window > 0 {
+		r.MonthlyCostUSD = r.CostUSD * float64(30*24*time.Hour) / float64(window)
+	}
+
+	sort.Slice(r.Functions, func(i, j int) bool { return r.Functions[i].Name < r.Functions[j].Name })
+	for runtime, count := range runtimes {
+		r.Runtimes = append(r.Runtimes, RuntimeCount{Runtime: runtime, Count: count})
+	}
+	sort.Slice(r.Runtimes, func(i, j int) bool {
+		if r.Runtimes[i].Count != r.Runtimes[j].Count {
+			return r.Runtimes[i].Count > r.Runtimes[j].Count
+		}
+		return r.Runtimes[i].Runtime < r.Runtimes[j].Runtime
+	})
+	sort.Slice(r.Deprecations, func(i, j int) bool { return r.Deprecations[i].Function < r.Deprecations[j].Function })
+
+	r.Errors = collectErrors(ctx, prov, r.Functions, now.Add(-window))
+	r.RightSizing = insights.CollectRightSizing(ctx, prov, functions, stats, window)
+	for _, rs := range r.RightSizing {
+		if rs.Memory.Provisioning == insights.OverProvisioned {
+			r.SavingsUSD += rs.MonthlySavingsUSD()
+		}
+	}
+	return r, nil
+}
+
+// collectErrors groups the error logs of the functions with the most errors
+func collectErrors(ctx context.Context, prov provider.Provider, functions []Function, since time.Time) []FunctionErrors {
+	failing := make([]Function, 0, len(functions))
+	for _, fn := range functions {
+		if fn.Errors > 0 {
+			failing = append(failing, fn)
+		}
+	}
+	sort.Slice(failing, func(i, j int) bool { return failing[i].Errors > failing[j].Errors })
+	if len(failing) > errorFunctions {
+		failing = failing[:errorFunctions]
+	}
+
+	summaries := make([]FunctionErrors, 0, len(failing))
+	for _, fn := range failing {
+		summary := FunctionErrors{Function: fn.Name, Errors: fn.Errors, ErrorRate: fn.ErrorRate}
+		entries, err := prov.GetFunctionLogs(ctx, fn.Name, provider.LogQuery{
+			Limit:       errorLogLimit,
+			Since:       since,
+			MinSeverity: "ERROR",
+		})
+		if err != nil {
+			logger.Logger.Printf("Report: error reading error logs for %s: %v", fn.Name, err)
+			summary.LogsError = err.Error()
+		} else {
+			summary.Groups = insights.GroupErrors(entries)
+			if len(summary.Groups) > errorGroupsPerFunction {
+				summary.Groups = summary.Groups[:errorGroupsPerFunction]
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
@@ -0,0 +1,257 @@
+// Package secrets detects credentials left in function code and environment
+// variables using lightweight patterns and an entropy heuristic.
+package secrets
+
+import (
+	"bufio"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Severity ranks a finding, ordered from least to most severe
+type Severity int
+
+const (
+	SeverityLow Severity = iota
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+// String returns the label of a severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityCritical:
+		return "CRITICAL"
+	case SeverityHigh:
+		return "HIGH"
+	case SeverityMedium:
+		return "MEDIUM"
+	default:
+		return "LOW"
+	}
+}
+
+// Finding is a suspected secret
+type Finding struct {
+	Severity Severity
+	Rule     string
+	Location string // "path:line" for code, "env:NAME" for environment variables
+	Match    string // Redacted excerpt of the secret
+}
+
+// rule is a secret pattern; the secret is the last submatch, or the whole match
+type rule struct {
+	name     string
+	severity Severity
+	pattern  *regexp.Regexp
+}
+
+var rules = []rule{
+	{"Private key", SeverityCritical, regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----`)},
+	{"AWS secret access key", SeverityCritical, regexp.MustCompile(`(?i)aws.{0,20}(?:secret|private).{0,20}['"=:\s]([A-Za-z0-9/+]{40})(?:[^A-Za-z0-9/+]|$)`)},
+	{"AWS access key ID", SeverityHigh, regexp.MustCompile(`\b((?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA)[A-Z0-9]{16})\b`)},
+	{"GCP service account key", SeverityCritical, regexp.MustCompile(`"type"\s*:\s*"service_account"`)},
+	{"Google API key", SeverityHigh, regexp.MustCompile(`\b(AIza[0-9A-Za-z_-]{35})\b`)},
+	{"GitHub token", SeverityHigh, regexp.MustCompile(`\b((?:ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36}|github_pat_[A-Za-z0-9_]{82})\b`)},
+	{"Slack token", SeverityHigh, regexp.MustCompile(`\b(xox[abprs]-[A-Za-z0-9-]{10,})`)},
+	{"Stripe secret key", SeverityHigh, regexp.MustCompile(`\b((?:sk|rk)_live_[A-Za-z0-9]{20,})\b`)},
+	{"Password in URL", SeverityHigh, regexp.MustCompile(`[a-z][a-z0-9+.-]*://[^/\s:@]+:([^/\s:@]{3,})@`)},
+	{"Hardcoded credential", SeverityMedium, regexp.MustCompile(`(?i)(?:password|passwd|secret|api_?key|access_?token|auth_?token)\w*\s*[:=]\s*['"]([^'"\s]{8,})['"]`)},
+}
+
+const (
+	// minEntropyLength and minEntropy flag random-looking tokens
+	minEntropyLength = 20
+	minEntropy       = 4.5
+	// maxFileSize skips files too large to be hand-written source
+	maxFileSize = 1024 * 1024
+)
+
+var tokenPattern = regexp.MustCompile(`[A-Za-z0-9+/=_-]{20,}`)
+
+// sensitiveName matches environment variable names that suggest a secret
+var sensitiveName = regexp.MustCompile(`(?i)(password|passwd|secret|token|api_?key|private_?key|credential)`)
+
+// skipDirs hold installed packages rather than the function's own code
+var skipDirs = map[string]bool{
+	"node_modules":  true,
+	"vendor":        true,
+	".git":          true,
+	"__pycache__":   true,
+	"site-packages": true,
+}
+
+// skipFiles are generated files full of hashes that look random
+var skipFiles = map[string]bool{
+	"package-lock.json": true,
+	"yarn.lock":         true,
+	"pnpm-lock.yaml":    true,
+	"go.sum":            true,
+	"poetry.lock":       true,
+	"Pipfile.lock":      true,
+}
+
+// ScanEnvironment checks environment variable values. A value under a name
+// that suggests a secret is reported even if it matches no pattern, since it
+// is stored in plain text in the function configuration.
+func ScanEnvironment(env map[string]string) []Finding {
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var findings []Finding
+	for _, name := range names {
+		value := env[name]
+		location := "env:" + name
+		found := scanLine(value, location)
+		if len(found) == 0 && value != "" && IsSensitiveName(name) && !isReference(value) {
+			found = append(found, Finding{Severity: SeverityMedium, Rule: "Plain-text secret in environment", Location: location, Match: redact(value)})
+		}
+		findings = append(findings, found...)
+	}
+	sortFindings(findings)
+	return findings
+}
+
+// IsSensitiveName reports whether an environment variable name suggests that
+// it holds a secret
+func IsSensitiveName(name string) bool {
+	return sensitiveName.MatchString(name)
+}
+
+// isReference reports whether a value points to a secret store instead of
+// holding the secret
+func isReference(value string) bool {
+	for _, prefix := range []string{"arn:aws:secretsmanager:", "arn:aws:ssm:", "projects/", "{{resolve:", "ssm:", "/"} {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScanDir checks the text files under dir
+func ScanDir(dir string) ([]Finding, error) {
+	var findings []Finding
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if skipFiles[d.Name()] || strings.HasSuffix(d.Name(), ".min.js") || strings.HasSuffix(d.Name(), ".map") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.Size() > maxFileSize {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return nil
+		}
+		defer f.Close()
+
+		rel, _ := filepath.Rel(dir, path)
+		rel = filepath.ToSlash(rel)
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxFileSize)
+		for line := 1; scanner.Scan(); line++ {
+			text := scanner.Text()
+			if strings.ContainsRune(text, 0) {
+				// Binary file
+				return nil
+			}
+			findings = append(findings, scanLine(text, rel+":"+strconv.Itoa(line))...)
+		}
+		return nil
+	})
+	sortFindings(findings)
+	return findings, err
+}
+
+// scanLine applies the rules to a line, falling back to the entropy check
+func scanLine(text, location string) []Finding {
+	var findings []Finding
+	seen := make(map[string]bool)
+	for _, r := range rules {
+		match := r.pattern.FindStringSubmatch(text)
+		// Rules are ordered by severity, so a secret is reported by the first one
+		if match == nil || seen[match[len(match)-1]] {
+			continue
+		}
+		seen[match[len(match)-1]] = true
+		findings = append(findings, Finding{Severity: r.severity, Rule: r.name, Location: location, Match: redact(match[len(match)-1])})
+	}
+	if len(findings) > 0 {
+		return findings
+	}
+
+	for _, token := range tokenPattern.FindAllString(text, -1) {
+		if len(token) >= minEntropyLength && entropy(token) >= minEntropy && hasMixedClasses(token) {
+			findings = append(findings, Finding{Severity: SeverityLow, Rule: "High-entropy string", Location: location, Match: redact(token)})
+			break
+		}
+	}
+	return findings
+}
+
+// entropy returns the Shannon entropy of s in bits per character
+func entropy(s string) float64 {
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+	var h float64
+	n := float64(len(s))
+	for _, c := range counts {
+		p := float64(c) / n
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// hasMixedClasses filters out identifiers and paths by requiring upper case,
+// lower case and digits
+func hasMixedClasses(s string) bool {
+	var upper, lower, digit bool
+	for _, r := range s {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= '0' && r <= '9':
+			digit = true
+		}
+	}
+	return upper && lower && digit
+}
+
+// redact keeps enough of a secret to recognise it
+func redact(s string) string {
+	if len(s) <= 8 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:4] + strings.Repeat("*", min(len(s)-4, 12))
+}
+
+// sortFindings orders findings by severity, keeping their scan order otherwise
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity > findings[j].Severity
+	})
+}
@@ -0,0 +1,202 @@
+// Package server exposes the provider operations over an HTTP/JSON API so
+// other tools can use f6n's multi-cloud abstraction without the TUI.
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+)
+
+const (
+	// defaultLogLimit and defaultMetricsWindow apply when a request doesn't set them
+	defaultLogLimit      = 100
+	defaultMetricsWindow = time.Hour
+	// maxPayloadBytes caps invoke payloads, matching Lambda's synchronous limit
+	maxPayloadBytes = 6 << 20
+)
+
+// Server serves the provider API
+type Server struct {
+	provider provider.Provider
+	token    string // bearer token required on every request but /healthz
+	mux      *http.ServeMux
+}
+
+// New creates an API server for a provider. A token is required: the API
+// reads environment values and invokes functions with f6n's credentials, and
+// a browser can reach a server bound to localhost.
+func New(prov provider.Provider, token string) (*Server, error) {
+	if strings.TrimSpace(token) == "" {
+		return nil, errors.New("the API requires a bearer token: set --api-token or F6N_API_TOKEN")
+	}
+	s := &Server{provider: prov, token: token, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+	s.mux.HandleFunc("GET /api/v1/functions", s.handleListFunctions)
+	s.mux.HandleFunc("GET /api/v1/functions/{name}", s.handleGetFunction)
+	s.mux.HandleFunc("GET /api/v1/functions/{name}/logs", s.handleLogs)
+	s.mux.HandleFunc("GET /api/v1/functions/{name}/metrics", s.handleMetrics)
+	s.mux.HandleFunc("POST /api/v1/functions/{name}/invoke", s.handleInvoke)
+	return s, nil
+}
+
+// ServeHTTP authenticates and logs requests before routing them
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	if r.URL.Path != "/healthz" {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+			return
+		}
+	}
+	s.mux.ServeHTTP(w, r)
+	logger.Logger.Printf("API %s %s (%s)", r.Method, r.URL.Path, time.Since(start).Round(time.Millisecond))
+}
+
+// ListenAndServe serves on addr until ctx is cancelled
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	srv := &http.Server{Addr: addr, Handler: s, ReadHeaderTimeout: 10 * time.Second}
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe() }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"status":   "ok",
+		"provider": string(s.provider.GetProviderName()),
+		"region":   s.provider.GetRegion(),
+	})
+}
+
+func (s *Server) handleListFunctions(w http.ResponseWriter, r *http.Request) {
+	functions, err := s.provider.ListFunctions(r.Context())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"functions": functions})
+}
+
+func (s *Server) handleGetFunction(w http.ResponseWriter, r *http.Request) {
+	fn, err := s.provider.GetFunction(r.Context(), r.PathValue("name"))
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, fn)
+}
+
+// handleLogs accepts limit, since (e.g. 30m), severity and text query parameters
+func (s *Server) handleLogs(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := provider.LogQuery{
+		Limit:       defaultLogLimit,
+		MinSeverity: strings.ToUpper(q.Get("severity")),
+		Text:        q.Get("text"),
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q", v))
+			return
+		}
+		query.Limit = limit
+	}
+	if v := q.Get("since"); v != "" {
+		since, err := time.ParseDuration(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid since %q (use e.g. 30m or 6h)", v))
+			return
+		}
+		query.Since = time.Now().Add(-since)
+	}
+
+	entries, err := s.provider.GetFunctionLogs(r.Context(), r.PathValue("name"), query)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"entries": entries})
+}
+
+// handleMetrics accepts a since query parameter (default 1h)
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	window := defaultMetricsWindow
+	if v := r.URL.Query().Get("since"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid since %q (use e.g. 1h)", v))
+			return
+		}
+		window = d
+	}
+
+	end := time.Now()
+	metrics, err := s.provider.GetFunctionMetrics(r.Context(), r.PathValue("name"), end.Add(-window), end)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, metrics)
+}
+
+// handleInvoke invokes the function with the request body as payload
+func (s *Server) handleInvoke(w http.ResponseWriter, r *http.Request) {
+	invoker, ok := provider.As[provider.Invoker](s.provider)
+	if !ok {
+		writeError(w, http.StatusNotImplemented, fmt.Errorf("invoke is not supported for %s", s.provider.GetProviderName()))
+		return
+	}
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadBytes+1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("failed to read payload: %w", err))
+		return
+	}
+	if len(payload) > maxPayloadBytes {
+		writeError(w, http.StatusRequestEntityTooLarge, errors.New("payload exceeds 6 MB"))
+		return
+	}
+	if len(payload) == 0 {
+		payload = []byte("{}")
+	}
+
+	result, err := invoker.Invoke(r.Context(), r.PathValue("name"), payload)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Logger.Printf("Error encoding API response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"f6n/internal/provider"
+)
+
+func TestNewRequiresToken(t *testing.T) {
+	for _, token := range []string{"", "  "} {
+		if _, err := New(provider.NewDemoProvider(), token); err == nil {
+			t.Errorf("New with token %q succeeded, want an error", token)
+		}
+	}
+}
+
+func TestServeHTTPChecksToken(t *testing.T) {
+	s, err := New(provider.NewDemoProvider(), "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		want          int
+	}{
+		{"health without token", "/healthz", "", http.StatusOK},
+		{"no token", "/api/v1/functions", "", http.StatusUnauthorized},
+		{"wrong token", "/api/v1/functions", "Bearer guess", http.StatusUnauthorized},
+		{"token prefix", "/api/v1/functions", "Bearer secre", http.StatusUnauthorized},
+		{"other scheme", "/api/v1/functions", "Basic secret", http.StatusUnauthorized},
+		{"bare token", "/api/v1/functions", "secret", http.StatusUnauthorized},
+		{"token", "/api/v1/functions", "Bearer secret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
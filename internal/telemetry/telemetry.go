@@ -0,0 +1,135 @@
+// Package telemetry exports what f6n observes to an OTLP endpoint: function
+// metrics as gauges and cloud API calls as spans.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"f6n/internal/provider"
+	"f6n/internal/version"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// meterName is the instrumentation scope of f6n's metrics
+const meterName = "f6n"
+
+// Setup installs OTLP/HTTP trace and metric exporters for the given base
+// endpoint (e.g. http://localhost:4318). With an empty endpoint nothing is
+// exported. The returned function flushes and stops the exporters.
+func Setup(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q (expected e.g. http://localhost:4318)", endpoint)
+	}
+	basePath := strings.TrimRight(u.Path, "/")
+
+	traceOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host), otlptracehttp.WithURLPath(basePath + "/v1/traces")}
+	metricOpts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpoint(u.Host), otlpmetrichttp.WithURLPath(basePath + "/v1/metrics")}
+	if u.Scheme != "https" {
+		traceOpts = append(traceOpts, otlptracehttp.WithInsecure())
+		metricOpts = append(metricOpts, otlpmetrichttp.WithInsecure())
+	}
+
+	traceExporter, err := otlptracehttp.New(ctx, traceOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	metricExporter, err := otlpmetrichttp.New(ctx, metricOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+	}
+
+	res := resource.NewSchemaless(
+		semconv.ServiceName("f6n"),
+		semconv.ServiceVersion(version.Version),
+	)
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(traceExporter), sdktrace.WithResource(res))
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)), sdkmetric.WithResource(res))
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetMeterProvider(meterProvider)
+
+	return func(ctx context.Context) error {
+		return errors.Join(tracerProvider.Shutdown(ctx), meterProvider.Shutdown(ctx))
+	}, nil
+}
+
+// gauges are created lazily so they bind to the provider installed by Setup
+type gauges struct {
+	invocations metric.Float64Gauge
+	errors      metric.Float64Gauge
+	durationMax metric.Float64Gauge
+	durationP99 metric.Float64Gauge
+	duration    metric.Float64Gauge
+	throttles   metric.Float64Gauge
+	concurrency metric.Float64Gauge
+}
+
+func newGauges() gauges {
+	meter := otel.Meter(meterName)
+	g := gauges{}
+	g.invocations, _ = meter.Float64Gauge("f6n.function.invocations", metric.WithDescription("Invocations in the observed window"))
+	g.errors, _ = meter.Float64Gauge("f6n.function.errors", metric.WithDescription("Errors in the observed window"))
+	g.durationMax, _ = meter.Float64Gauge("f6n.function.duration.max", metric.WithUnit("ms"), metric.WithDescription("Maximum invocation duration"))
+	g.durationP99, _ = meter.Float64Gauge("f6n.function.duration.p99", metric.WithUnit("ms"), metric.WithDescription("p99 invocation duration"))
+	g.duration, _ = meter.Float64Gauge("f6n.function.duration", metric.WithUnit("ms"), metric.WithDescription("Latest average invocation duration"))
+	g.throttles, _ = meter.Float64Gauge("f6n.function.throttles", metric.WithDescription("Latest throttle count"))
+	g.concurrency, _ = meter.Float64Gauge("f6n.function.concurrent_executions", metric.WithDescription("Latest concurrent executions"))
+	return g
+}
+
+func functionAttributes(cloud provider.CloudProvider, functionName string) metric.MeasurementOption {
+	return metric.WithAttributes(
+		attribute.String("cloud.provider", string(cloud)),
+		attribute.String("faas.name", functionName),
+	)
+}
+
+// RecordFunctionStats records the aggregate stats of functions as gauges
+func RecordFunctionStats(ctx context.Context, cloud provider.CloudProvider, stats map[string]provider.FunctionStats) {
+	g := newGauges()
+	for name, s := range stats {
+		attrs := functionAttributes(cloud, name)
+		g.invocations.Record(ctx, s.Invocations, attrs)
+		g.errors.Record(ctx, s.Errors, attrs)
+		g.durationMax.Record(ctx, s.MaxDurationMs, attrs)
+		g.durationP99.Record(ctx, s.P99DurationMs, attrs)
+	}
+}
+
+// RecordFunctionMetrics records the latest point of each metric series as gauges
+func RecordFunctionMetrics(ctx context.Context, cloud provider.CloudProvider, metrics *provider.FunctionMetrics) {
+	if metrics == nil {
+		return
+	}
+	g := newGauges()
+	attrs := functionAttributes(cloud, metrics.FunctionName)
+	for _, series := range []struct {
+		gauge metric.Float64Gauge
+		data  provider.MetricData
+	}{
+		{g.duration, metrics.Duration},
+		{g.throttles, metrics.Throttles},
+		{g.concurrency, metrics.ConcurrentExecutions},
+	} {
+		if n := len(series.data.DataPoints); n > 0 {
+			series.gauge.Record(ctx, series.data.DataPoints[n-1].Value, attrs)
+		}
+	}
+}
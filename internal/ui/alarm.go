@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type alarmCreatedMsg struct {
+	functionName string
+	name         string
+	err          error
+}
+
+// defaultAlarmStatistics maps Lambda metrics to their usual alarm statistic
+var defaultAlarmStatistics = map[string]string{
+	"Errors":               "Sum",
+	"Throttles":            "Sum",
+	"Invocations":          "Sum",
+	"Duration":             "Average",
+	"ConcurrentExecutions": "Maximum",
+}
+
+// openAlarmForm shows the alarm creation form for the selected function
+func (m Model) openAlarmForm() (tea.Model, tea.Cmd) {
+	manager, ok := provider.As[provider.AlarmManager](m.provider)
+	if !ok {
+		m.statusMsg = fmt.Sprintf("Creating alarms is not supported for %s", strings.ToUpper(string(m.provider.GetProviderName())))
+		return m, nil
+	}
+	if !m.permitted(m.selectedFunc, provider.ActionCreateAlarm) {
+		return m, nil
+	}
+
+	fn := m.selectedFunc.Name
+	fields := []formField{
+		newFormField("name", "Alarm name", fn+"-errors", "unique alarm name"),
+		newFormField("metric", "Metric", "Errors", "Errors, Throttles, Duration, Invocations, ConcurrentExecutions"),
+		newFormField("statistic", "Statistic", "", "defaults by metric: Sum, Average, Maximum..."),
+		newFormField("comparison", "Comparison", ">=", ">=, >, <=, <"),
+		newFormField("threshold", "Threshold", "1", "e.g. 1 error or 3000 ms"),
+		newFormField("periods", "Evaluation periods", "1", "consecutive periods before alarming"),
+		newFormField("period", "Period (seconds)", "300", "60 or a multiple of 60"),
+		newFormField("topic", "SNS topic ARN", "", "optional, e.g. arn:aws:sns:us-east-1:123456789012:alerts"),
+	}
+
+	cmd := m.openForm("Create alarm for "+fn, fields, func(m Model, values map[string]string) (Model, tea.Cmd, error) {
+		spec, err := parseAlarmSpec(values)
+		if err != nil {
+			return m, nil, err
+		}
+		m.statusMsg = fmt.Sprintf("Creating alarm %s...", spec.Name)
+		return m, createAlarm(manager, fn, spec), nil
+	})
+	return m, cmd
+}
+
+// parseAlarmSpec validates the alarm form values
+func parseAlarmSpec(values map[string]string) (provider.AlarmSpec, error) {
+	spec := provider.AlarmSpec{
+		Name:       values["name"],
+		MetricName: values["metric"],
+		Statistic:  values["statistic"],
+		Comparison: values["comparison"],
+		TopicARN:   values["topic"],
+	}
+
+	if spec.Name == "" {
+		return spec, fmt.Errorf("alarm name is required")
+	}
+	if _, ok := defaultAlarmStatistics[spec.MetricName]; !ok {
+		return spec, fmt.Errorf("unsupported metric %q", spec.MetricName)
+	}
+	if spec.Statistic == "" {
+		spec.Statistic = defaultAlarmStatistics[spec.MetricName]
+	}
+	switch spec.Statistic {
+	case "Sum", "Average", "Maximum", "Minimum", "SampleCount":
+	default:
+		return spec, fmt.Errorf("unsupported statistic %q", spec.Statistic)
+	}
+	switch spec.Comparison {
+	case ">=", ">", "<=", "<":
+	default:
+		return spec, fmt.Errorf("comparison must be one of >=, >, <=, <")
+	}
+
+	threshold, err := strconv.ParseFloat(values["threshold"], 64)
+	if err != nil {
+		return spec, fmt.Errorf("threshold must be a number")
+	}
+	spec.Threshold = threshold
+
+	periods, err := strconv.Atoi(values["periods"])
+	if err != nil || periods < 1 {
+		return spec, fmt.Errorf("evaluation periods must be a positive integer")
+	}
+	spec.EvaluationPeriods = int32(periods)
+
+	period, err := strconv.Atoi(values["period"])
+	if err != nil || period < 60 || period%60 != 0 {
+		return spec, fmt.Errorf("period must be 60 seconds or a multiple of 60")
+	}
+	spec.PeriodSeconds = int32(period)
+
+	if spec.TopicARN != "" && !strings.HasPrefix(spec.TopicARN, "arn:aws:sns:") {
+		return spec, fmt.Errorf("SNS topic must be an ARN starting with arn:aws:sns:")
+	}
+	return spec, nil
+}
+
+// createAlarm creates the alarm in the background
+func createAlarm(manager provider.AlarmManager, functionName string, spec provider.AlarmSpec) tea.Cmd {
+	return func() tea.Msg {
+		err := manager.CreateAlarm(context.Background(), functionName, spec)
+		if err != nil {
+			logger.Logger.Printf("Error creating alarm %s: %v", spec.Name, err)
+		}
+		return alarmCreatedMsg{functionName: functionName, name: spec.Name, err: err}
+	}
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"f6n/internal/audit"
+	"f6n/internal/logger"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// auditViewLimit is the number of latest entries AuditView shows
+const auditViewLimit = 500
+
+type auditLoadedMsg struct {
+	entries []audit.Entry
+	err     error
+}
+
+// recordAction appends a change made to a function and its outcome to the
+// audit log. A failure to write it is logged, not shown.
+func (m Model) recordAction(action, target, detail string, err error) {
+	if m.audit == nil {
+		return
+	}
+	entry := audit.Entry{
+		Provider: string(m.provider.GetProviderName()),
+		Region:   m.provider.GetRegion(),
+		Action:   action,
+		Target:   target,
+		Detail:   detail,
+		Outcome:  audit.OutcomeOK,
+	}
+	m.usage.Error(action, err)
+	if err != nil {
+		entry.Outcome = audit.OutcomeFailed
+		entry.Error = err.Error()
+	}
+	if err := m.audit.Record(entry); err != nil {
+		logger.Logger.Printf("Error writing audit log: %v", err)
+	}
+}
+
+// openAuditView handles ":audit", listing the latest recorded actions
+func (m Model) openAuditView() (tea.Model, tea.Cmd) {
+	if m.audit == nil {
+		m.statusMsg = "Audit log is not available"
+		return m, nil
+	}
+	m.currentView = AuditView
+	m.viewport.SetContent("Reading the audit log...")
+	log := m.audit
+	return m, func() tea.Msg {
+		entries, err := log.Recent(auditViewLimit)
+		if err != nil {
+			logger.Logger.Printf("Error reading audit log: %v", err)
+		}
+		return auditLoadedMsg{entries: entries, err: err}
+	}
+}
+
+// handleAuditLoaded renders the entries read from the audit log, newest first
+func (m Model) handleAuditLoaded(msg auditLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.currentView != AuditView {
+		return m, nil
+	}
+	var b strings.Builder
+	b.WriteString(styles.SelectedStyle.Render("━━━ Audit log ━━━") + "\n")
+	b.WriteString(styles.HelpStyle.Render(m.audit.Path()) + "\n\n")
+
+	switch {
+	case msg.err != nil:
+		b.WriteString(fmt.Sprintf("Error reading the audit log: %v", msg.err))
+	case len(msg.entries) == 0:
+		b.WriteString("No actions recorded yet. Environment updates, code edits, description edits, revealed environment values, event replays, invocations, alarms created and power tuning in f6n are recorded here.")
+	default:
+		b.WriteString(styles.InfoLabelStyle.Render(fmt.Sprintf("%-19s  %-20s  %-32s  %s", "Time", "Action", "Function", "Outcome")) + "\n")
+		for _, e := range msg.entries {
+			line := fmt.Sprintf("%-19s  %-20s  %-32s  ", e.Time.Local().Format("2006-01-02 15:04:05"), e.Action, fitCell(e.Target, 32))
+			if e.Outcome == audit.OutcomeOK {
+				line += "✅"
+			} else {
+				line += styles.ErrorStyle.Render("❌ " + e.Error)
+			}
+			if e.Detail != "" {
+				line += styles.HelpStyle.Render("  " + e.Detail)
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	m.viewport.SetContent(b.String())
+	m.viewport.GotoTop()
+	return m, nil
+}
@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/config"
+	"f6n/internal/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// minRefreshInterval is the shortest auto-refresh interval, as for watch mode
+const minRefreshInterval = 10 * time.Second
+
+// refreshViews are the views that can be refreshed automatically, by their
+// name in the config file and :autorefresh
+var refreshViews = []struct {
+	name string
+	view ViewType
+}{
+	{"list", ListView},
+	{"logs", LogsView},
+	{"metrics", MetricsView},
+}
+
+// autoRefresh holds the auto-refresh intervals of the views
+type autoRefresh struct {
+	intervals map[ViewType]time.Duration // absent or 0 when off
+	gen       int                        // bumped on every change so stale ticks are dropped
+}
+
+type refreshTickMsg struct {
+	view ViewType
+	gen  int
+}
+
+// parseRefreshSettings reads the refresh intervals of the config file. An
+// invalid interval leaves its view off and is reported, the others apply.
+func parseRefreshSettings(settings config.RefreshSettings) (map[ViewType]time.Duration, error) {
+	values := map[ViewType]string{ListView: settings.List, LogsView: settings.Logs, MetricsView: settings.Metrics}
+	intervals := map[ViewType]time.Duration{}
+	var invalid error
+	for _, v := range refreshViews {
+		interval, err := parseRefreshInterval(values[v.view])
+		if err != nil && invalid == nil {
+			invalid = fmt.Errorf("invalid refresh.%s %q (use e.g. 30s or 5m, at least %s, or off)", v.name, values[v.view], minRefreshInterval)
+		}
+		intervals[v.view] = interval
+	}
+	return intervals, invalid
+}
+
+// parseRefreshInterval reads an interval, 0 for off or empty
+func parseRefreshInterval(value string) (time.Duration, error) {
+	if value == "" || strings.EqualFold(value, "off") {
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < minRefreshInterval {
+		return 0, fmt.Errorf("invalid refresh interval %q", value)
+	}
+	return interval, nil
+}
+
+// scheduleRefreshes starts a tick for every view refreshed automatically
+func (m Model) scheduleRefreshes() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, v := range refreshViews {
+		cmds = append(cmds, m.scheduleRefresh(v.view))
+	}
+	return tea.Batch(cmds...)
+}
+
+// scheduleRefresh ticks after the view's interval, nil when it is off
+func (m Model) scheduleRefresh(view ViewType) tea.Cmd {
+	interval := m.refresh.intervals[view]
+	if interval <= 0 {
+		return nil
+	}
+	gen := m.refresh.gen
+	return tea.Tick(interval, func(time.Time) tea.Msg { return refreshTickMsg{view: view, gen: gen} })
+}
+
+// handleRefreshTick reloads the view when it is shown and idle, then waits
+// for the next tick. The list is left to watch mode when it is on, and logs
+// being streamed, paged back or scrolled through are kept as they are.
+func (m Model) handleRefreshTick(msg refreshTickMsg) (tea.Model, tea.Cmd) {
+	if msg.gen != m.refresh.gen {
+		return m, nil
+	}
+	next := m.scheduleRefresh(msg.view)
+	if m.currentView != msg.view || m.inputMode != NormalMode || m.offline.active {
+		return m, next
+	}
+
+	switch msg.view {
+	case ListView:
+		if m.watch.interval > 0 {
+			return m, next
+		}
+		logger.Logger.Printf("Auto-refreshing the function list")
+		return m, tea.Batch(m.fetchFunctions(), next)
+	case LogsView:
+		if m.selectedFunc == nil || m.logs.streaming || m.logs.showingStream || !m.logs.olderBefore.IsZero() || !m.viewport.AtBottom() {
+			return m, next
+		}
+		logger.Logger.Printf("Auto-refreshing the logs of %s", m.selectedFunc.Name)
+		return m, tea.Batch(m.fetchFunctionLogs(m.selectedFunc.Name), next)
+	case MetricsView:
+		if m.selectedFunc == nil {
+			return m, next
+		}
+		logger.Logger.Printf("Auto-refreshing the metrics of %s", m.selectedFunc.Name)
+		return m, tea.Batch(m.fetchMetricsViewData(m.selectedFunc.Name), next)
+	}
+	return m, next
+}
+
+// executeAutoRefreshCommand handles ":autorefresh [list|logs|metrics]
+// [interval|off]"; without arguments it shows the intervals
+func (m Model) executeAutoRefreshCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		m.statusMsg = "Auto-refresh: " + m.describeRefresh()
+		return m, nil
+	}
+	if len(args) != 2 {
+		m.statusMsg = "Usage: :autorefresh [list|logs|metrics] [interval|off]"
+		return m, nil
+	}
+
+	view, found := ListView, false
+	for _, v := range refreshViews {
+		if strings.EqualFold(args[0], v.name) {
+			view, found = v.view, true
+		}
+	}
+	if !found {
+		m.statusMsg = fmt.Sprintf("Unknown view %q (use list, logs or metrics)", args[0])
+		return m, nil
+	}
+	interval, err := parseRefreshInterval(args[1])
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Invalid refresh interval %q (use e.g. 30s or 5m, at least %s, or off)", args[1], minRefreshInterval)
+		return m, nil
+	}
+
+	if m.refresh.intervals == nil {
+		m.refresh.intervals = map[ViewType]time.Duration{}
+	}
+	m.refresh.intervals[view] = interval
+	m.refresh.gen++
+	m.statusMsg = "Auto-refresh: " + m.describeRefresh()
+	return m, m.scheduleRefreshes()
+}
+
+// describeRefresh lists the auto-refresh interval of every view, e.g.
+// "list off, logs 30s, metrics 1m0s"
+func (m Model) describeRefresh() string {
+	parts := make([]string, 0, len(refreshViews))
+	for _, v := range refreshViews {
+		interval := "off"
+		if d := m.refresh.intervals[v.view]; d > 0 {
+			interval = d.String()
+		}
+		parts = append(parts, v.name+" "+interval)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Bus carries the updates of long-running activities (log streams, the
+// watcher, bulk downloads, reading downloaded code) into the TUI. The
+// activities run in their own goroutines and publish a message whenever
+// something happens, rather than each update being requested by a command
+// returned from Update.
+type Bus struct {
+	mu      sync.Mutex
+	program *tea.Program
+	pending []tea.Msg // Published before the program was attached
+}
+
+// NewBus creates a bus; attach it to the program before running it
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Attach delivers the messages published so far and later ones to program
+func (b *Bus) Attach(program *tea.Program) {
+	b.mu.Lock()
+	pending := b.pending
+	b.program, b.pending = program, nil
+	b.mu.Unlock()
+
+	if len(pending) > 0 {
+		go func() {
+			for _, msg := range pending {
+				program.Send(msg)
+			}
+		}()
+	}
+}
+
+// Publish sends msg to the program. It blocks until the program takes the
+// message, so it must be called from the activity's goroutine, never from
+// Update. Once the program has exited, messages are dropped.
+func (b *Bus) Publish(msg tea.Msg) {
+	b.mu.Lock()
+	program := b.program
+	if program == nil {
+		b.pending = append(b.pending, msg)
+	}
+	b.mu.Unlock()
+
+	if program != nil {
+		program.Send(msg)
+	}
+}
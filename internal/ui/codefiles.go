@@ -0,0 +1,289 @@
+package ui
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"f6n/internal/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// archiveMaxEntries caps the entries listed per archive in CodeDisplayView
+const archiveMaxEntries = 200
+
+// codeChunkInterval is how often the files read so far are published while
+// the downloaded code is loading
+const codeChunkInterval = 100 * time.Millisecond
+
+// codeFilesChunkMsg carries the downloaded files read since the previous
+// chunk, published on the bus with the generation of the load
+type codeFilesChunkMsg struct {
+	gen      int
+	content  string
+	sections []codeSection // Files of the chunk, located in the whole content
+	done     bool          // Whether this is the last chunk
+	err      error
+}
+
+// loadCodeFiles starts reading the downloaded code of a function into
+// CodeDisplayView. The files appear as they are read, so that large packages
+// don't hold up the UI.
+func (m *Model) loadCodeFiles(functionName string) tea.Cmd {
+	logger.Logger.Printf("Loading code files for function: %s", functionName)
+	m.code.stopLoad()
+	ctx, cancel := context.WithCancel(context.Background())
+	m.code.cancelLoad = cancel
+	m.code.loading = true
+	m.code.content, m.code.sections = "", nil
+	gen, bus := m.code.loadGen, m.bus
+	readmeStyle, width := m.markdownStyle(), m.markdownWidth()
+	return func() tea.Msg {
+		streamCodeFiles(ctx, bus, gen, filepath.Join("downloads", functionName), readmeStyle, width)
+		return nil
+	}
+}
+
+// stopLoad stops reading the downloaded files, ignoring the chunks on their way
+func (c *codeModel) stopLoad() {
+	if c.cancelLoad != nil {
+		c.cancelLoad()
+		c.cancelLoad = nil
+	}
+	c.loading = false
+	c.loadGen++
+}
+
+// streamCodeFiles reads the code files under dirPath, numbered and indexed
+// by file, then lists the other files. A README.md at the top of the code is
+// rendered first with readmeStyle, wrapped at width. What was read is
+// published every codeChunkInterval until ctx is done.
+func streamCodeFiles(ctx context.Context, bus *Bus, gen int, dirPath, readmeStyle string, width int) {
+	if _, err := os.Stat(dirPath); os.IsNotExist(err) {
+		bus.Publish(codeFilesChunkMsg{gen: gen, err: fmt.Errorf("code not downloaded yet. Press 'd' first to download the code")})
+		return
+	}
+
+	var chunk, others strings.Builder
+	var sections []codeSection
+	line, files := 0, 0 // Lines published or in the chunk, code files read
+	flushed := time.Now()
+	write := func(text string) {
+		chunk.WriteString(text)
+		line += strings.Count(text, "\n")
+	}
+	flush := func(done bool) {
+		bus.Publish(codeFilesChunkMsg{gen: gen, content: chunk.String(), sections: sections, done: done})
+		chunk.Reset()
+		sections, flushed = nil, time.Now()
+	}
+
+	if name, readme, ok := renderReadme(dirPath, readmeStyle, width); ok {
+		write(fmt.Sprintf("📖 %s\n", name))
+		write("─────────────────────────────────────\n")
+		write(readme + "\n")
+	}
+
+	write(fmt.Sprintf("📁 Code Files for %s\n", filepath.Base(dirPath)))
+	write("═══════════════════════════════════════\n\n")
+
+	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		// Skip directories and non-readable files
+		if info.IsDir() {
+			return nil
+		}
+
+		// Show common code file extensions, and list other files after them
+		relPath, _ := filepath.Rel(dirPath, path)
+		ext := strings.ToLower(filepath.Ext(path))
+		if !isCodeFile(ext) {
+			others.WriteString(describeOtherFile(path, relPath, info.Size()))
+			return nil
+		}
+
+		files++
+		write(fmt.Sprintf("📄 %s\n", relPath))
+		write("─────────────────────────────────────\n")
+
+		if info.Size() > codeInlineMaxSize {
+			// Large files are read a page at a time when opened
+			sections = append(sections, codeSection{file: relPath, start: line, first: 1})
+			write(fmt.Sprintf("File is %s; open it with :file %s (or :<line>) to page through it\n\n",
+				formatSize(info.Size()), filepath.ToSlash(relPath)))
+		} else if fileContent, err := os.ReadFile(path); err != nil {
+			write(fmt.Sprintf("Error reading file: %v\n\n", err))
+		} else {
+			lines := strings.Split(strings.TrimSuffix(string(fileContent), "\n"), "\n")
+			sections = append(sections, codeSection{file: relPath, start: line, first: 1, lines: len(lines), total: len(lines)})
+			write(numberLines(lines, 1, 0))
+			write("\n")
+		}
+
+		if time.Since(flushed) >= codeChunkInterval {
+			flush(false)
+		}
+		return nil
+	})
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if err != nil {
+		logger.Logger.Printf("Error reading code files: %v", err)
+		bus.Publish(codeFilesChunkMsg{gen: gen, err: fmt.Errorf("failed to read code files: %w", err)})
+		return
+	}
+
+	if others.Len() > 0 {
+		write("🗂  Other files\n")
+		write("─────────────────────────────────────\n")
+		write(others.String())
+	}
+	if files == 0 {
+		write("No code files found in the downloaded directory.\n")
+		write("The download may contain only configuration files or archives.")
+	}
+
+	logger.Logger.Printf("Code files loaded successfully")
+	flush(true)
+}
+
+// renderReadme renders the README.md at the top of dirPath as Markdown, for
+// the landing page of CodeDisplayView. A README that can't be read or
+// rendered is left out, and listed with the other files.
+func renderReadme(dirPath, style string, width int) (string, string, bool) {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return "", "", false
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(entry.Name(), "README.md") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dirPath, entry.Name()))
+		if err != nil {
+			logger.Logger.Printf("Error reading %s: %v", entry.Name(), err)
+			return "", "", false
+		}
+		rendered, err := renderMarkdown(string(content), style, width)
+		if err != nil {
+			logger.Logger.Printf("Error rendering %s: %v", entry.Name(), err)
+			return "", "", false
+		}
+		return entry.Name(), rendered, true
+	}
+	return "", "", false
+}
+
+// isArchive reports whether a file extension is a zip-based archive
+func isArchive(ext string) bool {
+	switch ext {
+	case ".zip", ".jar", ".war", ".whl", ".egg", ".nupkg":
+		return true
+	}
+	return false
+}
+
+// formatSize renders a byte count in B, KB, MB or GB
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, suffix := float64(n)/unit, "KB"
+	for _, next := range []string{"MB", "GB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
+
+// fileType sniffs the content type of a file from its first bytes, telling
+// text from binary content
+func fileType(path string) (string, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false, err
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", false, err
+	}
+	head = head[:n]
+
+	// Drop a multi-byte rune cut at the end of the sample
+	sample := head
+	for i := 0; i < utf8.UTFMax-1 && len(sample) > 0 && !utf8.Valid(sample); i++ {
+		sample = sample[:len(sample)-1]
+	}
+	binary := bytes.IndexByte(head, 0) >= 0 || !utf8.Valid(sample)
+	contentType := http.DetectContentType(head)
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return contentType, binary, nil
+}
+
+// describeOtherFile renders a file that is not shown as code: archives with
+// their entries, other files with their size and type
+func describeOtherFile(path, relPath string, size int64) string {
+	if isArchive(strings.ToLower(filepath.Ext(path))) {
+		if listing, err := listArchive(path, relPath, size); err == nil {
+			return listing
+		}
+	}
+
+	contentType, binary, err := fileType(path)
+	if err != nil {
+		return fmt.Sprintf("  • %s (%s, unreadable: %v)\n", relPath, formatSize(size), err)
+	}
+	kind := "text"
+	if binary {
+		kind = "binary"
+	}
+	return fmt.Sprintf("  • %s (%s, %s, %s)\n", relPath, kind, contentType, formatSize(size))
+}
+
+// listArchive lists the entries of a zip-based archive with their sizes
+func listArchive(path, relPath string, size int64) (string, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("  📦 %s (archive, %d entries, %s)\n", relPath, len(r.File), formatSize(size)))
+	for i, f := range r.File {
+		if i == archiveMaxEntries {
+			b.WriteString(fmt.Sprintf("      … and %d more\n", len(r.File)-archiveMaxEntries))
+			break
+		}
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("      %10s  %s\n", formatSize(int64(f.UncompressedSize64)), f.Name))
+	}
+	return b.String(), nil
+}
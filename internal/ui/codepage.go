@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"f6n/internal/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// codeInlineMaxSize is the largest file shown with the other files; larger
+	// files are read a page at a time on their own
+	codeInlineMaxSize = 100 * 1024
+	// codePageLines is the number of lines per page of a paged file
+	codePageLines = 2000
+)
+
+// readCodePage reads the page of lines starting at line first (1-based),
+// counting the file's lines without keeping the others in memory
+func readCodePage(path string, first int) (lines []string, total int, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		total++
+		if total >= first && total < first+codePageLines {
+			lines = append(lines, scanner.Text())
+		}
+	}
+	return lines, total, scanner.Err()
+}
+
+// pageStart returns the first line of the page containing line
+func pageStart(line int) int {
+	return (max(line, 1)-1)/codePageLines*codePageLines + 1
+}
+
+// loadCodePage shows the page of a downloaded file containing line in
+// CodeDisplayView, scrolled to line when highlight is set
+func (m Model) loadCodePage(function, file string, line int, highlight bool) tea.Cmd {
+	return func() tea.Msg {
+		path := filepath.Join("downloads", function, file)
+		first := pageStart(line)
+		lines, total, err := readCodePage(path, first)
+		if err != nil {
+			logger.Logger.Printf("Error reading %s: %v", path, err)
+			return codeFilesLoadedMsg{err: fmt.Errorf("failed to read %s: %w", file, err)}
+		}
+		if first > total && total > 0 {
+			first = pageStart(total)
+			if lines, total, err = readCodePage(path, first); err != nil {
+				return codeFilesLoadedMsg{err: fmt.Errorf("failed to read %s: %w", file, err)}
+			}
+		}
+
+		var b strings.Builder
+		header := fmt.Sprintf("📄 %s/%s", function, filepath.ToSlash(file))
+		if total > codePageLines {
+			last := first + len(lines) - 1
+			header += fmt.Sprintf(" (lines %d-%d of %d, page %d/%d: [ and ] to turn)",
+				first, last, total, (first-1)/codePageLines+1, (total-1)/codePageLines+1)
+		}
+		b.WriteString(header + "\n")
+		b.WriteString("─────────────────────────────────────\n")
+		offset, highlighted := 0, 0
+		if highlight {
+			// The two header lines precede the first line of the page
+			offset, highlighted = max(line-first+2-grepContextLines, 0), line
+		}
+		b.WriteString(numberLines(lines, first, highlighted))
+		return codeFilesLoadedMsg{
+			content:  b.String(),
+			sections: []codeSection{{file: file, start: 2, first: first, lines: len(lines), total: total}},
+			offset:   offset,
+		}
+	}
+}
+
+// openCodeFile shows a file of the selected function in CodeDisplayView,
+// paged when it is large
+func (m Model) openCodeFile(file string, line int) (tea.Model, tea.Cmd) {
+	if m.selectedFunc == nil {
+		m.statusMsg = "No function selected"
+		return m, nil
+	}
+	file = strings.TrimPrefix(filepath.Clean("/"+file), string(filepath.Separator))
+	if file == "" {
+		m.statusMsg = "Usage: :file <path relative to the downloaded code>"
+		return m, nil
+	}
+	info, err := os.Stat(filepath.Join("downloads", m.selectedFunc.Name, file))
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %v", err)
+		return m, nil
+	}
+	if info.IsDir() {
+		m.statusMsg = file + " is a directory"
+		return m, nil
+	}
+	m.currentView = CodeDisplayView
+	m.code.file, m.code.line = file, line
+	m.viewport.SetContent(fmt.Sprintf("Loading %s...", file))
+	return m, m.loadCodePage(m.selectedFunc.Name, file, line, line > 0)
+}
+
+// turnCodePage shows the next (delta 1) or previous (delta -1) page of a paged file
+func (m Model) turnCodePage(delta int) (tea.Model, tea.Cmd) {
+	if m.code.file == "" || len(m.code.sections) != 1 || m.selectedFunc == nil {
+		return m, nil
+	}
+	section := m.code.sections[0]
+	first := section.first + delta*codePageLines
+	if first < 1 || first > section.total {
+		return m, nil
+	}
+	m.code.line = 0
+	return m, m.loadCodePage(m.selectedFunc.Name, m.code.file, first, false)
+}
@@ -0,0 +1,282 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// codeModel is the sub-model of CodeView and CodeDisplayView: the handler
+// code or downloaded files shown, and the edit state
+type codeModel struct {
+	content         string
+	editMode        bool        // Whether the handler code is being edited
+	heading         string      // Heading above the handler file being edited
+	originalContent string      // Source before editing
+	history         editHistory // Undo/redo of the edit
+	revision        string      // Revision of the function the edit started from
+	file            string      // File paged in CodeDisplayView, relative to the download
+	line            int         // Line of file to highlight, 0 for none
+	offset          int         // Line to scroll to once the content is shown
+	sections        []codeSection
+	// Reading of the downloaded files, shown as they arrive
+	loading    bool
+	loadGen    int                // Generation of the current load
+	cancelLoad context.CancelFunc // Stops reading the files
+}
+
+// Update stores loaded code, with the line to scroll to, and handles the
+// CodeView and CodeDisplayView keys
+func (c codeModel) Update(ctx viewContext, msg tea.Msg) (codeModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case viewKeyMsg:
+		if ctx.view == CodeDisplayView {
+			return c, c.handleDisplayKey(string(msg))
+		}
+		return c, c.handleKey(ctx, string(msg))
+	case functionCodeLoadedMsg:
+		c.offset = 0
+		if msg.err != nil {
+			c.content = fmt.Sprintf("Error: %v", msg.err)
+		} else {
+			c.content = msg.code
+		}
+	case codeFilesChunkMsg:
+		if msg.gen != c.loadGen {
+			return c, nil
+		}
+		if msg.err != nil {
+			c.stopLoad()
+			c.content = fmt.Sprintf("Error loading code files: %v\n\nPress 'esc' to go back.", msg.err)
+			return c, nil
+		}
+		c.content += msg.content
+		c.sections = append(c.sections, msg.sections...)
+		c.loading = !msg.done
+	case codeFilesLoadedMsg:
+		// A page of a file replaces the files being read
+		c.stopLoad()
+		c.offset = 0
+		if msg.err != nil {
+			c.content = fmt.Sprintf("Error loading code files: %v\n\nPress 'esc' to go back.", msg.err)
+			return c, nil
+		}
+		c.content = msg.content
+		c.sections = msg.sections
+		c.offset = msg.offset
+	}
+	return c, nil
+}
+
+// View renders the loaded code
+func (c codeModel) View() string {
+	if c.loading {
+		return strings.TrimPrefix(c.content+"\n⏳ Reading downloaded files...", "\n")
+	}
+	return c.content
+}
+
+// openCodeView shows the handler code of the selected function
+func (m Model) openCodeView() (tea.Model, tea.Cmd) {
+	m.currentView = CodeView
+	m.rememberRecent(CodeView.String())
+	m.viewport.SetContent("Loading code...")
+	m.announceDraft(codeDraft)
+	return m, m.fetchFunctionCode(m.selectedFunc.Name)
+}
+
+// editCode enters edit mode on content, an edit of the code shown in original
+// at revision
+func (m Model) editCode(original, content, revision string) (tea.Model, tea.Cmd) {
+	m.code.editMode = true
+	m.code.originalContent = original
+	m.code.revision = revision
+	m.textarea.SetValue(content)
+	m.textarea.SetWidth(m.width - 4)
+	m.textarea.SetHeight(m.height - 10)
+	m.textarea.Focus()
+	m.code.history = newEditHistory(original)
+	return m, nil
+}
+
+// handleKey handles the CodeView keys outside edit mode: editing the handler
+// code and viewing the downloaded files
+func (c codeModel) handleKey(ctx viewContext, key string) tea.Cmd {
+	if key == "E" {
+		return route(func(m Model) (tea.Model, tea.Cmd) { return m.openInEditor("") })
+	}
+	if ctx.fn == nil {
+		return nil
+	}
+	switch key {
+	case "v":
+		return route(Model.openCodeDisplayView)
+	case "e":
+		return route(Model.startCodeEdit)
+	}
+	return nil
+}
+
+// handleDisplayKey handles the CodeDisplayView keys
+func (c codeModel) handleDisplayKey(key string) tea.Cmd {
+	switch key {
+	case "E":
+		return route(func(m Model) (tea.Model, tea.Cmd) { return m.openInEditor("") })
+	case "[":
+		return route(func(m Model) (tea.Model, tea.Cmd) { return m.turnCodePage(-1) })
+	case "]":
+		return route(func(m Model) (tea.Model, tea.Cmd) { return m.turnCodePage(1) })
+	}
+	return nil
+}
+
+// openCodeDisplayView shows the downloaded files of the selected function
+func (m Model) openCodeDisplayView() (tea.Model, tea.Cmd) {
+	m.currentView = CodeDisplayView
+	m.code.file, m.code.line = "", 0
+	cmd := m.loadCodeFiles(m.selectedFunc.Name)
+	m.viewport.SetContent(m.code.View())
+	return m, cmd
+}
+
+// startCodeEdit enters edit mode on the handler code, from the draft left
+// last time if wanted
+func (m Model) startCodeEdit() (tea.Model, tea.Cmd) {
+	if _, ok := provider.As[provider.CodeUpdater](m.provider); !ok {
+		m.statusMsg = fmt.Sprintf("Editing code is not supported for %s", strings.ToUpper(string(m.provider.GetProviderName())))
+		return m, nil
+	}
+	if !m.permitted(m.selectedFunc, provider.ActionUpdateCode) {
+		return m, nil
+	}
+	heading, original, ok := splitCodeHeading(m.code.content)
+	if !ok {
+		m.statusMsg = "Only a handler file shown in full can be edited"
+		return m, nil
+	}
+	m.code.heading = heading
+	if d := m.findDraft(codeDraft); d != nil {
+		return m.offerDraft(d, original, func(m Model, content, revision string) (tea.Model, tea.Cmd) {
+			return m.editCode(original, content, revision)
+		})
+	}
+	return m.editCode(original, original, m.selectedFunc.Revision)
+}
+
+// handleEditKey saves with ctrl+s, undoes and redoes with ctrl+z and ctrl+y,
+// and cancels with esc; every other key goes to the editor
+func (m Model) handleEditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+
+	case "esc":
+		// Exit edit mode without saving, keeping the edit as a draft
+		m.keepDraft()
+		m.code.editMode = false
+		m.viewport.SetContent(m.code.View())
+		m.textarea.Blur()
+		return m, nil
+
+	case "ctrl+s":
+		// Save once the function is known not to have changed remotely
+		return m.checkRevision(codeDraft)
+
+	case "ctrl+z":
+		if !m.code.history.undo(&m.textarea) {
+			m.statusMsg = "Nothing to undo"
+		}
+		return m, nil
+
+	case "ctrl+y":
+		if !m.code.history.redo(&m.textarea) {
+			m.statusMsg = "Nothing to redo"
+		}
+		return m, nil
+	}
+
+	before := snapshot(m.textarea)
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	if m.textarea.Value() != before.value {
+		m.code.history.record(before, msg)
+	}
+	return m, cmd
+}
+
+// splitCodeHeading splits the heading GetFunctionCode puts above a handler
+// file, e.g. "━━━ app.py (python3.12, handler app.handler) ━━━", from its
+// source. Notes such as where a larger package is have no such heading.
+func splitCodeHeading(content string) (heading, source string, ok bool) {
+	if !strings.HasPrefix(content, "━━━ ") {
+		return "", "", false
+	}
+	const end = " ━━━\n\n"
+	i := strings.Index(content, end)
+	if i < 0 || !strings.Contains(content[:i], ", handler ") {
+		return "", "", false
+	}
+	return content[:i+len(end)], content[i+len(end):], true
+}
+
+// confirmCodeDeploy asks before the edited handler file is deployed, as it
+// replaces the code the live function runs
+func (m Model) confirmCodeDeploy() (tea.Model, tea.Cmd) {
+	name := m.selectedFunc.Name
+	items := []pickerItem{
+		{label: "Keep editing", detail: "nothing is deployed"},
+		{label: "Deploy", detail: "upload the package with the edited file to the live function"},
+	}
+	title := fmt.Sprintf("Deploy the edited code of %s?", name)
+	m.openPicker(title, items, func(m Model, idx int) (tea.Model, tea.Cmd) {
+		if idx == 0 || m.selectedFunc == nil || m.selectedFunc.Name != name || !m.editing(codeDraft) {
+			m.statusMsg = "Code not deployed"
+			return m, nil
+		}
+		return m.saveCode()
+	})
+	return m, nil
+}
+
+// saveCode deploys the edited handler file. The editor stays open until it
+// is deployed, with the edit kept on failure.
+func (m Model) saveCode() (tea.Model, tea.Cmd) {
+	updater, _ := provider.As[provider.CodeUpdater](m.provider)
+	name, source, revision := m.selectedFunc.Name, m.textarea.Value(), m.code.revision
+	file := ""
+	if fields := strings.Fields(m.code.heading); len(fields) > 1 {
+		file = fields[1]
+	}
+	m.statusMsg = fmt.Sprintf("Deploying the edited code of %s...", name)
+	return m, func() tea.Msg {
+		err := updater.UpdateHandlerCode(context.Background(), name, source, revision)
+		if err != nil {
+			logger.Logger.Printf("Error updating code of %s: %v", name, err)
+		}
+		return codeSavedMsg{function: name, file: file, source: source, err: err}
+	}
+}
+
+// handleCodeSaved shows the deployed code and records it in the audit log
+func (m Model) handleCodeSaved(msg codeSavedMsg) (tea.Model, tea.Cmd) {
+	m.recordAction("update-code", msg.function, "file: "+msg.file, msg.err)
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %v", msg.err)
+		return m, nil
+	}
+	deleteDraft(codeDraft, msg.function)
+	if m.selectedFunc != nil && m.selectedFunc.Name == msg.function && m.currentView == CodeView {
+		m.code.editMode = false
+		m.code.content = m.code.heading + msg.source
+		m.textarea.Blur()
+		m.viewport.SetContent(m.code.View())
+	}
+	m.statusMsg = fmt.Sprintf("✅ Code of %s updated; the function is being redeployed", msg.function)
+	// Reload the list for the update's state
+	return m, m.fetchFunctions()
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"strings"
+
+	"f6n/internal/config"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+)
+
+// customColumnShare is the share of the table width each custom column takes
+const customColumnShare = 0.09
+
+// customColumn is a compiled config.CustomColumn, shown in the function list
+// and usable in its filter under field, the lowercased letters of its name
+type customColumn struct {
+	title  string
+	field  string
+	source string // "tag" or "env"
+	key    string
+}
+
+// compileCustomColumns parses the configured columns, skipping those with an
+// unknown source or whose field would shadow a built-in filter field
+func compileCustomColumns(columns []config.CustomColumn) []customColumn {
+	compiled := make([]customColumn, 0, len(columns))
+	for _, column := range columns {
+		source, key, _ := strings.Cut(strings.TrimSpace(column.Value), ":")
+		source = strings.ToLower(source)
+		if source == "label" {
+			// GCP labels are loaded as tags
+			source = "tag"
+		}
+		if (source != "tag" && source != "env") || key == "" {
+			logger.Logger.Printf("Skipping column %q: value %q must be tag:<key>, label:<key> or env:<variable>", column.Name, column.Value)
+			continue
+		}
+		field := strings.Map(func(r rune) rune {
+			if r >= 'a' && r <= 'z' {
+				return r
+			}
+			return -1
+		}, strings.ToLower(column.Name))
+		if field == "" || knownFilterField(field, compiled) {
+			logger.Logger.Printf("Skipping column %q: its filter field %q is empty or taken", column.Name, field)
+			continue
+		}
+		compiled = append(compiled, customColumn{title: column.Name, field: field, source: source, key: key})
+	}
+	return compiled
+}
+
+// value reads the column of a function: its tag, matching the key in any
+// case, or its environment variable
+func (c customColumn) value(fn provider.FunctionInfo) string {
+	if c.source == "env" {
+		return fn.Environment[c.key]
+	}
+	for k, v := range fn.Tags {
+		if strings.EqualFold(k, c.key) {
+			return v
+		}
+	}
+	return ""
+}
+
+// customColumnCells returns the cells of the custom columns of a list row,
+// "-" where the function has no value
+func customColumnCells(columns []customColumn, fn provider.FunctionInfo) []string {
+	cells := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if v := c.value(fn); v != "" {
+			cells = append(cells, v)
+		} else {
+			cells = append(cells, "-")
+		}
+	}
+	return cells
+}
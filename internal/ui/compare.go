@@ -0,0 +1,305 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// comparison holds the CompareView state
+type comparison struct {
+	marked      *provider.FunctionInfo // Function marked with 'x' in the list, waiting for the second
+	left, right provider.FunctionInfo
+	onlyDiffs   bool            // Hide the fields both functions have in common
+	revealed    map[string]bool // environment variables whose values are shown
+}
+
+// comparedAttributes are the configuration fields CompareView lines up under
+// the function names, followed by the encryption, environment variables,
+// secrets and tags
+var comparedAttributes = []struct {
+	label string
+	value func(fn provider.FunctionInfo, units unitFormat) string
+}{
+	{"ARN/Resource", func(fn provider.FunctionInfo, _ unitFormat) string { return fn.ARN }},
+	{"Runtime", func(fn provider.FunctionInfo, _ unitFormat) string { return fn.Runtime }},
+	{"Handler", func(fn provider.FunctionInfo, _ unitFormat) string { return fn.Handler }},
+	{"Memory", func(fn provider.FunctionInfo, u unitFormat) string { return u.memory(float64(fn.Memory)) }},
+	{"Timeout", func(fn provider.FunctionInfo, u unitFormat) string { return u.seconds(fn.Timeout) }},
+	{"Code Size", func(fn provider.FunctionInfo, u unitFormat) string {
+		if fn.CodeSize == 0 {
+			return ""
+		}
+		return u.bytes(fn.CodeSize)
+	}},
+	{"Region/Location", func(fn provider.FunctionInfo, _ unitFormat) string { return fn.Region }},
+	{"Profile", func(fn provider.FunctionInfo, _ unitFormat) string { return fn.Profile }},
+	{"Role", func(fn provider.FunctionInfo, _ unitFormat) string { return fn.Role }},
+	{"State", func(fn provider.FunctionInfo, _ unitFormat) string { return fn.State }},
+	{"Last Update", func(fn provider.FunctionInfo, _ unitFormat) string { return fn.LastUpdateStatus }},
+	{"Last Modified", func(fn provider.FunctionInfo, _ unitFormat) string { return fn.LastModified }},
+	{"Revision", func(fn provider.FunctionInfo, _ unitFormat) string { return fn.Revision }},
+	{"Description", func(fn provider.FunctionInfo, _ unitFormat) string { return fn.Description }},
+}
+
+// comparedField is a row of CompareView
+type comparedField struct {
+	label       string
+	left, right string
+	masked      bool // the values are environment values not revealed
+}
+
+func (f comparedField) differs() bool {
+	return f.left != f.right
+}
+
+// shown returns a value of the field as rendered, masked when the field is
+func (f comparedField) shown(value string) string {
+	if f.masked && value != "" {
+		return maskedEnvValue
+	}
+	return orDash(value)
+}
+
+// compareFunctions lines up the configurations of two functions.
+// Environment values are masked unless their variable is in revealed; they
+// are still compared, so differing values are flagged.
+func compareFunctions(left, right provider.FunctionInfo, cloud provider.CloudProvider, units unitFormat, revealed map[string]bool) []comparedField {
+	var fields []comparedField
+	for _, attr := range comparedAttributes {
+		fields = append(fields, comparedField{label: attr.label, left: attr.value(left, units), right: attr.value(right, units)})
+	}
+	if encryption := formatEncryption(&left, cloud); encryption != "" {
+		fields = append(fields, comparedField{label: "Encryption", left: encryption, right: formatEncryption(&right, cloud)})
+	}
+	env := compareMaps("env ", left.Environment, right.Environment)
+	for i := range env {
+		env[i].masked = !revealed[strings.TrimPrefix(env[i].label, "env ")]
+	}
+	fields = append(fields, env...)
+	fields = append(fields, compareMaps("secret ", left.SecretEnvironment, right.SecretEnvironment)...)
+	fields = append(fields, compareMaps("tag ", left.Tags, right.Tags)...)
+	return fields
+}
+
+// compareMaps lines up the keys of either map, sorted
+func compareMaps(prefix string, left, right map[string]string) []comparedField {
+	keys := make(map[string]bool, len(left)+len(right))
+	for k := range left {
+		keys[k] = true
+	}
+	for k := range right {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	fields := make([]comparedField, 0, len(sorted))
+	for _, k := range sorted {
+		fields = append(fields, comparedField{label: prefix + k, left: left[k], right: right[k]})
+	}
+	return fields
+}
+
+// markForComparison marks the function under the cursor, or compares it with
+// the function marked before
+func (m Model) markForComparison() (tea.Model, tea.Cmd) {
+	fn := m.list.selected()
+	if fn == nil {
+		return m, nil
+	}
+	marked := m.compare.marked
+	switch {
+	case marked == nil:
+		selected := *fn
+		m.compare.marked = &selected
+		m.statusMsg = fmt.Sprintf("Marked %s for comparison. Press 'x' on another function to compare", fn.Name)
+		return m, nil
+	case marked.Name == fn.Name && marked.Profile == fn.Profile:
+		m.compare.marked = nil
+		m.statusMsg = "Comparison mark cleared"
+		return m, nil
+	}
+	return m.openCompareView(*marked, *fn)
+}
+
+// executeCompareCommand handles ":compare <function> [function]", comparing
+// two functions, or the current function with another
+func (m Model) executeCompareCommand(args []string) (tea.Model, tea.Cmd) {
+	var names []string
+	switch len(args) {
+	case 1:
+		fn := m.currentFunction()
+		if fn == nil {
+			m.statusMsg = "No function selected"
+			return m, nil
+		}
+		names = []string{fn.Name, args[0]}
+	case 2:
+		names = args
+	default:
+		m.statusMsg = "Usage: :compare <function> [function]"
+		return m, nil
+	}
+
+	var pair []provider.FunctionInfo
+	for _, name := range names {
+		fn, ok := m.findFunction(name)
+		if !ok {
+			m.statusMsg = fmt.Sprintf("Function %s not found", name)
+			return m, nil
+		}
+		pair = append(pair, fn)
+	}
+	return m.openCompareView(pair[0], pair[1])
+}
+
+// findFunction looks a listed function up by name
+func (m Model) findFunction(name string) (provider.FunctionInfo, bool) {
+	for _, fn := range m.list.allFunctions {
+		if fn.Name == name {
+			return fn, true
+		}
+	}
+	return provider.FunctionInfo{}, false
+}
+
+// openCompareView shows two functions side by side
+func (m Model) openCompareView(left, right provider.FunctionInfo) (tea.Model, tea.Cmd) {
+	m.compare = comparison{left: left, right: right, onlyDiffs: m.compare.onlyDiffs}
+	m.currentView = CompareView
+	m.refreshCompareView()
+	return m, nil
+}
+
+// handleCompareKey handles the CompareView specific keys
+func (m Model) handleCompareKey(key string) (tea.Model, tea.Cmd, bool) {
+	switch key {
+	case "h":
+		m.compare.onlyDiffs = !m.compare.onlyDiffs
+		m.refreshCompareView()
+		return m, nil, true
+	case "s":
+		m.compare.left, m.compare.right = m.compare.right, m.compare.left
+		m.refreshCompareView()
+		return m, nil, true
+	case "v":
+		model, cmd := m.openCompareRevealPicker()
+		return model, cmd, true
+	}
+	return m, nil, false
+}
+
+// openCompareRevealPicker handles "v" in CompareView, choosing an
+// environment variable of either function whose values to reveal, or to
+// hide again
+func (m Model) openCompareRevealPicker() (tea.Model, tea.Cmd) {
+	c := m.compare
+	var keys []string
+	for _, f := range compareMaps("", c.left.Environment, c.right.Environment) {
+		keys = append(keys, f.label)
+	}
+	if len(keys) == 0 {
+		m.statusMsg = "No environment variables to reveal"
+		return m, nil
+	}
+	items := make([]pickerItem, 0, len(keys))
+	for _, key := range keys {
+		detail := "hidden"
+		if c.revealed[key] {
+			detail = "revealed"
+		}
+		items = append(items, pickerItem{label: key, detail: detail})
+	}
+	m.openPicker(fmt.Sprintf("Reveal an environment value of %s and %s", c.left.Name, c.right.Name), items, func(m Model, idx int) (tea.Model, tea.Cmd) {
+		return m.toggleCompareReveal(keys[idx])
+	})
+	return m, nil
+}
+
+// toggleCompareReveal shows the values of an environment variable in both
+// functions, recording it in the audit log for each function that has it, or
+// masks them again
+func (m Model) toggleCompareReveal(key string) (tea.Model, tea.Cmd) {
+	revealed := maps.Clone(m.compare.revealed)
+	if revealed == nil {
+		revealed = make(map[string]bool)
+	}
+	if revealed[key] {
+		delete(revealed, key)
+		m.statusMsg = fmt.Sprintf("%s hidden", key)
+	} else {
+		revealed[key] = true
+		m.statusMsg = fmt.Sprintf("%s revealed", key)
+		for _, fn := range []provider.FunctionInfo{m.compare.left, m.compare.right} {
+			if _, ok := fn.Environment[key]; ok {
+				m.recordAction("reveal-env", fn.Name, "variable: "+key, nil)
+			}
+		}
+	}
+	m.compare.revealed = revealed
+	m.refreshCompareView()
+	return m, nil
+}
+
+// refreshCompareView renders the two configurations in columns, highlighting
+// the fields that differ
+func (m *Model) refreshCompareView() {
+	c := m.compare
+	fields := compareFunctions(c.left, c.right, m.provider.GetProviderName(), m.units(), c.revealed)
+
+	const labelWidth = 24
+	valueWidth := (m.viewport.Width - labelWidth - 10) / 2
+	if valueWidth < 20 {
+		valueWidth = 20
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.SelectedStyle.Render(fmt.Sprintf("━━━ %s vs %s ━━━", c.left.Name, c.right.Name)) + "\n\n")
+	b.WriteString(styles.InfoLabelStyle.Render(fmt.Sprintf("  %-*s %-*s %s", labelWidth, "", valueWidth,
+		fitCell(c.left.Name, valueWidth), fitCell(c.right.Name, valueWidth))) + "\n")
+
+	differing := 0
+	for _, f := range fields {
+		if !f.differs() {
+			if c.onlyDiffs {
+				continue
+			}
+			b.WriteString(fmt.Sprintf("  %-*s %-*s %s\n", labelWidth, fitCell(f.label, labelWidth),
+				valueWidth, fitCell(f.shown(f.left), valueWidth), fitCell(f.shown(f.right), valueWidth)))
+			continue
+		}
+		differing++
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("≠ %-*s %-*s %s", labelWidth, fitCell(f.label, labelWidth),
+			valueWidth, fitCell(f.shown(f.left), valueWidth), fitCell(f.shown(f.right), valueWidth))) + "\n")
+	}
+
+	b.WriteString(fmt.Sprintf("\n%d of %d fields differ\n", differing, len(fields)))
+	m.viewport.SetContent(b.String())
+}
+
+// fitCell cuts s to width runes, marking the cut with an ellipsis
+func fitCell(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// orDash renders an empty value as a dash
+func orDash(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return s
+}
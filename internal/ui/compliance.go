@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"f6n/internal/insights"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// complianceEnabled reports whether a compliance policy is configured
+func (m Model) complianceEnabled() bool {
+	return m.cfg != nil && !m.cfg.File.Compliance.IsEmpty()
+}
+
+// violations checks a function against the configured compliance policy
+func (m Model) violations(fn provider.FunctionInfo) []insights.Violation {
+	if !m.complianceEnabled() {
+		return nil
+	}
+	return insights.CheckCompliance(fn, m.cfg.File.Compliance)
+}
+
+// formatComplianceCell renders the Compliance column value
+func formatComplianceCell(violations []insights.Violation) string {
+	if len(violations) == 0 {
+		return "✅"
+	}
+	return fmt.Sprintf("❌ %d", len(violations))
+}
+
+// openComplianceView lists the policy violations of every function
+func (m Model) openComplianceView() (tea.Model, tea.Cmd) {
+	if !m.complianceEnabled() {
+		m.statusMsg = fmt.Sprintf("No compliance policy configured. Add a compliance section to %s", m.cfg.ConfigPath)
+		return m, nil
+	}
+	m.currentView = ComplianceView
+	m.refreshComplianceView()
+	return m, nil
+}
+
+// refreshComplianceView renders the violations grouped by function
+func (m *Model) refreshComplianceView() {
+	policy := m.cfg.File.Compliance
+	var b strings.Builder
+	b.WriteString(styles.SelectedStyle.Render("━━━ Compliance violations ━━━") + "\n\n")
+
+	var rules []string
+	if len(policy.AllowedRuntimes) > 0 {
+		rules = append(rules, "runtimes: "+strings.Join(policy.AllowedRuntimes, ", "))
+	}
+	if policy.MaxTimeout > 0 {
+		rules = append(rules, fmt.Sprintf("max timeout: %ds", policy.MaxTimeout))
+	}
+	if len(policy.RequiredTags) > 0 {
+		rules = append(rules, "required tags: "+strings.Join(policy.RequiredTags, ", "))
+	}
+	if len(policy.ForbiddenRegions) > 0 {
+		rules = append(rules, "forbidden regions: "+strings.Join(policy.ForbiddenRegions, ", "))
+	}
+	b.WriteString(styles.HelpStyle.Render("Policy: "+strings.Join(rules, " • ")) + "\n\n")
+
+	failing, total := 0, 0
+	tagsMissing := false
+	for _, fn := range m.list.allFunctions {
+		tagsMissing = tagsMissing || fn.Tags == nil
+		violations := m.violations(fn)
+		if len(violations) == 0 {
+			continue
+		}
+		failing++
+		total += len(violations)
+		b.WriteString(styles.InfoLabelStyle.Render(fn.Name) + "\n")
+		for _, v := range violations {
+			b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  ❌ %-8s %s", v.Rule, v.Detail)) + "\n")
+		}
+	}
+
+	if failing == 0 {
+		b.WriteString(fmt.Sprintf("✅ All %d functions comply with the policy.\n", len(m.list.allFunctions)))
+	} else {
+		b.WriteString(fmt.Sprintf("\n%d violations in %d of %d functions\n", total, failing, len(m.list.allFunctions)))
+	}
+	if len(policy.RequiredTags) > 0 && tagsMissing {
+		b.WriteString(styles.HelpStyle.Render("\nRequired tags were not checked for functions whose tags could not be loaded") + "\n")
+	}
+	m.viewport.SetContent(b.String())
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/charts"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// concurrencyWarnRatio highlights periods where concurrency approached the limit
+const concurrencyWarnRatio = 0.8
+
+type concurrencyLoadedMsg struct {
+	functionName string
+	info         *provider.ConcurrencyInfo
+	err          error
+}
+
+// fetchConcurrency loads concurrency usage and limits over the metrics window, if supported
+func (m Model) fetchConcurrency(name string) tea.Cmd {
+	reporter, ok := provider.As[provider.ConcurrencyReporter](m.provider)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		endTime := time.Now()
+		info, err := reporter.GetConcurrency(context.Background(), name, endTime.Add(-1*time.Hour), endTime)
+		if err != nil {
+			logger.Logger.Printf("Error fetching concurrency for %s: %v", name, err)
+		}
+		return concurrencyLoadedMsg{functionName: name, info: info, err: err}
+	}
+}
+
+// renderConcurrency charts concurrent executions against the limit and
+// provisioned concurrency utilization
+func (mt metricsModel) renderConcurrency(width int) string {
+	if mt.concurrencyErr != nil {
+		return styles.HelpStyle.Render(fmt.Sprintf("Concurrency unavailable: %v", mt.concurrencyErr))
+	}
+	info := mt.concurrency
+	if info == nil {
+		return styles.HelpStyle.Render("Loading concurrency...")
+	}
+
+	var sections []string
+	title := fmt.Sprintf("🚦 Concurrent executions vs %s limit (%d)", info.LimitSource, info.Limit)
+	sections = append(sections, charts.RenderLimitChart(info.Executions, float64(info.Limit), concurrencyWarnRatio, width-8, 10, title))
+
+	var near []string
+	for _, point := range info.Executions {
+		if info.Limit > 0 && point.Value >= float64(info.Limit)*concurrencyWarnRatio {
+			near = append(near, point.Timestamp.Format("15:04"))
+		}
+	}
+	if len(near) > 0 {
+		sections = append(sections, styles.ErrorStyle.Render(fmt.Sprintf("⚠️  Reached %.0f%% of the limit in %d periods: %s",
+			concurrencyWarnRatio*100, len(near), strings.Join(near, ", "))))
+	}
+
+	if info.Provisioned > 0 {
+		utilization := make([]provider.MetricDataPoint, 0, len(info.ProvisionedUtilization))
+		for _, point := range info.ProvisionedUtilization {
+			utilization = append(utilization, provider.MetricDataPoint{Timestamp: point.Timestamp, Value: point.Value * float64(info.Provisioned)})
+		}
+		title := fmt.Sprintf("🔋 Provisioned concurrency in use (%d allocated)", info.Provisioned)
+		sections = append(sections, charts.RenderLimitChart(utilization, float64(info.Provisioned), concurrencyWarnRatio, width-8, 10, title))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type browserOpenedMsg struct {
+	url string
+	err error
+}
+
+// browserCommand returns the command opening url in the default browser
+func browserCommand(url string) *exec.Cmd {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		return exec.Command("xdg-open", url)
+	}
+}
+
+// openInConsole opens the current function's page in the cloud console
+func (m Model) openInConsole() (tea.Model, tea.Cmd) {
+	fn := m.currentFunction()
+	if fn == nil {
+		return m, nil
+	}
+	linker, ok := provider.As[provider.ConsoleLinker](m.provider)
+	if !ok {
+		m.statusMsg = fmt.Sprintf("Console links are not supported for %s", strings.ToUpper(string(m.provider.GetProviderName())))
+		return m, nil
+	}
+
+	url := linker.ConsoleURL(*fn)
+	logger.Logger.Printf("Opening console page %s", url)
+	m.statusMsg = "Opening " + url
+	return m, func() tea.Msg {
+		// Don't wait for the opener: some stay attached to the browser
+		cmd := browserCommand(url)
+		if err := cmd.Start(); err != nil {
+			return browserOpenedMsg{url: url, err: err}
+		}
+		go cmd.Wait()
+		return browserOpenedMsg{url: url}
+	}
+}
+
+// handleBrowserOpened reports a browser that could not be started, with the
+// URL to open by hand
+func (m Model) handleBrowserOpened(msg browserOpenedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		logger.Logger.Printf("Error opening browser: %v", msg.err)
+		m.statusMsg = fmt.Sprintf("❌ Could not open a browser (%v). Open %s", msg.err, msg.url)
+	}
+	return m, nil
+}
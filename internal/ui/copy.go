@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type referencesLoadedMsg struct {
+	function string
+	refs     []provider.FunctionReference
+	err      error
+}
+
+// openCopyPicker loads the current function's ARN, log group and URL and
+// offers to copy one of them
+func (m Model) openCopyPicker() (tea.Model, tea.Cmd) {
+	fn := m.currentFunction()
+	if fn == nil {
+		return m, nil
+	}
+	lister, ok := provider.As[provider.ReferenceLister](m.provider)
+	if !ok {
+		m.statusMsg = fmt.Sprintf("Copying references is not supported for %s", strings.ToUpper(string(m.provider.GetProviderName())))
+		return m, nil
+	}
+
+	target := *fn
+	m.statusMsg = "Loading references of " + target.Name + "..."
+	return m, func() tea.Msg {
+		refs, err := lister.References(context.Background(), target)
+		if err != nil {
+			// The ARN and log group are still worth offering
+			logger.Logger.Printf("Error loading references of %s: %v", target.Name, err)
+		}
+		return referencesLoadedMsg{function: target.Name, refs: refs, err: err}
+	}
+}
+
+// handleReferencesLoaded opens the copy picker with the loaded references
+func (m Model) handleReferencesLoaded(msg referencesLoadedMsg) (tea.Model, tea.Cmd) {
+	if len(msg.refs) == 0 {
+		m.statusMsg = fmt.Sprintf("❌ Failed to load references of %s: %v", msg.function, msg.err)
+		return m, nil
+	}
+	m.statusMsg = ""
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Function URL unavailable: %v", msg.err)
+	}
+
+	items := make([]pickerItem, 0, len(msg.refs))
+	for _, ref := range msg.refs {
+		items = append(items, pickerItem{label: ref.Label, detail: ref.Value})
+	}
+	refs := msg.refs
+	m.openPicker("Copy from "+msg.function, items, func(m Model, idx int) (tea.Model, tea.Cmd) {
+		ref := refs[idx]
+		if err := clipboard.WriteAll(ref.Value); err != nil {
+			logger.Logger.Printf("Error copying %s to clipboard: %v", ref.Label, err)
+			m.statusMsg = fmt.Sprintf("%s: %s (clipboard unavailable)", ref.Label, ref.Value)
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("✅ Copied %s: %s", ref.Label, ref.Value)
+		return m, nil
+	})
+	return m, nil
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"f6n/internal/config"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CredentialsPrompt is shown instead of the function list when the GCP
+// Application Default Credentials are missing or expired. It can run the
+// gcloud login flow and starts the regular UI once credentials work.
+type CredentialsPrompt struct {
+	cfg     *config.Config
+	bus     *Bus
+	err     *provider.CredentialsError
+	connect func() (provider.Provider, error)
+	status  string
+}
+
+type loginFinishedMsg struct {
+	err error
+}
+
+// NewCredentialsPrompt creates the prompt for err. connect builds the
+// provider again once the user has logged in; the regular UI then uses bus.
+func NewCredentialsPrompt(cfg *config.Config, bus *Bus, err *provider.CredentialsError, connect func() (provider.Provider, error)) CredentialsPrompt {
+	return CredentialsPrompt{cfg: cfg, bus: bus, err: err, connect: connect}
+}
+
+// Init does nothing until the user picks an action
+func (p CredentialsPrompt) Init() tea.Cmd {
+	return nil
+}
+
+// Update runs the login flow, retries the connection or quits
+func (p CredentialsPrompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			return p, tea.Quit
+		case "l":
+			return p.login(false)
+		case "n":
+			return p.login(true)
+		case "r", "enter":
+			return p.retry()
+		}
+	case loginFinishedMsg:
+		if msg.err != nil {
+			logger.Logger.Printf("gcloud login failed: %v", msg.err)
+			p.status = fmt.Sprintf("❌ Login failed: %v", msg.err)
+			return p, nil
+		}
+		return p.retry()
+	}
+	return p, nil
+}
+
+// login suspends the TUI while gcloud runs the login flow, in the browser or
+// by copying a URL when noBrowser is set
+func (p CredentialsPrompt) login(noBrowser bool) (tea.Model, tea.Cmd) {
+	if _, err := exec.LookPath("gcloud"); err != nil {
+		p.status = "❌ gcloud not found. Install the Google Cloud CLI (https://cloud.google.com/sdk/docs/install) or set GOOGLE_APPLICATION_CREDENTIALS to a key file"
+		return p, nil
+	}
+	args := strings.Fields(provider.ADCLoginCommand)
+	if noBrowser {
+		args = append(args, "--no-launch-browser")
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	logger.Logger.Printf("Running %s", strings.Join(cmd.Args, " "))
+	return p, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return loginFinishedMsg{err: err}
+	})
+}
+
+// retry connects again and hands over to the regular UI on success
+func (p CredentialsPrompt) retry() (tea.Model, tea.Cmd) {
+	prov, err := p.connect()
+	if err != nil {
+		logger.Logger.Printf("Retrying GCP connection failed: %v", err)
+		var credsErr *provider.CredentialsError
+		if errors.As(err, &credsErr) {
+			p.err = credsErr
+			p.status = "❌ Credentials still missing or expired"
+		} else {
+			p.status = fmt.Sprintf("❌ %v", err)
+		}
+		return p, nil
+	}
+
+	// The regular UI sizes itself from the WindowSizeMsg it would have had at startup
+	m := NewModel(prov, p.cfg, p.bus)
+	return m, tea.Batch(m.Init(), tea.WindowSize())
+}
+
+// View explains the problem and the available actions
+func (p CredentialsPrompt) View() string {
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render("f6n - GCP credentials required") + "\n\n")
+
+	if p.err.Expired {
+		b.WriteString("Your Application Default Credentials were rejected: they have expired or\nbeen revoked.\n\n")
+	} else {
+		b.WriteString("No Application Default Credentials were found. f6n uses them to call the\nCloud Functions, Logging and Monitoring APIs.\n\n")
+	}
+	b.WriteString(styles.HelpStyle.Render(p.err.Err.Error()) + "\n\n")
+	b.WriteString("Create or refresh them with:\n\n")
+	b.WriteString("    " + styles.CommandValueStyle.Render(provider.ADCLoginCommand) + "\n\n")
+	b.WriteString("or point GOOGLE_APPLICATION_CREDENTIALS at a service account key file.\n\n")
+
+	keys := []struct{ key, action string }{
+		{"l", "log in now (opens a browser)"},
+		{"n", "log in without a browser (prints a URL to open elsewhere)"},
+		{"r", "retry after logging in from another terminal"},
+		{"q", "quit"},
+	}
+	for _, k := range keys {
+		b.WriteString(fmt.Sprintf("  %s  %s\n", styles.CommandKeyStyle.Render(k.key), k.action))
+	}
+	if p.status != "" {
+		b.WriteString("\n" + p.status + "\n")
+	}
+	return styles.ViewportStyle.Render(b.String())
+}
@@ -0,0 +1,342 @@
+package ui
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// curlTimeout bounds a smoke test request
+	curlTimeout = 30 * time.Second
+	// curlBodyLimit caps the response body read and shown
+	curlBodyLimit = 1 << 20
+	// curlHeaderSeparator separates the headers typed in the request form
+	curlHeaderSeparator = "|"
+)
+
+// httpRequest is a smoke test request sent to a function's HTTP endpoint
+type httpRequest struct {
+	function string
+	method   string
+	url      string
+	headers  []string // "Name: value"
+	body     string
+}
+
+// httpResponse is what the endpoint answered
+type httpResponse struct {
+	status    string
+	code      int
+	headers   http.Header
+	body      []byte
+	truncated bool // The body was longer than curlBodyLimit
+	latency   time.Duration
+}
+
+// curlTargetMsg carries the URL of a function to send a request to: its
+// function URL or HTTPS trigger, empty when it has none. With send set the
+// request is sent right away rather than shown in the form first.
+type curlTargetMsg struct {
+	request httpRequest
+	send    bool
+	path    string // Appended to the function URL, e.g. /health
+	err     error
+}
+
+type httpResponseMsg struct {
+	request  httpRequest
+	response *httpResponse
+	err      error
+}
+
+// openCurl handles "C", looking up the function's URL to fill the request form
+func (m Model) openCurl() (tea.Model, tea.Cmd) {
+	if m.selectedFunc == nil {
+		return m, nil
+	}
+	if m.lastRequest != nil && m.lastRequest.function == m.selectedFunc.Name {
+		// Edit the request sent last
+		return m.openCurlForm(*m.lastRequest)
+	}
+	return m, m.resolveCurlTarget(httpRequest{function: m.selectedFunc.Name, method: http.MethodGet}, "", false)
+}
+
+// executeCurlCommand handles ":curl [method] [url|/path] [body]", sending a
+// request to a URL, or to a path of the selected function's URL
+func (m Model) executeCurlCommand(args []string) (tea.Model, tea.Cmd) {
+	if m.currentView == ListView {
+		m.selectedFunc = m.currentFunction()
+	}
+	req := httpRequest{method: http.MethodGet}
+	if m.selectedFunc != nil {
+		req.function = m.selectedFunc.Name
+	}
+	if len(args) > 0 && isHTTPMethod(args[0]) {
+		req.method, args = strings.ToUpper(args[0]), args[1:]
+	}
+	path := ""
+	if len(args) > 0 && (strings.HasPrefix(args[0], "/") || strings.Contains(args[0], "://")) {
+		path, args = args[0], args[1:]
+	}
+	req.body = strings.Join(args, " ")
+
+	if strings.Contains(path, "://") {
+		req.url = path
+		m.statusMsg = fmt.Sprintf("Sending %s %s...", req.method, req.url)
+		return m, sendHTTPRequest(req)
+	}
+	if m.selectedFunc == nil {
+		m.statusMsg = "Usage: :curl [method] [url|/path] [body], with a function selected for paths"
+		return m, nil
+	}
+	return m, m.resolveCurlTarget(req, path, true)
+}
+
+// isHTTPMethod reports whether s names an HTTP method
+func isHTTPMethod(s string) bool {
+	return slices.Contains([]string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}, strings.ToUpper(s))
+}
+
+// resolveCurlTarget looks up the URL of the request's function
+func (m *Model) resolveCurlTarget(req httpRequest, path string, send bool) tea.Cmd {
+	lister, ok := provider.As[provider.ReferenceLister](m.provider)
+	fn, found := m.findFunction(req.function)
+	if !ok || !found {
+		return func() tea.Msg { return curlTargetMsg{request: req, send: send, path: path} }
+	}
+	m.statusMsg = "Looking up the URL of " + req.function + "..."
+	return func() tea.Msg {
+		refs, err := lister.References(context.Background(), fn)
+		for _, ref := range refs {
+			if ref.Label == "URL" {
+				req.url = ref.Value
+			}
+		}
+		return curlTargetMsg{request: req, send: send, path: path, err: err}
+	}
+}
+
+// handleCurlTarget sends the request to the function's URL, or shows the
+// request form
+func (m Model) handleCurlTarget(msg curlTargetMsg) (tea.Model, tea.Cmd) {
+	m.statusMsg = ""
+	req := msg.request
+	if req.url != "" && msg.path != "" {
+		req.url = strings.TrimRight(req.url, "/") + msg.path
+	}
+	if !msg.send || req.url == "" {
+		if req.url == "" {
+			m.statusMsg = fmt.Sprintf("%s has no function URL or HTTPS trigger; enter an endpoint such as its API Gateway URL", req.function)
+			if msg.err != nil {
+				m.statusMsg = fmt.Sprintf("Function URL unavailable: %v", msg.err)
+			}
+		}
+		return m.openCurlForm(req)
+	}
+	m.statusMsg = fmt.Sprintf("Sending %s %s...", req.method, req.url)
+	return m, sendHTTPRequest(req)
+}
+
+// openCurlForm shows the request for editing before it is sent
+func (m Model) openCurlForm(req httpRequest) (tea.Model, tea.Cmd) {
+	fields := []formField{
+		newFormField("method", "Method", req.method, "GET, POST, PUT, PATCH, DELETE..."),
+		newFormField("url", "URL", req.url, "https://... function URL or API Gateway endpoint"),
+		newFormField("headers", "Headers", strings.Join(req.headers, " "+curlHeaderSeparator+" "), "Name: value | Name: value"),
+		newFormField("body", "Body", req.body, `optional, e.g. {"ping": true}`),
+	}
+	cmd := m.openForm("HTTP request to "+cmp.Or(req.function, "an endpoint"), fields, func(m Model, values map[string]string) (Model, tea.Cmd, error) {
+		next, err := parseHTTPRequest(req.function, values)
+		if err != nil {
+			return m, nil, err
+		}
+		m.statusMsg = fmt.Sprintf("Sending %s %s...", next.method, next.url)
+		return m, sendHTTPRequest(next), nil
+	})
+	return m, cmd
+}
+
+// parseHTTPRequest validates the request form values
+func parseHTTPRequest(function string, values map[string]string) (httpRequest, error) {
+	req := httpRequest{
+		function: function,
+		method:   strings.ToUpper(values["method"]),
+		url:      values["url"],
+		body:     values["body"],
+	}
+	if req.method == "" {
+		req.method = http.MethodGet
+	}
+	if !isHTTPMethod(req.method) {
+		return req, fmt.Errorf("unsupported method %q", req.method)
+	}
+	u, err := url.Parse(req.url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return req, fmt.Errorf("URL must be an http:// or https:// address")
+	}
+	for _, header := range strings.Split(values["headers"], curlHeaderSeparator) {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(header, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return req, fmt.Errorf("header %q must be Name: value", header)
+		}
+		req.headers = append(req.headers, strings.TrimSpace(name)+": "+strings.TrimSpace(value))
+	}
+	return req, nil
+}
+
+// sendHTTPRequest sends the request in the background, timing the response
+func sendHTTPRequest(req httpRequest) tea.Cmd {
+	return func() tea.Msg {
+		resp, err := doHTTPRequest(req)
+		if err != nil {
+			logger.Logger.Printf("Error sending %s %s: %v", req.method, req.url, err)
+		}
+		return httpResponseMsg{request: req, response: resp, err: err}
+	}
+}
+
+// doHTTPRequest sends the request and reads up to curlBodyLimit of the body
+func doHTTPRequest(req httpRequest) (*httpResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), curlTimeout)
+	defer cancel()
+
+	var body io.Reader
+	if req.body != "" {
+		body = strings.NewReader(req.body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, req.url, body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	for _, header := range req.headers {
+		name, value, _ := strings.Cut(header, ":")
+		httpReq.Header.Add(name, strings.TrimSpace(value))
+	}
+	trimmed := strings.TrimSpace(req.body)
+	if httpReq.Header.Get("Content-Type") == "" && (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, curlBodyLimit+1))
+	latency := time.Since(start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response: %w", err)
+	}
+
+	out := &httpResponse{status: resp.Status, code: resp.StatusCode, headers: resp.Header, body: data, latency: latency}
+	if len(data) > curlBodyLimit {
+		out.body, out.truncated = data[:curlBodyLimit], true
+	}
+	return out, nil
+}
+
+// handleHTTPResponse shows the response in HTTPView
+func (m Model) handleHTTPResponse(msg httpResponseMsg) (tea.Model, tea.Cmd) {
+	req := msg.request
+	m.lastRequest = &req
+	m.statusMsg = ""
+	m.currentView = HTTPView
+	m.viewport.SetContent(renderHTTPResponse(msg, m.units()))
+	m.viewport.GotoTop()
+	return m, nil
+}
+
+// renderHTTPResponse renders the request sent and the status, latency,
+// headers and body of the response
+func renderHTTPResponse(msg httpResponseMsg, units unitFormat) string {
+	var b strings.Builder
+	req := msg.request
+	b.WriteString(styles.SelectedStyle.Render(fmt.Sprintf("━━━ %s %s ━━━", req.method, req.url)) + "\n")
+	for _, header := range req.headers {
+		b.WriteString(styles.HelpStyle.Render("  "+header) + "\n")
+	}
+	if req.body != "" {
+		b.WriteString(styles.HelpStyle.Render("  "+req.body) + "\n")
+	}
+	b.WriteString("\n")
+
+	if msg.err != nil {
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("❌ Request failed: %v", msg.err)))
+		return b.String()
+	}
+	resp := msg.response
+	status := "✅ " + resp.status
+	if resp.code >= 400 {
+		status = styles.ErrorStyle.Render("❌ " + resp.status)
+	}
+	size := units.bytes(int64(len(resp.body)))
+	if resp.truncated {
+		size = "over " + size
+	}
+	b.WriteString(fmt.Sprintf("%s  •  %s  •  %s\n\n", status, units.millis(float64(resp.latency.Microseconds())/1000), size))
+
+	b.WriteString(styles.InfoLabelStyle.Render("Headers:") + "\n")
+	names := make([]string, 0, len(resp.headers))
+	for name := range resp.headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		b.WriteString(fmt.Sprintf("  %s: %s\n", name, strings.Join(resp.headers[name], ", ")))
+	}
+
+	b.WriteString("\n" + styles.InfoLabelStyle.Render("Body:") + "\n")
+	switch {
+	case len(resp.body) == 0:
+		b.WriteString(styles.HelpStyle.Render("(empty)"))
+	case json.Valid(resp.body):
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, resp.body, "", "  "); err == nil {
+			b.Write(pretty.Bytes())
+		} else {
+			b.Write(resp.body)
+		}
+	default:
+		b.WriteString(strings.ToValidUTF8(string(resp.body), "�"))
+	}
+	if resp.truncated {
+		b.WriteString("\n" + styles.HelpStyle.Render(fmt.Sprintf("… body cut at %s", units.bytes(curlBodyLimit))))
+	}
+	return b.String()
+}
+
+// handleHTTPKey handles the HTTPView keys
+func (m Model) handleHTTPKey(key string) (tea.Model, tea.Cmd, bool) {
+	if m.lastRequest == nil {
+		return m, nil, false
+	}
+	switch key {
+	case "r":
+		m.statusMsg = fmt.Sprintf("Sending %s %s...", m.lastRequest.method, m.lastRequest.url)
+		return m, sendHTTPRequest(*m.lastRequest), true
+	case "C":
+		model, cmd := m.openCurlForm(*m.lastRequest)
+		return model, cmd, true
+	}
+	return m, nil, false
+}
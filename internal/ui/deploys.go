@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// deployHistory is the DeploysView state
+type deployHistory struct {
+	function    string
+	deployments []provider.Deployment // nil while loading
+	err         error
+}
+
+type deploymentsLoadedMsg struct {
+	function    string
+	deployments []provider.Deployment
+	err         error
+}
+
+// executeDeploysCommand handles ":deploys [function]", the deploy history of
+// the named or selected function
+func (m Model) executeDeploysCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) > 1 {
+		m.statusMsg = "Usage: :deploys [function]"
+		return m, nil
+	}
+	if len(args) == 1 {
+		fn, ok := m.findFunction(args[0])
+		if !ok {
+			m.statusMsg = fmt.Sprintf("Function %s not found", args[0])
+			return m, nil
+		}
+		m.selectedFunc = &fn
+		return m.openDeploysView()
+	}
+	fn := m.currentFunction()
+	if fn == nil {
+		m.statusMsg = "Select a function to show its deployments"
+		return m, nil
+	}
+	m.selectedFunc = fn
+	return m.openDeploysView()
+}
+
+// openDeploysView handles "H" in DetailView, showing the recent deployments
+// of the selected function
+func (m Model) openDeploysView() (tea.Model, tea.Cmd) {
+	if m.selectedFunc == nil {
+		return m, nil
+	}
+	lister, ok := provider.As[provider.DeploymentLister](m.provider)
+	if !ok {
+		m.statusMsg = fmt.Sprintf("Deploy history is not supported for %s", strings.ToUpper(string(m.provider.GetProviderName())))
+		return m, nil
+	}
+	fn := *m.selectedFunc
+	m.currentView = DeploysView
+	m.deploys = deployHistory{function: fn.Name}
+	m.viewport.SetContent(m.deploys.render(m.units()))
+	m.viewport.GotoTop()
+	return m, func() tea.Msg {
+		deployments, err := lister.ListDeployments(context.Background(), fn)
+		if err != nil {
+			logger.Logger.Printf("Error listing deployments of %s: %v", fn.Name, err)
+		}
+		return deploymentsLoadedMsg{function: fn.Name, deployments: deployments, err: err}
+	}
+}
+
+// handleDeploymentsLoaded shows the deployments, if still wanted
+func (m Model) handleDeploymentsLoaded(msg deploymentsLoadedMsg) (tea.Model, tea.Cmd) {
+	m.usage.Error("list-deployments", msg.err)
+	if msg.function != m.deploys.function {
+		return m, nil
+	}
+	m.deploys.deployments, m.deploys.err = msg.deployments, msg.err
+	if m.deploys.deployments == nil {
+		m.deploys.deployments = []provider.Deployment{}
+	}
+	if m.currentView == DeploysView {
+		m.viewport.SetContent(m.deploys.render(m.units()))
+	}
+	return m, nil
+}
+
+// handleDeploysKey handles the DeploysView keys
+func (m Model) handleDeploysKey(key string) (tea.Model, tea.Cmd, bool) {
+	if key != "r" {
+		return m, nil, false
+	}
+	model, cmd := m.openDeploysView()
+	return model, cmd, true
+}
+
+// render lists the deployments from the most recent: status, what changed,
+// when, how long and by whom, the source version deployed and where the
+// build logs or the recorded event are
+func (d deployHistory) render(units unitFormat) string {
+	var b strings.Builder
+	b.WriteString(styles.SelectedStyle.Render(fmt.Sprintf("━━━ Deployments of %s ━━━", d.function)) + "\n\n")
+	switch {
+	case d.err != nil:
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("❌ Failed to list deployments: %v", d.err)))
+		return b.String()
+	case d.deployments == nil:
+		b.WriteString(styles.HelpStyle.Render("Loading..."))
+		return b.String()
+	case len(d.deployments) == 0:
+		b.WriteString(styles.HelpStyle.Render("No recent deployments found"))
+		return b.String()
+	}
+
+	for _, dep := range d.deployments {
+		status := deploymentStatus(dep.Status)
+		if dep.Current {
+			status += styles.InfoLabelStyle.Render("  (current)")
+		}
+		b.WriteString(fmt.Sprintf("%s  %s\n", status, dep.ID))
+		if dep.Change != "" {
+			b.WriteString("  " + dep.Change + "\n")
+		}
+
+		when := "  " + dep.Started.Local().Format("2006-01-02 15:04:05")
+		switch {
+		case dep.Started.IsZero():
+			when = "  not started"
+		case !dep.Finished.IsZero():
+			when += ", took " + units.millis(float64(dep.Finished.Sub(dep.Started).Milliseconds()))
+		case deploymentRunning(dep.Status):
+			when += ", running for " + formatDuration(time.Since(dep.Started).Truncate(time.Second))
+		}
+		if dep.Actor != "" {
+			when += " by " + dep.Actor
+		}
+		b.WriteString(when + "\n")
+		if dep.Source != "" {
+			b.WriteString("  Source: " + dep.Source + "\n")
+		}
+		if dep.URL != "" {
+			b.WriteString(styles.HelpStyle.Render("  Link: "+dep.URL) + "\n")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// deploymentStatus marks the outcome of a deployment
+func deploymentStatus(status string) string {
+	switch strings.ToUpper(status) {
+	case "SUCCESS", "SUCCEEDED":
+		return "✅ " + status
+	case "FAILURE", "FAILED", "INTERNAL_ERROR", "TIMEOUT", "CANCELLED", "EXPIRED":
+		return styles.ErrorStyle.Render("❌ " + status)
+	}
+	return "⟳ " + status
+}
+
+// deploymentRunning reports whether a deployment is queued or in progress
+func deploymentRunning(status string) bool {
+	switch strings.ToUpper(status) {
+	case "QUEUED", "PENDING", "WORKING", "IN_PROGRESS":
+		return true
+	}
+	return false
+}
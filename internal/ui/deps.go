@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"f6n/internal/deps"
+	"f6n/internal/logger"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// dependencies holds the DepsView state
+type dependencies struct {
+	function string
+	list     []deps.Dependency
+	vulns    [][]string // Known vulnerability IDs, indexed like list; nil until checked
+	checking bool
+	err      error
+}
+
+type depsScannedMsg struct {
+	function string
+	list     []deps.Dependency
+	err      error
+}
+
+type vulnsCheckedMsg struct {
+	function string
+	vulns    [][]string
+	err      error
+}
+
+// executeDepsCommand handles ":deps", listing the dependencies declared in the
+// downloaded code of the current function
+func (m Model) executeDepsCommand() (tea.Model, tea.Cmd) {
+	fn := m.currentFunction()
+	if fn == nil {
+		m.statusMsg = "No function selected"
+		return m, nil
+	}
+	dir := filepath.Join("downloads", fn.Name)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		m.statusMsg = fmt.Sprintf("Code of %s is not downloaded yet. Press 'w' in the list to download it", fn.Name)
+		return m, nil
+	}
+
+	name := fn.Name
+	m.selectedFunc = fn
+	m.currentView = DepsView
+	m.deps = dependencies{function: name}
+	m.viewport.SetContent(fmt.Sprintf("Reading package manifests of %s...", name))
+	return m, func() tea.Msg {
+		list, err := deps.Scan(dir)
+		if err != nil {
+			logger.Logger.Printf("Error scanning dependencies of %s: %v", name, err)
+		}
+		return depsScannedMsg{function: name, list: list, err: err}
+	}
+}
+
+// handleDepsKey handles the DepsView specific keys
+func (m Model) handleDepsKey(key string) (tea.Model, tea.Cmd, bool) {
+	if key != "c" {
+		return m, nil, false
+	}
+	if m.deps.checking || len(m.deps.list) == 0 {
+		return m, nil, true
+	}
+
+	m.deps.checking = true
+	m.deps.err = nil
+	m.statusMsg = "Looking up known vulnerabilities in OSV..."
+	name, list := m.deps.function, m.deps.list
+	return m, func() tea.Msg {
+		vulns, err := deps.CheckVulnerabilities(context.Background(), list)
+		if err != nil {
+			logger.Logger.Printf("Error checking vulnerabilities of %s: %v", name, err)
+		}
+		return vulnsCheckedMsg{function: name, vulns: vulns, err: err}
+	}, true
+}
+
+// handleDepsMsg records scanned dependencies and vulnerability lookups
+func (m Model) handleDepsMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case depsScannedMsg:
+		if msg.function != m.deps.function {
+			return m, nil
+		}
+		m.deps.list, m.deps.err = msg.list, msg.err
+	case vulnsCheckedMsg:
+		if msg.function != m.deps.function {
+			return m, nil
+		}
+		m.deps.checking = false
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Vulnerability lookup failed: %v", msg.err)
+			return m, nil
+		}
+		m.deps.vulns = msg.vulns
+		vulnerable := 0
+		for _, ids := range msg.vulns {
+			if len(ids) > 0 {
+				vulnerable++
+			}
+		}
+		m.statusMsg = fmt.Sprintf("✅ No known vulnerabilities in %s's pinned dependencies", msg.function)
+		if vulnerable > 0 {
+			m.statusMsg = fmt.Sprintf("⚠️  %d dependencies of %s have known vulnerabilities", vulnerable, msg.function)
+		}
+	}
+	if m.currentView == DepsView {
+		m.refreshDepsView()
+	}
+	return m, nil
+}
+
+// refreshDepsView renders the dependencies grouped by manifest
+func (m *Model) refreshDepsView() {
+	d := m.deps
+	var b strings.Builder
+	b.WriteString(styles.SelectedStyle.Render(fmt.Sprintf("━━━ Dependencies of %s ━━━", d.function)) + "\n\n")
+
+	switch {
+	case d.err != nil:
+		b.WriteString(fmt.Sprintf("Error reading package manifests: %v", d.err))
+		m.viewport.SetContent(b.String())
+		return
+	case len(d.list) == 0:
+		b.WriteString("No package.json, requirements.txt or go.mod found in the downloaded code.")
+		m.viewport.SetContent(b.String())
+		return
+	}
+
+	manifest := ""
+	for i, dep := range d.list {
+		if dep.Manifest != manifest {
+			if manifest != "" {
+				b.WriteString("\n")
+			}
+			manifest = dep.Manifest
+			b.WriteString(styles.InfoLabelStyle.Render(fmt.Sprintf("📄 %s (%s)", manifest, dep.Ecosystem)) + "\n")
+		}
+
+		version := dep.Version
+		if version == "" {
+			version = "any"
+		}
+		var tags []string
+		if dep.Dev {
+			tags = append(tags, "dev")
+		}
+		if dep.Indirect {
+			tags = append(tags, "indirect")
+		}
+		line := fmt.Sprintf("  %-40s %-20s", dep.Name, version)
+		if len(tags) > 0 {
+			line += " " + styles.HelpStyle.Render(strings.Join(tags, ", "))
+		}
+
+		switch {
+		case d.vulns == nil:
+		case len(d.vulns[i]) > 0:
+			line = styles.ErrorStyle.Render(fmt.Sprintf("%s ⚠️  %s", line, strings.Join(d.vulns[i], ", ")))
+		case dep.ExactVersion() == "":
+			line += " " + styles.HelpStyle.Render("(not pinned, not checked)")
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n")
+	switch {
+	case d.checking:
+		b.WriteString(styles.HelpStyle.Render("Looking up known vulnerabilities in OSV..."))
+	case d.vulns == nil:
+		b.WriteString(styles.HelpStyle.Render("Press 'c' to check pinned versions against the OSV vulnerability database"))
+	}
+	m.viewport.SetContent(b.String())
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type descriptionUpdatedMsg struct {
+	function    string
+	description string
+	err         error
+}
+
+// openDescriptionForm handles "d" in DetailView, showing the description of
+// the selected function for editing
+func (m Model) openDescriptionForm() (tea.Model, tea.Cmd) {
+	updater, ok := provider.As[provider.DescriptionUpdater](m.provider)
+	if !ok {
+		m.statusMsg = fmt.Sprintf("Editing descriptions is not supported for %s", strings.ToUpper(string(m.provider.GetProviderName())))
+		return m, nil
+	}
+	if m.selectedFunc == nil || !m.permitted(m.selectedFunc, provider.ActionUpdateDescription) {
+		return m, nil
+	}
+
+	fn := m.selectedFunc.Name
+	fields := []formField{
+		newFormField("description", "Description", m.selectedFunc.Description, "What the function does"),
+	}
+	cmd := m.openForm("Description of "+fn, fields, func(m Model, values map[string]string) (Model, tea.Cmd, error) {
+		description := strings.TrimSpace(values["description"])
+		m.statusMsg = fmt.Sprintf("Updating the description of %s...", fn)
+		return m, updateDescription(updater, fn, description), nil
+	})
+	return m, cmd
+}
+
+// updateDescription sets the description in the background
+func updateDescription(updater provider.DescriptionUpdater, name, description string) tea.Cmd {
+	return func() tea.Msg {
+		err := updater.UpdateDescription(context.Background(), name, description)
+		if err != nil {
+			logger.Logger.Printf("Error updating the description of %s: %v", name, err)
+		}
+		return descriptionUpdatedMsg{function: name, description: description, err: err}
+	}
+}
+
+// handleDescriptionUpdated records a description change and shows it in the
+// details
+func (m Model) handleDescriptionUpdated(msg descriptionUpdatedMsg) (tea.Model, tea.Cmd) {
+	m.recordAction("update-description", msg.function, fmt.Sprintf("%q", msg.description), msg.err)
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %v", msg.err)
+		return m, nil
+	}
+	for i := range m.list.allFunctions {
+		if m.list.allFunctions[i].Name == msg.function {
+			m.list.allFunctions[i].Description = msg.description
+		}
+	}
+	if m.selectedFunc != nil && m.selectedFunc.Name == msg.function {
+		m.selectedFunc.Description = msg.description
+		if m.currentView == DetailView {
+			m.refreshDetailView()
+		}
+	}
+	m.statusMsg = fmt.Sprintf("✅ Updated the description of %s", msg.function)
+	// Reload the list for the update's state
+	return m, m.fetchFunctions()
+}
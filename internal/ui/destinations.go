@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type asyncConfigLoadedMsg struct {
+	functionName string
+	config       *provider.AsyncInvokeConfig
+	err          error
+}
+
+// fetchAsyncConfig loads the asynchronous invocation destinations of a
+// function, if supported
+func (m Model) fetchAsyncConfig(name string) tea.Cmd {
+	reader, ok := provider.As[provider.AsyncConfigReader](m.provider)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		config, err := reader.GetAsyncInvokeConfig(context.Background(), name)
+		if err != nil {
+			logger.Logger.Printf("Error reading async invoke config for %s: %v", name, err)
+		}
+		return asyncConfigLoadedMsg{functionName: name, config: config, err: err}
+	}
+}
+
+// renderDestinations draws where asynchronous invocations go once they
+// succeed or fail:
+//
+//	my-fn ─┬─ on success ─▶ SQS queue done
+//	       └─ on failure ─▶ SNS topic alerts
+func (d detailModel) renderDestinations(name string) string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Async Destinations:") + "\n")
+
+	switch {
+	case d.asyncErr != nil:
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  Error loading destinations: %v", d.asyncErr)) + "\n")
+		return b.String()
+	case d.asyncConfig == nil:
+		b.WriteString(styles.HelpStyle.Render("  Loading...") + "\n")
+		return b.String()
+	case len(d.asyncConfig.Destinations) == 0:
+		b.WriteString(styles.HelpStyle.Render("  No destinations configured") + "\n")
+	}
+
+	indent := strings.Repeat(" ", lipgloss.Width(name)+4)
+	for i, dest := range d.asyncConfig.Destinations {
+		var branch string
+		switch {
+		case len(d.asyncConfig.Destinations) == 1:
+			branch = "  " + name + " ───"
+		case i == 0:
+			branch = "  " + name + " ─┬─"
+		case i == len(d.asyncConfig.Destinations)-1:
+			branch = indent + "└─"
+		default:
+			branch = indent + "├─"
+		}
+
+		condition, style := "on success", styles.InfoValueStyle
+		if dest.Condition == "OnFailure" {
+			condition, style = "on failure", styles.ErrorStyle
+		}
+		b.WriteString(fmt.Sprintf("%s %s ─▶ %s\n", branch, style.Render(condition), describeDestination(dest.Target)))
+	}
+
+	var settings []string
+	if d.asyncConfig.MaxRetries != nil {
+		settings = append(settings, fmt.Sprintf("retries: %d", *d.asyncConfig.MaxRetries))
+	}
+	if d.asyncConfig.MaxEventAge > 0 {
+		settings = append(settings, "max event age: "+d.asyncConfig.MaxEventAge.String())
+	}
+	if len(settings) > 0 {
+		b.WriteString("  " + styles.HelpStyle.Render(strings.Join(settings, " • ")) + "\n")
+	}
+	return b.String()
+}
+
+// describeDestination names the resource of a destination ARN with its
+// type, e.g. "SQS queue orders" for arn:aws:sqs:us-east-1:123:orders
+func describeDestination(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return arn
+	}
+	resource := parts[5]
+	if parts[2] == "lambda" {
+		// Keep the version or alias of function:name:qualifier
+		resource = strings.TrimPrefix(resource, "function:")
+	} else if i := strings.LastIndexAny(resource, ":/"); i >= 0 {
+		resource = resource[i+1:]
+	}
+
+	kinds := map[string]string{
+		"sqs":    "SQS queue",
+		"sns":    "SNS topic",
+		"lambda": "Lambda function",
+		"events": "EventBridge bus",
+		"s3":     "S3 bucket",
+	}
+	kind, ok := kinds[parts[2]]
+	if !ok {
+		return arn
+	}
+	return styles.InfoLabelStyle.Render(kind) + " " + styles.InfoValueStyle.Render(resource)
+}
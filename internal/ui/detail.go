@@ -0,0 +1,314 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// backlogWarnMessages and backlogWarnAge flag a trigger queue that is falling behind
+	backlogWarnMessages = 1000
+	backlogWarnAge      = 5 * time.Minute
+)
+
+type triggersLoadedMsg struct {
+	functionName string
+	mappings     []provider.EventSourceMapping
+	err          error
+}
+
+// fetchTriggers loads the event source mappings of a function, if supported
+func (m Model) fetchTriggers(name string) tea.Cmd {
+	lister, ok := provider.As[provider.TriggerLister](m.provider)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		mappings, err := lister.ListEventSourceMappings(context.Background(), name)
+		if err != nil {
+			logger.Logger.Printf("Error listing event source mappings for %s: %v", name, err)
+		}
+		return triggersLoadedMsg{functionName: name, mappings: mappings, err: err}
+	}
+}
+
+type schedulesLoadedMsg struct {
+	functionName string
+	jobs         []provider.ScheduledJob
+	err          error
+}
+
+// fetchSchedules loads the scheduler jobs invoking a function, if supported
+func (m Model) fetchSchedules(fn provider.FunctionInfo) tea.Cmd {
+	lister, ok := provider.As[provider.ScheduleLister](m.provider)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		jobs, err := lister.ListSchedules(context.Background(), fn)
+		if err != nil {
+			logger.Logger.Printf("Error listing schedules for %s: %v", fn.Name, err)
+		}
+		return schedulesLoadedMsg{functionName: fn.Name, jobs: jobs, err: err}
+	}
+}
+
+// detailModel is the DetailView sub-model: what the function's configuration
+// doesn't tell, loaded when the view opens. A nil list means still loading.
+type detailModel struct {
+	triggers     []provider.EventSourceMapping
+	triggersErr  error
+	schedules    []provider.ScheduledJob
+	schedulesErr error
+	asyncConfig  *provider.AsyncInvokeConfig
+	asyncErr     error
+	retention    *provider.LogRetention
+	retentionErr error
+	shifts       []provider.TrafficShift
+	shiftsErr    error
+	revealed     map[string]bool // environment variables whose value is shown
+}
+
+// Update stores the triggers, schedules, destinations, gradual deployments and
+// log retention of the selected function, and handles the DetailView keys
+func (d detailModel) Update(ctx viewContext, msg tea.Msg) (detailModel, tea.Cmd) {
+	if ctx.fn == nil {
+		return d, nil
+	}
+	switch msg := msg.(type) {
+	case viewKeyMsg:
+		return d, d.handleKey(string(msg))
+	case triggersLoadedMsg:
+		if msg.functionName == ctx.fn.Name {
+			d.triggers, d.triggersErr = msg.mappings, msg.err
+			if d.triggers == nil {
+				d.triggers = []provider.EventSourceMapping{}
+			}
+		}
+	case schedulesLoadedMsg:
+		if msg.functionName == ctx.fn.Name {
+			d.schedules, d.schedulesErr = msg.jobs, msg.err
+			if d.schedules == nil {
+				d.schedules = []provider.ScheduledJob{}
+			}
+		}
+	case asyncConfigLoadedMsg:
+		if msg.functionName == ctx.fn.Name {
+			d.asyncConfig, d.asyncErr = msg.config, msg.err
+			if d.asyncConfig == nil {
+				d.asyncConfig = &provider.AsyncInvokeConfig{}
+			}
+		}
+	case logRetentionLoadedMsg:
+		if msg.functionName == ctx.fn.Name {
+			d.retention, d.retentionErr = msg.retention, msg.err
+		}
+	case trafficShiftsLoadedMsg:
+		if msg.functionName == ctx.fn.Name {
+			d.shifts, d.shiftsErr = msg.shifts, msg.err
+			if d.shifts == nil {
+				d.shifts = []provider.TrafficShift{}
+			}
+		}
+	}
+	return d, nil
+}
+
+// View renders the selected function's details, health, triggers,
+// destinations and gradual deployments
+func (d detailModel) View(ctx viewContext) string {
+	if ctx.fn == nil {
+		return ""
+	}
+	content := formatFunctionDetails(ctx.fn, ctx.provider.GetProviderName(), ctx.units, d.revealed, ctx.markdown) + "\n" + renderHealth(ctx.health(*ctx.fn))
+	if _, ok := provider.As[provider.TriggerLister](ctx.provider); ok {
+		content += "\n" + d.renderTriggers()
+	}
+	if _, ok := provider.As[provider.ScheduleLister](ctx.provider); ok {
+		content += "\n" + d.renderSchedules()
+	}
+	if _, ok := provider.As[provider.AsyncConfigReader](ctx.provider); ok {
+		content += "\n" + d.renderDestinations(ctx.fn.Name)
+	}
+	if _, ok := provider.As[provider.TrafficShiftReader](ctx.provider); ok {
+		content += "\n" + d.renderTrafficShifts()
+	}
+	if _, ok := provider.As[provider.LogRetentionManager](ctx.provider); ok {
+		content += "\n" + d.renderLogRetention(ctx.units)
+	}
+	return content
+}
+
+// openDetailView shows the details of the selected function and loads its
+// triggers, schedules, async destinations, gradual deployments and log
+// retention
+func (m Model) openDetailView() (tea.Model, tea.Cmd) {
+	m.currentView = DetailView
+	m.rememberRecent(DetailView.String())
+	m.detail = detailModel{}
+	m.refreshDetailView()
+	m.announceDraft(envDraft)
+	return m, tea.Batch(m.fetchTriggers(m.selectedFunc.Name), m.fetchSchedules(*m.selectedFunc), m.fetchAsyncConfig(m.selectedFunc.Name),
+		m.fetchLogRetention(*m.selectedFunc), m.fetchTrafficShifts(*m.selectedFunc), m.fetchPermissions(*m.selectedFunc))
+}
+
+// refreshDetailView re-renders the viewport from the detail sub-model
+func (m *Model) refreshDetailView() {
+	if m.selectedFunc != nil {
+		m.viewport.SetContent(m.detail.View(m.viewContext()))
+	}
+}
+
+// detailKeys are the DetailView keys, each opening a view, form or picker of
+// the selected function
+var detailKeys = map[string]func(Model) (tea.Model, tea.Cmd){
+	"F": Model.loadFailedEvents,
+	"S": Model.openSecurityView,
+	"o": Model.openInConsole,
+	"y": Model.openCopyPicker,
+	"e": Model.openEnvEditor,
+	"d": Model.openDescriptionForm,
+	"D": Model.openLoggingForm,
+	"R": Model.openRetentionPicker,
+	"C": Model.openCurl,
+	"H": Model.openDeploysView,
+	"v": Model.openRevealPicker,
+	"i": Model.openInvoke,
+}
+
+// handleKey handles the DetailView keys
+func (d detailModel) handleKey(key string) tea.Cmd {
+	if open, ok := detailKeys[key]; ok {
+		return route(open)
+	}
+	return nil
+}
+
+// renderTriggers renders the event source mappings with queue backlogs, and
+// the storage notifications invoking the function
+func (d detailModel) renderTriggers() string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Triggers:") + "\n")
+
+	switch {
+	case d.triggersErr != nil:
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  Error loading triggers: %v", d.triggersErr)) + "\n")
+		return b.String()
+	case d.triggers == nil:
+		b.WriteString(styles.HelpStyle.Render("  Loading...") + "\n")
+		return b.String()
+	case len(d.triggers) == 0:
+		b.WriteString(styles.HelpStyle.Render("  No event source mappings or bucket notifications") + "\n")
+		return b.String()
+	}
+
+	for _, mapping := range d.triggers {
+		var status []string
+		if mapping.State != "" {
+			status = append(status, mapping.State)
+		}
+		if mapping.BatchSize > 0 {
+			status = append(status, fmt.Sprintf("batch %d", mapping.BatchSize))
+		}
+		line := "  • " + mapping.SourceARN
+		if len(status) > 0 {
+			line += " (" + strings.Join(status, ", ") + ")"
+		}
+		b.WriteString(line + "\n")
+		if mapping.Backlog != nil {
+			b.WriteString("    " + formatBacklog(*mapping.Backlog) + "\n")
+		}
+		b.WriteString(formatEventTrigger(mapping))
+	}
+	return b.String()
+}
+
+// formatEventTrigger renders the event type, filters, Eventarc trigger and
+// retry policy of an S3 notification or event-triggered function
+func formatEventTrigger(mapping provider.EventSourceMapping) string {
+	var b strings.Builder
+	field := func(label, value string) {
+		b.WriteString("    " + styles.InfoLabelStyle.Render(label) + " " + styles.InfoValueStyle.Render(value) + "\n")
+	}
+	if mapping.EventType != "" {
+		field("Event:  ", mapping.EventType)
+	}
+	for _, filter := range mapping.Filters {
+		field("Filter: ", filter)
+	}
+	if mapping.Trigger != "" && mapping.Trigger != mapping.SourceARN {
+		field("Via:    ", mapping.Trigger)
+	}
+	if mapping.Retry != "" {
+		field("Retry:  ", strings.TrimPrefix(mapping.Retry, "RETRY_POLICY_"))
+	}
+	return b.String()
+}
+
+// renderSchedules renders the scheduler jobs invoking the function, answering
+// when it runs
+func (d detailModel) renderSchedules() string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Schedules:") + "\n")
+
+	switch {
+	case d.schedulesErr != nil:
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  Error loading schedules: %v", d.schedulesErr)) + "\n")
+		return b.String()
+	case d.schedules == nil:
+		b.WriteString(styles.HelpStyle.Render("  Loading...") + "\n")
+		return b.String()
+	case len(d.schedules) == 0:
+		b.WriteString(styles.HelpStyle.Render("  No scheduler jobs target this function") + "\n")
+		return b.String()
+	}
+
+	for _, job := range d.schedules {
+		schedule := job.Schedule
+		if job.TimeZone != "" {
+			schedule += " (" + job.TimeZone + ")"
+		}
+		line := fmt.Sprintf("  • %s: %s", job.Name, schedule)
+		if job.State != "" && job.State != "ENABLED" {
+			line += " " + styles.ErrorStyle.Render(job.State)
+		}
+		b.WriteString(line + "\n")
+		if job.Description != "" {
+			b.WriteString("    " + styles.HelpStyle.Render(job.Description) + "\n")
+		}
+		var runs []string
+		if !job.NextRun.IsZero() {
+			runs = append(runs, "next: "+job.NextRun.Local().Format("2006-01-02 15:04:05"))
+		}
+		if !job.LastAttempt.IsZero() {
+			runs = append(runs, "last: "+job.LastAttempt.Local().Format("2006-01-02 15:04:05"))
+		}
+		if len(runs) > 0 {
+			b.WriteString("    " + styles.InfoValueStyle.Render(strings.Join(runs, " • ")) + "\n")
+		}
+		b.WriteString("    " + styles.HelpStyle.Render("→ "+job.Target) + "\n")
+	}
+	return b.String()
+}
+
+// formatBacklog renders a queue backlog, highlighted when the queue falls behind
+func formatBacklog(backlog provider.QueueBacklog) string {
+	age := "age of oldest: n/a"
+	if backlog.OldestAge > 0 {
+		age = fmt.Sprintf("age of oldest: %s", backlog.OldestAge)
+	}
+	line := fmt.Sprintf("backlog: %d messages (%d in flight) • %s", backlog.Messages, backlog.InFlight, age)
+	if backlog.Messages >= backlogWarnMessages || backlog.OldestAge >= backlogWarnAge {
+		return styles.ErrorStyle.Render("⚠️  " + line)
+	}
+	return styles.InfoValueStyle.Render(line)
+}
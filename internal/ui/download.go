@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"f6n/internal/logger"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// bulkDownloadWorkers is the number of concurrent downloads of :download-all
+const bulkDownloadWorkers = 4
+
+// bulkDownloadResult is the outcome of one download of :download-all
+type bulkDownloadResult struct {
+	name string
+	path string
+	err  error
+}
+
+// bulkDownload holds the progress of :download-all
+type bulkDownload struct {
+	names   []string
+	results map[string]bulkDownloadResult
+	running bool
+	grep    string // :grep pattern to run once the downloads finish
+}
+
+type bulkDownloadProgressMsg struct {
+	result bulkDownloadResult
+}
+
+type bulkDownloadDoneMsg struct{}
+
+// executeDownloadAllCommand handles ":download-all", downloading the code of
+// every function matching the current filter
+func (m Model) executeDownloadAllCommand() (tea.Model, tea.Cmd) {
+	if m.bulkDownload != nil && m.bulkDownload.running {
+		m.currentView = BulkDownloadView
+		m.refreshBulkDownloadView()
+		return m, nil
+	}
+	if len(m.list.functions) == 0 {
+		m.statusMsg = "No functions to download"
+		return m, nil
+	}
+
+	names := make([]string, 0, len(m.list.functions))
+	for _, fn := range m.list.functions {
+		names = append(names, fn.Name)
+	}
+
+	// The workers publish each finished download on the bus
+	jobs := make(chan string)
+	prov, bus := m.provider, m.bus
+	var wg sync.WaitGroup
+	for range min(bulkDownloadWorkers, len(names)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				path, err := downloadCode(context.Background(), prov, name)
+				bus.Publish(bulkDownloadProgressMsg{result: bulkDownloadResult{name: name, path: path, err: err}})
+			}
+		}()
+	}
+	go func() {
+		for _, name := range names {
+			jobs <- name
+		}
+		close(jobs)
+		wg.Wait()
+		bus.Publish(bulkDownloadDoneMsg{})
+	}()
+
+	logger.Logger.Printf("Downloading code of %d functions", len(names))
+	m.bulkDownload = &bulkDownload{
+		names:   names,
+		results: make(map[string]bulkDownloadResult, len(names)),
+		running: true,
+	}
+	m.currentView = BulkDownloadView
+	m.refreshBulkDownloadView()
+	return m, nil
+}
+
+// handleBulkDownloadMsg records download progress
+func (m Model) handleBulkDownloadMsg(msg tea.Msg) (tea.Model, tea.Cmd) {
+	d := m.bulkDownload
+	if d == nil {
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case bulkDownloadProgressMsg:
+		d.results[msg.result.name] = msg.result
+	case bulkDownloadDoneMsg:
+		d.running = false
+		failed := d.failed()
+		m.statusMsg = fmt.Sprintf("✅ Downloaded %d of %d functions", len(d.names)-failed, len(d.names))
+		if failed > 0 {
+			m.statusMsg = fmt.Sprintf("⚠️  Downloaded %d of %d functions, %d failed", len(d.names)-failed, len(d.names), failed)
+		}
+		if d.grep != "" && failed < len(d.names) {
+			pattern := d.grep
+			d.grep = ""
+			m.refreshBulkDownloadView()
+			return m.executeGrepCommand(pattern)
+		}
+	}
+	if m.currentView == BulkDownloadView {
+		m.refreshBulkDownloadView()
+	}
+	return m, nil
+}
+
+func (d *bulkDownload) failed() int {
+	failed := 0
+	for _, r := range d.results {
+		if r.err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// refreshBulkDownloadView renders the aggregate progress and the per-function status
+func (m *Model) refreshBulkDownloadView() {
+	d := m.bulkDownload
+	if d == nil {
+		return
+	}
+
+	done, failed := len(d.results), d.failed()
+	var b strings.Builder
+	b.WriteString(styles.SelectedStyle.Render(fmt.Sprintf("━━━ Downloading code of %d functions ━━━", len(d.names))) + "\n\n")
+
+	const barWidth = 40
+	filled := barWidth * done / len(d.names)
+	b.WriteString(fmt.Sprintf("[%s%s] %d/%d", strings.Repeat("█", filled), strings.Repeat("░", barWidth-filled), done, len(d.names)))
+	if failed > 0 {
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  %d failed", failed)))
+	}
+	if !d.running {
+		b.WriteString("  done")
+	}
+	b.WriteString("\n\n")
+
+	for _, name := range d.names {
+		r, ok := d.results[name]
+		switch {
+		case !ok:
+			b.WriteString(styles.HelpStyle.Render("  … "+name) + "\n")
+		case r.err != nil:
+			b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  ✗ %s: %v", name, r.err)) + "\n")
+		default:
+			b.WriteString(fmt.Sprintf("  ✓ %s → %s\n", name, r.path))
+		}
+	}
+	m.viewport.SetContent(b.String())
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"f6n/internal/config"
+	"f6n/internal/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// draftKind is what a draft edits
+type draftKind string
+
+const (
+	codeDraft draftKind = "code"
+	envDraft  draftKind = "environment"
+)
+
+// draft is an unsaved edit kept in the state directory when the editor is
+// left, offered back the next time the function is opened
+type draft struct {
+	Function string    `json:"function"`
+	Kind     draftKind `json:"kind"`
+	Saved    time.Time `json:"saved"`
+	Revision string    `json:"revision,omitempty"` // Revision of the function the edit started from
+	Content  string    `json:"content"`
+}
+
+// draftPath returns where the draft of a function's edit is kept
+func draftPath(kind draftKind, function string) string {
+	return filepath.Join(config.StateDir(), "drafts", string(kind), url.PathEscape(function)+".json")
+}
+
+// writeDraft stores a draft, replacing the previous one of the function
+func writeDraft(d draft) error {
+	path := draftPath(d.Kind, d.Function)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create drafts directory: %w", err)
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	// Drafts may hold environment values, readable by the user only
+	return os.WriteFile(path, data, 0600)
+}
+
+// readDraft returns the draft of a function's edit, nil when there is none
+func readDraft(kind draftKind, function string) (*draft, error) {
+	data, err := os.ReadFile(draftPath(kind, function))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var d draft
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("failed to parse draft: %w", err)
+	}
+	return &d, nil
+}
+
+// deleteDraft removes the draft of a function's edit, if any
+func deleteDraft(kind draftKind, function string) {
+	if err := os.Remove(draftPath(kind, function)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Logger.Printf("Error deleting %s draft of %s: %v", kind, function, err)
+	}
+}
+
+// findDraft looks up the draft of the selected function's edit
+func (m Model) findDraft(kind draftKind) *draft {
+	if m.selectedFunc == nil {
+		return nil
+	}
+	d, err := readDraft(kind, m.selectedFunc.Name)
+	if err != nil {
+		logger.Logger.Printf("Error reading %s draft of %s: %v", kind, m.selectedFunc.Name, err)
+	}
+	return d
+}
+
+// pendingEdit returns the unsaved edit in the code or environment editor
+func (m Model) pendingEdit() (draftKind, string, bool) {
+	if m.selectedFunc == nil {
+		return "", "", false
+	}
+	value := m.textarea.Value()
+	switch {
+	case m.currentView == CodeView && m.code.editMode && m.code.history.dirty(value):
+		return codeDraft, value, true
+	case m.currentView == EnvEditView && value != m.envOriginal:
+		return envDraft, value, true
+	}
+	return "", "", false
+}
+
+// keepDraft saves the unsaved edit, if any, as a draft of the selected function
+func (m *Model) keepDraft() {
+	kind, content, ok := m.pendingEdit()
+	if !ok {
+		return
+	}
+	d := draft{Function: m.selectedFunc.Name, Kind: kind, Saved: time.Now(), Revision: m.editRevision(kind), Content: content}
+	if err := writeDraft(d); err != nil {
+		logger.Logger.Printf("Error saving %s draft of %s: %v", kind, d.Function, err)
+		m.statusMsg = fmt.Sprintf("❌ Failed to keep a draft of the edit: %v", err)
+		return
+	}
+	m.statusMsg = fmt.Sprintf("📝 Unsaved %s edit kept as a draft; press e to restore it", kind)
+}
+
+// SaveDraft keeps the edit in progress when the program exits, so that it
+// can be restored the next time the function is opened
+func (m Model) SaveDraft() {
+	m.keepDraft()
+}
+
+// announceDraft tells that a draft of the selected function's edit is waiting
+func (m *Model) announceDraft(kind draftKind) {
+	if d := m.findDraft(kind); d != nil {
+		m.statusMsg = fmt.Sprintf("📝 Unsaved %s edit from %s kept as a draft; press e to restore or discard it",
+			kind, d.Saved.Local().Format("2006-01-02 15:04"))
+	}
+}
+
+// offerDraft asks whether to restore the draft or discard it, then calls
+// edit with the content to start from and the revision it is an edit of
+func (m Model) offerDraft(d *draft, current string, edit func(m Model, content, revision string) (tea.Model, tea.Cmd)) (tea.Model, tea.Cmd) {
+	items := []pickerItem{
+		{label: "Restore the draft", detail: fmt.Sprintf("%d lines", strings.Count(strings.TrimSuffix(d.Content, "\n"), "\n")+1)},
+		{label: "Discard the draft", detail: "edit the current " + string(d.Kind)},
+	}
+	title := fmt.Sprintf("Unsaved %s edit of %s from %s", d.Kind, d.Function, d.Saved.Local().Format("2006-01-02 15:04"))
+	m.openPicker(title, items, func(m Model, idx int) (tea.Model, tea.Cmd) {
+		if idx == 0 {
+			return edit(m, d.Content, d.Revision)
+		}
+		deleteDraft(d.Kind, d.Function)
+		return edit(m, current, m.selectedFunc.Revision)
+	})
+	return m, nil
+}
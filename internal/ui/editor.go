@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"f6n/internal/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type editorFinishedMsg struct {
+	path string
+	err  error
+}
+
+// editorCommand builds the command opening path in $VISUAL or $EDITOR (vi
+// when neither is set), positioned at line when it is positive
+func editorCommand(path string, line int) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	args := strings.Fields(editor)
+	name := filepath.Base(args[0])
+	switch name {
+	case "code", "code-insiders", "codium":
+		// GUI editors return immediately unless asked to wait
+		args = append(args, "--wait")
+		if line > 0 {
+			args = append(args, "--goto", path+":"+strconv.Itoa(line))
+			return exec.Command(args[0], args[1:]...)
+		}
+	case "subl":
+		args = append(args, "--wait")
+		if line > 0 {
+			return exec.Command(args[0], append(args[1:], path+":"+strconv.Itoa(line))...)
+		}
+	case "vi", "vim", "nvim", "nano", "emacs", "micro", "kak":
+		if line > 0 {
+			args = append(args, "+"+strconv.Itoa(line))
+		}
+	}
+	args = append(args, path)
+	return exec.Command(args[0], args[1:]...)
+}
+
+// openInEditor suspends the TUI while the editor runs on the current
+// function's downloaded code, or the file shown in CodeDisplayView
+func (m Model) openInEditor(target string) (tea.Model, tea.Cmd) {
+	if m.selectedFunc == nil {
+		m.statusMsg = "No function selected"
+		return m, nil
+	}
+
+	dir := filepath.Join("downloads", m.selectedFunc.Name)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		m.statusMsg = fmt.Sprintf("Code of %s is not downloaded yet. Press 'w' in the list to download it", m.selectedFunc.Name)
+		return m, nil
+	}
+
+	path, line := dir, 0
+	switch {
+	case target != "":
+		path = filepath.Join(dir, filepath.Clean("/"+target))
+	case m.currentView == CodeDisplayView && m.code.file != "":
+		path, line = filepath.Join(dir, m.code.file), m.code.line
+	}
+	if _, err := os.Stat(path); err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %v", err)
+		return m, nil
+	}
+
+	cmd := editorCommand(path, line)
+	logger.Logger.Printf("Opening %s with %s", path, strings.Join(cmd.Args, " "))
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editorFinishedMsg{path: path, err: err}
+	})
+}
+
+// handleEditorFinished reports the editor's exit and reloads the code shown
+func (m Model) handleEditorFinished(msg editorFinishedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		logger.Logger.Printf("Editor exited with error: %v", msg.err)
+		m.statusMsg = fmt.Sprintf("❌ Editor failed: %v", msg.err)
+		return m, nil
+	}
+	m.statusMsg = "Closed " + msg.path
+	if m.currentView != CodeDisplayView || m.selectedFunc == nil {
+		return m, nil
+	}
+	if m.code.file != "" {
+		return m.openGrepMatch(grepMatch{function: m.selectedFunc.Name, file: m.code.file, line: m.code.line})
+	}
+	cmd := m.loadCodeFiles(m.selectedFunc.Name)
+	return m, cmd
+}
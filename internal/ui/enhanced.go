@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/charts"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// enhancedMemoryWarnRatio highlights periods close to running out of memory
+const enhancedMemoryWarnRatio = 0.9
+
+type enhancedMetricsLoadedMsg struct {
+	functionName string
+	metrics      *provider.EnhancedMetrics
+	err          error
+}
+
+// fetchEnhancedMetrics loads the Lambda Insights metrics over the metrics
+// window, if supported
+func (m Model) fetchEnhancedMetrics(name string) tea.Cmd {
+	reporter, ok := provider.As[provider.EnhancedMetricsReporter](m.provider)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		endTime := time.Now()
+		metrics, err := reporter.GetEnhancedMetrics(context.Background(), name, endTime.Add(-1*time.Hour), endTime)
+		if err != nil {
+			logger.Logger.Printf("Error fetching enhanced metrics for %s: %v", name, err)
+		}
+		return enhancedMetricsLoadedMsg{functionName: name, metrics: metrics, err: err}
+	}
+}
+
+// renderEnhancedMetrics charts the CPU, memory and network usage reported by
+// Lambda Insights, or explains how to enable it
+func (mt metricsModel) renderEnhancedMetrics(width int) string {
+	if mt.enhancedErr != nil {
+		return styles.HelpStyle.Render(fmt.Sprintf("Enhanced metrics unavailable: %v", mt.enhancedErr))
+	}
+	metrics := mt.enhanced
+	if metrics == nil {
+		return styles.HelpStyle.Render("Checking for Lambda Insights...")
+	}
+	if metrics.Extension == "" {
+		return styles.HelpStyle.Render("Lambda Insights is not enabled. Add the LambdaInsightsExtension layer to chart CPU, memory and network usage.")
+	}
+
+	width -= 8
+	sections := []string{
+		styles.InfoLabelStyle.Render("🔬 Lambda Insights") + " " + styles.HelpStyle.Render(metrics.Extension),
+		charts.RenderTimeSeriesChart(metrics.CPUTime, width, 10, "CPU time per invocation (ms)"),
+		charts.RenderLimitChart(metrics.Memory, 100, enhancedMemoryWarnRatio, width, 8, "Memory utilization (% of configured)"),
+		charts.RenderTimeSeriesChart(kilobytes(metrics.Network), width, 10, "Network (KB received and sent)"),
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// kilobytes converts points in bytes to KB
+func kilobytes(points []provider.MetricDataPoint) []provider.MetricDataPoint {
+	converted := make([]provider.MetricDataPoint, len(points))
+	for i, point := range points {
+		converted[i] = provider.MetricDataPoint{Timestamp: point.Timestamp, Value: point.Value / 1024}
+	}
+	return converted
+}
@@ -0,0 +1,283 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"sort"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// secretPrefix marks a value as a Secret Manager reference in the env editor
+const secretPrefix = "secret:"
+
+// envKeyPattern matches the variable names both providers accept
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type envUpdatedMsg struct {
+	function string
+	env      map[string]string
+	secrets  map[string]string
+	err      error
+}
+
+// openEnvEditor edits the selected function's environment as KEY=value lines
+func (m Model) openEnvEditor() (tea.Model, tea.Cmd) {
+	if m.selectedFunc == nil {
+		return m, nil
+	}
+	if _, ok := provider.As[provider.EnvironmentUpdater](m.provider); !ok {
+		m.statusMsg = fmt.Sprintf("Editing environment variables is not supported for %s", strings.ToUpper(string(m.provider.GetProviderName())))
+		return m, nil
+	}
+	if !m.permitted(m.selectedFunc, provider.ActionUpdateEnvironment) {
+		return m, nil
+	}
+
+	var b strings.Builder
+	b.WriteString("# KEY=value, one per line. Lines starting with # are ignored.\n")
+	if m.provider.GetProviderName() == provider.GCP {
+		b.WriteString("# Secret Manager references: KEY=secret:projects/P/secrets/S/versions/V (or secret:S[:V])\n")
+	}
+	for _, key := range sortedEnvKeys(m.selectedFunc.Environment) {
+		b.WriteString(key + "=" + m.selectedFunc.Environment[key] + "\n")
+	}
+	for _, key := range sortedEnvKeys(m.selectedFunc.SecretEnvironment) {
+		b.WriteString(key + "=" + secretPrefix + m.selectedFunc.SecretEnvironment[key] + "\n")
+	}
+
+	original := b.String()
+	if d := m.findDraft(envDraft); d != nil {
+		return m.offerDraft(d, original, func(m Model, content, revision string) (tea.Model, tea.Cmd) {
+			return m.editEnvironment(original, content, revision)
+		})
+	}
+	return m.editEnvironment(original, original, m.selectedFunc.Revision)
+}
+
+// editEnvironment opens the env editor on content, an edit of original at
+// revision
+func (m Model) editEnvironment(original, content, revision string) (tea.Model, tea.Cmd) {
+	m.currentView = EnvEditView
+	m.envOriginal = original
+	m.envRevision = revision
+	m.textarea.SetValue(content)
+	m.textarea.SetWidth(m.width - 4)
+	m.textarea.SetHeight(m.height - 10)
+	m.textarea.Focus()
+	return m, nil
+}
+
+// handleEnvEditKey saves with ctrl+s and cancels with esc, keeping the edit
+// as a draft; every other key goes to the editor
+func (m Model) handleEnvEditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.statusMsg = "Environment changes discarded"
+		m.keepDraft()
+		m.textarea.Blur()
+		m.currentView = DetailView
+		return m, nil
+	case "ctrl+s":
+		return m.saveEnvironment()
+	}
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return m, cmd
+}
+
+// saveEnvironment validates the edited variables, then saves them once the
+// function is known not to have changed remotely
+func (m Model) saveEnvironment() (tea.Model, tea.Cmd) {
+	fn := m.selectedFunc
+	env, secrets, err := parseEnvText(m.textarea.Value(), m.provider.GetProviderName() == provider.GCP)
+	if err != nil {
+		m.statusMsg = "❌ " + err.Error()
+		return m, nil
+	}
+	if maps.Equal(env, fn.Environment) && maps.Equal(secrets, fn.SecretEnvironment) {
+		m.statusMsg = "No changes to save"
+		return m, nil
+	}
+	return m.checkRevision(envDraft)
+}
+
+// pushEnvironment sends the edited variables to the provider
+func (m Model) pushEnvironment() (tea.Model, tea.Cmd) {
+	env, secrets, err := parseEnvText(m.textarea.Value(), m.provider.GetProviderName() == provider.GCP)
+	if err != nil {
+		m.statusMsg = "❌ " + err.Error()
+		return m, nil
+	}
+
+	updater, _ := provider.As[provider.EnvironmentUpdater](m.provider)
+	name, revision := m.selectedFunc.Name, m.envRevision
+	m.statusMsg = fmt.Sprintf("Updating environment of %s...", name)
+	return m, func() tea.Msg {
+		err := updater.UpdateEnvironment(context.Background(), name, env, secrets, revision)
+		if err != nil {
+			logger.Logger.Printf("Error updating environment of %s: %v", name, err)
+		}
+		return envUpdatedMsg{function: name, env: env, secrets: secrets, err: err}
+	}
+}
+
+// handleEnvUpdated applies a saved environment and returns to DetailView. On
+// failure the editor stays open with the edits.
+func (m Model) handleEnvUpdated(msg envUpdatedMsg) (tea.Model, tea.Cmd) {
+	var changed []string
+	if fn, ok := m.findFunction(msg.function); ok {
+		changed = append(changedVariables(fn.Environment, msg.env), changedVariables(fn.SecretEnvironment, msg.secrets)...)
+	}
+	m.recordAction("update-environment", msg.function, "variables: "+strings.Join(changed, ", "), msg.err)
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %v", msg.err)
+		return m, nil
+	}
+	deleteDraft(envDraft, msg.function)
+	for i := range m.list.allFunctions {
+		if m.list.allFunctions[i].Name == msg.function {
+			m.list.allFunctions[i].Environment = msg.env
+			m.list.allFunctions[i].SecretEnvironment = msg.secrets
+		}
+	}
+	if m.selectedFunc != nil && m.selectedFunc.Name == msg.function {
+		m.selectedFunc.Environment = msg.env
+		m.selectedFunc.SecretEnvironment = msg.secrets
+		if m.currentView == EnvEditView {
+			m.textarea.Blur()
+			m.currentView = DetailView
+			m.refreshDetailView()
+		}
+	}
+	m.statusMsg = fmt.Sprintf("✅ Environment of %s updated; the function is being redeployed", msg.function)
+	// Reload the list for the update's state
+	return m, m.fetchFunctions()
+}
+
+// changedVariables returns the names of the variables added, changed or
+// removed, sorted. Values are left out as they may be secrets.
+func changedVariables(before, after map[string]string) []string {
+	var names []string
+	for k, v := range after {
+		if old, ok := before[k]; !ok || old != v {
+			names = append(names, k)
+		}
+	}
+	for k := range before {
+		if _, ok := after[k]; !ok {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseEnvText parses KEY=value lines, splitting out secret: references when
+// secrets are allowed
+func parseEnvText(text string, allowSecrets bool) (env, secrets map[string]string, err error) {
+	env = make(map[string]string)
+	seen := make(map[string]bool)
+	for i, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok {
+			return nil, nil, fmt.Errorf("line %d: expected KEY=value", i+1)
+		}
+		if !envKeyPattern.MatchString(key) {
+			return nil, nil, fmt.Errorf("line %d: invalid variable name %q", i+1, key)
+		}
+		if seen[key] {
+			return nil, nil, fmt.Errorf("line %d: %s is defined twice", i+1, key)
+		}
+		seen[key] = true
+
+		if ref, ok := strings.CutPrefix(value, secretPrefix); ok {
+			if !allowSecrets {
+				return nil, nil, fmt.Errorf("line %d: secret references are only supported on GCP", i+1)
+			}
+			if secrets == nil {
+				secrets = make(map[string]string)
+			}
+			secrets[key] = strings.TrimSpace(ref)
+			continue
+		}
+		env[key] = value
+	}
+	return env, secrets, nil
+}
+
+func sortedEnvKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// renderEnvEditor renders the env editor with its header
+func (m Model) renderEnvEditor() string {
+	header := styles.InfoLabelStyle.Render("✏️  ENVIRONMENT OF "+m.selectedFunc.Name) +
+		styles.HelpStyle.Render(" (Ctrl+S to save, Esc to cancel)")
+	return header + "\n\n" + m.textarea.View()
+}
+
+// maskedEnvValue stands for an environment value until it is revealed. Its
+// fixed length doesn't give away the length of the value.
+const maskedEnvValue = "********"
+
+// openRevealPicker handles "v" in DetailView, choosing an environment
+// variable whose value to reveal, or to hide again
+func (m Model) openRevealPicker() (tea.Model, tea.Cmd) {
+	if m.selectedFunc == nil || len(m.selectedFunc.Environment) == 0 {
+		m.statusMsg = "No environment variables to reveal"
+		return m, nil
+	}
+	keys := sortedEnvKeys(m.selectedFunc.Environment)
+	items := make([]pickerItem, 0, len(keys))
+	for _, key := range keys {
+		detail := "hidden"
+		if m.detail.revealed[key] {
+			detail = "revealed"
+		}
+		items = append(items, pickerItem{label: key, detail: detail})
+	}
+	m.openPicker(fmt.Sprintf("Reveal an environment value of %s", m.selectedFunc.Name), items, func(m Model, idx int) (tea.Model, tea.Cmd) {
+		return m.toggleReveal(keys[idx])
+	})
+	return m, nil
+}
+
+// toggleReveal shows the value of an environment variable, recording it in
+// the audit log, or masks it again
+func (m Model) toggleReveal(key string) (tea.Model, tea.Cmd) {
+	revealed := maps.Clone(m.detail.revealed)
+	if revealed == nil {
+		revealed = make(map[string]bool)
+	}
+	if revealed[key] {
+		delete(revealed, key)
+		m.statusMsg = fmt.Sprintf("%s hidden", key)
+	} else {
+		revealed[key] = true
+		m.statusMsg = fmt.Sprintf("%s revealed", key)
+		m.recordAction("reveal-env", m.selectedFunc.Name, "variable: "+key, nil)
+	}
+	m.detail.revealed = revealed
+	m.refreshDetailView()
+	return m, nil
+}
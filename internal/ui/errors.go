@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/insights"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// errorsLookback is how far back ErrorsView looks for error logs
+const errorsLookback = 24 * time.Hour
+
+// errorsLogLimit caps the number of error entries fetched for grouping
+const errorsLogLimit = 1000
+
+type errorGroupsLoadedMsg struct {
+	groups  []insights.ErrorGroup
+	entries int
+	err     error
+}
+
+// fetchErrorGroups loads recent error logs and clusters them
+func (m Model) fetchErrorGroups(name string) tea.Cmd {
+	return func() tea.Msg {
+		query := provider.LogQuery{
+			Limit:       errorsLogLimit,
+			Since:       time.Now().Add(-errorsLookback),
+			MinSeverity: "ERROR",
+		}
+		entries, err := m.provider.GetFunctionLogs(context.Background(), name, query)
+		if err != nil {
+			logger.Logger.Printf("Error fetching error logs for %s: %v", name, err)
+			return errorGroupsLoadedMsg{err: err}
+		}
+
+		// Providers may return informational placeholders; keep real errors only
+		var errorsOnly []provider.LogEntry
+		for _, entry := range entries {
+			if query.Matches(entry) {
+				errorsOnly = append(errorsOnly, entry)
+			}
+		}
+		return errorGroupsLoadedMsg{groups: insights.GroupErrors(errorsOnly), entries: len(errorsOnly)}
+	}
+}
+
+// openErrorsView groups the recent errors of the selected function
+func (m Model) openErrorsView() (tea.Model, tea.Cmd) {
+	if m.selectedFunc == nil {
+		return m, nil
+	}
+	m.currentView = ErrorsView
+	m.viewport.SetContent("Analyzing recent errors...")
+	return m, m.fetchErrorGroups(m.selectedFunc.Name)
+}
+
+// handleErrorsKey handles the ErrorsView keys
+func (m Model) handleErrorsKey(key string) (tea.Model, tea.Cmd, bool) {
+	if key == "E" {
+		model, cmd := m.openErrorsView()
+		return model, cmd, true
+	}
+	return m, nil, false
+}
+
+// renderErrorGroups renders the top errors list
+func renderErrorGroups(functionName string, groups []insights.ErrorGroup, entries int) string {
+	var b strings.Builder
+	b.WriteString(styles.SelectedStyle.Render(fmt.Sprintf("━━━ Top Errors: %s ━━━", functionName)) + "\n")
+	b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("Last %s • %d error entries • %d distinct errors", errorsLookback, entries, len(groups))) + "\n\n")
+
+	if len(groups) == 0 {
+		b.WriteString("✅ No errors found in the selected window.\n")
+		return b.String()
+	}
+
+	for i, g := range groups {
+		b.WriteString(fmt.Sprintf("%s %s  first %s  last %s\n",
+			styles.CommandKeyStyle.Render(fmt.Sprintf("#%-3d", i+1)),
+			styles.ErrorStyle.Render(fmt.Sprintf("×%d", g.Count)),
+			g.FirstSeen.Format("01-02 15:04:05"),
+			g.LastSeen.Format("01-02 15:04:05"),
+		))
+		b.WriteString("    " + g.Signature + "\n")
+
+		// Show the first lines of the latest occurrence for context
+		sample := strings.Split(strings.TrimSpace(g.Sample), "\n")
+		if len(sample) > 3 {
+			sample = append(sample[:3], "…")
+		}
+		for _, line := range sample {
+			b.WriteString("    " + styles.HelpStyle.Render(line) + "\n")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"f6n/internal/iac"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// currentFunction returns the function under the cursor in ListView, or the
+// selected function in the other views
+func (m Model) currentFunction() *provider.FunctionInfo {
+	if m.currentView == ListView {
+		return m.list.selected()
+	}
+	return m.selectedFunc
+}
+
+// executeTerraformCommand handles ":terraform [--env] [path]". Triggers are
+// loaded first so GCP event triggers can be exported.
+func (m Model) executeTerraformCommand(args []string) (tea.Model, tea.Cmd) {
+	fn := m.currentFunction()
+	if fn == nil {
+		m.statusMsg = "Select a function to export"
+		return m, nil
+	}
+	args, withEnv := exportEnvFlag(args)
+	m.statusMsg = fmt.Sprintf("Exporting %s as Terraform...", fn.Name)
+	return m, m.renderExport(fn, "Terraform", ":terraform", iac.Terraform, args, withEnv)
+}
+
+type exportRenderedMsg struct {
+	fn      *provider.FunctionInfo
+	kind    string
+	command string
+	content string
+	args    []string
+	withEnv bool // the environment values were exported unmasked
+	err     error
+}
+
+// exportFormats maps the :export formats to their renderers and labels
+var exportFormats = map[string]struct {
+	label  string
+	render func(provider.FunctionInfo, provider.CloudProvider, []provider.EventSourceMapping) (string, error)
+}{
+	"sam":        {"SAM template", iac.SAM},
+	"serverless": {"serverless.yml", iac.Serverless},
+}
+
+// executeExportCommand handles ":export sam|serverless [--env] [path]".
+// Triggers are loaded first so they can be exported as events.
+func (m Model) executeExportCommand(args []string) (tea.Model, tea.Cmd) {
+	args, withEnv := exportEnvFlag(args)
+	if len(args) == 0 {
+		m.statusMsg = "usage: :export sam|serverless [--env] [path]"
+		return m, nil
+	}
+	format, ok := exportFormats[strings.ToLower(args[0])]
+	if !ok {
+		m.statusMsg = fmt.Sprintf("Unknown export format %q (use sam or serverless)", args[0])
+		return m, nil
+	}
+	fn := m.currentFunction()
+	if fn == nil {
+		m.statusMsg = "Select a function to export"
+		return m, nil
+	}
+
+	m.statusMsg = fmt.Sprintf("Exporting %s as %s...", fn.Name, format.label)
+	return m, m.renderExport(fn, format.label, ":export "+strings.ToLower(args[0]), format.render, args[1:], withEnv)
+}
+
+// exportEnvFlag removes --env from args, reporting whether it was given to
+// export the environment values unmasked
+func exportEnvFlag(args []string) ([]string, bool) {
+	rest := make([]string, 0, len(args))
+	withEnv := false
+	for _, arg := range args {
+		if arg == "--env" {
+			withEnv = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, withEnv
+}
+
+// renderExport loads the triggers of fn and renders it with render
+func (m Model) renderExport(fn *provider.FunctionInfo, kind, command string, render func(provider.FunctionInfo, provider.CloudProvider, []provider.EventSourceMapping) (string, error), args []string, withEnv bool) tea.Cmd {
+	prov := m.provider
+	return func() tea.Msg {
+		var triggers []provider.EventSourceMapping
+		if lister, ok := provider.As[provider.TriggerLister](prov); ok {
+			var err error
+			if triggers, err = lister.ListEventSourceMappings(context.Background(), fn.Name); err != nil {
+				logger.Logger.Printf("Error listing event source mappings for %s export: %v", fn.Name, err)
+				return exportRenderedMsg{err: fmt.Errorf("failed to load triggers: %w", err)}
+			}
+		}
+		content, err := render(exportedFunction(*fn, withEnv), prov.GetProviderName(), triggers)
+		return exportRenderedMsg{fn: fn, kind: kind, command: command, content: content, args: args, withEnv: withEnv, err: err}
+	}
+}
+
+// redactedEnvValue replaces the environment values of an export without
+// --env, standing out in the file as a value to fill in
+const redactedEnvValue = "<redacted>"
+
+// exportedFunction returns fn as it is named in its cloud account, so that
+// identifiers such as function_name, import IDs and resource names don't
+// carry the profile of a multi-profile inventory. Environment values are
+// redacted unless withEnv is set.
+func exportedFunction(fn provider.FunctionInfo, withEnv bool) provider.FunctionInfo {
+	fn.Name = provider.PlainName(fn)
+	if !withEnv && len(fn.Environment) > 0 {
+		redacted := make(map[string]string, len(fn.Environment))
+		for key := range fn.Environment {
+			redacted[key] = redactedEnvValue
+		}
+		fn.Environment = redacted
+	}
+	return fn
+}
+
+// showExport saves exported configuration to the path in args, readable by
+// the user only, or shows it in ExportView and copies it to the clipboard.
+// command is the command that saves it, for the hint shown when the
+// clipboard is unavailable. Exports with unmasked environment values are
+// recorded as reveals once saved or shown.
+func (m Model) showExport(fn *provider.FunctionInfo, kind, command, content string, args []string, withEnv bool) (tea.Model, tea.Cmd) {
+	revealed := withEnv && len(fn.Environment) > 0
+	note := ""
+	if len(fn.Environment) > 0 && !withEnv {
+		note = " (environment values redacted; add --env to include them)"
+	}
+	if len(args) > 0 {
+		path := args[0]
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			logger.Logger.Printf("Error writing %s export to %s: %v", kind, path, err)
+			m.statusMsg = fmt.Sprintf("❌ Failed to write %s: %v", path, err)
+			return m, nil
+		}
+		if revealed {
+			m.recordAction("reveal-env", fn.Name, "exported as "+kind+" to "+path, nil)
+		}
+		m.statusMsg = fmt.Sprintf("✅ %s for %s saved to %s%s", kind, fn.Name, path, note)
+		return m, nil
+	}
+
+	m.selectedFunc = fn
+	m.currentView = ExportView
+	m.viewport.SetContent(content)
+	m.viewport.GotoTop()
+	if revealed {
+		m.recordAction("reveal-env", fn.Name, "exported as "+kind, nil)
+	}
+	if err := clipboard.WriteAll(content); err != nil {
+		logger.Logger.Printf("Error copying %s export to clipboard: %v", kind, err)
+		m.statusMsg = fmt.Sprintf("%s for %s (clipboard unavailable; save with %s <path>)", kind, fn.Name, command)
+	} else {
+		m.statusMsg = fmt.Sprintf("✅ %s for %s copied to the clipboard%s", kind, fn.Name, note)
+	}
+	return m, nil
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"f6n/internal/iac"
+	"f6n/internal/provider"
+)
+
+func TestExportEnvFlag(t *testing.T) {
+	tests := []struct {
+		args    []string
+		rest    []string
+		withEnv bool
+	}{
+		{nil, []string{}, false},
+		{[]string{"main.tf"}, []string{"main.tf"}, false},
+		{[]string{"--env"}, []string{}, true},
+		{[]string{"--env", "main.tf"}, []string{"main.tf"}, true},
+		{[]string{"sam", "template.yaml", "--env"}, []string{"sam", "template.yaml"}, true},
+	}
+	for _, tt := range tests {
+		rest, withEnv := exportEnvFlag(tt.args)
+		if !slices.Equal(rest, tt.rest) || withEnv != tt.withEnv {
+			t.Errorf("exportEnvFlag(%q) = %q, %v, want %q, %v", tt.args, rest, withEnv, tt.rest, tt.withEnv)
+		}
+	}
+}
+
+func TestExportedFunctionRedactsEnvironment(t *testing.T) {
+	fn := provider.FunctionInfo{
+		Name:        "orders-api@prod",
+		Profile:     "prod",
+		Runtime:     "python3.12",
+		Handler:     "app.handler",
+		Memory:      512,
+		Timeout:     15,
+		Environment: map[string]string{"DB_PASSWORD": "hunter2", "STAGE": "prod"},
+	}
+
+	redacted := exportedFunction(fn, false)
+	if redacted.Name != "orders-api" {
+		t.Errorf("Name = %q, want the name without the profile", redacted.Name)
+	}
+	for key, value := range redacted.Environment {
+		if value != redactedEnvValue {
+			t.Errorf("%s = %q, want %q", key, value, redactedEnvValue)
+		}
+	}
+	if len(redacted.Environment) != len(fn.Environment) {
+		t.Errorf("Environment has %d variables, want %d", len(redacted.Environment), len(fn.Environment))
+	}
+	if fn.Environment["DB_PASSWORD"] != "hunter2" {
+		t.Error("exportedFunction changed the environment of the function")
+	}
+
+	for _, render := range []func(provider.FunctionInfo, provider.CloudProvider, []provider.EventSourceMapping) (string, error){iac.Terraform, iac.SAM, iac.Serverless} {
+		content, err := render(redacted, provider.AWS, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(content, "hunter2") || !strings.Contains(content, "DB_PASSWORD") {
+			t.Errorf("export does not redact the value of DB_PASSWORD:\n%s", content)
+		}
+	}
+
+	if revealed := exportedFunction(fn, true); revealed.Environment["DB_PASSWORD"] != "hunter2" {
+		t.Errorf("DB_PASSWORD = %q with --env, want its value", revealed.Environment["DB_PASSWORD"])
+	}
+}
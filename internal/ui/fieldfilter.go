@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"f6n/internal/provider"
+)
+
+// fieldCondition is a single "field op value" comparison
+type fieldCondition struct {
+	path  []string
+	op    string
+	value string
+}
+
+// fieldFilter is a set of conditions over JSON log fields, all of which must match
+type fieldFilter struct {
+	expr       string
+	conditions []fieldCondition
+}
+
+// fieldConditionRe splits "status>=500" into field, operator and value
+var fieldConditionRe = regexp.MustCompile(`^([A-Za-z0-9_.@-]+)(>=|<=|!=|=|>|<|~)(.*)$`)
+
+// parseFieldFilter parses a space-separated list of conditions such as
+// `level=error status>=500 msg~timeout`. Field names may use dots to reach
+// nested JSON objects; `~` is a case-insensitive substring match.
+func parseFieldFilter(expr string) (*fieldFilter, error) {
+	filter := &fieldFilter{expr: strings.TrimSpace(expr)}
+	for _, term := range strings.Fields(expr) {
+		parts := fieldConditionRe.FindStringSubmatch(term)
+		if parts == nil {
+			return nil, fmt.Errorf("invalid condition %q (expected field=value, field>=number, ...)", term)
+		}
+		filter.conditions = append(filter.conditions, fieldCondition{
+			path:  strings.Split(parts[1], "."),
+			op:    parts[2],
+			value: strings.Trim(parts[3], `"'`),
+		})
+	}
+	if len(filter.conditions) == 0 {
+		return nil, fmt.Errorf("empty field filter")
+	}
+	return filter, nil
+}
+
+// String returns the filter expression as typed
+func (f *fieldFilter) String() string {
+	return f.expr
+}
+
+// Matches reports whether a log entry's JSON payload satisfies every condition.
+// Entries whose message is not a JSON object never match.
+func (f *fieldFilter) Matches(entry provider.LogEntry) bool {
+	fields, ok := parseJSONMessage(entry.Message)
+	if !ok {
+		return false
+	}
+
+	for _, cond := range f.conditions {
+		actual, found := lookupField(fields, entry, cond.path)
+		if !found || !cond.matches(actual) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseJSONMessage decodes a log message holding a JSON object
+func parseJSONMessage(message string) (map[string]any, bool) {
+	message = strings.TrimSpace(message)
+	if !strings.HasPrefix(message, "{") {
+		return nil, false
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(message), &fields); err != nil {
+		return nil, false
+	}
+	return fields, true
+}
+
+// lookupField resolves a dotted path in the JSON payload, falling back to the
+// entry's severity and labels for top-level names missing from the payload
+func lookupField(fields map[string]any, entry provider.LogEntry, path []string) (any, bool) {
+	var current any = fields
+	for _, key := range path {
+		obj, ok := current.(map[string]any)
+		if !ok {
+			current = nil
+			break
+		}
+		if current, ok = obj[key]; !ok {
+			current = nil
+			break
+		}
+	}
+	if current != nil {
+		return current, true
+	}
+
+	if len(path) == 1 {
+		if strings.EqualFold(path[0], "severity") && entry.Severity != "" {
+			return entry.Severity, true
+		}
+		if value, ok := entry.Labels[path[0]]; ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// matches compares a decoded JSON value against the condition. Values are
+// compared numerically when both sides are numbers, otherwise as strings.
+func (c fieldCondition) matches(actual any) bool {
+	var actualStr string
+	switch v := actual.(type) {
+	case string:
+		actualStr = v
+	case float64:
+		actualStr = strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		actualStr = strconv.FormatBool(v)
+	default:
+		encoded, _ := json.Marshal(v)
+		actualStr = string(encoded)
+	}
+
+	if c.op == "~" {
+		return strings.Contains(strings.ToLower(actualStr), strings.ToLower(c.value))
+	}
+
+	actualNum, errA := strconv.ParseFloat(actualStr, 64)
+	wantNum, errW := strconv.ParseFloat(c.value, 64)
+	if errA == nil && errW == nil {
+		switch c.op {
+		case "=":
+			return actualNum == wantNum
+		case "!=":
+			return actualNum != wantNum
+		case ">":
+			return actualNum > wantNum
+		case ">=":
+			return actualNum >= wantNum
+		case "<":
+			return actualNum < wantNum
+		case "<=":
+			return actualNum <= wantNum
+		}
+	}
+
+	cmp := strings.Compare(strings.ToLower(actualStr), strings.ToLower(c.value))
+	switch c.op {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
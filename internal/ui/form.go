@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"strings"
+
+	"f6n/internal/i18n"
+	"f6n/internal/ui/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// formField is a single labelled text input in a form
+type formField struct {
+	key   string
+	label string
+	input textinput.Model
+}
+
+// form is a small multi-field input shown in place of the main content
+type form struct {
+	title    string
+	fields   []formField
+	focus    int
+	err      string
+	onSubmit func(m Model, values map[string]string) (Model, tea.Cmd, error)
+}
+
+// newFormField creates a form field with an initial value and placeholder
+func newFormField(key, label, value, placeholder string) formField {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.CharLimit = 512
+	ti.Width = 50
+	ti.SetValue(value)
+	return formField{key: key, label: label, input: ti}
+}
+
+// openForm switches the model into form mode. onSubmit receives the field
+// values by key; returning an error keeps the form open and shows the error.
+func (m *Model) openForm(title string, fields []formField, onSubmit func(m Model, values map[string]string) (Model, tea.Cmd, error)) tea.Cmd {
+	m.form = &form{
+		title:    title,
+		fields:   fields,
+		onSubmit: onSubmit,
+	}
+	m.inputMode = FormMode
+	return m.form.focusField(0)
+}
+
+// focusField moves the focus to field i
+func (f *form) focusField(i int) tea.Cmd {
+	f.fields[f.focus].input.Blur()
+	f.focus = i
+	return f.fields[i].input.Focus()
+}
+
+// values returns the trimmed field values by key
+func (f *form) values() map[string]string {
+	values := make(map[string]string, len(f.fields))
+	for _, field := range f.fields {
+		values[field.key] = strings.TrimSpace(field.input.Value())
+	}
+	return values
+}
+
+// handleFormKey handles navigation, editing and submission while a form is open
+func (m Model) handleFormKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	f := m.form
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.form = nil
+		m.inputMode = NormalMode
+		return m, nil
+	case "tab", "down":
+		return m, f.focusField((f.focus + 1) % len(f.fields))
+	case "shift+tab", "up":
+		return m, f.focusField((f.focus - 1 + len(f.fields)) % len(f.fields))
+	case "enter", "ctrl+s":
+		if msg.String() == "enter" && f.focus < len(f.fields)-1 {
+			return m, f.focusField(f.focus + 1)
+		}
+		m.form = nil
+		m.inputMode = NormalMode
+		next, cmd, err := f.onSubmit(m, f.values())
+		if err != nil {
+			f.err = err.Error()
+			m.form = f
+			m.inputMode = FormMode
+			return m, nil
+		}
+		return next, cmd
+	}
+
+	var cmd tea.Cmd
+	f.fields[f.focus].input, cmd = f.fields[f.focus].input.Update(msg)
+	return m, cmd
+}
+
+// View renders the form
+func (f *form) View() string {
+	var b strings.Builder
+	b.WriteString(styles.InfoLabelStyle.Render(f.title) + "\n\n")
+
+	labelWidth := 0
+	for _, field := range f.fields {
+		if len(field.label) > labelWidth {
+			labelWidth = len(field.label)
+		}
+	}
+
+	for i, field := range f.fields {
+		label := field.label + ":" + strings.Repeat(" ", labelWidth-len(field.label)+1)
+		if i == f.focus {
+			label = styles.SelectedStyle.Render("> " + label)
+		} else {
+			label = styles.CommandValueStyle.Render("  " + label)
+		}
+		b.WriteString(label + field.input.View() + "\n")
+	}
+
+	if f.err != "" {
+		b.WriteString("\n" + styles.ErrorStyle.Render("✗ "+f.err) + "\n")
+	}
+
+	b.WriteString("\n" + styles.HelpStyle.Render(i18n.T("tab/↑/↓: move • enter: next/submit • ctrl+s: submit • esc: cancel")))
+	return b.String()
+}
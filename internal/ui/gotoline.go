@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// codeSection locates a file's content in CodeDisplayView
+type codeSection struct {
+	file  string // Relative to the function's download directory
+	start int    // Viewport line showing line first of the file
+	first int    // First line shown, after the previous pages of a paged file
+	lines int    // Lines shown, 0 for a file too large to show inline
+	total int    // Lines in the file
+}
+
+// numberLines prefixes lines with their number, counting from first and
+// highlighting the line numbered highlight (0 for none)
+func numberLines(lines []string, first, highlight int) string {
+	var b strings.Builder
+	for i, line := range lines {
+		line = fmt.Sprintf("%5d  %s", first+i, line)
+		if first+i == highlight {
+			line = styles.SelectedStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// executeGotoCommand handles ":goto <line>" and ":<line>", scrolling
+// CodeDisplayView to a line of the file at the top of the view
+func (m Model) executeGotoCommand(arg string) (tea.Model, tea.Cmd) {
+	line, err := strconv.Atoi(arg)
+	if err != nil || line < 1 {
+		m.statusMsg = "Usage: :goto <line> or :<line>"
+		return m, nil
+	}
+	if m.currentView != CodeDisplayView || len(m.code.sections) == 0 {
+		m.statusMsg = "Go to line works in the downloaded code view (v in the code view)"
+		return m, nil
+	}
+
+	section := m.code.sections[0]
+	for _, s := range m.code.sections {
+		if s.start-2 > m.viewport.YOffset {
+			break
+		}
+		section = s
+	}
+	if section.total > 0 && line > section.total {
+		m.statusMsg = fmt.Sprintf("%s has %d lines", section.file, section.total)
+		return m, nil
+	}
+	if line < section.first || line >= section.first+section.lines {
+		// The line is on another page, or the file is too large to show inline
+		return m.openCodeFile(section.file, line)
+	}
+
+	m.viewport.SetYOffset(max(section.start+line-section.first-grepContextLines, 0))
+	if section.file == m.code.file {
+		m.code.line = line
+	}
+	m.statusMsg = fmt.Sprintf("%s:%d", section.file, line)
+	return m, nil
+}
@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// grepMaxMatches caps the matches listed by :grep
+	grepMaxMatches = 500
+	// grepMaxFileSize skips files too large to be hand-written source
+	grepMaxFileSize = 1024 * 1024
+	// grepContextLines is the number of lines shown above a hit in CodeDisplayView
+	grepContextLines = 5
+)
+
+// grepMatch is a line of downloaded code matching a :grep pattern
+type grepMatch struct {
+	function string
+	file     string // Relative to the function's download directory
+	line     int
+	text     string
+}
+
+type grepResultsMsg struct {
+	pattern   string
+	matches   []grepMatch
+	functions int
+	truncated bool
+	err       error
+}
+
+// downloadedFunctions returns the names of the functions with code in downloads/
+func downloadedFunctions() ([]string, error) {
+	entries, err := os.ReadDir("downloads")
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// executeGrepCommand handles ":grep <pattern>", searching the downloaded code
+// of all functions. When nothing has been downloaded yet, the filtered
+// functions are downloaded first and the search runs once they are done.
+func (m Model) executeGrepCommand(pattern string) (tea.Model, tea.Cmd) {
+	if pattern == "" {
+		m.statusMsg = "Usage: :grep <pattern>"
+		return m, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Invalid pattern: %v", err)
+		return m, nil
+	}
+
+	names, err := downloadedFunctions()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Failed to read downloads: %v", err)
+		return m, nil
+	}
+	if len(names) == 0 {
+		if m.bulkDownload != nil && m.bulkDownload.running {
+			m.bulkDownload.grep = pattern
+			m.statusMsg = "Search will run when the running download finishes"
+			return m, nil
+		}
+		model, cmd := m.executeDownloadAllCommand()
+		m = model.(Model)
+		if m.bulkDownload != nil && m.bulkDownload.running {
+			m.bulkDownload.grep = pattern
+			m.statusMsg = "No code downloaded yet; search will run when the download finishes"
+		}
+		return m, cmd
+	}
+
+	m.statusMsg = fmt.Sprintf("Searching %d downloaded functions for /%s/...", len(names), pattern)
+	return m, func() tea.Msg {
+		matches, truncated, err := searchCode(names, re)
+		if err != nil {
+			logger.Logger.Printf("Error searching downloaded code: %v", err)
+		}
+		return grepResultsMsg{pattern: pattern, matches: matches, functions: len(names), truncated: truncated, err: err}
+	}
+}
+
+// searchCode returns the lines of the functions' code files matching re, in
+// function and file order
+func searchCode(functions []string, re *regexp.Regexp) ([]grepMatch, bool, error) {
+	var matches []grepMatch
+	for _, fn := range functions {
+		root := filepath.Join("downloads", fn)
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || info.Size() > grepMaxFileSize || !isCodeFile(strings.ToLower(filepath.Ext(path))) {
+				return nil
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				return nil
+			}
+			defer f.Close()
+
+			rel, _ := filepath.Rel(root, path)
+			scanner := bufio.NewScanner(f)
+			scanner.Buffer(make([]byte, 0, 64*1024), grepMaxFileSize)
+			for line := 1; scanner.Scan(); line++ {
+				text := scanner.Text()
+				if !re.MatchString(text) {
+					continue
+				}
+				matches = append(matches, grepMatch{function: fn, file: rel, line: line, text: text})
+				if len(matches) >= grepMaxMatches {
+					return filepath.SkipAll
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return matches, false, err
+		}
+		if len(matches) >= grepMaxMatches {
+			return matches, true, nil
+		}
+	}
+	return matches, false, nil
+}
+
+// openGrepResults lists the matches grouped by function and file
+func (m Model) openGrepResults(msg grepResultsMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Search failed: %v", msg.err)
+		return m, nil
+	}
+	if len(msg.matches) == 0 {
+		m.statusMsg = fmt.Sprintf("No matches for /%s/ in %d downloaded functions", msg.pattern, msg.functions)
+		return m, nil
+	}
+
+	items := make([]pickerItem, 0, len(msg.matches))
+	for _, match := range msg.matches {
+		text := strings.TrimSpace(match.text)
+		if len(text) > 100 {
+			text = text[:99] + "…"
+		}
+		items = append(items, pickerItem{
+			label: fmt.Sprintf("%5d: %s", match.line, text),
+			group: match.function + "/" + filepath.ToSlash(match.file),
+		})
+	}
+
+	title := fmt.Sprintf("%d matches for /%s/", len(msg.matches), msg.pattern)
+	if msg.truncated {
+		title = fmt.Sprintf("First %d matches for /%s/", len(msg.matches), msg.pattern)
+	}
+	matches := msg.matches
+	m.openPicker(title, items, func(m Model, idx int) (tea.Model, tea.Cmd) {
+		return m.openGrepMatch(matches[idx])
+	})
+	return m, nil
+}
+
+// openGrepMatch shows the file of a match in CodeDisplayView, scrolled to the hit
+func (m Model) openGrepMatch(match grepMatch) (tea.Model, tea.Cmd) {
+	m.selectedFunc = &provider.FunctionInfo{Name: match.function}
+	for i := range m.list.allFunctions {
+		if m.list.allFunctions[i].Name == match.function {
+			m.selectedFunc = &m.list.allFunctions[i]
+			break
+		}
+	}
+	return m.openCodeFile(match.file, match.line)
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"f6n/internal/insights"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type firingAlarmsLoadedMsg struct {
+	alarms map[string][]string
+	err    error
+}
+
+// fetchFiringAlarms loads the alarms currently firing, if the provider supports alarms
+func (m Model) fetchFiringAlarms() tea.Cmd {
+	reporter, ok := provider.As[provider.AlarmStateReporter](m.provider)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		alarms, err := reporter.GetFiringAlarms(context.Background())
+		if err != nil {
+			logger.Logger.Printf("Error fetching alarm states: %v", err)
+		}
+		return firingAlarmsLoadedMsg{alarms: alarms, err: err}
+	}
+}
+
+// functionHealth assesses a function from the data loaded for the list
+func (m Model) functionHealth(fn provider.FunctionInfo) insights.Health {
+	var stats *provider.FunctionStats
+	if s, ok := m.functionStats[fn.Name]; ok {
+		stats = &s
+	}
+
+	var alarms []string
+	if m.firingAlarms != nil {
+		alarms = m.firingAlarms[fn.Name]
+		if alarms == nil {
+			alarms = []string{}
+		}
+	}
+	return insights.AssessHealth(fn, stats, alarms)
+}
+
+// formatHealthCell renders the Health column value
+func formatHealthCell(h insights.Health) string {
+	if len(h.Checks) == 0 {
+		return insights.HealthUnknown.Icon()
+	}
+	return h.Level.Icon() + " " + h.Level.String()
+}
+
+// renderHealth renders the health breakdown shown in DetailView
+func renderHealth(h insights.Health) string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Health: %s", formatHealthCell(h))) + "\n")
+	if len(h.Checks) == 0 {
+		b.WriteString(styles.HelpStyle.Render("  No health data available yet") + "\n")
+		return b.String()
+	}
+	for _, check := range h.Checks {
+		line := fmt.Sprintf("  %s %-12s %s", check.Level.Icon(), check.Name+":", check.Detail)
+		if check.Level == insights.HealthCrit {
+			line = styles.ErrorStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("  Error rate covers the last %s", timeoutLookback)) + "\n")
+	return b.String()
+}
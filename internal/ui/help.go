@@ -0,0 +1,20 @@
+package ui
+
+import (
+	_ "embed"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// helpMarkdown is the content of HelpView
+//
+//go:embed help.md
+var helpMarkdown string
+
+// openHelpView handles ":help", showing the keys and commands
+func (m Model) openHelpView() (tea.Model, tea.Cmd) {
+	m.currentView = HelpView
+	m.viewport.SetContent(m.markdown(helpMarkdown))
+	m.viewport.GotoTop()
+	return m, nil
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"f6n/internal/config"
+	"f6n/internal/logger"
+	"f6n/internal/ui/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// highlightRule is a compiled config.HighlightRule
+type highlightRule struct {
+	re    *regexp.Regexp
+	style lipgloss.Style
+}
+
+// compileHighlightRules compiles the configured rules, skipping invalid patterns
+func compileHighlightRules(rules []config.HighlightRule) []highlightRule {
+	compiled := make([]highlightRule, 0, len(rules))
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			logger.Logger.Printf("Skipping invalid highlight pattern %q: %v", rule.Pattern, err)
+			continue
+		}
+
+		color := rule.Color
+		if color == "" {
+			color = styles.ColorYellow
+		}
+		compiled = append(compiled, highlightRule{
+			re:    re,
+			style: lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(rule.Bold),
+		})
+	}
+	return compiled
+}
+
+// Styles of the log lines by severity; INFO and unknown levels keep the
+// default color
+var (
+	errorLineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+	warnLineStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color(styles.ColorYellow))
+	debugLineStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(styles.ColorDimmed)).Faint(true)
+)
+
+// Levels named in plain log lines: as a prefix ("ERROR ...", "[WARN] ...",
+// "error: ...") or as a field (level=error, "level":"warn")
+var (
+	levelPrefixRe = regexp.MustCompile(`(?i)^\[?(error|err|fatal|critical|warning|warn|debug|trace|info)\b`)
+	levelFieldRe  = regexp.MustCompile(`(?i)\blevel"?\s*[=:]\s*"?(error|err|fatal|critical|warning|warn|debug|trace|info)\b`)
+)
+
+// severityStyle returns the style of a severity, nil for the default color
+func severityStyle(severity string) *lipgloss.Style {
+	switch strings.ToUpper(severity) {
+	case "ERROR", "ERR", "FATAL", "CRITICAL", "ALERT", "EMERGENCY":
+		return &errorLineStyle
+	case "WARN", "WARNING":
+		return &warnLineStyle
+	case "DEBUG", "TRACE":
+		return &debugLineStyle
+	}
+	return nil
+}
+
+// logLineStyle returns the style of a formatted "[timestamp] SEVERITY: message"
+// line: by the entry's severity, or when the provider gave none (DEFAULT) by
+// the level the message starts with or names in a field
+func logLineStyle(line string) *lipgloss.Style {
+	_, rest, ok := strings.Cut(line, "] ")
+	if !ok || !strings.HasPrefix(line, "[") {
+		return nil
+	}
+	severity, message, ok := strings.Cut(rest, ": ")
+	if !ok {
+		return nil
+	}
+	if severity != "DEFAULT" && severity != "" {
+		return severityStyle(severity)
+	}
+	if m := levelPrefixRe.FindStringSubmatch(message); m != nil {
+		return severityStyle(m[1])
+	}
+	if m := levelFieldRe.FindStringSubmatch(message); m != nil {
+		return severityStyle(m[1])
+	}
+	return nil
+}
+
+// highlightLine colors the matches of each rule in line, and the rest of the
+// line with base unless it is nil. Rules are applied to the plain text so that
+// escape codes from one rule are never matched by another; where matches
+// overlap, the earlier rule wins.
+func highlightLine(line string, rules []highlightRule, base *lipgloss.Style) string {
+	type span struct {
+		start, end int
+		style      lipgloss.Style
+	}
+
+	var spans []span
+	taken := make([]bool, len(line))
+	for _, rule := range rules {
+	matches:
+		for _, loc := range rule.re.FindAllStringIndex(line, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			for i := loc[0]; i < loc[1]; i++ {
+				if taken[i] {
+					continue matches
+				}
+			}
+			for i := loc[0]; i < loc[1]; i++ {
+				taken[i] = true
+			}
+			spans = append(spans, span{start: loc[0], end: loc[1], style: rule.style})
+		}
+	}
+
+	plain := func(text string) string {
+		if base == nil || text == "" {
+			return text
+		}
+		return base.Render(text)
+	}
+	if len(spans) == 0 {
+		return plain(line)
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+
+	var b strings.Builder
+	pos := 0
+	for _, sp := range spans {
+		b.WriteString(plain(line[pos:sp.start]))
+		b.WriteString(sp.style.Render(line[sp.start:sp.end]))
+		pos = sp.end
+	}
+	b.WriteString(plain(line[pos:]))
+	return b.String()
+}
+
+// highlight colors every line by its severity and applies the highlight
+// rules, when highlighting is enabled. The function names of merged streams
+// are colored either way.
+func (l logsModel) highlight(lines []string) []string {
+	if !l.highlightEnabled && len(l.tailing) < 2 {
+		return lines
+	}
+
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		prefix, rest := l.colorTailPrefix(line)
+		if l.highlightEnabled {
+			rest = highlightLine(rest, l.highlightRules, logLineStyle(rest))
+		}
+		out[i] = prefix + rest
+	}
+	return out
+}
@@ -0,0 +1,249 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"f6n/internal/charts"
+	"f6n/internal/config"
+	"f6n/internal/history"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// minHistoryInterval is the shortest interval metrics are sampled at
+	minHistoryInterval = time.Minute
+	// defaultHistoryRetentionDays is how long samples are kept unless configured
+	defaultHistoryRetentionDays = 30
+)
+
+// historyState holds the background sampling of the favorite functions' metrics
+type historyState struct {
+	store     *history.Store // nil when no history is kept, in demo and replay mode
+	interval  time.Duration  // 0 when sampling is off
+	retention time.Duration
+	stop      context.CancelFunc // Stops the sampler, nil when not running
+}
+
+type historySampledMsg struct {
+	functions []string
+	err       error
+}
+
+type metricsHistoryLoadedMsg struct {
+	functionName string
+	samples      []history.Sample
+	favorite     bool
+	err          error
+}
+
+// setupHistory opens the metrics history of the provider and starts sampling
+// the favorite functions at the configured interval
+func (m *Model) setupHistory() error {
+	name := m.stateName()
+	if name == "" {
+		return nil
+	}
+	m.history.store = history.New(filepath.Join(config.StateDir(), "history", name+".jsonl"))
+	settings := m.cfg.File.MetricsHistory
+	days := settings.RetentionDays
+	if days <= 0 {
+		days = defaultHistoryRetentionDays
+	}
+	m.history.retention = time.Duration(days) * 24 * time.Hour
+	if settings.Interval == "" {
+		return nil
+	}
+	interval, err := time.ParseDuration(settings.Interval)
+	if err != nil || interval < minHistoryInterval {
+		return fmt.Errorf("invalid metrics_history.interval %q (use e.g. 5m, at least %s)", settings.Interval, minHistoryInterval)
+	}
+	m.history.interval = interval
+	m.restartHistory()
+	return nil
+}
+
+// restartHistory (re)starts the sampler for the current favorites, or stops
+// it when there are none
+func (m *Model) restartHistory() {
+	if m.history.stop != nil {
+		m.history.stop()
+		m.history.stop = nil
+	}
+	favorites := slices.Clone(m.cfg.File.Favorites)
+	if m.history.store == nil || m.history.interval <= 0 || len(favorites) == 0 {
+		return
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	m.history.stop = stop
+	go runHistorySampler(ctx, m.bus, m.provider, m.history.store, favorites, m.history.interval, m.history.retention)
+}
+
+// runHistorySampler drops the samples older than retention, then samples the
+// functions' metrics every interval and publishes each round on the bus,
+// until ctx is done
+func runHistorySampler(ctx context.Context, bus *Bus, prov provider.Provider, store *history.Store, functions []string, interval, retention time.Duration) {
+	if err := store.Prune(time.Now().Add(-retention)); err != nil {
+		logger.Logger.Printf("Error pruning metrics history: %v", err)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		msg := sampleHistory(ctx, prov, store, functions, interval)
+		if ctx.Err() != nil {
+			return
+		}
+		bus.Publish(msg)
+	}
+}
+
+// sampleHistory stores a sample of each function's metrics over the last
+// interval. A function whose metrics cannot be fetched is skipped.
+func sampleHistory(ctx context.Context, prov provider.Provider, store *history.Store, functions []string, interval time.Duration) historySampledMsg {
+	end := time.Now()
+	var samples []history.Sample
+	var lastErr error
+	for _, name := range functions {
+		metrics, err := prov.GetFunctionMetrics(ctx, name, end.Add(-interval), end)
+		if err != nil {
+			logger.Logger.Printf("History: error fetching metrics for %s: %v", name, err)
+			lastErr = err
+			continue
+		}
+		samples = append(samples, history.Summarize(name, metrics, end))
+	}
+	if err := store.Append(samples); err != nil {
+		logger.Logger.Printf("Error writing metrics history: %v", err)
+		lastErr = err
+	}
+	return historySampledMsg{functions: functions, err: lastErr}
+}
+
+// handleHistorySampled reloads the history shown in MetricsView when the
+// function was just sampled
+func (m Model) handleHistorySampled(msg historySampledMsg) (tea.Model, tea.Cmd) {
+	if m.currentView != MetricsView || m.selectedFunc == nil || !slices.Contains(msg.functions, m.selectedFunc.Name) {
+		return m, nil
+	}
+	return m, m.fetchMetricsHistory(m.selectedFunc.Name)
+}
+
+// fetchMetricsHistory reads the samples kept of a function
+func (m Model) fetchMetricsHistory(name string) tea.Cmd {
+	store := m.history.store
+	if store == nil {
+		return nil
+	}
+	since := time.Now().Add(-m.history.retention)
+	favorite := m.cfg.File.IsFavorite(name)
+	return func() tea.Msg {
+		samples, err := store.Read(name, since)
+		if err != nil {
+			logger.Logger.Printf("Error reading metrics history of %s: %v", name, err)
+		}
+		return metricsHistoryLoadedMsg{functionName: name, samples: samples, favorite: favorite, err: err}
+	}
+}
+
+// executeFavoriteCommand handles ":favorite", adding the selected function to
+// the favorites, whose metrics are sampled into the local history, or
+// removing it
+func (m Model) executeFavoriteCommand() (tea.Model, tea.Cmd) {
+	if m.currentView == ListView {
+		m.selectedFunc = m.currentFunction()
+	}
+	if m.selectedFunc == nil {
+		m.statusMsg = "Select a function to add to the favorites"
+		return m, nil
+	}
+	name := m.selectedFunc.Name
+	favorite := m.cfg.File.ToggleFavorite(name)
+	if err := m.cfg.Save(); err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Failed to save favorites: %v", err)
+		return m, nil
+	}
+	m.restartHistory()
+
+	switch {
+	case !favorite:
+		m.statusMsg = fmt.Sprintf("✅ %s removed from the favorites", name)
+	case m.history.store == nil:
+		m.statusMsg = fmt.Sprintf("✅ %s added to the favorites", name)
+	case m.history.interval <= 0:
+		m.statusMsg = fmt.Sprintf("✅ %s added to the favorites; set metrics_history.interval to keep a history of their metrics", name)
+	default:
+		m.statusMsg = fmt.Sprintf("✅ %s added to the favorites; its metrics are sampled every %s", name, m.history.interval)
+	}
+	if m.currentView == MetricsView {
+		return m, m.fetchMetricsHistory(name)
+	}
+	return m, nil
+}
+
+// renderHistory renders the samples kept of the function as sparklines, or
+// nothing for a function that is not sampled
+func (mt metricsModel) renderHistory(width int, units unitFormat) string {
+	if !mt.historyLoaded || (len(mt.history) == 0 && mt.historyErr == nil && !mt.historyFavorite) {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Local History:") + "\n")
+	switch {
+	case mt.historyErr != nil:
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  Error reading the metrics history: %v", mt.historyErr)) + "\n")
+		return b.String()
+	case len(mt.history) == 0:
+		b.WriteString(styles.HelpStyle.Render("  No samples yet: favorites are sampled every metrics_history.interval") + "\n")
+		return b.String()
+	}
+
+	first, last := mt.history[0].Time, mt.history[len(mt.history)-1].Time
+	b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("  %d samples from %s to %s", len(mt.history), first.Local().Format("Jan 2 15:04"), last.Local().Format("Jan 2 15:04"))) + "\n")
+
+	sparkWidth := max(min(width-50, 80), 10)
+	series := func(value func(history.Sample) float64) ([]provider.MetricDataPoint, float64, float64) {
+		points := make([]provider.MetricDataPoint, len(mt.history))
+		total, peak := 0.0, 0.0
+		for i, s := range mt.history {
+			v := value(s)
+			points[i] = provider.MetricDataPoint{Timestamp: s.Time, Value: v}
+			total += v
+			peak = max(peak, v)
+		}
+		return points, total, peak
+	}
+	row := func(label string, points []provider.MetricDataPoint, summary string) {
+		b.WriteString(fmt.Sprintf("  %-12s %s  %s\n", label, charts.RenderSparkline(points, sparkWidth), summary))
+	}
+
+	points, total, peak := series(func(s history.Sample) float64 { return s.Invocations })
+	invocations := total
+	row("Invocations", points, fmt.Sprintf("%.0f total, peak %.0f", total, peak))
+	points, total, _ = series(func(s history.Sample) float64 { return s.Errors })
+	summary := fmt.Sprintf("%.0f total", total)
+	if invocations > 0 {
+		summary += fmt.Sprintf(" (%.1f%%)", total/invocations*100)
+	}
+	row("Errors", points, summary)
+	points, total, _ = series(func(s history.Sample) float64 { return s.Throttles })
+	row("Throttles", points, fmt.Sprintf("%.0f total", total))
+	points, total, peak = series(func(s history.Sample) float64 { return s.DurationMs })
+	row("Duration", points, fmt.Sprintf("avg %s, peak %s", units.millis(total/float64(len(points))), units.millis(peak)))
+	points, _, peak = series(func(s history.Sample) float64 { return s.Concurrency })
+	row("Concurrency", points, fmt.Sprintf("peak %.0f", peak))
+	return b.String()
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"f6n/internal/insights"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// invocationsLookback is how far back the invocation list reads logs
+	invocationsLookback = time.Hour
+	// invocationsLogLimit caps the log lines read to build the list
+	invocationsLogLimit = 3000
+)
+
+type invocationsLoadedMsg struct {
+	function    string
+	invocations []insights.Invocation
+	err         error
+}
+
+// openInvocations handles "i" in LogsView and ":invocations", reading the
+// recent logs of the selected function to list its invocations
+func (m Model) openInvocations() (tea.Model, tea.Cmd) {
+	if m.currentView == ListView {
+		m.selectedFunc = m.currentFunction()
+	}
+	if m.selectedFunc == nil {
+		m.statusMsg = "Select a function to list its invocations"
+		return m, nil
+	}
+	name := m.selectedFunc.Name
+	m.statusMsg = fmt.Sprintf("Reading the last %s of logs of %s...", invocationsLookback, name)
+	prov := m.provider
+	return m, func() tea.Msg {
+		query := provider.LogQuery{Limit: invocationsLogLimit, Since: time.Now().Add(-invocationsLookback)}
+		entries, err := prov.GetFunctionLogs(context.Background(), name, query)
+		if err != nil {
+			logger.Logger.Printf("Error fetching logs for the invocations of %s: %v", name, err)
+			return invocationsLoadedMsg{function: name, err: err}
+		}
+		return invocationsLoadedMsg{function: name, invocations: insights.GroupInvocations(entries)}
+	}
+}
+
+// openInvocationPicker lists the invocations, newest first, each with its
+// duration, memory used and status; picking one shows its log lines
+func (m Model) openInvocationPicker(msg invocationsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Failed to read the logs of %s: %v", msg.function, msg.err)
+		return m, nil
+	}
+	if len(msg.invocations) == 0 {
+		m.statusMsg = fmt.Sprintf("No invocations of %s in the last %s", msg.function, invocationsLookback)
+		return m, nil
+	}
+	m.statusMsg = ""
+
+	units := m.units()
+	items := make([]pickerItem, 0, len(msg.invocations))
+	for _, inv := range msg.invocations {
+		duration, memory := "-", "-"
+		if inv.Duration > 0 {
+			duration = units.millis(inv.Duration)
+		}
+		if inv.Report != nil {
+			memory = fmt.Sprintf("%s / %s", units.memory(float64(inv.Report.MaxMemoryUsedMB)), units.memory(float64(inv.Report.MemorySizeMB)))
+			if inv.Report.InitDuration > 0 {
+				duration += " (cold)"
+			}
+		}
+		items = append(items, pickerItem{
+			label:  fmt.Sprintf("%s  %-16s  %-10s  %-20s  %s", m.logs.timestamps.format(inv.Start), fitCell(inv.RequestID, 16), invocationStatus(inv.Status), memory, duration),
+			detail: fmt.Sprintf("%d lines", len(inv.Entries)),
+		})
+	}
+
+	invocations, function := msg.invocations, msg.function
+	title := fmt.Sprintf("%d invocations of %s in the last %s", len(invocations), function, invocationsLookback)
+	m.openPicker(title, items, func(m Model, idx int) (tea.Model, tea.Cmd) {
+		return m.showInvocationLogs(function, invocations[idx])
+	})
+	return m, nil
+}
+
+// invocationStatus labels an invocation status for the list
+func invocationStatus(status string) string {
+	switch status {
+	case insights.InvocationOK:
+		return "✅ ok"
+	case insights.InvocationTimeout:
+		return "⏳ timeout"
+	case insights.InvocationError:
+		return "❌ error"
+	}
+	return "▶ running"
+}
+
+// showInvocationLogs shows the log lines of one invocation in LogsView
+func (m Model) showInvocationLogs(function string, inv insights.Invocation) (tea.Model, tea.Cmd) {
+	m.logs.stopStream()
+	m.logs.showingStream = false
+	m.logs.entries, m.logs.err = inv.Entries, nil
+	m.logs.staleAsOf = time.Time{}
+	// Older pages would continue from the invocation's lines
+	m.logs.olderDone = true
+	m.currentView = LogsView
+	m.refreshLogView()
+	m.viewport.GotoTop()
+	m.statusMsg = fmt.Sprintf("Invocation %s of %s (%d lines); press l for all logs", inv.RequestID, function, len(inv.Entries))
+	return m, nil
+}
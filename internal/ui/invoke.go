@@ -0,0 +1,314 @@
+package ui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	"github.com/charmbracelet/bubbles/filepicker"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// invokePayloadLimit caps the payload sent, the request limit of a
+// synchronous Lambda invocation
+const invokePayloadLimit = 6 << 20
+
+// invocation holds the InvokeView state: the JSON payload being edited, the
+// file picker loading one from a file, and the result of the last invocation
+type invocation struct {
+	function string
+	payloads map[string]string // payload sent last per function
+	picker   *filepicker.Model // set while a payload file is chosen
+	result   *invokeResultMsg  // shown instead of the editor, nil while editing
+}
+
+type invokeResultMsg struct {
+	function string
+	payload  string
+	result   *provider.InvokeResult
+	latency  time.Duration
+	err      error
+}
+
+// openInvoke handles "i" in DetailView, editing a JSON payload to invoke the
+// selected function with, the one sent last or {}
+func (m Model) openInvoke() (tea.Model, tea.Cmd) {
+	if m.selectedFunc == nil {
+		return m, nil
+	}
+	if _, ok := provider.As[provider.Invoker](m.provider); !ok {
+		m.statusMsg = fmt.Sprintf("Invoking functions is not supported for %s", strings.ToUpper(string(m.provider.GetProviderName())))
+		return m, nil
+	}
+	payload, ok := m.invoke.payloads[m.selectedFunc.Name]
+	if !ok {
+		payload = "{}"
+	}
+	return m.editPayload(m.selectedFunc.Name, payload)
+}
+
+// editPayload shows the payload editor of InvokeView on payload
+func (m Model) editPayload(function, payload string) (tea.Model, tea.Cmd) {
+	m.invoke.function = function
+	m.invoke.picker = nil
+	m.invoke.result = nil
+	m.currentView = InvokeView
+	m.textarea.SetValue(payload)
+	m.textarea.SetWidth(m.width - 4)
+	m.textarea.SetHeight(m.height - 10)
+	m.textarea.Focus()
+	return m, nil
+}
+
+// handleInvokeEditKey sends the payload with ctrl+s, opens the file picker
+// with ctrl+o and goes back to DetailView with esc; every other key goes to
+// the editor, or to the file picker while it is open
+func (m Model) handleInvokeEditKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.invoke.picker != nil {
+		return m.handlePayloadPickerKey(msg)
+	}
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.textarea.Blur()
+		m.currentView = DetailView
+		return m, nil
+	case "ctrl+s":
+		return m.sendInvocation(m.textarea.Value())
+	case "ctrl+o":
+		return m.openPayloadPicker()
+	}
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return m, cmd
+}
+
+// openPayloadPicker lists the working directory to load the payload from a file
+func (m Model) openPayloadPicker() (tea.Model, tea.Cmd) {
+	picker := filepicker.New()
+	picker.AutoHeight = false
+	picker.SetHeight(max(m.height-12, 5))
+	if dir, err := os.Getwd(); err == nil {
+		picker.CurrentDirectory = dir
+	}
+	m.invoke.picker = &picker
+	return m, picker.Init()
+}
+
+// handlePayloadPickerKey moves through the file picker, closing it with esc
+// or once a file is chosen
+func (m Model) handlePayloadPickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc":
+		m.invoke.picker = nil
+		return m, nil
+	}
+	picker, cmd := m.invoke.picker.Update(msg)
+	m.invoke.picker = &picker
+	if selected, path := picker.DidSelectFile(msg); selected {
+		m.invoke.picker = nil
+		return m.loadPayloadFile(path)
+	}
+	return m, cmd
+}
+
+// updatePayloadPicker passes the messages of the file picker, such as the
+// directory it read, on to it
+func (m Model) updatePayloadPicker(msg tea.Msg) (tea.Model, tea.Cmd) {
+	picker, cmd := m.invoke.picker.Update(msg)
+	m.invoke.picker = &picker
+	return m, cmd
+}
+
+// loadPayloadFile puts the content of path in the payload editor. Invalid
+// JSON is loaded too, to be fixed before it is sent.
+func (m Model) loadPayloadFile(path string) (tea.Model, tea.Cmd) {
+	info, err := os.Stat(path)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Failed to read %s: %v", path, err)
+		return m, nil
+	}
+	if info.Size() > invokePayloadLimit {
+		m.statusMsg = fmt.Sprintf("❌ %s is larger than the %s payload limit", path, m.units().bytes(invokePayloadLimit))
+		return m, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Failed to read %s: %v", path, err)
+		return m, nil
+	}
+	m.textarea.SetValue(string(data))
+	m.textarea.CursorStart()
+	if err := validatePayload(data); err != nil {
+		m.statusMsg = fmt.Sprintf("⚠️ Loaded %s, but %v", path, err)
+	} else {
+		m.statusMsg = "Loaded " + path
+	}
+	return m, nil
+}
+
+// validatePayload checks that the payload is JSON within invokePayloadLimit,
+// giving the line of a syntax error
+func validatePayload(payload []byte) error {
+	if len(payload) > invokePayloadLimit {
+		return fmt.Errorf("the payload is larger than %d bytes", invokePayloadLimit)
+	}
+	var value any
+	err := json.Unmarshal(payload, &value)
+	if syntaxErr := (*json.SyntaxError)(nil); errors.As(err, &syntaxErr) {
+		line := bytes.Count(payload[:syntaxErr.Offset], []byte("\n")) + 1
+		return fmt.Errorf("the payload is not valid JSON: line %d: %v", line, err)
+	}
+	if err != nil {
+		return fmt.Errorf("the payload is not valid JSON: %v", err)
+	}
+	return nil
+}
+
+// sendInvocation validates the payload, {} when empty, and invokes the
+// function with it synchronously
+func (m Model) sendInvocation(payload string) (tea.Model, tea.Cmd) {
+	payload = strings.TrimSpace(payload)
+	if payload == "" {
+		payload = "{}"
+	}
+	if err := validatePayload([]byte(payload)); err != nil {
+		m.statusMsg = "❌ " + err.Error()
+		return m, nil
+	}
+	invoker, ok := provider.As[provider.Invoker](m.provider)
+	if !ok {
+		return m, nil
+	}
+
+	name := m.invoke.function
+	m.statusMsg = fmt.Sprintf("Invoking %s...", name)
+	return m, func() tea.Msg {
+		start := time.Now()
+		result, err := invoker.Invoke(context.Background(), name, []byte(payload))
+		if err != nil {
+			logger.Logger.Printf("Error invoking %s: %v", name, err)
+		}
+		return invokeResultMsg{function: name, payload: payload, result: result, latency: time.Since(start), err: err}
+	}
+}
+
+// handleInvokeResult records the invocation and shows its result, keeping
+// the payload to send again
+func (m Model) handleInvokeResult(msg invokeResultMsg) (tea.Model, tea.Cmd) {
+	detail := fmt.Sprintf("payload: %s", m.units().bytes(int64(len(msg.payload))))
+	if msg.result != nil && msg.result.FunctionError != "" {
+		detail += ", function error: " + msg.result.FunctionError
+	}
+	m.recordAction("invoke", msg.function, detail, msg.err)
+
+	if m.invoke.payloads == nil {
+		m.invoke.payloads = make(map[string]string)
+	}
+	m.invoke.payloads[msg.function] = msg.payload
+	if m.currentView != InvokeView || m.invoke.function != msg.function {
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Invoking %s failed: %v", msg.function, msg.err)
+		} else {
+			m.statusMsg = fmt.Sprintf("✅ %s invoked", msg.function)
+		}
+		return m, nil
+	}
+	m.statusMsg = ""
+	m.invoke.result = &msg
+	m.textarea.Blur()
+	m.viewport.SetContent(renderInvokeResult(msg, m.units()))
+	m.viewport.GotoTop()
+	return m, nil
+}
+
+// handleInvokeKey handles the InvokeView keys while a result is shown
+func (m Model) handleInvokeKey(key string) (tea.Model, tea.Cmd, bool) {
+	result := m.invoke.result
+	if result == nil {
+		return m, nil, false
+	}
+	switch key {
+	case "r":
+		model, cmd := m.sendInvocation(result.payload)
+		return model, cmd, true
+	case "e":
+		model, cmd := m.editPayload(result.function, result.payload)
+		return model, cmd, true
+	}
+	return m, nil, false
+}
+
+// renderInvokeEditor renders the payload editor, or the file picker while a
+// payload file is chosen
+func (m Model) renderInvokeEditor() string {
+	if picker := m.invoke.picker; picker != nil {
+		header := styles.InfoLabelStyle.Render("📂 PAYLOAD FILE FOR "+m.invoke.function) +
+			styles.HelpStyle.Render(" (Enter to load, Esc to cancel)")
+		return header + "\n" + styles.HelpStyle.Render(picker.CurrentDirectory) + "\n\n" + picker.View()
+	}
+	header := styles.InfoLabelStyle.Render("▶️  INVOKE "+m.invoke.function) +
+		styles.HelpStyle.Render(" (JSON payload; Ctrl+S to send, Ctrl+O to load a file, Esc to cancel)")
+	return header + "\n\n" + m.textarea.View()
+}
+
+// renderInvokeResult renders the payload sent and the outcome, response and
+// log of the invocation
+func renderInvokeResult(msg invokeResultMsg, units unitFormat) string {
+	var b strings.Builder
+	b.WriteString(styles.SelectedStyle.Render(fmt.Sprintf("━━━ Invoke %s ━━━", msg.function)) + "\n")
+	b.WriteString(styles.HelpStyle.Render("  "+compactJSON(msg.payload)) + "\n\n")
+
+	if msg.err != nil {
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("❌ Invocation failed: %v", msg.err)))
+		return b.String()
+	}
+	result := msg.result
+	status := "✅ Succeeded"
+	if result.FunctionError != "" {
+		status = styles.ErrorStyle.Render("❌ Function error: " + result.FunctionError)
+	}
+	b.WriteString(fmt.Sprintf("%s  •  %s", status, units.millis(float64(msg.latency.Microseconds())/1000)))
+	if result.ExecutionID != "" {
+		b.WriteString("  •  " + result.ExecutionID)
+	}
+	b.WriteString("\n\n" + styles.InfoLabelStyle.Render("Response:") + "\n")
+	switch {
+	case result.Payload == "":
+		b.WriteString(styles.HelpStyle.Render("(empty)"))
+	case json.Valid([]byte(result.Payload)):
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, []byte(result.Payload), "", "  "); err == nil {
+			b.Write(pretty.Bytes())
+		} else {
+			b.WriteString(result.Payload)
+		}
+	default:
+		b.WriteString(strings.ToValidUTF8(result.Payload, "�"))
+	}
+	if result.Log != "" {
+		b.WriteString("\n\n" + styles.InfoLabelStyle.Render("Log:") + "\n" + result.Log)
+	}
+	return b.String()
+}
+
+// compactJSON puts a valid JSON payload on one line
+func compactJSON(payload string) string {
+	var b bytes.Buffer
+	if err := json.Compact(&b, []byte(payload)); err != nil {
+		return payload
+	}
+	return b.String()
+}
@@ -0,0 +1,264 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"f6n/internal/insights"
+	"f6n/internal/provider"
+)
+
+// functionCondition is a single "field op value" comparison over a function,
+// e.g. memory>512
+type functionCondition struct {
+	field string
+	op    string
+	value string
+	read  func(provider.FunctionInfo) string // a custom column's value, nil for built-in fields
+}
+
+// filterTerm is a condition or a piece of free text, possibly negated with !
+type filterTerm struct {
+	cond   *functionCondition
+	text   string // Matched against the name, runtime and description
+	negate bool
+}
+
+// functionFilter is what the list filter parsed into: alternatives joined by
+// OR, each a group of terms that must all match
+type functionFilter struct {
+	alternatives [][]filterTerm
+}
+
+// functionConditionRe splits "memory>=512" into field, operator and value
+var functionConditionRe = regexp.MustCompile(`^([a-z]+)(>=|<=|!=|:|=|>|<)(.*)$`)
+
+// functionFields reads the fields conditions can be scoped to. Numeric
+// fields compare as numbers: memory in MB, timeout in seconds.
+var functionFields = map[string]func(provider.FunctionInfo) string{
+	"name":        func(fn provider.FunctionInfo) string { return fn.Name },
+	"runtime":     func(fn provider.FunctionInfo) string { return fn.Runtime },
+	"memory":      func(fn provider.FunctionInfo) string { return strconv.Itoa(int(fn.Memory)) },
+	"timeout":     func(fn provider.FunctionInfo) string { return strconv.Itoa(int(fn.Timeout)) },
+	"state":       func(fn provider.FunctionInfo) string { return fn.State },
+	"handler":     func(fn provider.FunctionInfo) string { return fn.Handler },
+	"region":      func(fn provider.FunctionInfo) string { return fn.Region },
+	"profile":     func(fn provider.FunctionInfo) string { return fn.Profile },
+	"description": func(fn provider.FunctionInfo) string { return fn.Description },
+}
+
+// numericFields are the fields compared as numbers
+var numericFields = map[string]bool{"memory": true, "timeout": true}
+
+// parseFunctionFilter parses the list filter: space-separated terms, each
+// either a condition such as runtime:python, memory>512 or timeout<30, or
+// free text. ":" matches a substring and "=" the whole value, ignoring case;
+// numeric fields also take >, >=, < and <=. owner: matches the owner, team
+// and service tags, tag:key or tag:key=value any tag (label: the same, for
+// GCP labels), and the fields of
+// custom columns their value, e.g. stage=prod. Terms naming no
+// known field, and conditions still missing their value while being typed,
+// are matched as text or ignored, so that the list narrows as one types.
+//
+// A leading ! negates a term. Terms must all match (AND may be written
+// between them), and OR separates alternatives, binding looser than AND:
+// "runtime:node !tag:team=infra OR runtime:go" keeps the Node.js functions
+// not owned by infra and all Go functions.
+func parseFunctionFilter(expr string, columns []customColumn) functionFilter {
+	var filter functionFilter
+	var group []filterTerm
+	var text []string
+	endGroup := func() {
+		if len(text) > 0 {
+			// Consecutive words match as a phrase, as in a plain text filter
+			group = append(group, filterTerm{text: strings.Join(text, " ")})
+		}
+		if len(group) > 0 {
+			filter.alternatives = append(filter.alternatives, group)
+		}
+		group, text = nil, nil
+	}
+
+	for _, word := range strings.Fields(expr) {
+		switch word {
+		case "OR":
+			endGroup()
+			continue
+		case "AND":
+			continue
+		}
+		term := strings.ToLower(word)
+		negate := false
+		if rest, ok := strings.CutPrefix(term, "!"); ok {
+			negate, term = true, rest
+		}
+		if term == "" {
+			continue
+		}
+
+		parts := functionConditionRe.FindStringSubmatch(term)
+		if parts == nil || !knownFilterField(parts[1], columns) {
+			if negate {
+				group = append(group, filterTerm{text: term, negate: true})
+			} else {
+				text = append(text, term)
+			}
+			continue
+		}
+		cond := functionCondition{field: parts[1], op: parts[2], value: strings.Trim(parts[3], `"'`)}
+		for _, column := range columns {
+			if column.field == cond.field {
+				cond.read = column.value
+			}
+		}
+		// owner: alone keeps the functions without an owner
+		if cond.value == "" && !(cond.field == "owner" && cond.op == ":") {
+			continue
+		}
+		group = append(group, filterTerm{cond: &cond, negate: negate})
+	}
+	endGroup()
+	return filter
+}
+
+// knownFilterField reports whether conditions can be scoped to field, a
+// built-in field or that of a custom column
+func knownFilterField(field string, columns []customColumn) bool {
+	if _, ok := functionFields[field]; ok || field == "owner" || field == "tag" || field == "label" {
+		return true
+	}
+	return slices.ContainsFunc(columns, func(c customColumn) bool { return c.field == field })
+}
+
+// Matches reports whether the function satisfies every term of one of the
+// alternatives. An empty filter matches every function.
+func (f functionFilter) Matches(fn provider.FunctionInfo) bool {
+	if len(f.alternatives) == 0 {
+		return true
+	}
+	for _, group := range f.alternatives {
+		if matchesAll(fn, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAll reports whether the function satisfies every term
+func matchesAll(fn provider.FunctionInfo, terms []filterTerm) bool {
+	for _, term := range terms {
+		if term.matches(fn) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// matches evaluates the term, before negation
+func (t filterTerm) matches(fn provider.FunctionInfo) bool {
+	if t.cond != nil {
+		return t.cond.matches(fn)
+	}
+	return strings.Contains(strings.ToLower(fn.Name), t.text) ||
+		strings.Contains(strings.ToLower(fn.Runtime), t.text) ||
+		strings.Contains(strings.ToLower(fn.Description), t.text)
+}
+
+// matches evaluates the condition against the function
+func (c functionCondition) matches(fn provider.FunctionInfo) bool {
+	switch c.field {
+	case "owner":
+		ownership := insights.FunctionOwnership(fn)
+		if c.value == "" {
+			return ownership.Primary() == ""
+		}
+		return c.op == "!=" != ownership.Matches(c.value)
+	case "tag", "label":
+		return c.matchesTag(fn.Tags)
+	}
+
+	read := functionFields[c.field]
+	if c.read != nil {
+		read = c.read
+	}
+	actual := strings.ToLower(read(fn))
+	if numericFields[c.field] {
+		want, err := parseFilterNumber(c.field, c.value)
+		if err != nil {
+			return false
+		}
+		got, _ := strconv.ParseFloat(actual, 64)
+		return compareNumbers(got, c.op, want)
+	}
+	switch c.op {
+	case ":":
+		return strings.Contains(actual, c.value)
+	case "=":
+		return actual == c.value
+	case "!=":
+		return actual != c.value
+	}
+	// Ordering only applies to numbers
+	return false
+}
+
+// matchesTag matches tag:key, any tag with that key, or tag:key=value
+func (c functionCondition) matchesTag(tags map[string]string) bool {
+	key, value, withValue := strings.Cut(c.value, "=")
+	found := false
+	for k, v := range tags {
+		if strings.EqualFold(k, key) && (!withValue || strings.EqualFold(v, value)) {
+			found = true
+			break
+		}
+	}
+	if c.op == "!=" {
+		return !found
+	}
+	return found
+}
+
+// parseFilterNumber reads the value of a numeric condition: memory in MB or
+// with an mb/gb suffix, the timeout in seconds or as a duration such as 5m
+func parseFilterNumber(field, value string) (float64, error) {
+	switch field {
+	case "memory":
+		if gb, ok := strings.CutSuffix(value, "gb"); ok {
+			n, err := strconv.ParseFloat(gb, 64)
+			return n * 1024, err
+		}
+		value = strings.TrimSuffix(value, "mb")
+	case "timeout":
+		if d, err := time.ParseDuration(value); err == nil {
+			return d.Seconds(), nil
+		}
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number, not %q", field, value)
+	}
+	return n, nil
+}
+
+// compareNumbers applies a comparison operator, ":" meaning equality
+func compareNumbers(got float64, op string, want float64) bool {
+	switch op {
+	case ":", "=":
+		return got == want
+	case "!=":
+		return got != want
+	case ">":
+		return got > want
+	case ">=":
+		return got >= want
+	case "<":
+		return got < want
+	case "<=":
+		return got <= want
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"testing"
+
+	"f6n/internal/provider"
+)
+
+func TestFunctionFilterMatches(t *testing.T) {
+	fn := provider.FunctionInfo{
+		Name:        "orders-api",
+		Runtime:     "python3.12",
+		Memory:      1024,
+		Timeout:     15,
+		Description: "Takes orders",
+		Tags:        map[string]string{"team": "payments", "Stage": "prod"},
+	}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   bool
+	}{
+		{"empty", "", true},
+		{"text", "orders", true},
+		{"text phrase", "takes orders", true},
+		{"text mismatch", "billing", false},
+		{"field substring", "runtime:python", true},
+		{"field whole value", "runtime=python", false},
+		{"field not equal", "runtime!=nodejs20.x", true},
+		{"tag key", "tag:team", true},
+		{"tag key missing", "tag:owner", false},
+		{"tag key and value", "tag:team=payments", true},
+		{"tag value ignores case", "tag:stage=PROD", true},
+		{"tag value mismatch", "tag:team=infra", false},
+		{"tag not equal", "tag!=team=infra", true},
+		{"tag not equal matching", "tag!=team=payments", false},
+		{"negated tag", "!tag:team=payments", false},
+		{"label like tag", "label:team=payments", true},
+		{"memory greater", "memory>512", true},
+		{"memory greater equal", "memory>1024", false},
+		{"memory at least gb", "memory>=1gb", true},
+		{"memory at most mb", "memory<=512mb", false},
+		{"memory equal", "memory=1024", true},
+		{"memory not equal", "memory!=1024", false},
+		{"timeout less", "timeout<30", true},
+		{"timeout duration", "timeout<10s", false},
+		{"timeout minutes", "timeout<1m", true},
+		{"invalid number", "memory>lots", false},
+		{"value being typed", "memory>", true},
+		{"and", "runtime:python memory>512", true},
+		{"and mismatch", "runtime:python memory<512", false},
+		{"explicit and", "runtime:python AND timeout<30", true},
+		{"or", "runtime:go OR tag:team=payments", true},
+		{"or mismatch", "runtime:go OR memory<128", false},
+		{"negated text", "!billing", true},
+		{"unknown field as text", "foo:bar", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseFunctionFilter(tt.filter, nil).Matches(fn); got != tt.want {
+				t.Errorf("%q matches = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFunctionFilterCustomColumn(t *testing.T) {
+	columns := []customColumn{{title: "Stage", field: "stage", source: "tag", key: "Stage"}}
+	fn := provider.FunctionInfo{Name: "orders-api", Tags: map[string]string{"Stage": "prod"}}
+
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"stage=prod", true},
+		{"stage:pro", true},
+		{"stage=dev", false},
+		{"stage!=dev", true},
+	}
+	for _, tt := range tests {
+		if got := parseFunctionFilter(tt.filter, columns).Matches(fn); got != tt.want {
+			t.Errorf("%q matches = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/i18n"
+	"f6n/internal/insights"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// listModel is the ListView sub-model: the functions and the table listing them
+type listModel struct {
+	table        table.Model
+	functions    []provider.FunctionInfo // Functions shown, after the filter
+	allFunctions []provider.FunctionInfo // Unfiltered list
+	filterActive bool                    // Whether a filter is currently applied
+	activeFilter string                  // The current filter text
+	loading      bool
+	fetched      time.Time      // When the list was last fetched
+	tailMarks    []string       // Functions marked with 'T' to stream their logs together
+	columns      []customColumn // Columns of the config file, after the built-in ones
+}
+
+// newListModel creates the function table, loading until the first list arrives
+func newListModel(columns []customColumn, compliance bool) listModel {
+	t := table.New(
+		table.WithColumns(listColumns(120, compliance, columns)),
+		table.WithFocused(true),
+		table.WithHeight(20),
+	)
+
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("#07646bff")).
+		BorderBottom(true).
+		Bold(true)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(lipgloss.Color("#07646bff")).
+		Bold(true)
+	t.SetStyles(s)
+
+	return listModel{table: t, loading: true, columns: columns}
+}
+
+// Update stores a loaded function list, handles the ListView keys and passes
+// other messages to the table
+func (l listModel) Update(ctx viewContext, msg tea.Msg) (listModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case functionsLoadedMsg:
+		l.loading = false
+		if msg.err == nil {
+			l.allFunctions = msg.functions
+			l.functions = msg.functions
+			l.fetched = time.Now()
+		}
+		return l, nil
+	case viewKeyMsg:
+		return l.handleKey(string(msg))
+	}
+	var cmd tea.Cmd
+	l.table, cmd = l.table.Update(msg)
+	return l, cmd
+}
+
+// View renders the function table
+func (l listModel) View() string {
+	return l.table.View()
+}
+
+// selected returns the function under the cursor, nil when the list is empty
+func (l listModel) selected() *provider.FunctionInfo {
+	if idx := l.table.Cursor(); idx >= 0 && idx < len(l.functions) {
+		return &l.functions[idx]
+	}
+	return nil
+}
+
+// filter shows the functions matching the filter expression, see
+// parseFunctionFilter
+func (l *listModel) filter(text string) {
+	if strings.TrimSpace(text) == "" {
+		l.functions = l.allFunctions
+		return
+	}
+	filter := parseFunctionFilter(text, l.columns)
+	l.functions = []provider.FunctionInfo{}
+	for _, fn := range l.allFunctions {
+		if filter.Matches(fn) {
+			l.functions = append(l.functions, fn)
+		}
+	}
+}
+
+// formatOwnerCell shows who owns the function, "-" when it has no ownership tags
+func formatOwnerCell(fn provider.FunctionInfo) string {
+	if owner := insights.FunctionOwnership(fn).Primary(); owner != "" {
+		return owner
+	}
+	return "-"
+}
+
+// handleKey handles the ListView keys, most of which open a view of the
+// function under the cursor
+func (l listModel) handleKey(key string) (listModel, tea.Cmd) {
+	switch key {
+	case "\\":
+		return l, route(Model.openListFilter)
+	case "r":
+		l.loading = true
+		return l, route(func(m Model) (tea.Model, tea.Cmd) { return m, m.fetchFunctions() })
+	case "t":
+		if len(l.allFunctions) == 0 {
+			return l, nil
+		}
+		return l, route(Model.openRanking)
+	case "S":
+		return l, route(Model.openSecurityView)
+	case "o":
+		return l, route(Model.openInConsole)
+	case "y":
+		return l, route(Model.openCopyPicker)
+	case "x":
+		return l, route(Model.markForComparison)
+	case "T":
+		return l, route(Model.toggleTailMark)
+	case "f":
+		return l, route(Model.openFilterPicker)
+	}
+
+	fn := l.selected()
+	if fn == nil {
+		return l, nil
+	}
+	switch key {
+	case "enter":
+		return l, routeFunction(fn, Model.openDetailView)
+	case "l":
+		return l, routeFunction(fn, Model.openLogsView)
+	case "F":
+		return l, routeFunction(fn, Model.loadFailedEvents)
+	case "E":
+		return l, routeFunction(fn, Model.openErrorsView)
+	case "c":
+		return l, routeFunction(fn, Model.openCodeView)
+	case "m":
+		return l, routeFunction(fn, Model.openMetricsView)
+	case "w":
+		return l, route(func(m Model) (tea.Model, tea.Cmd) { return m.downloadCode(fn) })
+	}
+	return l, nil
+}
+
+// openListFilter enters FilterMode to filter the list
+func (m Model) openListFilter() (tea.Model, tea.Cmd) {
+	m.inputMode = FilterMode
+	m.textInput.Placeholder = i18n.T("Filter functions, e.g. runtime:python memory>512...")
+	m.textInput.SetValue("")
+	m.textInput.Focus()
+	return m, textinput.Blink
+}
+
+// downloadCode downloads the code of fn into downloads/
+func (m Model) downloadCode(fn *provider.FunctionInfo) (tea.Model, tea.Cmd) {
+	if !m.permitted(fn, provider.ActionDownloadCode) {
+		return m, nil
+	}
+	logger.Logger.Printf("Starting download for function: %s", fn.Name)
+	m.viewport.SetContent(fmt.Sprintf("Downloading code for %s...", fn.Name))
+	return m, tea.Batch(
+		func() tea.Msg { return downloadingMsg{functionName: fn.Name} },
+		m.downloadFunctionCode(fn.Name),
+	)
+}
+
+// listColumns returns the ListView columns sized to span totalWidth, the
+// built-in columns narrowing to make room for the custom ones
+func listColumns(totalWidth int, compliance bool, custom []customColumn) []table.Column {
+	scale := max(1-customColumnShare*float64(len(custom)), 0.5)
+	width := func(share float64) int { return int(float64(totalWidth) * share * scale) }
+	var columns []table.Column
+	if !compliance {
+		columns = []table.Column{
+			{Title: i18n.T("Function Name"), Width: width(0.26)},
+			{Title: i18n.T("Runtime"), Width: width(0.11)},
+			{Title: i18n.T("Memory"), Width: width(0.08)},
+			{Title: i18n.T("Timeout"), Width: width(0.08)},
+			{Title: i18n.T("State"), Width: width(0.10)},
+			{Title: i18n.T("Health"), Width: width(0.09)},
+			{Title: i18n.T("Owner"), Width: width(0.11)},
+			{Title: i18n.T("Last Modified"), Width: width(0.17)},
+		}
+	} else {
+		columns = []table.Column{
+			{Title: i18n.T("Function Name"), Width: width(0.23)},
+			{Title: i18n.T("Runtime"), Width: width(0.10)},
+			{Title: i18n.T("Memory"), Width: width(0.07)},
+			{Title: i18n.T("Timeout"), Width: width(0.07)},
+			{Title: i18n.T("State"), Width: width(0.09)},
+			{Title: i18n.T("Health"), Width: width(0.08)},
+			{Title: i18n.T("Owner"), Width: width(0.10)},
+			{Title: i18n.T("Last Modified"), Width: width(0.15)},
+			{Title: i18n.T("Compliance"), Width: width(0.11)},
+		}
+	}
+	for _, c := range custom {
+		columns = append(columns, table.Column{Title: c.title, Width: int(float64(totalWidth) * (1 - scale) / float64(len(custom)))})
+	}
+	return columns
+}
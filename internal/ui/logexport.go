@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type logsSavedMsg struct {
+	path  string
+	count int
+	err   error
+}
+
+// logRecord is the JSONL representation of an exported log entry
+type logRecord struct {
+	Function  string            `json:"function"`
+	Timestamp time.Time         `json:"timestamp"`
+	Severity  string            `json:"severity"`
+	Message   string            `json:"message"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// buffer returns the log entries currently shown in LogsView
+func (l logsModel) buffer() []provider.LogEntry {
+	source := l.entries
+	if l.showingStream {
+		source = l.streamEntries
+	}
+
+	entries := make([]provider.LogEntry, 0, len(source))
+	for _, entry := range source {
+		if isNotice(entry) || (l.fieldFilter != nil && !l.fieldFilter.Matches(entry)) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// executeSaveCommand handles ":save logs <path>"
+func (m Model) executeSaveCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) < 2 || args[0] != "logs" {
+		m.statusMsg = "usage: :save logs <path> (use .jsonl for JSON lines)"
+		return m, nil
+	}
+	if m.currentView != LogsView || m.selectedFunc == nil {
+		m.statusMsg = "Open a function's logs before saving them"
+		return m, nil
+	}
+
+	entries := m.logs.buffer()
+	if len(entries) == 0 {
+		m.statusMsg = "No log entries to save"
+		return m, nil
+	}
+
+	return m, saveLogs(m.selectedFunc.Name, entries, args[1], m.logs.timestamps)
+}
+
+// saveLogs writes log entries to path as plain text with timestamps as shown,
+// or as JSONL when the extension is .jsonl or .ndjson, with RFC 3339
+// timestamps in the timezone of ts
+func saveLogs(functionName string, entries []provider.LogEntry, path string, ts logTimestamps) tea.Cmd {
+	return func() tea.Msg {
+		if dir := filepath.Dir(path); dir != "." {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return logsSavedMsg{err: fmt.Errorf("failed to create directory: %w", err)}
+			}
+		}
+
+		file, err := os.Create(path)
+		if err != nil {
+			return logsSavedMsg{err: fmt.Errorf("failed to create %s: %w", path, err)}
+		}
+		defer file.Close()
+
+		w := bufio.NewWriter(file)
+		ext := strings.ToLower(filepath.Ext(path))
+		jsonLines := ext == ".jsonl" || ext == ".ndjson"
+		enc := json.NewEncoder(w)
+
+		for _, entry := range entries {
+			if jsonLines {
+				err = enc.Encode(logRecord{
+					Function:  functionName,
+					Timestamp: entry.Timestamp.In(ts.loc),
+					Severity:  entry.Severity,
+					Message:   entry.Message,
+					Labels:    entry.Labels,
+				})
+			} else {
+				_, err = fmt.Fprintln(w, formatLogEntry(entry, ts))
+			}
+			if err != nil {
+				return logsSavedMsg{err: fmt.Errorf("failed to write %s: %w", path, err)}
+			}
+		}
+
+		if err := w.Flush(); err != nil {
+			return logsSavedMsg{err: fmt.Errorf("failed to write %s: %w", path, err)}
+		}
+
+		absPath, _ := filepath.Abs(path)
+		logger.Logger.Printf("Saved %d log entries for %s to %s", len(entries), functionName, absPath)
+		return logsSavedMsg{path: absPath, count: len(entries)}
+	}
+}
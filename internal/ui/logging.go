@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	// applicationLogLevels are the levels application logs can be filtered at,
+	// most detailed first
+	applicationLogLevels = []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
+	// systemLogLevels are those of the logs the platform writes
+	systemLogLevels = []string{"DEBUG", "INFO", "WARN"}
+)
+
+type loggingUpdatedMsg struct {
+	function string
+	config   provider.LoggingConfig
+	err      error
+}
+
+// loggingUpdater returns the provider's LoggingConfigUpdater, setting the
+// status when changing log levels is not supported
+func (m *Model) loggingUpdater() (provider.LoggingConfigUpdater, bool) {
+	updater, ok := provider.As[provider.LoggingConfigUpdater](m.provider)
+	if !ok {
+		m.statusMsg = fmt.Sprintf("Changing log levels is not supported for %s", strings.ToUpper(string(m.provider.GetProviderName())))
+	}
+	return updater, ok
+}
+
+// openLoggingForm handles "D" in DetailView, showing the log format and
+// levels of the selected function for editing
+func (m Model) openLoggingForm() (tea.Model, tea.Cmd) {
+	updater, ok := m.loggingUpdater()
+	if !ok || m.selectedFunc == nil || !m.permitted(m.selectedFunc, provider.ActionUpdateLogging) {
+		return m, nil
+	}
+
+	current := provider.LoggingConfig{Format: "Text"}
+	if m.selectedFunc.Logging != nil {
+		current = *m.selectedFunc.Logging
+	}
+	fn := m.selectedFunc.Name
+	fields := []formField{
+		newFormField("format", "Log format", current.Format, "JSON or Text"),
+		newFormField("application", "Application log level", current.ApplicationLevel, "JSON only: "+strings.Join(applicationLogLevels, ", ")),
+		newFormField("system", "System log level", current.SystemLevel, "JSON only: "+strings.Join(systemLogLevels, ", ")),
+	}
+	cmd := m.openForm("Log levels of "+fn, fields, func(m Model, values map[string]string) (Model, tea.Cmd, error) {
+		config, err := parseLoggingConfig(values, current.LogGroup)
+		if err != nil {
+			return m, nil, err
+		}
+		m.statusMsg = fmt.Sprintf("Updating log levels of %s...", fn)
+		return m, updateLoggingConfig(updater, fn, config), nil
+	})
+	return m, cmd
+}
+
+// executeLogLevelCommand handles ":loglevel <level> [system level]", switching
+// the selected function to JSON logs at the given levels, or ":loglevel text"
+func (m Model) executeLogLevelCommand(args []string) (tea.Model, tea.Cmd) {
+	if m.currentView == ListView {
+		m.selectedFunc = m.currentFunction()
+	}
+	if len(args) == 0 || len(args) > 2 {
+		m.statusMsg = "Usage: :loglevel <application level> [system level], or :loglevel text"
+		return m, nil
+	}
+	updater, ok := m.loggingUpdater()
+	if !ok || m.selectedFunc == nil || !m.permitted(m.selectedFunc, provider.ActionUpdateLogging) {
+		return m, nil
+	}
+
+	values := map[string]string{"format": "JSON", "application": args[0]}
+	if strings.EqualFold(args[0], "text") {
+		values = map[string]string{"format": "Text"}
+	}
+	if len(args) == 2 {
+		values["system"] = args[1]
+	} else if m.selectedFunc.Logging != nil && values["format"] == "JSON" {
+		values["system"] = m.selectedFunc.Logging.SystemLevel
+	}
+	logGroup := ""
+	if m.selectedFunc.Logging != nil {
+		logGroup = m.selectedFunc.Logging.LogGroup
+	}
+	config, err := parseLoggingConfig(values, logGroup)
+	if err != nil {
+		m.statusMsg = "❌ " + err.Error()
+		return m, nil
+	}
+	fn := m.selectedFunc.Name
+	m.statusMsg = fmt.Sprintf("Updating log levels of %s...", fn)
+	return m, updateLoggingConfig(updater, fn, config)
+}
+
+// parseLoggingConfig validates the log format and levels entered, keeping
+// the function's log group
+func parseLoggingConfig(values map[string]string, logGroup string) (provider.LoggingConfig, error) {
+	config := provider.LoggingConfig{
+		ApplicationLevel: strings.ToUpper(values["application"]),
+		SystemLevel:      strings.ToUpper(values["system"]),
+		LogGroup:         logGroup,
+	}
+	switch strings.ToLower(values["format"]) {
+	case "json":
+		config.Format = "JSON"
+	case "text", "":
+		config.Format = "Text"
+		if config.ApplicationLevel != "" || config.SystemLevel != "" {
+			return config, fmt.Errorf("log levels need the JSON log format")
+		}
+		return config, nil
+	default:
+		return config, fmt.Errorf("log format must be JSON or Text")
+	}
+	if config.ApplicationLevel != "" && !slices.Contains(applicationLogLevels, config.ApplicationLevel) {
+		return config, fmt.Errorf("application log level must be one of %s", strings.Join(applicationLogLevels, ", "))
+	}
+	if config.SystemLevel != "" && !slices.Contains(systemLogLevels, config.SystemLevel) {
+		return config, fmt.Errorf("system log level must be one of %s", strings.Join(systemLogLevels, ", "))
+	}
+	return config, nil
+}
+
+// updateLoggingConfig applies the logging configuration in the background
+func updateLoggingConfig(updater provider.LoggingConfigUpdater, name string, config provider.LoggingConfig) tea.Cmd {
+	return func() tea.Msg {
+		err := updater.UpdateLoggingConfig(context.Background(), name, config)
+		if err != nil {
+			logger.Logger.Printf("Error updating log levels of %s: %v", name, err)
+		}
+		return loggingUpdatedMsg{function: name, config: config, err: err}
+	}
+}
+
+// handleLoggingUpdated records a log level change and shows it in the details
+func (m Model) handleLoggingUpdated(msg loggingUpdatedMsg) (tea.Model, tea.Cmd) {
+	m.recordAction("update-logging", msg.function, formatLogging(&msg.config), msg.err)
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %v", msg.err)
+		return m, nil
+	}
+	for i := range m.list.allFunctions {
+		if m.list.allFunctions[i].Name == msg.function {
+			config := msg.config
+			m.list.allFunctions[i].Logging = &config
+		}
+	}
+	if m.selectedFunc != nil && m.selectedFunc.Name == msg.function {
+		config := msg.config
+		m.selectedFunc.Logging = &config
+		if m.currentView == DetailView {
+			m.refreshDetailView()
+		}
+	}
+	m.statusMsg = fmt.Sprintf("✅ Logs of %s are now %s", msg.function, formatLogging(&msg.config))
+	// Reload the list for the update's state
+	return m, m.fetchFunctions()
+}
+
+// formatLogging describes a logging configuration, e.g. "JSON, application
+// DEBUG, system INFO"
+func formatLogging(config *provider.LoggingConfig) string {
+	if config == nil || config.Format == "" {
+		return "Text"
+	}
+	parts := []string{config.Format}
+	if config.Format == "JSON" {
+		parts = append(parts, "application "+cmp.Or(config.ApplicationLevel, "INFO"), "system "+cmp.Or(config.SystemLevel, "INFO"))
+	}
+	return strings.Join(parts, ", ")
+}
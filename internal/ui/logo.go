@@ -0,0 +1,62 @@
+package ui
+
+import "f6n/internal/i18n"
+
+const (
+	// logoLines is the height of the logo and the blank lines below it
+	logoLines = 8
+	// logoMinHeight is the terminal height below which the logo is hidden in
+	// auto mode, leaving the rows to the function table
+	logoMinHeight = 40
+)
+
+// logoVisible reports whether the ASCII-art logo is shown. It is never shown
+// in accessibility mode; otherwise the ui.logo config setting decides unless
+// it is toggled with L at runtime.
+func (m Model) logoVisible() bool {
+	if m.accessible() {
+		return false
+	}
+	setting := m.logoSetting
+	if setting == "" && m.cfg != nil {
+		setting = m.cfg.File.UI.Logo
+	}
+	switch setting {
+	case "show":
+		return true
+	case "hide":
+		return false
+	default: // auto
+		return m.height == 0 || m.height >= logoMinHeight
+	}
+}
+
+// toggleLogo shows or hides the logo for the rest of the session
+func (m *Model) toggleLogo() {
+	if m.accessible() {
+		m.statusMsg = i18n.T("The logo is not shown in accessibility mode")
+		return
+	}
+	if m.logoVisible() {
+		m.logoSetting = "hide"
+		m.statusMsg = i18n.T("Logo hidden (set ui.logo in the config file to keep it)")
+	} else {
+		m.logoSetting = "show"
+		m.statusMsg = i18n.T("Logo shown")
+	}
+	m.layoutTable()
+}
+
+// layoutTable sizes the table to the rows left by the logo, info panel,
+// shortcuts and help
+func (m *Model) layoutTable() {
+	// Top padding: 5, ASCII art: 6, Info: 3, Shortcuts: 3, Help: 2, Extra spacing: 3 = 22 total
+	availableHeight := m.height - 22
+	if !m.logoVisible() {
+		availableHeight += logoLines
+	}
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+	m.list.table.SetHeight(availableHeight)
+}
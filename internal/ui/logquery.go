@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/config"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultLogLimit is the number of log lines fetched for LogsView
+const defaultLogLimit = 200
+
+// providerLogQuery converts the active saved query into a provider query
+func (l logsModel) providerLogQuery() provider.LogQuery {
+	query := provider.LogQuery{Limit: defaultLogLimit}
+	if l.query == nil {
+		return query
+	}
+
+	query.MinSeverity = l.query.Severity
+	query.Text = l.query.Text
+	if since, err := time.ParseDuration(l.query.Since); err == nil && since > 0 {
+		query.Since = time.Now().Add(-since)
+	}
+	return query
+}
+
+// describeLogQuery returns a short human-readable summary of a saved query
+func describeLogQuery(q config.LogQuery) string {
+	var parts []string
+	if q.Severity != "" {
+		parts = append(parts, "severity>="+strings.ToUpper(q.Severity))
+	}
+	if q.Text != "" {
+		parts = append(parts, fmt.Sprintf("text=%q", q.Text))
+	}
+	if q.Since != "" {
+		parts = append(parts, "since "+q.Since)
+	}
+	if len(parts) == 0 {
+		return "all logs"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parseLogQueryArgs parses "key=value" arguments of the :query save command
+func parseLogQueryArgs(name string, args []string) (config.LogQuery, error) {
+	query := config.LogQuery{Name: name}
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return query, fmt.Errorf("expected key=value, got %q", arg)
+		}
+		switch strings.ToLower(key) {
+		case "severity":
+			if provider.SeverityRank(value) == 0 && !strings.EqualFold(value, "DEFAULT") {
+				return query, fmt.Errorf("unknown severity %q", value)
+			}
+			query.Severity = strings.ToUpper(value)
+		case "text":
+			query.Text = value
+		case "since":
+			if _, err := time.ParseDuration(value); err != nil {
+				return query, fmt.Errorf("invalid since %q: %w", value, err)
+			}
+			query.Since = value
+		default:
+			return query, fmt.Errorf("unknown query field %q (expected severity, text or since)", key)
+		}
+	}
+	return query, nil
+}
+
+// executeQueryCommand handles ":query <name>" and ":query save <name> key=value..."
+func (m Model) executeQueryCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		return m.openLogQueryPicker()
+	}
+
+	if args[0] == "save" {
+		if len(args) < 2 {
+			m.statusMsg = "usage: :query save <name> [severity=ERROR] [text=...] [since=1h]"
+			return m, nil
+		}
+		query, err := parseLogQueryArgs(args[1], args[2:])
+		if err != nil {
+			m.statusMsg = fmt.Sprintf("Invalid query: %v", err)
+			return m, nil
+		}
+		m.cfg.File.SetLogQuery(query)
+		if err := m.cfg.Save(); err != nil {
+			m.statusMsg = fmt.Sprintf("Failed to save query: %v", err)
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Saved log query %q (%s)", query.Name, describeLogQuery(query))
+		return m, nil
+	}
+
+	query, ok := m.cfg.File.FindLogQuery(args[0])
+	if !ok {
+		m.statusMsg = fmt.Sprintf("No saved log query named %q", args[0])
+		return m, nil
+	}
+	return m.applyLogQuery(&query)
+}
+
+// openLogQueryPicker lists the saved log queries for selection
+func (m Model) openLogQueryPicker() (tea.Model, tea.Cmd) {
+	items := []pickerItem{{label: "(no filter)", detail: "all logs"}}
+	for _, q := range m.cfg.File.LogQueries {
+		items = append(items, pickerItem{label: q.Name, detail: describeLogQuery(q)})
+	}
+
+	m.openPicker("Saved log queries", items, func(m Model, idx int) (tea.Model, tea.Cmd) {
+		if idx == 0 {
+			return m.applyLogQuery(nil)
+		}
+		query := m.cfg.File.LogQueries[idx-1]
+		return m.applyLogQuery(&query)
+	})
+	return m, nil
+}
+
+// applyLogQuery activates a saved query and reloads the logs if LogsView is open
+func (m Model) applyLogQuery(query *config.LogQuery) (tea.Model, tea.Cmd) {
+	m.logs.query = query
+	if query == nil {
+		m.statusMsg = "Log query cleared"
+	} else {
+		m.statusMsg = fmt.Sprintf("Log query %q applied", query.Name)
+	}
+
+	if m.currentView == LogsView && m.selectedFunc != nil && !m.logs.streaming {
+		m.viewport.SetContent("Loading logs...")
+		return m, m.fetchFunctionLogs(m.selectedFunc.Name)
+	}
+	return m, nil
+}
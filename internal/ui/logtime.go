@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/config"
+)
+
+// defaultLogTimestampLayout is the layout of log timestamps unless the
+// config file sets logs.timestamp_format
+const defaultLogTimestampLayout = "2006-01-02 15:04:05"
+
+// logTimestampLayouts are the layouts of logs.timestamp_format, the epoch
+// format being formatted apart
+var logTimestampLayouts = map[string]string{
+	"":        defaultLogTimestampLayout,
+	"rfc3339": "2006-01-02T15:04:05.000Z07:00",
+	"short":   "Jan _2 15:04:05",
+	"epoch":   "",
+}
+
+// logTimestamps formats the timestamps of log entries in LogsView, streamed
+// or not, and in saved logs
+type logTimestamps struct {
+	layout string // Time layout, empty for Unix seconds
+	loc    *time.Location
+}
+
+// newLogTimestamps reads logs.timestamp_format and logs.timezone. A setting
+// that is not valid is reported and left at its default.
+func newLogTimestamps(s config.LogSettings) (logTimestamps, error) {
+	ts := logTimestamps{layout: defaultLogTimestampLayout, loc: time.Local}
+	var problems []string
+
+	if layout, ok := logTimestampLayouts[strings.ToLower(s.TimestampFormat)]; ok {
+		ts.layout = layout
+	} else {
+		problems = append(problems, fmt.Sprintf("unknown log timestamp format %q (rfc3339, epoch or short)", s.TimestampFormat))
+	}
+
+	switch strings.ToLower(s.Timezone) {
+	case "", "local":
+	case "utc":
+		ts.loc = time.UTC
+	default:
+		loc, err := time.LoadLocation(s.Timezone)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("unknown log timezone %q", s.Timezone))
+		} else {
+			ts.loc = loc
+		}
+	}
+	if len(problems) > 0 {
+		return ts, errors.New(strings.Join(problems, "; "))
+	}
+	return ts, nil
+}
+
+// format renders t in the configured timezone and format
+func (ts logTimestamps) format(t time.Time) string {
+	if ts.loc != nil {
+		t = t.In(ts.loc)
+	}
+	if ts.layout == "" {
+		return fmt.Sprintf("%d.%03d", t.Unix(), t.Nanosecond()/int(time.Millisecond))
+	}
+	return t.Format(ts.layout)
+}
@@ -0,0 +1,445 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"f6n/internal/config"
+	"f6n/internal/i18n"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxStreamEntries caps the real-time log buffer
+const maxStreamEntries = 1000
+
+// logsModel is the LogsView sub-model: the fetched logs, the real-time
+// stream and how both are filtered and shown
+type logsModel struct {
+	entries     []provider.LogEntry // Static logs currently displayed
+	err         error               // Error fetching the static logs
+	query       *config.LogQuery    // Saved query applied to LogsView
+	fieldFilter *fieldFilter        // JSON field filter applied to LogsView
+	// Highlighting rules applied to log lines
+	highlightRules   []highlightRule
+	highlightEnabled bool
+	expandRepeats    bool          // Show repeated log lines individually instead of "×N"
+	timestamps       logTimestamps // Format of the entry timestamps
+	// Log streaming fields
+	streaming     bool                // Whether we're currently streaming logs
+	streamCancel  context.CancelFunc  // Function to cancel log streaming
+	streamEntries []provider.LogEntry // Buffer for real-time logs, including stream notices
+	showingStream bool                // Whether LogsView shows the stream rather than static logs
+	streamErr     error               // Error from log streaming
+	streamGen     int                 // Generation of the current stream
+	// Several functions streamed together, their lines merged
+	tailing     []string // Functions streamed, set when there are more than one
+	tailWidth   int      // Width of the function name prefixed to their lines
+	streamsOpen int      // Streams of the current generation still running
+	// Paging back through older logs
+	olderBefore  time.Time // Time the pages are fetched back from, zero before the first
+	olderToken   string    // Token of the next older page
+	olderLoading bool
+	olderDone    bool // Whether there are no older logs
+	// Logs fetched last per function, shown while offline
+	cache     map[string]cachedLogs
+	staleAsOf time.Time // When the logs shown were fetched, zero unless from the cache
+}
+
+// cachedLogs are the logs of a function fetched last
+type cachedLogs struct {
+	entries []provider.LogEntry
+	fetched time.Time
+}
+
+// forwardLogStream publishes the entries of the log stream of function on the
+// bus until the stream fails or ctx is done. gen tells the messages of a
+// stream from those of one stopped earlier.
+func forwardLogStream(ctx context.Context, bus *Bus, gen int, function string, entries <-chan provider.LogEntry, errs <-chan error) {
+	for {
+		select {
+		case entry, ok := <-entries:
+			if !ok {
+				// Channel closed, streaming ended
+				bus.Publish(logStreamErrorMsg{gen: gen, function: function, err: fmt.Errorf("log stream ended")})
+				return
+			}
+			bus.Publish(newLogEntryMsg{gen: gen, function: function, entry: entry})
+		case err, ok := <-errs:
+			if !ok {
+				// No more errors; keep reading entries
+				errs = nil
+				continue
+			}
+			bus.Publish(logStreamErrorMsg{gen: gen, function: function, err: err})
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Update handles fetched logs, the messages of the log stream and the
+// LogsView keys
+func (l logsModel) Update(ctx viewContext, msg tea.Msg) (logsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case viewKeyMsg:
+		return l.handleKey(ctx, string(msg))
+
+	case functionLogsLoadedMsg:
+		l.err = msg.err
+		l.showingStream = false
+		l.staleAsOf = time.Time{}
+		if msg.err == nil {
+			l.entries = msg.logs
+			if l.cache == nil {
+				l.cache = make(map[string]cachedLogs)
+			}
+			l.cache[msg.function] = cachedLogs{entries: msg.logs, fetched: time.Now()}
+		} else if cached, ok := l.cache[msg.function]; ok && provider.IsNetworkError(msg.err) {
+			// Offline: the logs fetched last are shown under a banner
+			l.entries, l.err, l.staleAsOf = cached.entries, nil, cached.fetched
+		}
+		// Older pages continue from the new logs
+		l.olderBefore, l.olderToken, l.olderLoading, l.olderDone = time.Time{}, "", false, false
+
+	case logStreamStartedMsg:
+		l.stopStream()
+		l.streaming = true
+		l.showingStream = true
+		l.setTailing(msg.functions)
+		l.streamEntries = []provider.LogEntry{
+			noticeEntry(fmt.Sprintf("🔴 Streaming logs for %s (real-time) - Press 's' to stop", strings.Join(msg.functions, ", "))),
+		}
+		l.streamErr = nil
+
+		// Each stream is read until it is stopped or fails, its entries
+		// arriving through the bus
+		streamCtx, cancel := context.WithCancel(context.Background())
+		l.streamCancel = cancel
+		l.streamGen++
+		l.streamsOpen = len(msg.functions)
+		gen, prov, bus := l.streamGen, ctx.provider, ctx.bus
+		cmds := make([]tea.Cmd, 0, len(msg.functions))
+		for _, name := range msg.functions {
+			cmds = append(cmds, func() tea.Msg {
+				entries, errs := prov.StreamFunctionLogs(streamCtx, name)
+				forwardLogStream(streamCtx, bus, gen, name, entries, errs)
+				return nil
+			})
+		}
+		return l, tea.Batch(cmds...)
+
+	case newLogEntryMsg:
+		if !l.streaming || msg.gen != l.streamGen {
+			return l, nil
+		}
+		// Apply the active saved query to streamed entries as well
+		if l.providerLogQuery().Matches(msg.entry) {
+			l.appendStreamEntry(l.tagTailEntry(msg.function, msg.entry))
+		}
+
+	case logStreamErrorMsg:
+		if !l.streaming || msg.gen != l.streamGen {
+			return l, nil
+		}
+		l.streamsOpen--
+		if len(l.tailing) > 1 {
+			// The other functions keep streaming
+			l.appendStreamEntry(noticeEntry(fmt.Sprintf("❌ Stream error for %s: %v", msg.function, msg.err)))
+			if l.streamsOpen > 0 {
+				return l, nil
+			}
+			l.streamErr = msg.err
+			l.stopStream()
+			return l, nil
+		}
+		l.streamErr = msg.err
+		l.stopStream()
+		l.appendStreamEntry(noticeEntry(fmt.Sprintf("❌ Stream error: %v", msg.err)))
+	}
+	return l, nil
+}
+
+// View renders the buffer LogsView is showing
+func (l logsModel) View() string {
+	if l.err != nil && !l.showingStream {
+		return fmt.Sprintf("Error: %v", l.err)
+	}
+	lines := l.lines()
+	if !l.expandRepeats {
+		lines = collapseRepeats(lines)
+	}
+	return strings.Join(l.highlight(lines), "\n")
+}
+
+// openLogsView shows the recent logs of the selected function
+func (m Model) openLogsView() (tea.Model, tea.Cmd) {
+	m.currentView = LogsView
+	m.rememberRecent(LogsView.String())
+	m.viewport.SetContent("Loading logs...")
+	return m, m.fetchFunctionLogs(m.selectedFunc.Name)
+}
+
+// handleKey handles the LogsView keys
+func (l logsModel) handleKey(ctx viewContext, key string) (logsModel, tea.Cmd) {
+	switch key {
+	case "\\":
+		return l, route(Model.openLogFieldFilter)
+
+	case "f":
+		return l, route(Model.openLogQueryPicker)
+
+	case "h":
+		l.highlightEnabled = !l.highlightEnabled
+		status := "Highlighting off"
+		if l.highlightEnabled {
+			status = fmt.Sprintf("Highlighting on (severity colors and %d rules)", len(l.highlightRules))
+		}
+		return l, route(func(m Model) (tea.Model, tea.Cmd) {
+			m.statusMsg = status
+			m.refreshLogView()
+			return m, nil
+		})
+
+	case "x":
+		l.expandRepeats = !l.expandRepeats
+		return l, route(Model.showLogs)
+
+	case "E":
+		// Leaving LogsView stops any active stream
+		l.stopStream()
+		return l, route(Model.openErrorsView)
+
+	case "i":
+		return l, route(Model.openInvocations)
+	}
+
+	if ctx.fn == nil {
+		return l, nil
+	}
+	switch key {
+	case "l":
+		// 'l' refreshes static logs, stopping the stream if active
+		l.stopStream()
+		return l, route(Model.openLogsView)
+
+	case "o":
+		return l, route(Model.loadOlderLogs)
+
+	case "s":
+		if !l.streaming {
+			names := []string{ctx.fn.Name}
+			if len(l.tailing) > 1 && l.showingStream {
+				// Resume streaming the functions streamed together
+				names = l.tailing
+			}
+			return l, route(func(m Model) (tea.Model, tea.Cmd) {
+				if len(names) > 1 {
+					return m, m.startTail(names)
+				}
+				return m, m.startLogStreaming(names[0])
+			})
+		}
+		l.stopStream()
+		l.appendStreamEntry(noticeEntry("⏹️  Log streaming stopped"))
+		return l, route(Model.showLogs)
+	}
+	return l, nil
+}
+
+// openLogFieldFilter enters LogFilterMode to filter the logs by their JSON
+// fields, starting from the filter applied
+func (m Model) openLogFieldFilter() (tea.Model, tea.Cmd) {
+	m.inputMode = LogFilterMode
+	m.textInput.Placeholder = i18n.T("Field filter, e.g. level=error status>=500")
+	m.textInput.SetValue("")
+	if m.logs.fieldFilter != nil {
+		m.textInput.SetValue(m.logs.fieldFilter.String())
+		m.textInput.CursorEnd()
+	}
+	m.textInput.Focus()
+	return m, textinput.Blink
+}
+
+// showLogs re-renders LogsView after its sub-model changed
+func (m Model) showLogs() (tea.Model, tea.Cmd) {
+	m.refreshLogView()
+	return m, nil
+}
+
+// loadOlderLogs fetches the page of logs before the oldest one shown
+func (m Model) loadOlderLogs() (tea.Model, tea.Cmd) {
+	pager, ok := provider.As[provider.LogPager](m.provider)
+	switch {
+	case !ok:
+		m.statusMsg = fmt.Sprintf("Loading older logs is not supported for %s", strings.ToUpper(string(m.provider.GetProviderName())))
+		return m, nil
+	case m.logs.showingStream || m.logs.err != nil:
+		m.statusMsg = "Older logs extend the fetched logs; press l to show them"
+		return m, nil
+	case m.logs.olderLoading:
+		return m, nil
+	case m.logs.olderDone:
+		m.statusMsg = "No older logs"
+		return m, nil
+	}
+
+	if m.logs.olderBefore.IsZero() {
+		m.logs.olderBefore = time.Now()
+		for _, entry := range m.logs.entries {
+			if !entry.Timestamp.IsZero() && entry.Timestamp.Before(m.logs.olderBefore) {
+				m.logs.olderBefore = entry.Timestamp
+			}
+		}
+	}
+	m.logs.olderLoading = true
+	m.statusMsg = "Loading older logs..."
+
+	name, query, before, token := m.selectedFunc.Name, m.logs.providerLogQuery(), m.logs.olderBefore, m.logs.olderToken
+	return m, func() tea.Msg {
+		page, err := pager.GetOlderFunctionLogs(context.Background(), name, query, before, token)
+		if err != nil {
+			logger.Logger.Printf("Error fetching older logs of %s: %v", name, err)
+		}
+		return olderLogsLoadedMsg{function: name, before: before, page: page, err: err}
+	}
+}
+
+// handleOlderLogsLoaded puts a page of older logs above the fetched ones,
+// keeping the lines on screen in place
+func (m Model) handleOlderLogsLoaded(msg olderLogsLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.selectedFunc == nil || m.selectedFunc.Name != msg.function || !m.logs.olderBefore.Equal(msg.before) {
+		return m, nil
+	}
+	m.logs.olderLoading = false
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Failed to load older logs: %v", msg.err)
+		return m, nil
+	}
+
+	m.logs.olderToken = msg.page.Next
+	m.logs.olderDone = msg.page.Next == ""
+	if len(msg.page.Entries) == 0 {
+		m.statusMsg = "No older logs"
+		return m, nil
+	}
+	m.logs.entries = append(slices.Clone(msg.page.Entries), m.logs.entries...)
+	m.statusMsg = fmt.Sprintf("Loaded %d older log entries from %s", len(msg.page.Entries),
+		m.logs.timestamps.format(msg.page.Entries[0].Timestamp))
+
+	if m.currentView == LogsView && !m.logs.showingStream {
+		lines := m.viewport.TotalLineCount()
+		m.refreshLogView()
+		m.viewport.SetYOffset(m.viewport.YOffset + m.viewport.TotalLineCount() - lines)
+	}
+	return m, nil
+}
+
+// stopStream cancels the log stream, if any
+func (l *logsModel) stopStream() {
+	if l.streamCancel != nil {
+		l.streamCancel()
+		l.streamCancel = nil
+	}
+	l.streaming = false
+}
+
+// noticeEntry wraps an f6n status message (stream started/stopped/failed) so
+// it can sit in the log buffer alongside real entries
+func noticeEntry(message string) provider.LogEntry {
+	return provider.LogEntry{Message: message}
+}
+
+// isNotice reports whether entry is an f6n status message rather than a log entry
+func isNotice(entry provider.LogEntry) bool {
+	return entry.Timestamp.IsZero() && entry.Severity == ""
+}
+
+// appendStreamEntry adds an entry to the real-time buffer
+func (l *logsModel) appendStreamEntry(entry provider.LogEntry) {
+	l.streamEntries = append(l.streamEntries, entry)
+	if len(l.streamEntries) > maxStreamEntries {
+		l.streamEntries = l.streamEntries[1:]
+	}
+}
+
+// formatLogEntry renders a log entry as a single display line
+func formatLogEntry(entry provider.LogEntry, ts logTimestamps) string {
+	if isNotice(entry) {
+		return entry.Message
+	}
+	timestamp := ts.format(entry.Timestamp)
+	return fmt.Sprintf("[%s] %s: %s", timestamp, entry.Severity, entry.Message)
+}
+
+// lines returns the display lines for the buffer LogsView is showing
+func (l logsModel) lines() []string {
+	entries := l.entries
+	if l.showingStream {
+		entries = l.streamEntries
+	}
+
+	lines := make([]string, 0, len(entries)+2)
+	if l.query != nil && !l.showingStream {
+		lines = append(lines, fmt.Sprintf("Query: %s (%s)", l.query.Name, describeLogQuery(*l.query)), "")
+	}
+	if l.fieldFilter != nil {
+		lines = append(lines, fmt.Sprintf("Field filter: %s", l.fieldFilter), "")
+	}
+	for _, entry := range entries {
+		if l.fieldFilter != nil && !isNotice(entry) && !l.fieldFilter.Matches(entry) {
+			continue
+		}
+		lines = append(lines, l.tailPrefix(entry)+formatLogEntry(entry, l.timestamps))
+	}
+	return lines
+}
+
+// refreshLogView re-renders the viewport from the current log buffer
+func (m *Model) refreshLogView() {
+	m.viewport.SetContent(m.logs.View())
+}
+
+// logLineKey strips the leading "[timestamp] " from a formatted log line,
+// after the function name of merged streams, so that repeats of the same
+// message compare equal
+func logLineKey(line string) string {
+	prefix := ""
+	if name, rest, ok := strings.Cut(line, tailSeparator); ok && !strings.HasPrefix(line, "[") {
+		prefix, line = name+tailSeparator, rest
+	}
+	if strings.HasPrefix(line, "[") {
+		if idx := strings.Index(line, "] "); idx != -1 {
+			return prefix + line[idx+2:]
+		}
+	}
+	return prefix + line
+}
+
+// collapseRepeats folds runs of consecutive identical log messages into a
+// single "message ×N" line, keeping the first occurrence's timestamp
+func collapseRepeats(lines []string) []string {
+	out := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); {
+		key := logLineKey(lines[i])
+		j := i + 1
+		for j < len(lines) && logLineKey(lines[j]) == key {
+			j++
+		}
+
+		if count := j - i; count > 1 {
+			out = append(out, fmt.Sprintf("%s ×%d", lines[i], count))
+		} else {
+			out = append(out, lines[i])
+		}
+		i = j
+	}
+	return out
+}
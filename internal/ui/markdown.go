@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"f6n/internal/logger"
+
+	"github.com/charmbracelet/glamour"
+)
+
+// renderMarkdown renders Markdown for the terminal, wrapped at width. The
+// "notty" style renders it without colors for the accessibility mode.
+func renderMarkdown(source, style string, width int) (string, error) {
+	renderer, err := glamour.NewTermRenderer(glamour.WithStandardStyle(style), glamour.WithWordWrap(width))
+	if err != nil {
+		return "", fmt.Errorf("failed to create the Markdown renderer: %w", err)
+	}
+	return renderer.Render(source)
+}
+
+// markdownStyle returns the glamour style of the viewport
+func (m Model) markdownStyle() string {
+	if m.accessible() {
+		return "notty"
+	}
+	return "dark"
+}
+
+// markdownWidth returns the width Markdown is wrapped at in the viewport
+func (m Model) markdownWidth() int {
+	return max(m.viewport.Width-2, 40)
+}
+
+// markdown renders Markdown to fit the viewport, or returns it as is when it
+// can't be rendered
+func (m Model) markdown(source string) string {
+	rendered, err := renderMarkdown(source, m.markdownStyle(), m.markdownWidth())
+	if err != nil {
+		logger.Logger.Printf("Error rendering Markdown: %v", err)
+		return source
+	}
+	return strings.Trim(rendered, "\n")
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"fmt"
+
+	"f6n/internal/history"
+	"f6n/internal/insights"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// metricsModel is the MetricsView sub-model: the provider metrics and the
+// usage derived from invocation reports. A nil field means still loading.
+type metricsModel struct {
+	data           *provider.FunctionMetrics
+	err            error
+	reports        []insights.Report // REPORT lines of the function, for usage and cost
+	reportsErr     error
+	concurrency    *provider.ConcurrencyInfo
+	concurrencyErr error
+	enhanced       *provider.EnhancedMetrics // Lambda Insights, when the provider reports it
+	enhancedErr    error
+	// Samples kept in the local history, see history.go
+	history         []history.Sample
+	historyErr      error
+	historyLoaded   bool
+	historyFavorite bool
+}
+
+// Update stores the metrics loaded for the selected function and handles the
+// MetricsView keys
+func (mt metricsModel) Update(ctx viewContext, msg tea.Msg) (metricsModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case viewKeyMsg:
+		return mt, mt.handleKey(ctx, string(msg))
+	case functionMetricsLoadedMsg:
+		mt.data, mt.err = msg.metrics, msg.err
+	case concurrencyLoadedMsg:
+		if ctx.fn != nil && msg.functionName == ctx.fn.Name {
+			mt.concurrency, mt.concurrencyErr = msg.info, msg.err
+		}
+	case enhancedMetricsLoadedMsg:
+		if ctx.fn != nil && msg.functionName == ctx.fn.Name {
+			mt.enhanced, mt.enhancedErr = msg.metrics, msg.err
+		}
+	case metricsHistoryLoadedMsg:
+		if ctx.fn != nil && msg.functionName == ctx.fn.Name {
+			mt.history, mt.historyErr, mt.historyFavorite = msg.samples, msg.err, msg.favorite
+			mt.historyLoaded = true
+		}
+	case invocationReportsLoadedMsg:
+		if ctx.fn != nil && msg.functionName == ctx.fn.Name {
+			mt.reports, mt.reportsErr = msg.reports, msg.err
+			if mt.reports == nil && mt.reportsErr == nil {
+				mt.reports = []insights.Report{}
+			}
+		}
+	}
+	return mt, nil
+}
+
+// View renders the provider metrics followed by the usage sections derived
+// from invocation reports
+func (mt metricsModel) View(ctx viewContext) string {
+	var content string
+	if mt.err != nil {
+		content = fmt.Sprintf("Error loading metrics: %v", mt.err)
+	} else if mt.data == nil {
+		content = "Loading metrics..."
+	} else {
+		content = renderMetricsContent(mt.data, ctx.width)
+	}
+
+	if ctx.fn != nil {
+		if risk, ok := ctx.timeoutRisk(*ctx.fn); ok {
+			content += "\n\n" + renderTimeoutRisk(risk, ctx.units)
+		}
+	}
+	if _, ok := provider.As[provider.ConcurrencyReporter](ctx.provider); ok {
+		content += "\n\n" + mt.renderConcurrency(ctx.width)
+	}
+	if _, ok := provider.As[provider.EnhancedMetricsReporter](ctx.provider); ok {
+		content += "\n\n" + mt.renderEnhancedMetrics(ctx.width)
+	}
+	if history := mt.renderHistory(ctx.width, ctx.units); history != "" {
+		content += "\n\n" + history
+	}
+	content += "\n\n" + mt.renderUsage(ctx.fn, ctx.width, ctx.units)
+	return content
+}
+
+// openMetricsView shows the metrics of the selected function, loading afresh
+func (m Model) openMetricsView() (tea.Model, tea.Cmd) {
+	logger.Logger.Printf("Switching to MetricsView for function: %s", m.selectedFunc.Name)
+	m.currentView = MetricsView
+	m.rememberRecent(MetricsView.String())
+	m.metrics = metricsModel{}
+	m.refreshMetricsView()
+	return m, tea.Batch(m.fetchMetricsViewData(m.selectedFunc.Name), m.fetchPermissions(*m.selectedFunc))
+}
+
+// refreshMetricsView re-renders the viewport from the metrics sub-model
+func (m *Model) refreshMetricsView() {
+	m.viewport.SetContent(m.metrics.View(m.viewContext()))
+}
+
+// handleKey handles the MetricsView keys
+func (mt metricsModel) handleKey(ctx viewContext, key string) tea.Cmd {
+	if ctx.fn == nil {
+		return nil
+	}
+	switch key {
+	case "a":
+		return route(Model.openAlarmForm)
+	case "m":
+		return route(Model.reloadMetricsView)
+	}
+	return nil
+}
+
+// reloadMetricsView fetches the metrics of the selected function again
+func (m Model) reloadMetricsView() (tea.Model, tea.Cmd) {
+	logger.Logger.Printf("Refreshing metrics for function: %s", m.selectedFunc.Name)
+	m.viewport.SetContent("Refreshing metrics...")
+	return m, m.fetchMetricsViewData(m.selectedFunc.Name)
+}
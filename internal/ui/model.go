@@ -5,12 +5,19 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	"f6n/internal/audit"
 	"f6n/internal/charts"
+	"f6n/internal/config"
+	"f6n/internal/i18n"
 	"f6n/internal/logger"
+	"f6n/internal/notify"
 	"f6n/internal/provider"
+	"f6n/internal/telemetry"
+	"f6n/internal/usage"
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textarea"
@@ -18,6 +25,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // InputMode represents the current input mode
@@ -27,35 +35,75 @@ const (
 	NormalMode InputMode = iota
 	FilterMode
 	CommandMode
+	PickerMode
+	LogFilterMode
+	FormMode
 )
 
-// Model represents the application state
+// Model represents the application state. It routes messages and key
+// presses to the sub-models of the main views (see router.go) and holds the
+// state they share: the selected function, the viewport and the input modes.
 type Model struct {
-	table           table.Model
-	viewport        viewport.Model
-	textInput       textinput.Model
-	textarea        textarea.Model
-	functions       []provider.FunctionInfo
-	allFunctions    []provider.FunctionInfo // Unfiltered list
-	provider        provider.Provider
-	accountID       string
-	currentView     ViewType
-	selectedFunc    *provider.FunctionInfo
-	environment     string
-	inputMode       InputMode
-	editMode        bool   // Whether CodeView is in edit mode
-	originalContent string // Store original content for cancel
-	filterActive    bool   // Whether a filter is currently applied
-	activeFilter    string // The current filter text
-	width           int
-	height          int
-	loading         bool
-	err             error
-	// Log streaming fields
-	streamingLogs bool               // Whether we're currently streaming logs
-	streamCancel  context.CancelFunc // Function to cancel log streaming
-	realTimeLogs  []string           // Buffer for real-time logs
-	logStreamErr  error              // Error from log streaming
+	viewport     viewport.Model
+	textInput    textinput.Model
+	textarea     textarea.Model
+	provider     provider.Provider
+	cfg          *config.Config
+	accountID    string
+	currentView  ViewType
+	selectedFunc *provider.FunctionInfo
+	environment  string
+	inputMode    InputMode
+	zoomed       bool // Whether the viewport fills the terminal
+	pendingG     bool // Whether g was pressed, waiting for gg
+	width        int
+	height       int
+	err          error
+	statusMsg    string  // One-line feedback shown above the help text
+	picker       *picker // Active picker when inputMode is PickerMode
+	form         *form   // Active form when inputMode is FormMode
+	envOriginal  string  // Environment the env editor opened with
+	envRevision  string  // Revision of the function the env edit started from
+	// Logo setting toggled at runtime, overriding ui.logo when set
+	logoSetting string
+	// Carries the updates of long-running activities, see bus.go
+	bus *Bus
+	// Sub-models of the main views
+	list    listModel
+	detail  detailModel
+	logs    logsModel
+	metrics metricsModel
+	code    codeModel
+	// Invocation stats per function name over timeoutLookback, used to flag timeout risks
+	functionStats map[string]provider.FunctionStats
+	ranking       ranking             // RankingView state
+	firingAlarms  map[string][]string // Alarms in ALARM state by function, nil until loaded
+	bulkDownload  *bulkDownload       // Progress of :download-all, nil until started
+	deps          dependencies        // DepsView state
+	security      security            // SecurityView state
+	compare       comparison          // CompareView state and the function marked for it
+	lastRequest   *httpRequest        // HTTP request sent last, see curl.go
+	deploys       deployHistory       // DeploysView state
+	rightSizing   rightSizing         // RightSizingView state
+	report        reportPreview       // ReportView state
+	powerTune     powerTuning         // PowerTuneView state
+	invoke        invocation          // InvokeView state
+	recent        []recentFunction    // Recently viewed functions, most recent first
+	// Actions the credentials may not perform, with the missing permissions,
+	// by function ARN; set once probed, nil when the probe failed
+	deniedActions map[string]map[provider.Action][]string
+	// Watch mode and the webhook it notifies, nil when not configured
+	watch    watchState
+	refresh  autoRefresh
+	notifier *notify.Webhook
+	// Sampling of the favorite functions' metrics, see history.go
+	history historyState
+	// Where changes made to functions are recorded, see audit.go
+	audit *audit.Log
+	// Set while the provider cannot be reached, see offline.go
+	offline offlineState
+	// Counts feature usage when telemetry is opted in to, see telemetry.go
+	usage *usage.Reporter
 }
 
 type functionsLoadedMsg struct {
@@ -84,20 +132,38 @@ type functionCodeLoadedMsg struct {
 }
 
 type functionLogsLoadedMsg struct {
-	logs []string
-	err  error
+	function string
+	logs     []provider.LogEntry
+	err      error
+}
+
+// olderLogsLoadedMsg is a page of logs older than those in LogsView,
+// fetched back from before
+type olderLogsLoadedMsg struct {
+	function string
+	before   time.Time
+	page     *provider.LogPage
+	err      error
 }
 
+// logStreamStartedMsg starts streaming the logs of one function, or those of
+// several merged into one view
 type logStreamStartedMsg struct {
-	functionName string
+	functions []string
 }
 
+// newLogEntryMsg and logStreamErrorMsg are published on the bus with the
+// generation of the stream they come from and the function streamed
 type newLogEntryMsg struct {
-	entry provider.LogEntry
+	gen      int
+	function string
+	entry    provider.LogEntry
 }
 
 type logStreamErrorMsg struct {
-	err error
+	gen      int
+	function string
+	err      error
 }
 
 type functionMetricsLoadedMsg struct {
@@ -115,17 +181,17 @@ type downloadingMsg struct {
 }
 
 type codeFilesLoadedMsg struct {
-	content string
-	err     error
-}
-
-type loadingCodeFilesMsg struct {
-	functionName string
+	content  string
+	sections []codeSection
+	offset   int // Line to scroll to
+	err      error
 }
 
-type editSavedMsg struct {
-	success bool
-	err     error
+type codeSavedMsg struct {
+	function string
+	file     string // the handler file edited
+	source   string
+	err      error
 }
 
 func (m Model) fetchFunctions() tea.Cmd {
@@ -154,42 +220,18 @@ func (m Model) fetchFunctionCode(name string) tea.Cmd {
 
 func (m Model) fetchFunctionLogs(name string) tea.Cmd {
 	return func() tea.Msg {
-		logs, err := m.provider.GetFunctionLogs(context.Background(), name, 200)
+		logs, err := m.provider.GetFunctionLogs(context.Background(), name, m.logs.providerLogQuery())
 		if err != nil {
 			println("Error fetching function logs:", err.Error())
-			return functionLogsLoadedMsg{err: err}
+			return functionLogsLoadedMsg{function: name, err: err}
 		}
-		return functionLogsLoadedMsg{logs: logs}
+		return functionLogsLoadedMsg{function: name, logs: logs}
 	}
 }
 
 func (m Model) startLogStreaming(name string) tea.Cmd {
 	return func() tea.Msg {
-		return logStreamStartedMsg{functionName: name}
-	}
-}
-
-func (m Model) streamLogs(ctx context.Context, name string) tea.Cmd {
-	return func() tea.Msg {
-		logChan, errChan := m.provider.StreamFunctionLogs(ctx, name)
-
-		// Listen for logs or errors
-		select {
-		case entry, ok := <-logChan:
-			if !ok {
-				// Channel closed, streaming ended
-				return logStreamErrorMsg{err: fmt.Errorf("log stream ended")}
-			}
-			return newLogEntryMsg{entry: entry}
-		case err, ok := <-errChan:
-			if !ok {
-				// Error channel closed
-				return logStreamErrorMsg{err: fmt.Errorf("error stream ended")}
-			}
-			return logStreamErrorMsg{err: err}
-		case <-ctx.Done():
-			return logStreamErrorMsg{err: ctx.Err()}
-		}
+		return logStreamStartedMsg{functions: []string{name}}
 	}
 }
 
@@ -204,6 +246,7 @@ func (m Model) fetchFunctionMetrics(name string) tea.Cmd {
 			logger.Logger.Printf("Error fetching metrics for %s: %v", name, err)
 			return functionMetricsLoadedMsg{err: err}
 		}
+		telemetry.RecordFunctionMetrics(context.Background(), m.provider.GetProviderName(), metrics)
 		return functionMetricsLoadedMsg{metrics: metrics}
 	}
 }
@@ -211,110 +254,43 @@ func (m Model) fetchFunctionMetrics(name string) tea.Cmd {
 func (m Model) downloadFunctionCode(name string) tea.Cmd {
 	logger.Logger.Printf("Starting download for function: %s", name)
 	return func() tea.Msg {
-		// Create downloads base directory if it doesn't exist
-		baseDir := "downloads"
-		if err := os.MkdirAll(baseDir, 0755); err != nil {
-			logger.Logger.Printf("Error creating downloads directory: %v", err)
-			return functionCodeDownloadedMsg{err: fmt.Errorf("failed to create downloads directory: %w", err)}
-		}
-
-		// Create function-specific download directory
-		downloadPath := filepath.Join(baseDir, name)
-
-		// Check if directory already exists and warn user
-		if _, err := os.Stat(downloadPath); err == nil {
-			logger.Logger.Printf("Download directory already exists, will overwrite: %s", downloadPath)
-		}
-
-		err := m.provider.DownloadFunctionCode(context.Background(), name, downloadPath)
+		absPath, err := downloadCode(context.Background(), m.provider, name)
 		if err != nil {
-			logger.Logger.Printf("Error downloading function code: %v", err)
-			return functionCodeDownloadedMsg{err: fmt.Errorf("download failed: %w", err)}
+			return functionCodeDownloadedMsg{err: err}
 		}
-
-		// Get absolute path for display
-		absPath, _ := filepath.Abs(downloadPath)
-
-		logger.Logger.Printf("Function code downloaded successfully to: %s", absPath)
 		return functionCodeDownloadedMsg{path: absPath}
 	}
 }
 
-func (m Model) loadCodeFiles(functionName string) tea.Cmd {
-	logger.Logger.Printf("Loading code files for function: %s", functionName)
-	return func() tea.Msg {
-		downloadPath := filepath.Join("downloads", functionName)
-
-		// Check if download directory exists
-		if _, err := os.Stat(downloadPath); os.IsNotExist(err) {
-			return codeFilesLoadedMsg{err: fmt.Errorf("code not downloaded yet. Press 'd' first to download the code")}
-		}
-
-		content, err := m.readCodeFiles(downloadPath)
-		if err != nil {
-			logger.Logger.Printf("Error reading code files: %v", err)
-			return codeFilesLoadedMsg{err: fmt.Errorf("failed to read code files: %w", err)}
-		}
-
-		logger.Logger.Printf("Code files loaded successfully")
-		return codeFilesLoadedMsg{content: content}
+// downloadCode downloads a function's code into downloads/<name> and returns
+// the absolute path of the download
+func downloadCode(ctx context.Context, prov provider.Provider, name string) (string, error) {
+	// Create downloads base directory if it doesn't exist
+	baseDir := "downloads"
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		logger.Logger.Printf("Error creating downloads directory: %v", err)
+		return "", fmt.Errorf("failed to create downloads directory: %w", err)
 	}
-}
-
-func (m Model) readCodeFiles(dirPath string) (string, error) {
-	var content strings.Builder
-	content.WriteString(fmt.Sprintf("📁 Code Files for %s\n", filepath.Base(dirPath)))
-	content.WriteString("═══════════════════════════════════════\n\n")
 
-	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Skip directories and non-readable files
-		if info.IsDir() {
-			return nil
-		}
-
-		// Only show common code file extensions
-		ext := strings.ToLower(filepath.Ext(path))
-		if !isCodeFile(ext) {
-			return nil
-		}
-
-		relPath, _ := filepath.Rel(dirPath, path)
-		content.WriteString(fmt.Sprintf("📄 %s\n", relPath))
-		content.WriteString("─────────────────────────────────────\n")
-
-		// Read file content (limit size for display)
-		fileContent, err := os.ReadFile(path)
-		if err != nil {
-			content.WriteString(fmt.Sprintf("Error reading file: %v\n\n", err))
-			return nil
-		}
-
-		// Limit file size for display (100KB max)
-		if len(fileContent) > 100*1024 {
-			content.WriteString(fmt.Sprintf("File too large (%d bytes). Showing first 100KB...\n\n", len(fileContent)))
-			fileContent = fileContent[:100*1024]
-		}
+	// Create function-specific download directory
+	downloadPath := filepath.Join(baseDir, name)
 
-		content.WriteString(string(fileContent))
-		content.WriteString("\n\n")
-
-		return nil
-	})
+	// Check if directory already exists and warn user
+	if _, err := os.Stat(downloadPath); err == nil {
+		logger.Logger.Printf("Download directory already exists, will overwrite: %s", downloadPath)
+	}
 
+	err := prov.DownloadFunctionCode(ctx, name, downloadPath)
 	if err != nil {
-		return "", err
+		logger.Logger.Printf("Error downloading function code: %v", err)
+		return "", fmt.Errorf("download failed: %w", err)
 	}
 
-	if content.Len() == 0 {
-		content.WriteString("No code files found in the downloaded directory.\n")
-		content.WriteString("The download may contain only configuration files or archives.")
-	}
+	// Get absolute path for display
+	absPath, _ := filepath.Abs(downloadPath)
 
-	return content.String(), nil
+	logger.Logger.Printf("Function code downloaded successfully to: %s", absPath)
+	return absPath, nil
 }
 
 func isCodeFile(ext string) bool {
@@ -360,33 +336,12 @@ func isCodeFile(ext string) bool {
 	return codeExtensions[ext]
 }
 
-// NewModel creates a new TUI model
-func NewModel(prov provider.Provider, environment string) Model {
-	columns := []table.Column{
-		{Title: "Function Name", Width: 40},
-		{Title: "Runtime", Width: 15},
-		{Title: "Memory", Width: 10},
-		{Title: "Timeout", Width: 10},
-		{Title: "Last Modified", Width: 20},
-	}
-
-	t := table.New(
-		table.WithColumns(columns),
-		table.WithFocused(true),
-		table.WithHeight(20),
-	)
-
-	s := table.DefaultStyles()
-	s.Header = s.Header.
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("#07646bff")).
-		BorderBottom(true).
-		Bold(true)
-	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(lipgloss.Color("#07646bff")).
-		Bold(true)
-	t.SetStyles(s)
+// NewModel creates a new TUI model. Log streams, the watcher and bulk
+// downloads deliver their updates through bus.
+func NewModel(prov provider.Provider, cfg *config.Config, bus *Bus) Model {
+	// Translations apply to the whole program. An unknown language given by
+	// the locale alone silently falls back to English.
+	langErr := i18n.SetLanguage(i18n.Detect(cfg.Language))
 
 	vp := viewport.New(80, 20)
 	vp.Style = lipgloss.NewStyle().
@@ -396,7 +351,7 @@ func NewModel(prov provider.Provider, environment string) Model {
 
 	// Initialize text input for filter/command mode
 	ti := textinput.New()
-	ti.Placeholder = "Filter functions..."
+	ti.Placeholder = i18n.T("Filter functions...")
 	ti.CharLimit = 100
 	ti.Width = 50
 
@@ -407,18 +362,68 @@ func NewModel(prov provider.Provider, environment string) Model {
 	ta.SetWidth(80)
 	ta.SetHeight(20)
 
-	return Model{
-		table:       t,
+	if cfg.Accessible {
+		// Colors and text attributes are dropped for the whole program
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+
+	var notifier *notify.Webhook
+	var statusMsg string
+	if langErr != nil && cfg.Language != "" {
+		logger.Logger.Printf("Translations disabled: %v", langErr)
+		statusMsg = langErr.Error()
+	}
+	if cfg.NotifyWebhook != "" {
+		var err error
+		if notifier, err = notify.NewWebhook(cfg.NotifyWebhook); err != nil {
+			logger.Logger.Printf("Notifications disabled: %v", err)
+			statusMsg = fmt.Sprintf("Notifications disabled: %v", err)
+		}
+	}
+
+	timestamps, err := newLogTimestamps(cfg.File.Logs)
+	if err != nil {
+		logger.Logger.Printf("Log timestamp settings: %v", err)
+		statusMsg = err.Error()
+	}
+
+	m := Model{
+		list:        newListModel(compileCustomColumns(cfg.File.Columns), !cfg.File.Compliance.IsEmpty()),
 		viewport:    vp,
 		textInput:   ti,
 		textarea:    ta,
 		provider:    prov,
+		cfg:         cfg,
 		currentView: ListView,
-		environment: environment,
+
+		logs: logsModel{
+			highlightRules:   compileHighlightRules(cfg.File.HighlightRules),
+			highlightEnabled: true,
+			timestamps:       timestamps,
+		},
+
+		environment: cfg.Environment,
 		inputMode:   NormalMode,
-		editMode:    false,
-		loading:     true,
+		statusMsg:   statusMsg,
+
+		bus:      bus,
+		notifier: notifier,
+		audit:    audit.New(audit.DefaultPath()),
+		usage:    usage.New(cfg.File.Telemetry, string(prov.GetProviderName())),
 	}
+	m.setWatch(cfg.WatchInterval, false)
+	intervals, err := parseRefreshSettings(cfg.File.Refresh)
+	if err != nil {
+		logger.Logger.Printf("Refresh settings: %v", err)
+		m.statusMsg = "⚠ " + err.Error()
+	}
+	m.refresh.intervals = intervals
+	m.recent = m.readRecent()
+	if err := m.setupHistory(); err != nil {
+		logger.Logger.Printf("Metrics history: %v", err)
+		m.statusMsg = "⚠ " + err.Error()
+	}
+	return m
 }
 
 // Init initializes the model
@@ -429,6 +434,7 @@ func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.fetchFunctions(),
 		m.fetchAccountID(),
+		m.scheduleRefreshes(),
 		tea.EnterAltScreen,
 	)
 }
@@ -450,85 +456,163 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case functionsLoadedMsg:
 		return m.handleFunctionsLoaded(msg)
 
-	case functionLogsLoadedMsg:
-		if msg.err != nil {
-			m.viewport.SetContent(fmt.Sprintf("Error: %v", msg.err))
-		} else {
-			m.viewport.SetContent(strings.Join(msg.logs, "\n"))
+	case referencesLoadedMsg:
+		return m.handleReferencesLoaded(msg)
+
+	case envUpdatedMsg:
+		return m.handleEnvUpdated(msg)
+
+	case descriptionUpdatedMsg:
+		return m.handleDescriptionUpdated(msg)
+
+	case loggingUpdatedMsg:
+		return m.handleLoggingUpdated(msg)
+
+	case logRetentionSetMsg:
+		return m.handleLogRetentionSet(msg)
+
+	case functionDescribedMsg:
+		return m.handleFunctionDescribed(msg)
+
+	case historySampledMsg:
+		return m.handleHistorySampled(msg)
+
+	case curlTargetMsg:
+		return m.handleCurlTarget(msg)
+
+	case httpResponseMsg:
+		return m.handleHTTPResponse(msg)
+
+	case invokeResultMsg:
+		return m.handleInvokeResult(msg)
+
+	case deploymentsLoadedMsg:
+		return m.handleDeploymentsLoaded(msg)
+
+	case refreshTickMsg:
+		return m.handleRefreshTick(msg)
+
+	case reportBuiltMsg:
+		return m.handleReportBuilt(msg)
+
+	case rightSizingLoadedMsg:
+		return m.handleRightSizingLoaded(msg)
+
+	case powerTuneMeasuredMsg:
+		return m.handlePowerTuneMeasured(msg)
+
+	case powerTuneRestoredMsg:
+		return m.handlePowerTuneRestored(msg)
+
+	case revisionCheckedMsg:
+		return m.handleRevisionChecked(msg)
+
+	case projectsLoadedMsg:
+		return m.openProjectPicker(msg)
+
+	case olderLogsLoadedMsg:
+		return m.handleOlderLogsLoaded(msg)
+
+	case invocationsLoadedMsg:
+		return m.openInvocationPicker(msg)
+
+	case functionLogsLoadedMsg, logStreamStartedMsg, newLogEntryMsg, logStreamErrorMsg:
+		var cmd tea.Cmd
+		m.logs, cmd = m.logs.Update(m.viewContext(), msg)
+		if loaded, ok := msg.(functionLogsLoadedMsg); ok {
+			m.usage.Error("get-logs", loaded.err)
+			if provider.IsNetworkError(loaded.err) {
+				cmd = tea.Batch(cmd, m.goOffline(loaded.err, m.logs.staleAsOf))
+			} else if loaded.err == nil {
+				cmd = tea.Batch(cmd, m.backOnline())
+			}
 		}
-		return m, nil
+		if m.currentView == LogsView {
+			m.refreshLogView()
+		}
+		return m, cmd
 
-	case functionMetricsLoadedMsg:
-		if msg.err != nil {
-			m.viewport.SetContent(fmt.Sprintf("Error loading metrics: %v", msg.err))
-		} else {
-			// Import charts and render metrics overview
-			content := renderMetricsContent(msg.metrics, m.width)
-			m.viewport.SetContent(content)
+	case functionMetricsLoadedMsg, concurrencyLoadedMsg, enhancedMetricsLoadedMsg, invocationReportsLoadedMsg, metricsHistoryLoadedMsg:
+		if loaded, ok := msg.(functionMetricsLoadedMsg); ok {
+			m.usage.Error("get-metrics", loaded.err)
 		}
-		return m, nil
+		var cmd tea.Cmd
+		m.metrics, cmd = m.metrics.Update(m.viewContext(), msg)
+		if _, ok := msg.(invocationReportsLoadedMsg); ok {
+			// REPORT lines may reveal a timeout risk the list doesn't show yet
+			m.updateTable()
+		}
+		if m.currentView == MetricsView {
+			m.refreshMetricsView()
+		}
+		return m, cmd
 
-	case logStreamStartedMsg:
-		// Start streaming logs for the function
-		m.streamingLogs = true
-		m.realTimeLogs = []string{fmt.Sprintf("🔴 Streaming logs for %s (real-time) - Press 's' to stop", msg.functionName)}
-		m.logStreamErr = nil
-
-		// Create a cancellable context for the stream
-		ctx, cancel := context.WithCancel(context.Background())
-		m.streamCancel = cancel
-
-		// Set initial content and start streaming
-		m.viewport.SetContent(strings.Join(m.realTimeLogs, "\n"))
-		return m, m.streamLogs(ctx, msg.functionName)
-
-	case newLogEntryMsg:
-		if m.streamingLogs {
-			// Format the log entry
-			timestamp := msg.entry.Timestamp.Format("2006-01-02 15:04:05")
-			logLine := fmt.Sprintf("[%s] %s: %s", timestamp, msg.entry.Severity, msg.entry.Message)
-
-			// Add to real-time logs buffer (keep last 1000 entries)
-			m.realTimeLogs = append(m.realTimeLogs, logLine)
-			if len(m.realTimeLogs) > 1000 {
-				m.realTimeLogs = m.realTimeLogs[1:]
-			}
+	case triggersLoadedMsg, schedulesLoadedMsg, asyncConfigLoadedMsg, logRetentionLoadedMsg, trafficShiftsLoadedMsg:
+		var cmd tea.Cmd
+		m.detail, cmd = m.detail.Update(m.viewContext(), msg)
+		if m.currentView == DetailView {
+			m.refreshDetailView()
+		}
+		return m, cmd
+
+	case codeFilesChunkMsg:
+		// The files read so far are shown as they arrive, keeping the scroll position
+		var cmd tea.Cmd
+		m.code, cmd = m.code.Update(m.viewContext(), msg)
+		if m.currentView == CodeDisplayView {
+			m.viewport.SetContent(m.code.View())
+		}
+		return m, cmd
+
+	case functionCodeLoadedMsg, codeFilesLoadedMsg:
+		var cmd tea.Cmd
+		m.code, cmd = m.code.Update(m.viewContext(), msg)
+		if m.currentView == CodeView || m.currentView == CodeDisplayView {
+			m.viewport.SetContent(m.code.View())
+			m.viewport.SetYOffset(m.code.offset)
+		}
+		return m, cmd
 
-			// Update viewport content
-			m.viewport.SetContent(strings.Join(m.realTimeLogs, "\n"))
+	case watchPolledMsg:
+		return m.handleWatchPolled(msg)
 
-			// Continue streaming
-			ctx, cancel := context.WithCancel(context.Background())
-			if m.streamCancel != nil {
-				m.streamCancel()
-			}
-			m.streamCancel = cancel
+	case offlineRetryMsg:
+		return m.handleOfflineRetry()
 
-			return m, m.streamLogs(ctx, m.selectedFunc.Name)
+	case notificationsSentMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Notification failed after %d sent: %v", msg.sent, msg.err)
 		}
 		return m, nil
 
-	case logStreamErrorMsg:
-		if m.streamingLogs {
-			m.logStreamErr = msg.err
-			m.streamingLogs = false
-			if m.streamCancel != nil {
-				m.streamCancel()
-				m.streamCancel = nil
+	case firingAlarmsLoadedMsg:
+		if msg.err == nil {
+			m.firingAlarms = msg.alarms
+			if m.firingAlarms == nil {
+				m.firingAlarms = map[string][]string{}
 			}
+			m.updateTable()
+		}
+		return m, nil
 
-			// Add error message to logs
-			errorLine := fmt.Sprintf("❌ Stream error: %v", msg.err)
-			m.realTimeLogs = append(m.realTimeLogs, errorLine)
-			m.viewport.SetContent(strings.Join(m.realTimeLogs, "\n"))
+	case rankingLoadedMsg:
+		if msg.window != m.ranking.window {
+			return m, nil
+		}
+		m.ranking.loading = false
+		m.ranking.stats, m.ranking.err = msg.stats, msg.err
+		if m.currentView == RankingView {
+			m.refreshRankingView()
 		}
 		return m, nil
 
-	case functionCodeLoadedMsg:
-		if msg.err != nil {
-			m.viewport.SetContent(fmt.Sprintf("Error: %v", msg.err))
-		} else {
-			m.viewport.SetContent(msg.code)
+	case functionStatsLoadedMsg:
+		if msg.err == nil {
+			m.functionStats = msg.stats
+			m.updateTable()
+			if m.currentView == MetricsView {
+				m.refreshMetricsView()
+			}
 		}
 		return m, nil
 
@@ -552,69 +636,123 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case loadingCodeFilesMsg:
-		m.viewport.SetContent(fmt.Sprintf("Loading code files for %s...\n\nReading downloaded files...", msg.functionName))
+	case grepResultsMsg:
+		return m.openGrepResults(msg)
+
+	case errorGroupsLoadedMsg:
+		if msg.err != nil {
+			m.viewport.SetContent(fmt.Sprintf("Error loading errors: %v", msg.err))
+		} else if m.selectedFunc != nil {
+			m.viewport.SetContent(renderErrorGroups(m.selectedFunc.Name, msg.groups, msg.entries))
+		}
+		return m, nil
+
+	case bulkDownloadProgressMsg, bulkDownloadDoneMsg:
+		return m.handleBulkDownloadMsg(msg)
+
+	case depsScannedMsg, vulnsCheckedMsg:
+		return m.handleDepsMsg(msg)
+
+	case codeSecretsScannedMsg:
+		return m.handleCodeSecretsScanned(msg)
+
+	case permissionsCheckedMsg:
+		return m.handlePermissionsChecked(msg)
+
+	case auditLoadedMsg:
+		return m.handleAuditLoaded(msg)
+
+	case postureAuditedMsg:
+		return m.handlePostureAudited(msg)
+
+	case editorFinishedMsg:
+		return m.handleEditorFinished(msg)
+
+	case browserOpenedMsg:
+		return m.handleBrowserOpened(msg)
+
+	case exportRenderedMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Export failed: %v", msg.err)
+			return m, nil
+		}
+		return m.showExport(msg.fn, msg.kind, msg.command, msg.content, msg.args, msg.withEnv)
+
+	case failedEventsLoadedMsg:
+		return m.openFailedEventPicker(msg)
+
+	case failedEventReplayedMsg:
+		m.recordAction("replay-event", msg.functionName, fmt.Sprintf("%s from %s", msg.event.ID, msg.event.Source), msg.err)
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Replay of %s failed, message kept: %v", msg.event.ID, msg.err)
+		} else {
+			m.statusMsg = fmt.Sprintf("✅ Replayed %s and deleted it from the queue", msg.event.ID)
+		}
 		return m, nil
 
-	case codeFilesLoadedMsg:
+	case alarmCreatedMsg:
+		m.recordAction("create-alarm", msg.functionName, msg.name, msg.err)
 		if msg.err != nil {
-			m.viewport.SetContent(fmt.Sprintf("Error loading code files: %v\n\nPress 'esc' to go back.", msg.err))
+			m.statusMsg = fmt.Sprintf("❌ Failed to create alarm %s: %v", msg.name, msg.err)
 		} else {
-			m.viewport.SetContent(msg.content)
+			m.statusMsg = fmt.Sprintf("✅ Alarm %s created", msg.name)
 		}
 		return m, nil
 
-	case editSavedMsg:
-		if msg.success {
-			// Show a temporary save confirmation
-			currentContent := m.viewport.View()
-			saveConfirmation := "✅ Changes saved!\n\n" + currentContent
-			m.viewport.SetContent(saveConfirmation)
-		} else if msg.err != nil {
-			errorMsg := fmt.Sprintf("❌ Save failed: %v\n\nPress 'esc' to go back.", msg.err)
-			m.viewport.SetContent(errorMsg)
+	case logsSavedMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Failed to save logs: %v", msg.err)
+		} else {
+			m.statusMsg = fmt.Sprintf("✅ Saved %d log entries to %s", msg.count, msg.path)
 		}
 		return m, nil
 
+	case codeSavedMsg:
+		return m.handleCodeSaved(msg)
+
+	case routeMsg:
+		model, cmd := msg(m)
+		m.countViewChange(model)
+		return model, cmd
+
 	case tea.KeyMsg:
-		return m.handleKeyPress(msg)
+		model, cmd := m.handleKeyPress(msg)
+		m.countViewChange(model)
+		return model, cmd
 	}
 
 	var cmd tea.Cmd
+	if m.currentView == InvokeView && m.invoke.picker != nil {
+		return m.updatePayloadPicker(msg)
+	}
 	if m.currentView == ListView {
-		m.table, cmd = m.table.Update(msg)
+		m.list, cmd = m.list.Update(m.viewContext(), msg)
 	} else {
 		m.viewport, cmd = m.viewport.Update(msg)
 	}
 	return m, cmd
 }
 
+// countViewChange counts the view model shows in the usage report when it is
+// not the one m shows
+func (m Model) countViewChange(model tea.Model) {
+	if next, ok := model.(Model); ok && next.currentView != m.currentView {
+		m.usage.Feature("view " + next.currentView.String())
+	}
+}
+
 // handleWindowSize handles window resize events
 func (m Model) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	m.width = msg.Width
 	m.height = msg.Height
 
-	// Calculate available height for table (subtract top padding, header, info rows, shortcuts, help)
-	// Top padding: 5, ASCII art: 6, Info: 3, Shortcuts: 3, Help: 2, Extra spacing: 3 = 22 total
-	availableHeight := msg.Height - 22
-	if availableHeight < 5 {
-		availableHeight = 5
-	}
-
-	m.table.SetHeight(availableHeight)
+	m.layoutTable()
 
 	// Update table column widths to span entire width
 	totalWidth := msg.Width - 4
-	m.table.SetColumns([]table.Column{
-		{Title: "Function Name", Width: int(float64(totalWidth) * 0.35)},
-		{Title: "Runtime", Width: int(float64(totalWidth) * 0.15)},
-		{Title: "Memory", Width: int(float64(totalWidth) * 0.12)},
-		{Title: "Timeout", Width: int(float64(totalWidth) * 0.12)},
-		{Title: "Last Modified", Width: int(float64(totalWidth) * 0.26)},
-	})
+	m.list.table.SetColumns(listColumns(totalWidth, m.complianceEnabled(), m.list.columns))
 
-	m.viewport.Width = msg.Width - 4
-	m.viewport.Height = msg.Height - 8
+	m.layoutViewport()
 
 	// Update textarea size for edit mode
 	m.textarea.SetWidth(msg.Width - 4)
@@ -625,288 +763,185 @@ func (m Model) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 
 // handleFunctionsLoaded handles the functions loaded message
 func (m Model) handleFunctionsLoaded(msg functionsLoadedMsg) (tea.Model, tea.Cmd) {
-	m.loading = false
+	m.list, _ = m.list.Update(m.viewContext(), msg)
+	m.usage.Error("list-functions", msg.err)
 	if msg.err != nil {
+		if provider.IsNetworkError(msg.err) {
+			return m.showCachedFunctions(msg.err)
+		}
 		m.err = msg.err
 		return m, nil
 	}
-
-	// Store both filtered and unfiltered lists
-	m.allFunctions = msg.functions
-	m.functions = msg.functions
+	m.writeInventory(msg.functions)
+	online := m.backOnline()
+	// Keep the selected function current, e.g. its revision after an update
+	if m.selectedFunc != nil {
+		for i := range m.list.allFunctions {
+			if fn := &m.list.allFunctions[i]; fn.Name == m.selectedFunc.Name && fn.Profile == m.selectedFunc.Profile {
+				m.selectedFunc = fn
+			}
+		}
+	}
 	m.updateTable()
-	return m, nil
+	return m, tea.Batch(m.fetchFunctionStats(msg.functions), m.fetchFiringAlarms(), online)
 }
 
 // updateTable updates the table with current functions list
 func (m *Model) updateTable() {
 	rows := []table.Row{}
-	for _, fn := range m.functions {
-		rows = append(rows, table.Row{
-			fn.Name,
+	units := m.units()
+	for _, fn := range m.list.functions {
+		timeout := units.seconds(fn.Timeout)
+		if risk, ok := m.timeoutRisk(fn); ok && risk.AtRisk() {
+			timeout += " ⚠️"
+		}
+		name := fn.Name
+		if slices.Contains(m.list.tailMarks, fn.Name) {
+			name = "▶ " + name
+		}
+		row := table.Row{
+			name,
 			fn.Runtime,
-			fmt.Sprintf("%d MB", fn.Memory),
-			fmt.Sprintf("%d s", fn.Timeout),
-			fn.LastModified,
-		})
+			units.memory(float64(fn.Memory)),
+			timeout,
+			formatStateCell(fn),
+			formatHealthCell(m.functionHealth(fn)),
+			formatOwnerCell(fn),
+			formatModifiedCell(fn),
+		}
+		if m.complianceEnabled() {
+			row = append(row, formatComplianceCell(m.violations(fn)))
+		}
+		row = append(row, customColumnCells(m.list.columns, fn)...)
+		rows = append(rows, row)
 	}
-	m.table.SetRows(rows)
+	m.list.table.SetRows(rows)
 }
 
 // filterFunctions filters functions based on the current filter text
 func (m *Model) filterFunctions() {
-	filterText := strings.ToLower(strings.TrimSpace(m.textInput.Value()))
-	if filterText == "" {
-		m.functions = m.allFunctions
-	} else {
-		m.functions = []provider.FunctionInfo{}
-		for _, fn := range m.allFunctions {
-			// Filter by function name, runtime, or description
-			if strings.Contains(strings.ToLower(fn.Name), filterText) ||
-				strings.Contains(strings.ToLower(fn.Runtime), filterText) ||
-				strings.Contains(strings.ToLower(fn.Description), filterText) {
-				m.functions = append(m.functions, fn)
-			}
-		}
-	}
+	m.applyFunctionFilter(m.textInput.Value())
+}
+
+// applyFunctionFilter shows the functions matching filterText
+func (m *Model) applyFunctionFilter(filterText string) {
+	m.list.filter(filterText)
 	m.updateTable()
 }
 
 // handleKeyPress handles keyboard input
 func (m Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	logger.Logger.Printf("Key pressed: %s", msg.String())
+	m.statusMsg = ""
+
 	// Handle input modes
-	if m.inputMode == FilterMode || m.inputMode == CommandMode {
+	if m.inputMode == PickerMode && m.picker != nil {
+		return m.handlePickerKey(msg)
+	}
+	if m.inputMode == FormMode && m.form != nil {
+		return m.handleFormKey(msg)
+	}
+	if m.inputMode == FilterMode || m.inputMode == CommandMode || m.inputMode == LogFilterMode {
 		return m.handleInputMode(msg)
 	}
 
-	// Normal mode key handling
-	switch msg.String() {
+	if m.currentView == EnvEditView {
+		return m.handleEnvEditKey(msg)
+	}
+	if m.currentView == InvokeView && m.invoke.result == nil {
+		return m.handleInvokeEditKey(msg)
+	}
+	if m.currentView == CodeView && m.code.editMode {
+		return m.handleEditKey(msg)
+	}
+	if m.scrollable() {
+		if model, cmd, handled := m.handleJumpKey(msg.String()); handled {
+			return model, cmd
+		}
+		m.pendingG = false
+	}
+	key := msg.String()
+	if model, cmd, handled := m.handleViewKey(key); handled {
+		return model, cmd
+	}
+
+	// Keys available in every view
+	switch key {
 	case "ctrl+c":
 		return m, tea.Quit
 
 	case "q":
-		if m.currentView == ListView {
-			return m, tea.Quit
+		if m.currentView == ListView && m.powerTune.running() {
+			// Quitting now would leave the function at a tuning memory size
+			m.statusMsg = fmt.Sprintf("Power tuning of %s is running: wait for it to set the memory back, or stop it with s in :powertune", m.powerTune.function)
+			return m, nil
 		}
-		return m, nil
-
-	case "\\":
-		// Enter filter mode
 		if m.currentView == ListView {
-			m.inputMode = FilterMode
-			m.textInput.Placeholder = "Filter functions..."
-			m.textInput.SetValue("")
-			m.textInput.Focus()
-			return m, textinput.Blink
+			return m, tea.Quit
 		}
 		return m, nil
 
 	case ":":
 		// Enter command mode
 		m.inputMode = CommandMode
-		m.textInput.Placeholder = "Enter command (:q to quit)..."
+		m.textInput.Placeholder = i18n.T("Enter command (:q to quit)...")
 		m.textInput.SetValue(":")
 		m.textInput.Focus()
 		m.textInput.CursorEnd()
 		return m, textinput.Blink
 
-	case "enter":
-		if m.currentView == ListView && len(m.functions) > 0 {
-			selectedIdx := m.table.Cursor()
-			if selectedIdx < len(m.functions) {
-				m.selectedFunc = &m.functions[selectedIdx]
-				m.currentView = DetailView
-				m.viewport.SetContent(formatFunctionDetails(m.selectedFunc))
-			}
-		}
-		return m, nil
-
 	case "esc":
-		// Clean up streaming when leaving LogsView
-		if m.currentView == LogsView && m.streamingLogs {
-			if m.streamCancel != nil {
-				m.streamCancel()
-				m.streamCancel = nil
-			}
-			m.streamingLogs = false
-		}
+		return m.goBack()
 
-		if m.currentView == CodeDisplayView {
-			// Go back to CodeView from CodeDisplayView
-			m.currentView = CodeView
-		} else if m.currentView != ListView {
-			m.currentView = ListView
-		} else if m.filterActive {
-			// Clear active filter when in list view
-			m.filterActive = false
-			m.activeFilter = ""
-			m.functions = m.allFunctions
-			m.updateTable()
-		}
+	case "L":
+		m.toggleLogo()
 		return m, nil
 
-	case "l":
-		if m.currentView == ListView && len(m.functions) > 0 {
-			selectedIdx := m.table.Cursor()
-			if selectedIdx < len(m.functions) {
-				m.selectedFunc = &m.functions[selectedIdx]
-				m.currentView = LogsView
-				m.viewport.SetContent("Loading logs...")
-				return m, m.fetchFunctionLogs(m.selectedFunc.Name)
-			}
-		} else if m.currentView == LogsView && m.selectedFunc != nil {
-			// In LogsView, 'l' refreshes static logs (stops streaming if active)
-			if m.streamingLogs && m.streamCancel != nil {
-				m.streamCancel()
-				m.streamingLogs = false
-				m.streamCancel = nil
-			}
-			m.viewport.SetContent("Loading logs...")
-			return m, m.fetchFunctionLogs(m.selectedFunc.Name)
-		}
-		return m, nil
-
-	case "s":
-		if m.currentView == LogsView && m.selectedFunc != nil {
-			if m.streamingLogs {
-				// Stop streaming
-				if m.streamCancel != nil {
-					m.streamCancel()
-					m.streamCancel = nil
-				}
-				m.streamingLogs = false
-
-				// Add stopped message to logs
-				stoppedLine := "⏹️  Log streaming stopped"
-				m.realTimeLogs = append(m.realTimeLogs, stoppedLine)
-				m.viewport.SetContent(strings.Join(m.realTimeLogs, "\n"))
-			} else {
-				// Start streaming
-				return m, m.startLogStreaming(m.selectedFunc.Name)
-			}
-		}
-		return m, nil
-
-	case "c":
-		if m.currentView == ListView && len(m.functions) > 0 {
-			selectedIdx := m.table.Cursor()
-			if selectedIdx < len(m.functions) {
-				m.selectedFunc = &m.functions[selectedIdx]
-				m.currentView = CodeView
-				m.viewport.SetContent("Loading code...")
-				return m, m.fetchFunctionCode(m.selectedFunc.Name)
-			}
-		}
-		return m, nil
-
-	case "m":
-		logger.Logger.Printf("'m' key pressed for metrics in view: %s", m.currentView.String())
-		if m.currentView == ListView && len(m.functions) > 0 {
-			selectedIdx := m.table.Cursor()
-			logger.Logger.Printf("Selected function index: %d, total functions: %d", selectedIdx, len(m.functions))
-			if selectedIdx < len(m.functions) {
-				m.selectedFunc = &m.functions[selectedIdx]
-				m.currentView = MetricsView
-				logger.Logger.Printf("Switching to MetricsView for function: %s", m.selectedFunc.Name)
-				m.viewport.SetContent("Loading metrics...")
-				return m, m.fetchFunctionMetrics(m.selectedFunc.Name)
-			}
-		} else if m.currentView == MetricsView && m.selectedFunc != nil {
-			// Refresh metrics when in MetricsView
-			logger.Logger.Printf("Refreshing metrics for function: %s", m.selectedFunc.Name)
-			m.viewport.SetContent("Refreshing metrics...")
-			return m, m.fetchFunctionMetrics(m.selectedFunc.Name)
-		}
-		return m, nil
-
-	case "w":
-		logger.Logger.Printf("'w' key pressed for download in view: %s", m.currentView.String())
-		if m.currentView == ListView && len(m.functions) > 0 {
-			selectedIdx := m.table.Cursor()
-			logger.Logger.Printf("Selected function index: %d, total functions: %d", selectedIdx, len(m.functions))
-			if selectedIdx < len(m.functions) {
-				selectedFunc := &m.functions[selectedIdx]
-				logger.Logger.Printf("Starting download for function: %s", selectedFunc.Name)
-				m.viewport.SetContent(fmt.Sprintf("Downloading code for %s...", selectedFunc.Name))
-				return m, tea.Batch(
-					func() tea.Msg { return downloadingMsg{functionName: selectedFunc.Name} },
-					m.downloadFunctionCode(selectedFunc.Name),
-				)
-			} else {
-				logger.Logger.Printf("Invalid function index: %d", selectedIdx)
-			}
-		} else {
-			logger.Logger.Printf("Download not available - currentView: %s, functions count: %d", m.currentView.String(), len(m.functions))
-		}
-		return m, nil
-
-	case "v":
-		if m.currentView == CodeView && m.selectedFunc != nil {
-			m.currentView = CodeDisplayView
-			m.viewport.SetContent(fmt.Sprintf("Loading code files for %s...", m.selectedFunc.Name))
-			return m, tea.Batch(
-				func() tea.Msg { return loadingCodeFilesMsg{functionName: m.selectedFunc.Name} },
-				m.loadCodeFiles(m.selectedFunc.Name),
-			)
-		}
-		return m, nil
-
-	case "e":
-		if m.currentView == CodeView && m.selectedFunc != nil {
-			if !m.editMode {
-				// Enter edit mode
-				m.editMode = true
-				m.originalContent = m.viewport.View()
-				m.textarea.SetValue(m.viewport.View())
-				m.textarea.SetWidth(m.width - 4)
-				m.textarea.SetHeight(m.height - 10)
-				m.textarea.Focus()
-			} else {
-				// Exit edit mode without saving
-				m.editMode = false
-				m.viewport.SetContent(m.originalContent)
-				m.textarea.Blur()
-			}
-		}
-		return m, nil
-
-	case "ctrl+s":
-		if m.currentView == CodeView && m.editMode && m.selectedFunc != nil {
-			// Save the edited content
-			editedContent := m.textarea.Value()
-			m.viewport.SetContent(editedContent)
-			m.editMode = false
-			m.textarea.Blur()
-
-			// TODO: Here you could add actual save functionality to persist changes
-			// For now, we just update the display
-			return m, func() tea.Msg {
-				return editSavedMsg{success: true}
-			}
-		}
-		return m, nil
-
-	case "r":
-		if m.currentView == ListView {
-			m.loading = true
-			return m, m.fetchFunctions()
-		}
+	case "z":
+		m.toggleZoom()
 		return m, nil
 	}
 
 	var cmd tea.Cmd
-	if m.currentView == ListView {
-		m.table, cmd = m.table.Update(msg)
-	} else if m.currentView == CodeView && m.editMode {
-		// Update textarea when in edit mode
-		m.textarea, cmd = m.textarea.Update(msg)
-	} else {
+	switch {
+	case boundKeys[key]:
+		// Bound in another view, ignored here
+	case m.currentView == ListView:
+		m.list, cmd = m.list.Update(m.viewContext(), msg)
+	default:
 		m.viewport, cmd = m.viewport.Update(msg)
 	}
 	return m, cmd
 }
 
+// goBack leaves the current view for the one it was opened from, or clears
+// the filter of the list
+func (m Model) goBack() (tea.Model, tea.Cmd) {
+	// Leaving LogsView stops the stream
+	if m.currentView == LogsView {
+		m.logs.stopStream()
+	}
+	if m.zoomed {
+		m.zoomed = false
+		m.layoutViewport()
+	}
+
+	if m.currentView == CodeDisplayView {
+		// Go back to CodeView from CodeDisplayView, no longer reading the files
+		m.code.stopLoad()
+		m.currentView = CodeView
+	} else if m.currentView != ListView {
+		m.currentView = ListView
+	} else if m.list.filterActive {
+		// Clear active filter when in list view
+		m.list.filterActive = false
+		m.list.activeFilter = ""
+		m.list.functions = m.list.allFunctions
+		m.updateTable()
+	}
+	return m, nil
+}
+
 // handleInputMode handles keys when in filter or command mode
 func (m Model) handleInputMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
@@ -919,9 +954,9 @@ func (m Model) handleInputMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.textInput.Blur()
 		if currentMode == FilterMode {
 			// Reset filter when escaping from filter mode
-			m.filterActive = false
-			m.activeFilter = ""
-			m.functions = m.allFunctions
+			m.list.filterActive = false
+			m.list.activeFilter = ""
+			m.list.functions = m.list.allFunctions
 			m.updateTable()
 		}
 		return m, nil
@@ -931,15 +966,29 @@ func (m Model) handleInputMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Apply filter and exit filter mode
 			filterText := strings.TrimSpace(m.textInput.Value())
 			if filterText != "" {
-				m.filterActive = true
-				m.activeFilter = filterText
+				m.list.filterActive = true
+				m.list.activeFilter = filterText
 			} else {
-				m.filterActive = false
-				m.activeFilter = ""
+				m.list.filterActive = false
+				m.list.activeFilter = ""
 			}
 			m.inputMode = NormalMode
 			m.textInput.Blur()
 			return m, nil
+		} else if m.inputMode == LogFilterMode {
+			expr := strings.TrimSpace(m.textInput.Value())
+			m.inputMode = NormalMode
+			m.textInput.Blur()
+			if expr == "" {
+				m.logs.fieldFilter = nil
+			} else if filter, err := parseFieldFilter(expr); err != nil {
+				m.statusMsg = fmt.Sprintf("Invalid field filter: %v", err)
+				return m, nil
+			} else {
+				m.logs.fieldFilter = filter
+			}
+			m.refreshLogView()
+			return m, nil
 		} else if m.inputMode == CommandMode {
 			// Execute command
 			command := strings.TrimSpace(m.textInput.Value())
@@ -965,13 +1014,100 @@ func (m Model) handleInputMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // executeCommand executes a vim-like command
 func (m Model) executeCommand(command string) (tea.Model, tea.Cmd) {
-	switch command {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return m, nil
+	}
+
+	// Only the names of known commands are counted, never their arguments
+	known := true
+	defer func() {
+		if known {
+			m.usage.Feature("command " + fields[0])
+		}
+	}()
+
+	switch fields[0] {
 	case ":q", ":quit":
 		return m, tea.Quit
 	case ":r", ":refresh":
-		m.loading = true
+		m.list.loading = true
 		return m, m.fetchFunctions()
+	case ":query":
+		return m.executeQueryCommand(fields[1:])
+	case ":watch":
+		return m.executeWatchCommand(fields[1:])
+	case ":autorefresh":
+		return m.executeAutoRefreshCommand(fields[1:])
+	case ":terraform":
+		return m.executeTerraformCommand(fields[1:])
+	case ":export":
+		return m.executeExportCommand(fields[1:])
+	case ":download-all":
+		return m.executeDownloadAllCommand()
+	case ":edit":
+		if m.currentView == ListView {
+			m.selectedFunc = m.currentFunction()
+		}
+		return m.openInEditor(strings.Join(fields[1:], " "))
+	case ":compliance":
+		return m.openComplianceView()
+	case ":deps":
+		return m.executeDepsCommand()
+	case ":audit":
+		return m.openAuditView()
+	case ":telemetry":
+		return m.executeTelemetryCommand(fields[1:])
+	case ":invocations":
+		return m.openInvocations()
+	case ":tail":
+		return m.executeTailCommand(fields[1:])
+	case ":loglevel":
+		return m.executeLogLevelCommand(fields[1:])
+	case ":recent":
+		return m.openRecentPicker()
+	case ":open":
+		return m.executeOpenCommand(fields[1:])
+	case ":filter":
+		return m.executeFilterCommand(fields[1:])
+	case ":favorite":
+		return m.executeFavoriteCommand()
+	case ":curl":
+		return m.executeCurlCommand(fields[1:])
+	case ":deploys":
+		return m.executeDeploysCommand(fields[1:])
+	case ":help":
+		return m.openHelpView()
+	case ":report":
+		return m.executeReportCommand(fields[1:])
+	case ":rightsize":
+		return m.openRightSizing()
+	case ":powertune":
+		return m.executePowerTuneCommand(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(command), ":powertune")))
+	case ":compare":
+		return m.executeCompareCommand(fields[1:])
+	case ":grep":
+		return m.executeGrepCommand(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(command), ":grep")))
+	case ":save":
+		return m.executeSaveCommand(fields[1:])
+	case ":file":
+		if m.currentView == ListView {
+			m.selectedFunc = m.currentFunction()
+		}
+		return m.openCodeFile(strings.Join(fields[1:], " "), 0)
+	case ":goto":
+		if len(fields) < 2 {
+			return m.executeGotoCommand("")
+		}
+		return m.executeGotoCommand(fields[1])
+	case ":project":
+		return m.executeProjectCommand(fields[1:])
 	default:
+		known = false
+		// ":42" jumps to line 42
+		if line := strings.TrimPrefix(fields[0], ":"); line != "" && strings.Trim(line, "0123456789") == "" {
+			return m.executeGotoCommand(line)
+		}
 		// Unknown command, just ignore
 		return m, nil
 	}
@@ -979,7 +1115,7 @@ func (m Model) executeCommand(command string) (tea.Model, tea.Cmd) {
 
 // View renders the UI
 func (m Model) View() string {
-	return renderView(m)
+	return m.plainText(renderView(m))
 }
 
 // renderMetricsContent renders the metrics overview using charts
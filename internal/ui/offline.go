@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"f6n/internal/config"
+	"f6n/internal/i18n"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// offlineRetryInterval is how often the function list is fetched again while
+// the provider cannot be reached
+const offlineRetryInterval = 30 * time.Second
+
+// offlineState is set while network calls fail: the views keep showing the
+// data fetched last, and the function list is retried in the background
+type offlineState struct {
+	active    bool
+	staleAsOf time.Time // When the data shown was fetched, zero when nothing is cached
+	err       error     // The last network error
+	retrying  bool      // Whether a retry is scheduled
+}
+
+type offlineRetryMsg struct{}
+
+// goOffline shows the offline banner with data as of staleAsOf and schedules
+// a retry, unless one is already scheduled
+func (m *Model) goOffline(err error, staleAsOf time.Time) tea.Cmd {
+	if !m.offline.active {
+		logger.Logger.Printf("Provider unreachable, showing cached data: %v", err)
+	}
+	m.offline.active = true
+	m.offline.err = err
+	if !staleAsOf.IsZero() {
+		m.offline.staleAsOf = staleAsOf
+	}
+	if m.offline.retrying {
+		return nil
+	}
+	m.offline.retrying = true
+	return tea.Tick(offlineRetryInterval, func(time.Time) tea.Msg { return offlineRetryMsg{} })
+}
+
+// handleOfflineRetry fetches the function list again while offline
+func (m Model) handleOfflineRetry() (tea.Model, tea.Cmd) {
+	m.offline.retrying = false
+	if !m.offline.active {
+		return m, nil
+	}
+	return m, m.fetchFunctions()
+}
+
+// backOnline clears the offline banner once a call succeeds again, and
+// fetches the logs shown from the cache anew
+func (m *Model) backOnline() tea.Cmd {
+	if !m.offline.active {
+		return nil
+	}
+	logger.Logger.Printf("Provider reachable again")
+	m.offline = offlineState{retrying: m.offline.retrying}
+	m.statusMsg = "✅ Back online"
+	if m.currentView == LogsView && m.selectedFunc != nil && !m.logs.showingStream && !m.logs.staleAsOf.IsZero() {
+		return m.fetchFunctionLogs(m.selectedFunc.Name)
+	}
+	return nil
+}
+
+// renderOfflineBanner renders the banner shown above the views while offline
+func (m Model) renderOfflineBanner() string {
+	text := i18n.T("Offline: no cached data")
+	if !m.offline.staleAsOf.IsZero() {
+		text = i18n.Tf("Offline: stale as of %s", m.offline.staleAsOf.Local().Format("15:04"))
+	}
+	text = "⚠ " + text + " - " + i18n.Tf("retrying every %s", offlineRetryInterval)
+	return styles.ErrorStyle.Render(text) + "\n" + styles.HelpStyle.Render(fmt.Sprintf("%v", m.offline.err)) + "\n"
+}
+
+// showCachedFunctions keeps showing the function list when fetching it fails
+// with a network error, falling back to the list kept from the last run
+func (m Model) showCachedFunctions(err error) (tea.Model, tea.Cmd) {
+	staleAsOf := m.list.fetched
+	if len(m.list.allFunctions) == 0 {
+		if inv := m.readInventory(); inv != nil {
+			m.list.allFunctions, m.list.functions = inv.Functions, inv.Functions
+			m.list.fetched, staleAsOf = inv.Fetched, inv.Fetched
+			m.updateTable()
+		}
+	}
+	return m, m.goOffline(err, staleAsOf)
+}
+
+// inventory is the function list kept in the state directory, shown when the
+// provider cannot be reached at startup
+type inventory struct {
+	Fetched   time.Time               `json:"fetched"`
+	Functions []provider.FunctionInfo `json:"functions"`
+}
+
+// stateName names the files kept of the current provider, profile or
+// project and region. It is empty in demo and replay mode, whose data is not
+// kept.
+func (m Model) stateName() string {
+	if m.cfg == nil || m.cfg.Demo || m.cfg.ReplayPath != "" {
+		return ""
+	}
+	scope := m.cfg.Profile
+	switch m.provider.GetProviderName() {
+	case provider.GCP:
+		scope = m.cfg.GCPProject
+	case provider.OpenFaaS:
+		scope = m.cfg.OpenFaaSGateway
+	case provider.Knative:
+		scope = m.cfg.KubeContext
+	case provider.AWS:
+		if endpoint, err := url.Parse(m.cfg.EndpointURL); err == nil && endpoint.Host != "" {
+			// Functions of LocalStack are kept apart from those of AWS
+			scope += "@" + endpoint.Host
+		}
+	}
+	return url.PathEscape(fmt.Sprintf("%s-%s-%s", m.provider.GetProviderName(), scope, m.provider.GetRegion()))
+}
+
+// inventoryPath returns where the function list is kept, empty when it is not
+func (m Model) inventoryPath() string {
+	name := m.stateName()
+	if name == "" {
+		return ""
+	}
+	return filepath.Join(config.StateDir(), "inventory", name+".json")
+}
+
+// writeInventory keeps the function list for offline use
+func (m Model) writeInventory(functions []provider.FunctionInfo) {
+	path := m.inventoryPath()
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(inventory{Fetched: time.Now(), Functions: functions})
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0700)
+	}
+	if err == nil {
+		err = os.WriteFile(path, data, 0600)
+	}
+	if err != nil {
+		logger.Logger.Printf("Error caching function list: %v", err)
+	}
+}
+
+// readInventory returns the function list kept last, nil when there is none
+func (m Model) readInventory() *inventory {
+	path := m.inventoryPath()
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Logger.Printf("Error reading cached function list: %v", err)
+		}
+		return nil
+	}
+	var inv inventory
+	if err := json.Unmarshal(data, &inv); err != nil {
+		logger.Logger.Printf("Error parsing cached function list: %v", err)
+		return nil
+	}
+	return &inv
+}
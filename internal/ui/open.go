@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type functionDescribedMsg struct {
+	name string
+	fn   *provider.FunctionInfo
+	err  error
+}
+
+// executeOpenCommand handles ":open <function>", showing the details of a
+// function by name. Functions missing from the list, e.g. deployed since it
+// was fetched or hidden by the filter, are looked up with the provider.
+func (m Model) executeOpenCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) != 1 {
+		m.statusMsg = "Usage: :open <function name or ARN>"
+		return m, nil
+	}
+	name := args[0]
+	if fn, ok := m.findFunction(name); ok {
+		m.selectedFunc = &fn
+		return m.openDetailView()
+	}
+
+	m.statusMsg = fmt.Sprintf("Looking up %s...", name)
+	prov := m.provider
+	return m, func() tea.Msg {
+		fn, err := prov.GetFunction(context.Background(), name)
+		if err != nil {
+			logger.Logger.Printf("Error describing function %s: %v", name, err)
+		}
+		return functionDescribedMsg{name: name, fn: fn, err: err}
+	}
+}
+
+// handleFunctionDescribed opens the details of a function looked up by name
+func (m Model) handleFunctionDescribed(msg functionDescribedMsg) (tea.Model, tea.Cmd) {
+	m.usage.Error("get-function", msg.err)
+	switch {
+	case msg.err != nil:
+		m.statusMsg = fmt.Sprintf("❌ Failed to look up %s: %v", msg.name, msg.err)
+		return m, nil
+	case msg.fn == nil:
+		m.statusMsg = fmt.Sprintf("❌ Function %s not found", msg.name)
+		return m, nil
+	}
+	fn := *msg.fn
+	m.selectedFunc = &fn
+	m.statusMsg = fmt.Sprintf("%s is not in the loaded list; press r in the list to fetch it again", fn.Name)
+	return m.openDetailView()
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// actionLabels describe the actions whose permissions are probed, for the
+// status line
+var actionLabels = map[provider.Action]string{
+	provider.ActionUpdateEnvironment: "edit environment variables",
+	provider.ActionDownloadCode:      "download code",
+	provider.ActionReplayEvents:      "replay failed events",
+	provider.ActionCreateAlarm:       "create alarms",
+	provider.ActionUpdateLogging:     "change log levels",
+	provider.ActionSetLogRetention:   "change log retention",
+	provider.ActionPowerTune:         "power-tune",
+	provider.ActionUpdateDescription: "edit the description",
+	provider.ActionUpdateCode:        "edit code",
+}
+
+// shortcutActions are the shortcuts of each view that start a probed action,
+// greyed out when the credentials lack its permissions
+var shortcutActions = map[ViewType]map[string]provider.Action{
+	ListView: {
+		"<F>": provider.ActionReplayEvents,
+		"<w>": provider.ActionDownloadCode,
+	},
+	DetailView: {
+		"<F>": provider.ActionReplayEvents,
+		"<e>": provider.ActionUpdateEnvironment,
+		"<d>": provider.ActionUpdateDescription,
+		"<D>": provider.ActionUpdateLogging,
+		"<R>": provider.ActionSetLogRetention,
+	},
+	MetricsView: {
+		"<a>": provider.ActionCreateAlarm,
+	},
+	CodeView: {
+		"<e>": provider.ActionUpdateCode,
+	},
+}
+
+type permissionsCheckedMsg struct {
+	arn    string
+	denied map[provider.Action][]string
+	err    error
+}
+
+// fetchPermissions probes the actions the credentials may perform on fn,
+// once per function. It returns nil when the provider cannot tell.
+func (m Model) fetchPermissions(fn provider.FunctionInfo) tea.Cmd {
+	checker, ok := provider.As[provider.PermissionChecker](m.provider)
+	if !ok {
+		return nil
+	}
+	if _, probed := m.deniedActions[fn.ARN]; probed {
+		return nil
+	}
+	return func() tea.Msg {
+		denied, err := checker.CheckPermissions(context.Background(), fn)
+		if err != nil {
+			logger.Logger.Printf("Error checking permissions on %s: %v", fn.Name, err)
+		}
+		return permissionsCheckedMsg{arn: fn.ARN, denied: denied, err: err}
+	}
+}
+
+// handlePermissionsChecked stores the actions denied on a function. When the
+// probe fails, every action stays offered.
+func (m Model) handlePermissionsChecked(msg permissionsCheckedMsg) (tea.Model, tea.Cmd) {
+	if m.deniedActions == nil {
+		m.deniedActions = make(map[string]map[provider.Action][]string)
+	}
+	if msg.err != nil {
+		m.deniedActions[msg.arn] = nil
+		return m, nil
+	}
+	m.deniedActions[msg.arn] = msg.denied
+	return m, nil
+}
+
+// missingPermissions returns the permissions the credentials lack for an
+// action on fn, nil when it is allowed or not probed
+func (m Model) missingPermissions(fn *provider.FunctionInfo, action provider.Action) []string {
+	if fn == nil {
+		return nil
+	}
+	return m.deniedActions[fn.ARN][action]
+}
+
+// permitted reports whether an action on fn may be started, naming the
+// missing permissions on the status line when it may not
+func (m *Model) permitted(fn *provider.FunctionInfo, action provider.Action) bool {
+	missing := m.missingPermissions(fn, action)
+	if len(missing) == 0 {
+		return true
+	}
+	m.statusMsg = fmt.Sprintf("🔒 Your credentials cannot %s on %s: missing %s",
+		actionLabels[action], fn.Name, strings.Join(missing, ", "))
+	return false
+}
+
+// shortcutDenied reports whether a shortcut of the current view starts an
+// action the credentials lack the permissions for
+func (m Model) shortcutDenied(key string) bool {
+	action, ok := shortcutActions[m.currentView][key]
+	return ok && len(m.missingPermissions(m.currentFunction(), action)) > 0
+}
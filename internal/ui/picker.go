@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"f6n/internal/i18n"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pickerItem is a single selectable entry in a picker
+type pickerItem struct {
+	label  string
+	detail string
+	group  string // Heading shown above the first item of each group
+}
+
+// picker is a small selectable list shown in place of the main content
+type picker struct {
+	title    string
+	items    []pickerItem
+	cursor   int
+	offset   int // First visible item
+	height   int // Number of visible items, 0 shows all
+	onSelect func(m Model, idx int) (tea.Model, tea.Cmd)
+}
+
+// openPicker switches the model into picker mode
+func (m *Model) openPicker(title string, items []pickerItem, onSelect func(m Model, idx int) (tea.Model, tea.Cmd)) {
+	m.picker = &picker{
+		title:    title,
+		items:    items,
+		height:   max(m.viewport.Height-4, 5),
+		onSelect: onSelect,
+	}
+	m.inputMode = PickerMode
+}
+
+// handlePickerKey handles navigation and selection while a picker is open
+func (m Model) handlePickerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.picker
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q":
+		m.picker = nil
+		m.inputMode = NormalMode
+		return m, nil
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(p.items)-1 {
+			p.cursor++
+		}
+	case "pgup":
+		p.cursor = max(p.cursor-p.height, 0)
+	case "pgdown":
+		p.cursor = max(min(p.cursor+p.height, len(p.items)-1), 0)
+	case "enter":
+		m.picker = nil
+		m.inputMode = NormalMode
+		if len(p.items) == 0 {
+			return m, nil
+		}
+		return p.onSelect(m, p.cursor)
+	}
+	p.scrollToCursor()
+	return m, nil
+}
+
+// scrollToCursor keeps the cursor within the visible items
+func (p *picker) scrollToCursor() {
+	if p.height <= 0 {
+		return
+	}
+	if p.cursor < p.offset {
+		p.offset = p.cursor
+	}
+	if p.cursor >= p.offset+p.height {
+		p.offset = p.cursor - p.height + 1
+	}
+}
+
+// View renders the picker list
+func (p *picker) View() string {
+	var b strings.Builder
+	title := p.title
+	if p.height > 0 && len(p.items) > p.height {
+		title += fmt.Sprintf(" (%d/%d)", p.cursor+1, len(p.items))
+	}
+	b.WriteString(styles.InfoLabelStyle.Render(title) + "\n\n")
+
+	if len(p.items) == 0 {
+		b.WriteString(styles.HelpStyle.Render("  "+i18n.T("Nothing to choose from")) + "\n")
+	}
+
+	end := len(p.items)
+	if p.height > 0 {
+		end = min(p.offset+p.height, end)
+	}
+	for i := p.offset; i < end; i++ {
+		item := p.items[i]
+		if item.group != "" && (i == p.offset || p.items[i-1].group != item.group) {
+			b.WriteString(styles.InfoLabelStyle.Render(item.group) + "\n")
+		}
+		line := "  " + item.label
+		if i == p.cursor {
+			line = styles.SelectedStyle.Render("> " + item.label)
+		}
+		if item.detail != "" {
+			line += "  " + styles.HelpStyle.Render(item.detail)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n" + styles.HelpStyle.Render(i18n.T("↑/↓: move • enter: select • esc: cancel")))
+	return b.String()
+}
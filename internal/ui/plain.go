@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"strings"
+
+	"f6n/internal/i18n"
+)
+
+// emojiMarkers replaces the emoji of the views with labeled ASCII markers.
+// Longer sequences come first: the replacer tries them in order.
+var emojiMarkers = strings.NewReplacer(
+	"🔴 Streaming", "[LIVE] Streaming",
+	"🔴", "[ALARM]",
+	"✅", "[OK]",
+	"❌", "[ERROR]",
+	"⚠️", "[WARN]",
+	"⚠", "[WARN]",
+	"⏹️", "[STOPPED]",
+	"✏️", "[EDIT]",
+	"⏱️", "[DURATION]",
+	"⏳", "[TIMEOUT]",
+	"⟳", "[UPDATING]",
+	"✗", "[FAILED]",
+	"✓", "[OK]",
+	"📄", "[FILE]",
+	"📁", "[DIR]",
+	"🗂", "[FILES]",
+	"📦", "[ARCHIVE]",
+	"📂", "[OPEN]",
+	"📖", "[README]",
+	"📝", "[DRAFT]",
+	"🚦", "[CONCURRENCY]",
+	"🔋", "[PROVISIONED]",
+	"💾", "[MEMORY]",
+	"💡", "[TIP]",
+	"🔒", "[DENIED]",
+	"💰", "[COST]",
+	"⚡", "[FAST]",
+	"⚖", "[BALANCED]",
+	"📊", "[METRICS]",
+	"🔥", "[INVOCATIONS]",
+	"📈", "[SUMMARY]",
+	"🔬", "[INSIGHTS]",
+	"\ufe0f", "", // Variation selector left by the emoji above
+)
+
+// asciiDrawing replaces box drawing, block and arrow characters with ASCII
+var asciiDrawing = strings.NewReplacer(
+	"─", "-", "━", "=", "═", "=", "│", "|",
+	"╭", "+", "╮", "+", "╰", "+", "╯", "+",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+",
+	"├", "+", "┤", "+", "┬", "+", "┴", "+", "┼", "+",
+	"▁", "_", "▂", ".", "▃", "-", "▄", ":", "▅", "=", "▆", "+", "▇", "*", "█", "#",
+	"░", ".", "·", ".",
+	"↑", "up", "↓", "down", "→", "->", "▶", ">", "▲", "^",
+	"≤", "<=", "≥", ">=", "≠", "!=",
+	"•", "|", "…", "...", "—", "-",
+)
+
+// accessible reports whether the plain-text accessibility mode is on
+func (m Model) accessible() bool {
+	return m.cfg != nil && m.cfg.Accessible
+}
+
+// plainText rewrites a rendered view for the accessibility mode, or only
+// replaces its emoji when they are turned off. Colors are already dropped by
+// the ASCII color profile set in NewModel.
+func (m Model) plainText(view string) string {
+	switch {
+	case m.accessible():
+		return asciiDrawing.Replace(emojiMarkers.Replace(view))
+	case m.cfg != nil && m.cfg.NoEmoji:
+		return emojiMarkers.Replace(view)
+	default:
+		return view
+	}
+}
+
+// selectedRowLabel names the table row under the cursor, which is otherwise
+// only shown by its background color
+func (m Model) selectedRowLabel() string {
+	cursor := m.list.table.Cursor()
+	if cursor < 0 || cursor >= len(m.list.functions) {
+		return ""
+	}
+	return i18n.Tf("Selected: %s (row %d of %d)", m.list.functions[cursor].Name, cursor+1, len(m.list.functions))
+}
@@ -0,0 +1,364 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"f6n/internal/insights"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// powerTuneInvocations is the number of invocations measured per memory size
+	powerTuneInvocations = 5
+	// powerTuneBarWidth is the width of the duration and cost bars
+	powerTuneBarWidth = 30
+)
+
+// powerTuneSizes are the memory sizes measured when none are given, in MB
+var powerTuneSizes = []int32{128, 256, 512, 1024, 1536, 2048, 3008}
+
+// powerTuning holds the PowerTuneView state: a sweep invoking a function at
+// each memory size in turn, then setting its memory back
+type powerTuning struct {
+	function   string
+	payload    []byte
+	sizes      []int32
+	originalMB int32
+	results    []insights.PowerTuningResult
+	err        error // the error that ended the sweep early
+	stopping   bool
+	done       bool // the original memory is set back
+	restoreErr error
+}
+
+// running reports whether a sweep has started and not set the memory back yet
+func (t powerTuning) running() bool {
+	return t.function != "" && !t.done
+}
+
+type powerTuneMeasuredMsg struct {
+	function string
+	result   insights.PowerTuningResult
+	err      error
+}
+
+type powerTuneRestoredMsg struct {
+	function string
+	err      error
+}
+
+// executePowerTuneCommand handles ":powertune [sizes] [payload|@file]",
+// measuring the selected function at comma-separated memory sizes in MB with
+// a JSON test payload, e.g. ":powertune 256,512,1024 {"id": 1}"
+func (m Model) executePowerTuneCommand(args string) (tea.Model, tea.Cmd) {
+	if m.powerTune.running() {
+		// Back to the sweep in progress
+		m.statusMsg = fmt.Sprintf("Power tuning of %s is still running", m.powerTune.function)
+		m.currentView = PowerTuneView
+		m.refreshPowerTuneView()
+		return m, nil
+	}
+	fn := m.currentFunction()
+	if fn == nil {
+		m.statusMsg = "Select a function to power-tune"
+		return m, nil
+	}
+	tuner, ok := provider.As[provider.PowerTuner](m.provider)
+	if !ok {
+		m.statusMsg = fmt.Sprintf("Power tuning is not supported for %s", strings.ToUpper(string(m.provider.GetProviderName())))
+		return m, nil
+	}
+	if !m.permitted(fn, provider.ActionPowerTune) {
+		return m, nil
+	}
+
+	sizes, payload, err := parsePowerTuneArgs(args, fn.Memory)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %v", err)
+		return m, nil
+	}
+	m.selectedFunc = fn
+	m.powerTune = powerTuning{function: fn.Name, payload: payload, sizes: sizes, originalMB: fn.Memory}
+	m.currentView = PowerTuneView
+	m.refreshPowerTuneView()
+	m.viewport.GotoTop()
+	return m, m.measurePower(tuner, sizes[0])
+}
+
+// parsePowerTuneArgs splits the arguments of :powertune into the memory
+// sizes, which include the current one, and the payload, {} by default
+func parsePowerTuneArgs(args string, currentMB int32) ([]int32, []byte, error) {
+	sizes := append([]int32(nil), powerTuneSizes...)
+	if first, rest, _ := strings.Cut(args, " "); first != "" && strings.Trim(first, "0123456789,") == "" {
+		sizes = nil
+		for _, field := range strings.Split(first, ",") {
+			size, err := strconv.ParseInt(field, 10, 32)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid memory size %q: expected MB, e.g. 512", field)
+			}
+			sizes = append(sizes, int32(size))
+		}
+		args = strings.TrimSpace(rest)
+	}
+	if currentMB > 0 {
+		sizes = append(sizes, currentMB)
+	}
+	slices.Sort(sizes)
+	sizes = slices.Compact(sizes)
+
+	payload := []byte("{}")
+	switch {
+	case strings.HasPrefix(args, "@"):
+		data, err := os.ReadFile(strings.TrimPrefix(args, "@"))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read the payload: %w", err)
+		}
+		payload = data
+	case args != "":
+		payload = []byte(args)
+	}
+	if !json.Valid(payload) {
+		return nil, nil, fmt.Errorf("the payload is not valid JSON")
+	}
+	return sizes, payload, nil
+}
+
+// measurePower sets the function's memory to memoryMB and invokes it
+// powerTuneInvocations times, reading each invocation's REPORT line
+func (m Model) measurePower(tuner provider.PowerTuner, memoryMB int32) tea.Cmd {
+	name, payload := m.powerTune.function, m.powerTune.payload
+	return func() tea.Msg {
+		ctx := context.Background()
+		if err := tuner.SetMemory(ctx, name, memoryMB); err != nil {
+			logger.Logger.Printf("Power tuning: %v", err)
+			return powerTuneMeasuredMsg{function: name, err: err}
+		}
+		var reports []insights.Report
+		failed := 0
+		for range powerTuneInvocations {
+			result, err := tuner.InvokeWithLog(ctx, name, payload)
+			if err != nil {
+				logger.Logger.Printf("Power tuning: %v", err)
+				return powerTuneMeasuredMsg{function: name, err: err}
+			}
+			if result.FunctionError != "" {
+				failed++
+			}
+			if report, ok := insights.ReportFromLog(result.Log); ok {
+				reports = append(reports, report)
+			}
+		}
+		if len(reports) == 0 {
+			return powerTuneMeasuredMsg{function: name, err: fmt.Errorf("no REPORT line in the logs of the invocations at %d MB", memoryMB)}
+		}
+		return powerTuneMeasuredMsg{function: name, result: insights.MeasurePower(memoryMB, reports, failed)}
+	}
+}
+
+// restorePowerTuneMemory sets the function's memory back to what it was
+// before the sweep
+func (m Model) restorePowerTuneMemory(tuner provider.PowerTuner) tea.Cmd {
+	name, memoryMB := m.powerTune.function, m.powerTune.originalMB
+	return func() tea.Msg {
+		err := tuner.SetMemory(context.Background(), name, memoryMB)
+		if err != nil {
+			logger.Logger.Printf("Power tuning: error setting the memory of %s back to %d MB: %v", name, memoryMB, err)
+		}
+		return powerTuneRestoredMsg{function: name, err: err}
+	}
+}
+
+// RestorePowerTune sets the memory back when the program ends during a sweep,
+// e.g. on ctrl+c, so the function is not left at a tuning memory size.
+// SetMemory waits for the update of the step in progress to finish first.
+func (m Model) RestorePowerTune(ctx context.Context) {
+	if !m.powerTune.running() {
+		return
+	}
+	tuner, ok := provider.As[provider.PowerTuner](m.provider)
+	if !ok {
+		return
+	}
+	t := m.powerTune
+	err := tuner.SetMemory(ctx, t.function, t.originalMB)
+	if err != nil {
+		logger.Logger.Printf("Power tuning: error setting the memory of %s back to %d MB: %v", t.function, t.originalMB, err)
+	}
+	detail := fmt.Sprintf("interrupted on exit, memory set back to %d MB", t.originalMB)
+	m.recordAction("power-tune", t.function, detail, errors.Join(t.err, err))
+}
+
+// handlePowerTuneMeasured records the measurement at a memory size and moves
+// on to the next, or sets the memory back once the sweep is over
+func (m Model) handlePowerTuneMeasured(msg powerTuneMeasuredMsg) (tea.Model, tea.Cmd) {
+	if msg.function != m.powerTune.function {
+		return m, nil
+	}
+	tuner, _ := provider.As[provider.PowerTuner](m.provider)
+	t := &m.powerTune
+	if msg.err != nil {
+		t.err = msg.err
+	} else {
+		t.results = append(t.results, msg.result)
+	}
+
+	var cmd tea.Cmd
+	if t.err != nil || t.stopping || len(t.results) == len(t.sizes) {
+		cmd = m.restorePowerTuneMemory(tuner)
+	} else {
+		cmd = m.measurePower(tuner, t.sizes[len(t.results)])
+	}
+	if m.currentView == PowerTuneView {
+		m.refreshPowerTuneView()
+	}
+	return m, cmd
+}
+
+// handlePowerTuneRestored ends the sweep and records it in the audit log
+func (m Model) handlePowerTuneRestored(msg powerTuneRestoredMsg) (tea.Model, tea.Cmd) {
+	if msg.function != m.powerTune.function {
+		return m, nil
+	}
+	t := &m.powerTune
+	t.done, t.restoreErr = true, msg.err
+
+	measured := make([]int32, 0, len(t.results))
+	for _, r := range t.results {
+		measured = append(measured, r.MemoryMB)
+	}
+	detail := fmt.Sprintf("measured %s MB, memory set back to %d MB", strings.Join(sizeLabels(measured), ", "), t.originalMB)
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Failed to set the memory of %s back to %d MB: %v", msg.function, t.originalMB, msg.err)
+	} else if t.err == nil {
+		m.statusMsg = fmt.Sprintf("✅ Power tuning of %s done, memory set back to %d MB", msg.function, t.originalMB)
+	}
+	m.recordAction("power-tune", msg.function, detail, errors.Join(t.err, msg.err))
+	if m.currentView == PowerTuneView {
+		m.refreshPowerTuneView()
+	}
+	return m, nil
+}
+
+// handlePowerTuneKey handles the PowerTuneView keys: s stops the sweep after
+// the memory size being measured, r runs it again once it is over
+func (m Model) handlePowerTuneKey(key string) (tea.Model, tea.Cmd, bool) {
+	switch key {
+	case "s":
+		if m.powerTune.running() && !m.powerTune.stopping {
+			m.powerTune.stopping = true
+			m.statusMsg = "Stopping power tuning after the current memory size..."
+			m.refreshPowerTuneView()
+		}
+		return m, nil, true
+	case "r":
+		if m.powerTune.running() {
+			return m, nil, true
+		}
+		t := m.powerTune
+		tuner, ok := provider.As[provider.PowerTuner](m.provider)
+		if !ok || t.function == "" {
+			return m, nil, true
+		}
+		m.powerTune = powerTuning{function: t.function, payload: t.payload, sizes: t.sizes, originalMB: t.originalMB}
+		m.refreshPowerTuneView()
+		return m, m.measurePower(tuner, t.sizes[0]), true
+	}
+	return m, nil, false
+}
+
+// refreshPowerTuneView renders the measurements: a table of the duration and
+// cost at each memory size, bars charting the tradeoff between them, and the
+// cheapest, fastest and balanced sizes
+//
+//	512 MB │██████████████░░░░░░░░░░░░░░░░  142 ms │██████░░░░░░░░░░░░░░░░░░░░░░░░ $1.39
+func (m *Model) refreshPowerTuneView() {
+	t := m.powerTune
+	var b strings.Builder
+	b.WriteString(styles.SelectedStyle.Render(fmt.Sprintf("━━━ Power tuning: %s ━━━", t.function)) + "\n\n")
+	b.WriteString(fmt.Sprintf("%s %d invocations at each of %s MB with %s\n",
+		styles.InfoLabelStyle.Render("Sweep:"), powerTuneInvocations, strings.Join(sizeLabels(t.sizes), ", "), fitCell(string(t.payload), 60)))
+
+	switch {
+	case t.restoreErr != nil:
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("❌ Failed to set the memory back to %d MB: %v. Set it back before leaving it this way.", t.originalMB, t.restoreErr)) + "\n")
+	case t.done:
+		b.WriteString(fmt.Sprintf("✅ Memory set back to %d MB\n", t.originalMB))
+	case t.err != nil || t.stopping || len(t.results) == len(t.sizes):
+		b.WriteString(fmt.Sprintf("⟳ Setting the memory back to %d MB...\n", t.originalMB))
+	default:
+		b.WriteString(fmt.Sprintf("⟳ Measuring %d MB (%d/%d)... The function runs for real, and with another memory size until the sweep ends.\n",
+			t.sizes[len(t.results)], len(t.results)+1, len(t.sizes)))
+	}
+	if t.err != nil {
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("❌ Sweep stopped: %v", t.err)) + "\n")
+	}
+	if len(t.results) == 0 {
+		m.viewport.SetContent(b.String())
+		return
+	}
+
+	picks := insights.PickPowerTuning(t.results)
+	b.WriteString(fmt.Sprintf("\n%9s %12s %12s %10s %8s %12s\n", "Memory", "Avg duration", "Avg billed", "Max used", "Errors", "Cost per 1M"))
+	for i, r := range t.results {
+		var marks []string
+		if i == picks.Cheapest {
+			marks = append(marks, "💰 cheapest")
+		}
+		if i == picks.Fastest {
+			marks = append(marks, "⚡ fastest")
+		}
+		if i == picks.Balanced {
+			marks = append(marks, "⚖ balanced")
+		}
+		if r.MemoryMB == t.originalMB {
+			marks = append(marks, "current")
+		}
+		errs := fmt.Sprintf("%8d", r.Errors)
+		if r.Errors > 0 {
+			errs = styles.ErrorStyle.Render(errs)
+		}
+		b.WriteString(fmt.Sprintf("%6d MB %9.0f ms %9.0f ms %7d MB %s %12s  %s\n",
+			r.MemoryMB, r.AvgDurationMs, r.AvgBilledMs, r.MaxMemoryUsedMB, errs,
+			fmt.Sprintf("$%.2f", r.CostPerMillionUSD), strings.Join(marks, ", ")))
+	}
+
+	maxDuration, maxCost := 0.0, 0.0
+	for _, r := range t.results {
+		maxDuration, maxCost = max(maxDuration, r.AvgDurationMs), max(maxCost, r.CostPerMillionUSD)
+	}
+	bar := func(v, top float64) string {
+		filled := 0
+		if top > 0 {
+			filled = int(v/top*powerTuneBarWidth + 0.5)
+		}
+		return strings.Repeat("█", filled) + strings.Repeat("░", powerTuneBarWidth-filled)
+	}
+	b.WriteString(fmt.Sprintf("\n%10s%-*s%11s%s\n", "", powerTuneBarWidth, "Duration", "", "Cost per 1M invocations"))
+	for _, r := range t.results {
+		b.WriteString(fmt.Sprintf("%6d MB │%s %6.0f ms │%s $%.2f\n",
+			r.MemoryMB, bar(r.AvgDurationMs, maxDuration), r.AvgDurationMs, bar(r.CostPerMillionUSD, maxCost), r.CostPerMillionUSD))
+	}
+
+	b.WriteString("\n" + styles.HelpStyle.Render("Cold starts are left out of the averages. Costs are on-demand estimates at us-east-1 x86 prices."))
+	m.viewport.SetContent(b.String())
+}
+
+// sizeLabels formats memory sizes for a list
+func sizeLabels(sizes []int32) []string {
+	labels := make([]string, 0, len(sizes))
+	for _, size := range sizes {
+		labels = append(labels, strconv.Itoa(int(size)))
+	}
+	return labels
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type projectsLoadedMsg struct {
+	projects []provider.ProjectInfo
+	err      error
+}
+
+// executeProjectCommand handles ":project [id]", switching to the given
+// project or picking one of the projects the caller can access
+func (m Model) executeProjectCommand(args []string) (tea.Model, tea.Cmd) {
+	switcher, ok := provider.As[provider.ProjectSwitcher](m.provider)
+	if !ok {
+		m.statusMsg = fmt.Sprintf("Project switching is not supported for %s", strings.ToUpper(string(m.provider.GetProviderName())))
+		return m, nil
+	}
+	if len(args) > 0 {
+		return m.switchProject(args[0])
+	}
+
+	m.statusMsg = "Loading projects..."
+	return m, func() tea.Msg {
+		projects, err := switcher.ListProjects(context.Background())
+		if err != nil {
+			logger.Logger.Printf("Error listing projects: %v", err)
+		}
+		return projectsLoadedMsg{projects: projects, err: err}
+	}
+}
+
+// openProjectPicker lists the accessible projects, the current one first
+func (m Model) openProjectPicker(msg projectsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Failed to list projects: %v", msg.err)
+		return m, nil
+	}
+	if len(msg.projects) == 0 {
+		m.statusMsg = "No accessible projects found"
+		return m, nil
+	}
+	m.statusMsg = ""
+
+	items := make([]pickerItem, 0, len(msg.projects))
+	for _, project := range msg.projects {
+		item := pickerItem{label: project.ID, detail: project.Name}
+		if project.ID == m.accountID {
+			item.detail = strings.TrimSpace(project.Name + " (current)")
+		}
+		items = append(items, item)
+	}
+	projects := msg.projects
+	m.openPicker("Switch project", items, func(m Model, idx int) (tea.Model, tea.Cmd) {
+		return m.switchProject(projects[idx].ID)
+	})
+	return m, nil
+}
+
+// switchProject rebuilds the provider for the project and reloads the
+// function list, dropping everything loaded from the previous project
+func (m Model) switchProject(projectID string) (tea.Model, tea.Cmd) {
+	if projectID == m.accountID {
+		m.statusMsg = "Already on project " + projectID
+		return m, nil
+	}
+	switcher, _ := provider.As[provider.ProjectSwitcher](m.provider)
+	prov, err := switcher.WithProject(projectID)
+	if err != nil {
+		logger.Logger.Printf("Error switching to project %s: %v", projectID, err)
+		m.statusMsg = fmt.Sprintf("❌ Failed to switch project: %v", err)
+		return m, nil
+	}
+	logger.Logger.Printf("Switched to project %s", projectID)
+
+	m.logs.stopStream()
+	m.logs.cache = nil
+	m.provider = prov
+	m.cfg.GCPProject = projectID
+	m.accountID = projectID
+	m.list.functions, m.list.allFunctions, m.selectedFunc = nil, nil, nil
+	m.functionStats, m.firingAlarms = nil, nil
+	// The watcher polls the provider it was started with
+	m.setWatch(m.watch.interval, false)
+	m.detail, m.metrics = detailModel{}, metricsModel{}
+	m.deps, m.security = dependencies{}, security{}
+	m.currentView = ListView
+	m.err = nil
+	m.list.loading = true
+	m.updateTable()
+	m.statusMsg = "Switched to project " + projectID
+	return m, m.fetchFunctions()
+}
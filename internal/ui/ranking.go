@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"f6n/internal/insights"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// rankingTopN is the number of functions shown in RankingView
+const rankingTopN = 20
+
+// rankingWindows are the windows RankingView cycles through with 't'
+var rankingWindows = []time.Duration{time.Hour, 6 * time.Hour, 24 * time.Hour, 7 * 24 * time.Hour}
+
+// rankingMetric is the column RankingView sorts by
+type rankingMetric int
+
+const (
+	rankByInvocations rankingMetric = iota
+	rankByErrors
+	rankByErrorRate
+	rankByP99
+	rankByCost
+)
+
+var rankingMetricNames = []string{"invocations", "errors", "error rate", "p99 duration", "cost"}
+
+// ranking holds the RankingView state
+type ranking struct {
+	window  int // index into rankingWindows
+	metric  rankingMetric
+	stats   map[string]provider.FunctionStats
+	err     error
+	loading bool
+}
+
+type rankingLoadedMsg struct {
+	window int
+	stats  map[string]provider.FunctionStats
+	err    error
+}
+
+// rankedFunction is a row of RankingView
+type rankedFunction struct {
+	name  string
+	stats provider.FunctionStats
+	cost  float64
+}
+
+// value returns the value the row is ranked by
+func (r rankedFunction) value(metric rankingMetric) float64 {
+	switch metric {
+	case rankByErrors:
+		return r.stats.Errors
+	case rankByErrorRate:
+		return r.stats.ErrorRate()
+	case rankByP99:
+		return r.stats.P99DurationMs
+	case rankByCost:
+		return r.cost
+	default:
+		return r.stats.Invocations
+	}
+}
+
+// formatWindow renders a ranking window as e.g. "6h" or "7d"
+func formatWindow(d time.Duration) string {
+	if d > 24*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return fmt.Sprintf("%dh", d/time.Hour)
+}
+
+// fetchRanking loads function stats for the current ranking window
+func (m Model) fetchRanking() tea.Cmd {
+	functions := m.list.allFunctions
+	window := m.ranking.window
+	return func() tea.Msg {
+		since := time.Now().Add(-rankingWindows[window])
+		stats, err := insights.CollectStats(context.Background(), m.provider, functions, since)
+		if err != nil {
+			logger.Logger.Printf("Error fetching ranking stats: %v", err)
+		}
+		return rankingLoadedMsg{window: window, stats: stats, err: err}
+	}
+}
+
+// openRanking switches to RankingView and loads the stats
+func (m Model) openRanking() (tea.Model, tea.Cmd) {
+	m.currentView = RankingView
+	m.ranking.loading = true
+	m.ranking.err = nil
+	m.refreshRankingView()
+	return m, m.fetchRanking()
+}
+
+// handleRankingKey handles the RankingView specific keys
+func (m Model) handleRankingKey(key string) (tea.Model, tea.Cmd, bool) {
+	switch key {
+	case "1", "2", "3", "4", "5":
+		m.ranking.metric = rankingMetric(key[0] - '1')
+		m.refreshRankingView()
+		return m, nil, true
+	case "t":
+		m.ranking.window = (m.ranking.window + 1) % len(rankingWindows)
+		model, cmd := m.openRanking()
+		return model, cmd, true
+	case "r":
+		model, cmd := m.openRanking()
+		return model, cmd, true
+	}
+	return m, nil, false
+}
+
+// refreshRankingView renders the ranking into the viewport
+func (m *Model) refreshRankingView() {
+	r := m.ranking
+	var b strings.Builder
+	b.WriteString(styles.SelectedStyle.Render(fmt.Sprintf("━━━ Top functions by %s (last %s) ━━━",
+		rankingMetricNames[r.metric], formatWindow(rankingWindows[r.window]))) + "\n\n")
+
+	switch {
+	case r.err != nil:
+		b.WriteString(fmt.Sprintf("Error loading function stats: %v", r.err))
+		m.viewport.SetContent(b.String())
+		return
+	case r.loading:
+		b.WriteString("Loading function stats...")
+		m.viewport.SetContent(b.String())
+		return
+	}
+
+	memory := make(map[string]int32, len(m.list.allFunctions))
+	for _, fn := range m.list.allFunctions {
+		memory[fn.Name] = fn.Memory
+	}
+	rows := make([]rankedFunction, 0, len(r.stats))
+	for name, stats := range r.stats {
+		rows = append(rows, rankedFunction{
+			name:  name,
+			stats: stats,
+			cost:  insights.EstimateCost(stats.Invocations, stats.TotalDurationMs, memory[name]),
+		})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		vi, vj := rows[i].value(r.metric), rows[j].value(r.metric)
+		if vi != vj {
+			return vi > vj
+		}
+		return rows[i].name < rows[j].name
+	})
+	if len(rows) > rankingTopN {
+		rows = rows[:rankingTopN]
+	}
+
+	if len(rows) == 0 {
+		b.WriteString("No invocations in this window.")
+		m.viewport.SetContent(b.String())
+		return
+	}
+
+	headers := []string{"Invocations", "Errors", "Err %", "p99 ms", "Cost $"}
+	header := fmt.Sprintf("%-4s %-40s", "#", "Function")
+	for i, h := range headers {
+		cell := fmt.Sprintf(" %12s", h)
+		if rankingMetric(i) == r.metric {
+			cell = styles.CommandKeyStyle.Render(cell)
+		}
+		header += cell
+	}
+	b.WriteString(header + "\n")
+
+	for i, row := range rows {
+		name := row.name
+		if len(name) > 40 {
+			name = name[:39] + "…"
+		}
+		b.WriteString(fmt.Sprintf("%-4d %-40s %12.0f %12.0f %12.2f %12.0f %12.4f\n",
+			i+1, name, row.stats.Invocations, row.stats.Errors, row.stats.ErrorRate()*100,
+			row.stats.P99DurationMs, row.cost))
+	}
+	m.viewport.SetContent(b.String())
+}
@@ -0,0 +1,137 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"f6n/internal/config"
+	"f6n/internal/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxRecentFunctions is the number of recently viewed functions remembered
+const maxRecentFunctions = 20
+
+// recentFunction is a function viewed recently, and the view it was seen in
+type recentFunction struct {
+	Name   string    `json:"name"`
+	View   string    `json:"view"` // detail, logs, metrics or code
+	Viewed time.Time `json:"viewed"`
+}
+
+// recentPath returns where the recently viewed functions of the current
+// provider, profile or project and region are kept, empty when they are not
+func (m Model) recentPath() string {
+	name := m.stateName()
+	if name == "" {
+		return ""
+	}
+	return filepath.Join(config.StateDir(), "recent", name+".json")
+}
+
+// readRecent returns the recently viewed functions kept from earlier runs,
+// most recent first
+func (m Model) readRecent() []recentFunction {
+	path := m.recentPath()
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Logger.Printf("Error reading recent functions: %v", err)
+		}
+		return nil
+	}
+	var recent []recentFunction
+	if err := json.Unmarshal(data, &recent); err != nil {
+		logger.Logger.Printf("Error parsing recent functions: %v", err)
+		return nil
+	}
+	return recent
+}
+
+// rememberRecent moves the selected function to the top of the recently
+// viewed functions, noting the view it is seen in, and keeps the list
+func (m *Model) rememberRecent(view string) {
+	if m.selectedFunc == nil {
+		return
+	}
+	name := m.selectedFunc.Name
+	recent := slices.DeleteFunc(slices.Clone(m.recent), func(r recentFunction) bool { return r.Name == name })
+	recent = append([]recentFunction{{Name: name, View: view, Viewed: time.Now()}}, recent...)
+	if len(recent) > maxRecentFunctions {
+		recent = recent[:maxRecentFunctions]
+	}
+	m.recent = recent
+
+	path := m.recentPath()
+	if path == "" {
+		return
+	}
+	data, err := json.Marshal(recent)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0700)
+	}
+	if err == nil {
+		err = os.WriteFile(path, data, 0600)
+	}
+	if err != nil {
+		logger.Logger.Printf("Error keeping recent functions: %v", err)
+	}
+}
+
+// openRecentPicker handles ":recent", listing the recently viewed functions
+// to go back to the view each was last seen in
+func (m Model) openRecentPicker() (tea.Model, tea.Cmd) {
+	if len(m.recent) == 0 {
+		m.statusMsg = "No recently viewed functions"
+		return m, nil
+	}
+	recent := m.recent
+	items := make([]pickerItem, len(recent))
+	for i, r := range recent {
+		items[i] = pickerItem{label: r.Name, detail: fmt.Sprintf("%s, %s", r.View, formatAgo(r.Viewed))}
+	}
+	m.openPicker("Recent functions", items, func(m Model, idx int) (tea.Model, tea.Cmd) {
+		r := recent[idx]
+		fn, ok := m.findFunction(r.Name)
+		if !ok {
+			// Not listed (any more): look it up by name
+			return m.executeOpenCommand([]string{r.Name})
+		}
+		m.selectedFunc = &fn
+		switch r.View {
+		case LogsView.String():
+			return m.openLogsView()
+		case MetricsView.String():
+			return m.openMetricsView()
+		case CodeView.String():
+			return m.openCodeView()
+		}
+		return m.openDetailView()
+	})
+	return m, nil
+}
+
+// formatAgo describes how long ago t was, e.g. "5 min ago" or "3 days ago"
+func formatAgo(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%d min ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%d h ago", int(d.Hours()))
+	case d < 48*time.Hour:
+		return "yesterday"
+	}
+	return fmt.Sprintf("%d days ago", int(d.Hours()/24))
+}
@@ -1,11 +1,18 @@
 package ui
 
 import (
+	"cmp"
 	"fmt"
+	"maps"
 	"os/user"
 	"runtime"
+	"slices"
 	"strings"
+	"time"
 
+	"f6n/internal/apicalls"
+	"f6n/internal/i18n"
+	"f6n/internal/insights"
 	"f6n/internal/provider"
 	"f6n/internal/ui/styles"
 
@@ -14,8 +21,9 @@ import (
 
 // renderView renders the main view
 func renderView(m Model) string {
-	// ASCII Art Header - always shown
-	ascii := renderASCII(m.width)
+	if m.zoomed && m.zoomable() && m.inputMode != PickerMode && m.inputMode != FormMode {
+		return renderZoomed(m)
+	}
 
 	// Info rows - always shown
 	info := renderInfo(m)
@@ -32,60 +40,89 @@ func renderView(m Model) string {
 		shortcuts,
 	)
 
-	logoLayout := lipgloss.NewStyle().
-		MarginRight(4).
-		Render(ascii)
-
 	var content string
 	var help string
 
 	// Handle different states
 	if m.err != nil {
-		content = fmt.Sprintf("\n  %s %v\n\n  Press q to quit.\n",
-			styles.ErrorStyle.Render("Error:"), m.err)
-		help = styles.HelpStyle.Render("Error occurred - check configuration")
-	} else if m.loading {
-		content = "\n\n  Loading Lambda functions...\n\n"
-		help = styles.HelpStyle.Render("Please wait...")
+		content = fmt.Sprintf("\n  %s %v\n\n  %s\n",
+			styles.ErrorStyle.Render(i18n.T("Error:")), m.err, i18n.T("Press q to quit."))
+		help = styles.HelpStyle.Render(i18n.T("Error occurred - check configuration"))
+	} else if m.list.loading {
+		content = "\n\n  " + i18n.T("Loading Lambda functions...") + "\n\n"
+		help = styles.HelpStyle.Render(i18n.T("Please wait..."))
 	} else {
 		// Normal view content
 		// Filter/Command input (show when in input mode or when filter is active)
 		var inputBox string
-		if m.inputMode == FilterMode || m.inputMode == CommandMode {
+		if m.inputMode == FilterMode || m.inputMode == CommandMode || m.inputMode == LogFilterMode {
 			inputBox = m.textInput.View() + "\n"
-		} else if m.filterActive {
+		} else if m.list.filterActive && m.currentView == ListView {
 			// Show active filter indicator
-			filterIndicator := styles.CommandKeyStyle.Render("Filter active:") + " " +
-				styles.InfoValueStyle.Render(m.activeFilter) + " " +
-				styles.HelpStyle.Render("(press Esc to clear)")
+			filterIndicator := styles.CommandKeyStyle.Render(i18n.T("Filter active:")) + " " +
+				styles.InfoValueStyle.Render(m.list.activeFilter) + " " +
+				styles.HelpStyle.Render(i18n.T("(press Esc to clear)"))
 			inputBox = filterIndicator + "\n"
 		}
 
 		// Main content
-		if len(m.functions) == 0 {
-			content = "\n  No Lambda functions found in this region.\n\n  " +
-				styles.HelpStyle.Render("Press 'r' to refresh or 'q' to quit")
+		if m.inputMode == PickerMode && m.picker != nil {
+			content = m.picker.View()
+		} else if m.inputMode == FormMode && m.form != nil {
+			content = m.form.View()
+		} else if len(m.list.functions) == 0 && m.offline.active {
+			content = "\n  " + i18n.T("No functions were cached to show while offline.") + "\n"
+		} else if len(m.list.functions) == 0 {
+			content = "\n  " + i18n.T("No Lambda functions found in this region.") + "\n\n  " +
+				styles.HelpStyle.Render(i18n.T("Press 'r' to refresh or 'q' to quit"))
 		} else if m.currentView == ListView {
-			content = inputBox + m.table.View()
-		} else if m.currentView == CodeView && m.editMode {
+			content = inputBox + m.list.table.View()
+			if m.accessible() {
+				content += "\n" + m.selectedRowLabel()
+			}
+		} else if m.currentView == EnvEditView {
+			content = m.renderEnvEditor()
+		} else if m.currentView == InvokeView && m.invoke.result == nil {
+			content = m.renderInvokeEditor()
+		} else if m.currentView == CodeView && m.code.editMode {
 			// Show textarea when in edit mode
-			editHeader := styles.InfoLabelStyle.Render("✏️  EDIT MODE") +
-				styles.HelpStyle.Render(" (Ctrl+S to save, E to cancel)")
+			editHeader := styles.InfoLabelStyle.Render("✏️  "+i18n.T("EDIT MODE")) +
+				styles.HelpStyle.Render(i18n.T(" (Ctrl+S to save, Ctrl+Z/Ctrl+Y to undo/redo, Esc to cancel)"))
+			if m.code.history.dirty(m.textarea.Value()) {
+				editHeader += " " + styles.ErrorStyle.Render("● "+i18n.T("modified"))
+			}
 			content = editHeader + "\n\n" + m.textarea.View()
 		} else {
-			content = m.viewport.View()
+			content = inputBox + m.viewport.View()
+		}
+
+		if m.offline.active {
+			content = m.renderOfflineBanner() + content
 		}
 
 		// Help text
 		if m.currentView == ListView {
-			help = styles.HelpStyle.Render("Use keyboard shortcuts above to navigate")
+			help = styles.HelpStyle.Render(i18n.T("Use keyboard shortcuts above to navigate"))
 		} else {
-			help = styles.HelpStyle.Render("↑/↓: scroll • esc: back • q: quit")
+			hint := i18n.T("↑/↓: scroll • gg/G: top/bottom • esc: back • q: quit")
+			if pos := positionIndicator(m.viewport); pos != "" && m.scrollable() {
+				hint = pos + " • " + hint
+			}
+			help = styles.HelpStyle.Render(hint)
+		}
+		if m.statusMsg != "" {
+			help = styles.InfoValueStyle.Render(m.statusMsg) + "\n" + help
 		}
 	}
 
-	// Combine all elements
-	view := fmt.Sprintf("%s\n\n%s\n\n%s\n\n%s", logoLayout, headerLayout, content, help)
+	// Combine all elements, with the ASCII art header unless it is hidden
+	view := fmt.Sprintf("%s\n\n%s\n\n%s", headerLayout, content, help)
+	if m.logoVisible() {
+		logoLayout := lipgloss.NewStyle().
+			MarginRight(4).
+			Render(renderASCII(m.width))
+		view = logoLayout + "\n\n" + view
+	}
 
 	// Apply top padding using lipgloss style
 	paddedView := lipgloss.NewStyle().
@@ -124,9 +161,14 @@ func renderInfo(m Model) string {
 	region := m.provider.GetRegion()
 	accountID := m.accountID
 
-	accountKey := "Account"
-	if providerName == "gcp" {
+	accountKey, regionKey := "Account", "Region"
+	switch m.provider.GetProviderName() {
+	case provider.GCP:
 		accountKey = "Project"
+	case provider.OpenFaaS:
+		accountKey, regionKey = "Gateway", "Namespace"
+	case provider.Knative:
+		accountKey, regionKey = "Context", "Namespace"
 	}
 
 	info := []struct {
@@ -135,30 +177,61 @@ func renderInfo(m Model) string {
 	}{
 		{"Provider", strings.ToUpper(providerName)},
 		{accountKey, accountID},
-		{"Region", region},
+		{regionKey, region},
 		{"Environment", m.environment},
-		{"Functions", fmt.Sprintf("%d", len(m.functions))},
+		{"Functions", fmt.Sprintf("%d", len(m.list.functions))},
 		{"CPU", getCPUInfo()},
 		{"MEM", getMemInfo()},
 		{"OS", getOSInfo()},
 		{"User", getUserInfo()},
 	}
+	if m.cfg != nil && m.cfg.EndpointURL != "" && m.provider.GetProviderName() == provider.AWS {
+		info = append(info, struct {
+			key   string
+			value string
+		}{"Endpoint", m.cfg.EndpointURL})
+	}
+	if m.watch.interval > 0 {
+		info = append(info, struct {
+			key   string
+			value string
+		}{"Watch", i18n.Tf("every %s", m.watch.interval)})
+	}
+	if calls := apicalls.Current(); calls.CallsPerMinute > 0 || calls.Backoff {
+		info = append(info, struct {
+			key   string
+			value string
+		}{"API", formatAPICalls(calls)})
+	}
 
 	// Build info in single column
 	var lines []string
 	for _, item := range info {
 		// Pink for key, teal for value
-		line := styles.CommandKeyStyle.Render(item.key+":") + " " + styles.InfoValueStyle.Render(item.value)
+		line := styles.CommandKeyStyle.Render(i18n.T(item.key)+":") + " " + styles.InfoValueStyle.Render(item.value)
 		lines = append(lines, line)
 	}
 
 	if providerName == "gcp" {
-		lines = append(lines, styles.HelpStyle.Render("\n(Cloud Functions, 1st Gen)"))
+		lines = append(lines, styles.HelpStyle.Render("\n"+i18n.T("(Cloud Functions, 1st Gen)")))
 	}
 
 	return strings.Join(lines, "\n")
 }
 
+// formatAPICalls summarizes the API calls of the last minute, with the
+// throttled ones and whether retries are backing off
+func formatAPICalls(calls apicalls.Stats) string {
+	value := i18n.Tf("%d calls/min", calls.CallsPerMinute)
+	if calls.ThrottledPerMinute > 0 {
+		value += ", " + i18n.Tf("%d throttled", calls.ThrottledPerMinute)
+	}
+	if calls.Backoff {
+		value += ", " + i18n.T("backing off")
+	}
+	return value
+}
+
 // getCPUInfo returns CPU architecture information
 func getCPUInfo() string {
 	return runtime.GOARCH
@@ -202,21 +275,31 @@ func renderShortcuts(m Model) string {
 			{"<enter>", "details"},
 			{"<l>", "logs"},
 			{"<m>", "metrics"},
+			{"<E>", "top errors"},
+			{"<t>", "top functions"},
+			{"<F>", "replay failed events"},
+			{"<S>", "security"},
+			{"<o>", "open in console"},
+			{"<y>", "copy ARN/log group/URL"},
+			{"<x>", "compare"},
+			{"<T>", "mark to tail"},
+			{"<f>", "saved filters"},
 			{"<c>", "code"},
 			{"<w>", "download"},
+			{"<L>", "toggle logo"},
 			{"<r>", "refresh"},
 			{"<q>", "quit"},
 		}
 	case CodeView:
-		if m.editMode {
+		if m.code.editMode {
 			shortcuts = []struct {
 				key   string
 				value string
 			}{
 				{"<ctrl+s>", "save"},
-				{"<e>", "cancel edit"},
-				{"<esc>", "back to list"},
-				{"<q>", "quit"},
+				{"<ctrl+z>", "undo"},
+				{"<ctrl+y>", "redo"},
+				{"<esc>", "cancel edit"},
 			}
 		} else {
 			shortcuts = []struct {
@@ -224,7 +307,9 @@ func renderShortcuts(m Model) string {
 				value string
 			}{
 				{"<e>", "edit"},
+				{"<E>", "open in $EDITOR"},
 				{"<v>", "view downloaded"},
+				{"<z>", "zoom"},
 				{"<esc>", "back to list"},
 				{"<q>", "quit"},
 			}
@@ -234,17 +319,31 @@ func renderShortcuts(m Model) string {
 			key   string
 			value string
 		}{
+			{"<E>", "open in $EDITOR"},
+			{"<[/]>", "prev/next page"},
+			{"<z>", "zoom"},
 			{"<esc>", "back to code"},
 			{"<q>", "quit"},
 		}
 	case LogsView:
-		if m.streamingLogs {
+		repeatsLabel := "expand repeats"
+		if m.logs.expandRepeats {
+			repeatsLabel = "collapse repeats"
+		}
+		if m.logs.streaming {
 			shortcuts = []struct {
 				key   string
 				value string
 			}{
 				{"<s>", "stop streaming"},
 				{"<l>", "static logs"},
+				{"<f>", "saved queries"},
+				{"<\\>", "field filter"},
+				{"<h>", "toggle highlights"},
+				{"<x>", repeatsLabel},
+				{"<E>", "top errors"},
+				{"<i>", "invocations"},
+				{"<z>", "zoom"},
 				{"<esc>", "back to list"},
 				{"<q>", "quit"},
 			}
@@ -255,6 +354,14 @@ func renderShortcuts(m Model) string {
 			}{
 				{"<s>", "stream logs"},
 				{"<l>", "refresh logs"},
+				{"<o>", "older logs"},
+				{"<f>", "saved queries"},
+				{"<\\>", "field filter"},
+				{"<h>", "toggle highlights"},
+				{"<x>", repeatsLabel},
+				{"<E>", "top errors"},
+				{"<i>", "invocations"},
+				{"<z>", "zoom"},
 				{"<esc>", "back to list"},
 				{"<q>", "quit"},
 			}
@@ -265,6 +372,200 @@ func renderShortcuts(m Model) string {
 			value string
 		}{
 			{"<m>", "refresh metrics"},
+			{"<a>", "create alarm"},
+			{"<z>", "zoom"},
+			{"<esc>", "back to list"},
+			{"<q>", "quit"},
+		}
+	case DetailView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<F>", "replay failed events"},
+			{"<S>", "security"},
+			{"<o>", "open in console"},
+			{"<y>", "copy ARN/log group/URL"},
+			{"<e>", "edit env"},
+			{"<v>", "reveal env value"},
+			{"<d>", "edit description"},
+			{"<D>", "log levels"},
+			{"<R>", "log retention"},
+			{"<C>", "HTTP request"},
+			{"<i>", "invoke"},
+			{"<H>", "deploy history"},
+			{"<esc>", "back to list"},
+			{"<q>", "quit"},
+		}
+	case RankingView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<1-5>", "sort by invocations/errors/error rate/p99/cost"},
+			{"<t>", "change window"},
+			{"<r>", "refresh"},
+			{"<esc>", "back to list"},
+			{"<q>", "quit"},
+		}
+	case ErrorsView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<E>", "refresh errors"},
+			{"<z>", "zoom"},
+			{"<esc>", "back to list"},
+			{"<q>", "quit"},
+		}
+	case BulkDownloadView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<↑/↓>", "scroll"},
+			{"<:download-all>", "show progress again"},
+			{"<esc>", "back to list (downloads continue)"},
+			{"<q>", "quit"},
+		}
+	case EnvEditView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<ctrl+s>", "save"},
+			{"<esc>", "cancel"},
+		}
+	case ComplianceView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<↑/↓>", "scroll"},
+			{"<esc>", "back to list"},
+			{"<q>", "quit"},
+		}
+	case TelemetryView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<:telemetry on>", "opt in"},
+			{"<:telemetry off>", "opt out"},
+			{"<esc>", "back to list"},
+			{"<q>", "quit"},
+		}
+	case HTTPView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<↑/↓>", "scroll"},
+			{"<r>", "send again"},
+			{"<C>", "edit request"},
+			{"<esc>", "back to list"},
+			{"<q>", "quit"},
+		}
+	case InvokeView:
+		if m.invoke.picker != nil {
+			shortcuts = []struct {
+				key   string
+				value string
+			}{
+				{"<enter>", "load file"},
+				{"<←/→>", "parent/open directory"},
+				{"<esc>", "cancel"},
+			}
+		} else if m.invoke.result == nil {
+			shortcuts = []struct {
+				key   string
+				value string
+			}{
+				{"<ctrl+s>", "send"},
+				{"<ctrl+o>", "load a file"},
+				{"<esc>", "cancel"},
+			}
+		} else {
+			shortcuts = []struct {
+				key   string
+				value string
+			}{
+				{"<↑/↓>", "scroll"},
+				{"<r>", "invoke again"},
+				{"<e>", "edit payload"},
+				{"<esc>", "back to list"},
+				{"<q>", "quit"},
+			}
+		}
+	case PowerTuneView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<↑/↓>", "scroll"},
+			{"<s>", "stop"},
+			{"<r>", "run again"},
+			{"<esc>", "back to list (tuning continues)"},
+			{"<q>", "quit"},
+		}
+	case DeploysView, RightSizingView, ReportView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<↑/↓>", "scroll"},
+			{"<r>", "refresh"},
+			{"<esc>", "back to list"},
+			{"<q>", "quit"},
+		}
+	case AuditView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<↑/↓>", "scroll"},
+			{"<:audit>", "reload"},
+			{"<esc>", "back to list"},
+			{"<q>", "quit"},
+		}
+	case CompareView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<↑/↓>", "scroll"},
+			{"<h>", "hide identical fields"},
+			{"<s>", "swap sides"},
+			{"<v>", "reveal env value"},
+			{"<esc>", "back to list"},
+			{"<q>", "quit"},
+		}
+	case SecurityView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<↑/↓>", "scroll"},
+			{"<esc>", "back to list"},
+			{"<q>", "quit"},
+		}
+	case DepsView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<↑/↓>", "scroll"},
+			{"<c>", "check vulnerabilities (OSV)"},
+			{"<esc>", "back to list"},
+			{"<q>", "quit"},
+		}
+	case ExportView:
+		shortcuts = []struct {
+			key   string
+			value string
+		}{
+			{"<↑/↓>", "scroll"},
+			{"<:terraform/:export … path>", "save to a file"},
 			{"<esc>", "back to list"},
 			{"<q>", "quit"},
 		}
@@ -282,15 +583,123 @@ func renderShortcuts(m Model) string {
 	var lines []string
 	for _, s := range shortcuts {
 		// Pink for key, grey for value
-		line := styles.CommandKeyStyle.Render(s.key) + ": " + styles.CommandValueStyle.Render(s.value)
+		line := styles.CommandKeyStyle.Render(s.key) + ": " + styles.CommandValueStyle.Render(i18n.T(s.value))
+		if m.shortcutDenied(s.key) {
+			// Greyed out, the status line names the missing permissions when pressed
+			line = styles.HelpStyle.Render(s.key + ": " + i18n.T(s.value) + " 🔒")
+		}
 		lines = append(lines, line)
 	}
 
 	return strings.Join(lines, "\n")
 }
 
-// formatFunctionDetails formats detailed function information for display
-func formatFunctionDetails(fn *provider.FunctionInfo) string {
+// formatStateCell renders the State column, marking unfinished or failed updates
+func formatStateCell(fn provider.FunctionInfo) string {
+	state := fn.State
+	if state == "" {
+		state = "-"
+	}
+	switch strings.ToUpper(fn.LastUpdateStatus) {
+	case "INPROGRESS":
+		state += " ⟳"
+	case "FAILED":
+		state += " ✗"
+	}
+	return state
+}
+
+// formatModifiedCell renders the Last Modified column as the time since the
+// function was modified, or as reported when the provider's format is unknown
+func formatModifiedCell(fn provider.FunctionInfo) string {
+	if fn.Modified.IsZero() {
+		return fn.LastModified
+	}
+	return formatAge(time.Since(fn.Modified))
+}
+
+// formatAge renders how long ago something happened, in the largest whole unit
+func formatAge(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d < time.Minute:
+		return i18n.T("just now")
+	case d < time.Hour:
+		return i18n.Tf("%d min ago", int(d/time.Minute))
+	case d < day:
+		return i18n.Tf("%d h ago", int(d/time.Hour))
+	case d < 2*day:
+		return i18n.T("yesterday")
+	case d < 30*day:
+		return i18n.Tf("%d days ago", int(d/day))
+	case d < 60*day:
+		return i18n.T("last month")
+	case d < 365*day:
+		return i18n.Tf("%d months ago", int(d/(30*day)))
+	case d < 2*365*day:
+		return i18n.T("last year")
+	default:
+		return i18n.Tf("%d years ago", int(d/(365*day)))
+	}
+}
+
+// formatEncryption names the key encrypting the function's environment
+// variables: a customer-managed KMS key or CMEK, or the provider's default
+// key. It is empty for providers that do not encrypt them, OpenFaaS and
+// Knative.
+func formatEncryption(fn *provider.FunctionInfo, cloud provider.CloudProvider) string {
+	switch cloud {
+	case provider.AWS:
+		if fn.KMSKeyARN != "" {
+			return "Customer managed KMS key " + fn.KMSKeyARN
+		}
+		return "AWS managed key (aws/lambda)"
+	case provider.GCP:
+		if fn.KMSKeyARN != "" {
+			return "Customer-managed key (CMEK) " + fn.KMSKeyARN
+		}
+		return "Google-managed key"
+	default:
+		return ""
+	}
+}
+
+// tagsHeading names the tags of the function as its provider does: tags on
+// AWS, labels elsewhere
+func tagsHeading(fn *provider.FunctionInfo) string {
+	if strings.HasPrefix(fn.ARN, "arn:") {
+		return "Tags"
+	}
+	return "Labels"
+}
+
+// formatScaling describes a Knative revision's concurrency limit, the
+// autoscaler's target and the scale bounds, e.g. "limit 10 per replica,
+// target 70 concurrency, scale 1-5"
+func formatScaling(scaling *provider.ScalingConfig) string {
+	parts := []string{"unlimited per replica"}
+	if scaling.ContainerConcurrency > 0 {
+		parts[0] = fmt.Sprintf("limit %d per replica", scaling.ContainerConcurrency)
+	}
+	if scaling.Target != "" {
+		parts = append(parts, fmt.Sprintf("target %s %s", scaling.Target, cmp.Or(scaling.Metric, "concurrency")))
+	} else if scaling.Metric != "" {
+		parts = append(parts, "metric "+scaling.Metric)
+	}
+	if scaling.MinScale != "" || scaling.MaxScale != "" {
+		parts = append(parts, fmt.Sprintf("scale %s-%s", cmp.Or(scaling.MinScale, "0"), cmp.Or(scaling.MaxScale, "∞")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// longDescription is the length from which descriptions are rendered as
+// Markdown below their label rather than inline
+const longDescription = 80
+
+// formatFunctionDetails formats detailed function information for display.
+// Environment values are masked unless their variable is in revealed, and
+// long descriptions are rendered as Markdown.
+func formatFunctionDetails(fn *provider.FunctionInfo, cloud provider.CloudProvider, units unitFormat, revealed map[string]bool, markdown func(string) string) string {
 	if fn == nil {
 		return ""
 	}
@@ -316,19 +725,64 @@ func formatFunctionDetails(fn *provider.FunctionInfo) string {
 	}
 
 	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Memory: "))
-	b.WriteString(fmt.Sprintf("%d MB\n\n", fn.Memory))
+	b.WriteString(units.memory(float64(fn.Memory)) + "\n\n")
 
 	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Timeout: "))
-	b.WriteString(fmt.Sprintf("%d seconds\n\n", fn.Timeout))
+	b.WriteString(units.seconds(fn.Timeout) + "\n\n")
+
+	if fn.CodeSize > 0 {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Code Size: "))
+		b.WriteString(units.bytes(fn.CodeSize) + "\n\n")
+	}
 
 	if fn.Region != "" {
 		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Region/Location: "))
 		b.WriteString(fn.Region + "\n\n")
 	}
 
+	if fn.Profile != "" {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Profile: "))
+		b.WriteString(fn.Profile + "\n\n")
+	}
+
 	if fn.Description != "" {
 		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Description: "))
-		b.WriteString(fn.Description + "\n\n")
+		if len(fn.Description) >= longDescription || strings.Contains(fn.Description, "\n") {
+			b.WriteString("\n" + markdown(fn.Description) + "\n\n")
+		} else {
+			b.WriteString(fn.Description + "\n\n")
+		}
+	}
+
+	if fn.Replicas > 0 || fn.AvailableReplicas > 0 || fn.InvocationCount > 0 {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Replicas: "))
+		b.WriteString(fmt.Sprintf("%d/%d available\n\n", fn.AvailableReplicas, fn.Replicas))
+	}
+	if fn.InvocationCount > 0 {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Invocations: "))
+		b.WriteString(fmt.Sprintf("%d since deployed\n\n", fn.InvocationCount))
+	}
+
+	if fn.Scaling != nil {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Concurrency: "))
+		b.WriteString(formatScaling(fn.Scaling) + "\n")
+		if fn.Scaling.Class != "" {
+			b.WriteString(styles.HelpStyle.Render("  Autoscaler "+fn.Scaling.Class) + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if owner := insights.FunctionOwnership(*fn).String(); owner != "" {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Owner: "))
+		b.WriteString(owner + "\n\n")
+	}
+
+	if len(fn.Tags) > 0 {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render(tagsHeading(fn)+":") + "\n")
+		for _, k := range slices.Sorted(maps.Keys(fn.Tags)) {
+			b.WriteString(fmt.Sprintf("  %s = %s\n", k, fn.Tags[k]))
+		}
+		b.WriteString("\n")
 	}
 
 	if fn.Role != "" {
@@ -336,14 +790,62 @@ func formatFunctionDetails(fn *provider.FunctionInfo) string {
 		b.WriteString(fn.Role + "\n\n")
 	}
 
+	if encryption := formatEncryption(fn, cloud); encryption != "" {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Encryption: "))
+		b.WriteString(encryption + "\n\n")
+	}
+
+	if fn.Logging != nil {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Logging: "))
+		b.WriteString(formatLogging(fn.Logging) + "\n")
+		if fn.Logging.LogGroup != "" {
+			b.WriteString(styles.HelpStyle.Render("  Log group "+fn.Logging.LogGroup) + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if fn.State != "" {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("State: "))
+		b.WriteString(fn.State + "\n")
+		if fn.StateReason != "" {
+			b.WriteString(styles.HelpStyle.Render("  "+fn.StateReason) + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if fn.LastUpdateStatus != "" {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Last Update Status: "))
+		b.WriteString(fn.LastUpdateStatus + "\n")
+		if fn.LastUpdateStatusReason != "" {
+			b.WriteString(styles.HelpStyle.Render("  "+fn.LastUpdateStatusReason) + "\n")
+		}
+		b.WriteString("\n")
+	}
+
 	if fn.LastModified != "" {
 		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Last Modified: "))
-		b.WriteString(fn.LastModified + "\n\n")
+		b.WriteString(fn.LastModified)
+		if !fn.Modified.IsZero() {
+			b.WriteString(styles.HelpStyle.Render(" (" + formatAge(time.Since(fn.Modified)) + ")"))
+		}
+		b.WriteString("\n\n")
 	}
 
 	if len(fn.Environment) > 0 {
-		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Environment Variables:\n"))
-		for k, v := range fn.Environment {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Environment Variables:") +
+			styles.HelpStyle.Render(" (values hidden, v reveals one)") + "\n")
+		for _, k := range sortedEnvKeys(fn.Environment) {
+			value := maskedEnvValue
+			if revealed[k] {
+				value = fn.Environment[k]
+			}
+			b.WriteString(fmt.Sprintf("  %s: %s\n", k, value))
+		}
+	}
+
+	if len(fn.SecretEnvironment) > 0 {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Secret Environment Variables:\n"))
+		for k, v := range fn.SecretEnvironment {
 			b.WriteString(fmt.Sprintf("  %s: %s\n", k, v))
 		}
 	}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type failedEventsLoadedMsg struct {
+	functionName string
+	events       []provider.FailedEvent
+	err          error
+}
+
+type failedEventReplayedMsg struct {
+	functionName string
+	event        provider.FailedEvent
+	err          error
+}
+
+// loadFailedEvents fetches the messages waiting in the selected function's failure queues
+func (m Model) loadFailedEvents() (tea.Model, tea.Cmd) {
+	replayer, ok := provider.As[provider.FailedEventReplayer](m.provider)
+	if !ok {
+		m.statusMsg = fmt.Sprintf("Failed-event replay is not supported for %s", strings.ToUpper(string(m.provider.GetProviderName())))
+		return m, nil
+	}
+	if !m.permitted(m.selectedFunc, provider.ActionReplayEvents) {
+		return m, nil
+	}
+
+	name := m.selectedFunc.Name
+	m.statusMsg = fmt.Sprintf("Reading failed events for %s...", name)
+	return m, func() tea.Msg {
+		events, err := replayer.ListFailedEvents(context.Background(), name)
+		if err != nil {
+			logger.Logger.Printf("Error listing failed events for %s: %v", name, err)
+		}
+		return failedEventsLoadedMsg{functionName: name, events: events, err: err}
+	}
+}
+
+// openFailedEventPicker lets the user choose a failed event to replay
+func (m Model) openFailedEventPicker(msg failedEventsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Failed to read failed events: %v", msg.err)
+		return m, nil
+	}
+	if len(msg.events) == 0 {
+		m.statusMsg = fmt.Sprintf("No failed events waiting for %s", msg.functionName)
+		return m, nil
+	}
+
+	replayer, _ := provider.As[provider.FailedEventReplayer](m.provider)
+	items := make([]pickerItem, 0, len(msg.events))
+	for _, event := range msg.events {
+		payload := strings.Join(strings.Fields(event.Payload), " ")
+		if len(payload) > 80 {
+			payload = payload[:79] + "…"
+		}
+		items = append(items, pickerItem{
+			label:  fmt.Sprintf("%s  %s", event.SentAt.Format("2006-01-02 15:04:05"), payload),
+			detail: event.Source[strings.LastIndex(event.Source, ":")+1:],
+		})
+	}
+
+	events := msg.events
+	fn := msg.functionName
+	m.openPicker(fmt.Sprintf("Replay a failed event for %s", fn), items, func(m Model, idx int) (tea.Model, tea.Cmd) {
+		event := events[idx]
+		m.statusMsg = fmt.Sprintf("Replaying event %s...", event.ID)
+		return m, func() tea.Msg {
+			err := replayer.ReplayFailedEvent(context.Background(), fn, event)
+			if err != nil {
+				logger.Logger.Printf("Error replaying event %s for %s: %v", event.ID, fn, err)
+			}
+			return failedEventReplayedMsg{functionName: fn, event: event, err: err}
+		}
+	})
+	return m, nil
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/logger"
+	"f6n/internal/report"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reportPreview holds the ReportView state
+type reportPreview struct {
+	window   time.Duration
+	markdown string
+	err      error
+	loading  bool
+}
+
+type reportBuiltMsg struct {
+	markdown string
+	err      error
+}
+
+// executeReportCommand handles ":report [window]", previewing the inventory
+// report over a window such as 24h, by default that of the -window flag
+func (m Model) executeReportCommand(args []string) (tea.Model, tea.Cmd) {
+	window := 7 * 24 * time.Hour
+	if m.cfg != nil && m.cfg.ReportWindow > 0 {
+		window = m.cfg.ReportWindow
+	}
+	if len(args) > 0 {
+		d, err := time.ParseDuration(args[0])
+		if err != nil || d <= 0 {
+			m.statusMsg = fmt.Sprintf("Invalid report window %q, e.g. 24h or 168h", args[0])
+			return m, nil
+		}
+		window = d
+	}
+	return m.openReportView(window)
+}
+
+// openReportView switches to ReportView and builds the report
+func (m Model) openReportView(window time.Duration) (tea.Model, tea.Cmd) {
+	m.currentView = ReportView
+	m.report = reportPreview{window: window, loading: true}
+	m.refreshReportView()
+	m.viewport.GotoTop()
+
+	prov := m.provider
+	return m, func() tea.Msg {
+		r, err := report.Build(context.Background(), prov, window)
+		if err != nil {
+			logger.Logger.Printf("Error building the report: %v", err)
+			return reportBuiltMsg{err: err}
+		}
+		var b strings.Builder
+		if err := report.Render(&b, r, "markdown"); err != nil {
+			return reportBuiltMsg{err: err}
+		}
+		return reportBuiltMsg{markdown: b.String()}
+	}
+}
+
+// handleReportBuilt shows the built report
+func (m Model) handleReportBuilt(msg reportBuiltMsg) (tea.Model, tea.Cmd) {
+	m.usage.Error("report", msg.err)
+	m.report.markdown, m.report.err, m.report.loading = msg.markdown, msg.err, false
+	if m.currentView == ReportView {
+		m.refreshReportView()
+	}
+	return m, nil
+}
+
+// handleReportKey handles the ReportView keys
+func (m Model) handleReportKey(key string) (tea.Model, tea.Cmd, bool) {
+	if key != "r" {
+		return m, nil, false
+	}
+	model, cmd := m.openReportView(m.report.window)
+	return model, cmd, true
+}
+
+// refreshReportView renders the report into the viewport
+func (m *Model) refreshReportView() {
+	r := m.report
+	switch {
+	case r.err != nil:
+		m.viewport.SetContent(styles.ErrorStyle.Render(fmt.Sprintf("❌ Failed to build the report: %v", r.err)))
+	case r.loading:
+		m.viewport.SetContent(fmt.Sprintf("Building the report over the last %s...", formatWindow(r.window)))
+	default:
+		m.viewport.SetContent(m.markdown(r.markdown))
+	}
+}
@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logStorageUSDPerGBMonth is the price of storing logs in CloudWatch Logs in
+// us-east-1
+const logStorageUSDPerGBMonth = 0.03
+
+type logRetentionLoadedMsg struct {
+	functionName string
+	retention    *provider.LogRetention
+	err          error
+}
+
+type logRetentionSetMsg struct {
+	functionName string
+	days         int32
+	err          error
+}
+
+// fetchLogRetention loads the retention of a function's logs, if supported
+func (m Model) fetchLogRetention(fn provider.FunctionInfo) tea.Cmd {
+	manager, ok := provider.As[provider.LogRetentionManager](m.provider)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		retention, err := manager.GetLogRetention(context.Background(), fn)
+		if err != nil {
+			logger.Logger.Printf("Error reading log retention of %s: %v", fn.Name, err)
+		}
+		return logRetentionLoadedMsg{functionName: fn.Name, retention: retention, err: err}
+	}
+}
+
+// renderLogRetention renders how long the function's logs are kept and the
+// space they take
+func (d detailModel) renderLogRetention(units unitFormat) string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Log Retention:") + "\n")
+
+	switch {
+	case d.retentionErr != nil:
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  Error loading log retention: %v", d.retentionErr)) + "\n")
+		return b.String()
+	case d.retention == nil:
+		b.WriteString(styles.HelpStyle.Render("  Loading...") + "\n")
+		return b.String()
+	case d.retention.Missing:
+		b.WriteString(styles.HelpStyle.Render(fmt.Sprintf("  No log group %s yet: the function has not logged anything", d.retention.LogGroup)) + "\n")
+		return b.String()
+	}
+
+	r := d.retention
+	if r.Days == 0 {
+		b.WriteString("  " + styles.ErrorStyle.Render("⚠ Never expire") + styles.HelpStyle.Render(" - logs are kept and billed forever") + "\n")
+	} else {
+		b.WriteString("  " + formatRetention(r.Days) + "\n")
+	}
+	gb := float64(r.StoredBytes) / (1 << 30)
+	b.WriteString(fmt.Sprintf("  Stored: %s (~$%.2f/month) in %s\n", units.bytes(r.StoredBytes), gb*logStorageUSDPerGBMonth, r.LogGroup))
+	return b.String()
+}
+
+// formatRetention describes a retention period, e.g. "30 days"
+func formatRetention(days int32) string {
+	switch days {
+	case 0:
+		return "Never expire"
+	case 1:
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", days)
+}
+
+// openRetentionPicker handles "R" in DetailView, offering the retention
+// periods the function's logs can be kept for
+func (m Model) openRetentionPicker() (tea.Model, tea.Cmd) {
+	manager, ok := provider.As[provider.LogRetentionManager](m.provider)
+	switch {
+	case !ok:
+		m.statusMsg = fmt.Sprintf("Changing log retention is not supported for %s", strings.ToUpper(string(m.provider.GetProviderName())))
+		return m, nil
+	case m.selectedFunc == nil || !m.permitted(m.selectedFunc, provider.ActionSetLogRetention):
+		return m, nil
+	case m.detail.retention == nil:
+		m.statusMsg = "The log retention is not loaded"
+		return m, nil
+	case m.detail.retention.Missing:
+		m.statusMsg = "The function has no log group yet"
+		return m, nil
+	}
+
+	current := m.detail.retention
+	choices := append([]int32{0}, current.Choices...)
+	items := make([]pickerItem, 0, len(choices))
+	for _, days := range choices {
+		item := pickerItem{label: formatRetention(days)}
+		if days == current.Days {
+			item.detail = "current"
+		}
+		items = append(items, item)
+	}
+
+	fn := *m.selectedFunc
+	m.openPicker("Keep the logs of "+fn.Name+" for", items, func(m Model, idx int) (tea.Model, tea.Cmd) {
+		days := choices[idx]
+		if days == current.Days {
+			m.statusMsg = "No changes to save"
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Setting the log retention of %s to %s...", fn.Name, formatRetention(days))
+		return m, func() tea.Msg {
+			err := manager.SetLogRetention(context.Background(), fn, days)
+			if err != nil {
+				logger.Logger.Printf("Error setting log retention of %s: %v", fn.Name, err)
+			}
+			return logRetentionSetMsg{functionName: fn.Name, days: days, err: err}
+		}
+	})
+	return m, nil
+}
+
+// handleLogRetentionSet records a retention change and shows it in the details
+func (m Model) handleLogRetentionSet(msg logRetentionSetMsg) (tea.Model, tea.Cmd) {
+	m.recordAction("set-log-retention", msg.functionName, formatRetention(msg.days), msg.err)
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Failed to set the log retention of %s: %v", msg.functionName, msg.err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("✅ Logs of %s are now kept for %s", msg.functionName, formatRetention(msg.days))
+	if msg.days == 0 {
+		m.statusMsg = fmt.Sprintf("✅ Logs of %s now never expire", msg.functionName)
+	}
+	if m.selectedFunc != nil && m.selectedFunc.Name == msg.functionName && m.detail.retention != nil {
+		retention := *m.detail.retention
+		retention.Days = msg.days
+		m.detail.retention = &retention
+		if m.currentView == DetailView {
+			m.refreshDetailView()
+		}
+	}
+	return m, nil
+}
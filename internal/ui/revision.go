@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"f6n/internal/logger"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// revisionCheckedMsg carries the current revision of a function whose edit
+// is about to be saved
+type revisionCheckedMsg struct {
+	kind     draftKind
+	function string
+	revision string
+	err      error
+}
+
+// editRevision returns the revision of the function the edit started from
+func (m Model) editRevision(kind draftKind) string {
+	if kind == envDraft {
+		return m.envRevision
+	}
+	return m.code.revision
+}
+
+// editing reports whether the edit of kind is still open
+func (m Model) editing(kind draftKind) bool {
+	if kind == envDraft {
+		return m.currentView == EnvEditView
+	}
+	return m.currentView == CodeView && m.code.editMode
+}
+
+// checkRevision fetches the selected function's current revision before the
+// edit is saved, so that changes made remotely meanwhile aren't overwritten
+// silently
+func (m Model) checkRevision(kind draftKind) (tea.Model, tea.Cmd) {
+	prov := m.provider
+	name := m.selectedFunc.Name
+	m.statusMsg = fmt.Sprintf("Checking %s for remote changes...", name)
+	return m, func() tea.Msg {
+		fn, err := prov.GetFunction(context.Background(), name)
+		if err != nil {
+			logger.Logger.Printf("Error checking the revision of %s: %v", name, err)
+			return revisionCheckedMsg{kind: kind, function: name, err: err}
+		}
+		return revisionCheckedMsg{kind: kind, function: name, revision: fn.Revision}
+	}
+}
+
+// handleRevisionChecked saves the edit when the function is unchanged, and
+// otherwise asks whether to overwrite the remote changes. A revision the
+// provider doesn't report can't be compared and is taken as unchanged.
+func (m Model) handleRevisionChecked(msg revisionCheckedMsg) (tea.Model, tea.Cmd) {
+	if m.selectedFunc == nil || m.selectedFunc.Name != msg.function || !m.editing(msg.kind) {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Failed to check %s for remote changes: %v", msg.function, msg.err)
+		return m, nil
+	}
+
+	started := m.editRevision(msg.kind)
+	if started == "" || msg.revision == "" || started == msg.revision {
+		return m.saveEdit(msg.kind)
+	}
+
+	logger.Logger.Printf("%s changed remotely during the %s edit: revision %s, now %s", msg.function, msg.kind, started, msg.revision)
+	items := []pickerItem{
+		{label: "Keep editing", detail: "save later, e.g. after reviewing the remote changes"},
+		{label: "Overwrite the remote changes", detail: "save the edit as it is"},
+	}
+	title := fmt.Sprintf("⚠️  %s changed remotely since the %s edit started", msg.function, msg.kind)
+	m.openPicker(title, items, func(m Model, idx int) (tea.Model, tea.Cmd) {
+		if idx == 0 {
+			m.statusMsg = "Edit not saved; the function changed remotely"
+			return m, nil
+		}
+		if msg.kind == envDraft {
+			m.envRevision = msg.revision
+		} else {
+			m.code.revision = msg.revision
+		}
+		return m.saveEdit(msg.kind)
+	})
+	return m, nil
+}
+
+// saveEdit saves the edit of kind. Code is only deployed once confirmed.
+func (m Model) saveEdit(kind draftKind) (tea.Model, tea.Cmd) {
+	if kind == envDraft {
+		return m.pushEnvironment()
+	}
+	return m.confirmCodeDeploy()
+}
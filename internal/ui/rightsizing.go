@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/insights"
+	"f6n/internal/logger"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// rightSizingWindow is the period invocation volume and memory usage are
+// read over for right-sizing recommendations
+const rightSizingWindow = 7 * 24 * time.Hour
+
+// rightSizing holds the RightSizingView state
+type rightSizing struct {
+	results []insights.RightSizing
+	err     error
+	loading bool
+}
+
+type rightSizingLoadedMsg struct {
+	results []insights.RightSizing
+	err     error
+}
+
+// openRightSizing handles ":rightsize", switching to RightSizingView and
+// loading the recommendations
+func (m Model) openRightSizing() (tea.Model, tea.Cmd) {
+	m.currentView = RightSizingView
+	m.rightSizing = rightSizing{loading: true}
+	m.refreshRightSizingView()
+
+	functions := m.list.allFunctions
+	prov := m.provider
+	return m, func() tea.Msg {
+		ctx := context.Background()
+		stats, err := insights.CollectStats(ctx, prov, functions, time.Now().Add(-rightSizingWindow))
+		if err != nil {
+			logger.Logger.Printf("Error fetching right-sizing stats: %v", err)
+			return rightSizingLoadedMsg{err: err}
+		}
+		return rightSizingLoadedMsg{results: insights.CollectRightSizing(ctx, prov, functions, stats, rightSizingWindow)}
+	}
+}
+
+// handleRightSizingLoaded shows the recommendations
+func (m Model) handleRightSizingLoaded(msg rightSizingLoadedMsg) (tea.Model, tea.Cmd) {
+	m.usage.Error("right-sizing", msg.err)
+	m.rightSizing = rightSizing{results: msg.results, err: msg.err}
+	if m.currentView == RightSizingView {
+		m.refreshRightSizingView()
+	}
+	return m, nil
+}
+
+// handleRightSizingKey handles the RightSizingView keys
+func (m Model) handleRightSizingKey(key string) (tea.Model, tea.Cmd, bool) {
+	if key != "r" {
+		return m, nil, false
+	}
+	model, cmd := m.openRightSizing()
+	return model, cmd, true
+}
+
+// refreshRightSizingView renders the recommendations into the viewport, the
+// largest savings first
+func (m *Model) refreshRightSizingView() {
+	r := m.rightSizing
+	var b strings.Builder
+	b.WriteString(styles.SelectedStyle.Render(fmt.Sprintf("━━━ Memory right-sizing (last %s, projected per month) ━━━", formatWindow(rightSizingWindow))) + "\n\n")
+
+	switch {
+	case r.err != nil:
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("❌ Failed to load function stats: %v", r.err)))
+		m.viewport.SetContent(b.String())
+		return
+	case r.loading:
+		b.WriteString("Reading invocation stats and memory usage...")
+		m.viewport.SetContent(b.String())
+		return
+	case len(r.results) == 0:
+		b.WriteString("No recommendations: they need invoked functions with Lambda REPORT log lines in this window.")
+		m.viewport.SetContent(b.String())
+		return
+	}
+
+	b.WriteString(fmt.Sprintf("%-36s %8s %8s %8s %12s %10s %11s %10s %10s\n",
+		"Function", "Memory", "p95 used", "Max used", "Invocations", "Avg ms", "Recommended", "Cost $", "Savings $"))
+	total := 0.0
+	for _, row := range r.results {
+		memory := row.Memory
+		recommended := "-"
+		savings := fmt.Sprintf("%10.2f", row.MonthlySavingsUSD())
+		switch memory.Provisioning {
+		case insights.OverProvisioned:
+			recommended = fmt.Sprintf("%d MB", memory.SuggestedMB)
+			savings = styles.InfoValueStyle.Render(savings)
+			total += row.MonthlySavingsUSD()
+		case insights.UnderProvisioned:
+			recommended = fmt.Sprintf("%d MB ⚠", memory.SuggestedMB)
+			savings = styles.ErrorStyle.Render(savings)
+		}
+		b.WriteString(fmt.Sprintf("%-36s %8s %8s %8s %12.0f %10.0f %11s %10.2f %s\n",
+			fitCell(row.Function, 36), fmt.Sprintf("%d MB", memory.ConfiguredMB),
+			fmt.Sprintf("%.0f MB", memory.P95UsedMB), fmt.Sprintf("%.0f MB", memory.MaxUsedMB),
+			row.MonthlyInvocations, row.AvgDurationMs, recommended, row.MonthlyCostUSD, savings))
+	}
+
+	b.WriteString(fmt.Sprintf("\n💰 Projected savings: $%.2f per month\n", total))
+	b.WriteString(styles.HelpStyle.Render("Savings assume durations stay the same: memory also sets the CPU share, so check CPU-bound functions after lowering it.\n" +
+		"⚠ marks functions whose peak usage nears their memory, to raise. Costs are on-demand estimates at us-east-1 x86 prices."))
+	m.viewport.SetContent(b.String())
+}
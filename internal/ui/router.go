@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"f6n/internal/insights"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The main views each have a sub-model holding their state, with a View
+// rendering its content and an Update of the same shape for all:
+//
+//	Update(ctx viewContext, msg tea.Msg) (T, tea.Cmd)
+//
+// handling the messages that concern the view and its keys:
+//
+//	ListView      listModel    (listview.go)
+//	DetailView    detailModel  (detail.go)
+//	LogsView      logsModel    (logview.go)
+//	MetricsView   metricsModel (metricsview.go)
+//	CodeView      codeModel    (codeview.go), with CodeDisplayView
+//
+// Model is the router. It owns what the views share (the selected function,
+// the viewport, the input modes) and hands each message to the sub-model it
+// belongs to. A key goes to the current view's sub-model as a viewKeyMsg
+// before the global keys; the sub-model asks the router with route for what
+// its own state doesn't cover, such as opening another view or a picker. The
+// views without a sub-model have their key handlers in viewKeys.
+
+// viewContext is the shared state a sub-model may read while handling a
+// message or rendering
+type viewContext struct {
+	provider provider.Provider
+	bus      *Bus
+	fn       *provider.FunctionInfo // Selected function, nil until one is opened
+	view     ViewType               // Current view
+	width    int
+	units    unitFormat
+	markdown func(string) string // Renders Markdown to fit the viewport
+	// Assessments combining the list stats and alarms with the view data
+	health      func(provider.FunctionInfo) insights.Health
+	timeoutRisk func(provider.FunctionInfo) (insights.TimeoutRisk, bool)
+}
+
+// viewContext returns the shared state for the sub-models
+func (m Model) viewContext() viewContext {
+	return viewContext{
+		provider:    m.provider,
+		bus:         m.bus,
+		fn:          m.selectedFunc,
+		view:        m.currentView,
+		width:       m.width,
+		units:       m.units(),
+		markdown:    m.markdown,
+		health:      m.functionHealth,
+		timeoutRisk: m.timeoutRisk,
+	}
+}
+
+// viewKeyMsg is a key pressed in a view, sent to its sub-model. The
+// sub-model returns a command for the keys it uses and nil for the others,
+// which go on to the global keys.
+type viewKeyMsg string
+
+// routeMsg runs an action of the router on behalf of a sub-model
+type routeMsg func(m Model) (tea.Model, tea.Cmd)
+
+// route asks the router to run action, e.g. route(Model.openSecurityView)
+func route(action func(Model) (tea.Model, tea.Cmd)) tea.Cmd {
+	return func() tea.Msg { return routeMsg(action) }
+}
+
+// routeFunction asks the router to select fn, then run open
+func routeFunction(fn *provider.FunctionInfo, open func(Model) (tea.Model, tea.Cmd)) tea.Cmd {
+	return route(func(m Model) (tea.Model, tea.Cmd) {
+		m.selectedFunc = fn
+		return open(m)
+	})
+}
+
+// viewKeyHandler handles a key pressed in a view, reporting whether it used it
+type viewKeyHandler func(m Model, key string) (tea.Model, tea.Cmd, bool)
+
+// viewKeys are the key handlers of the views with keys of their own but no
+// sub-model
+var viewKeys = map[ViewType]viewKeyHandler{
+	ErrorsView:      Model.handleErrorsKey,
+	RankingView:     Model.handleRankingKey,
+	DepsView:        Model.handleDepsKey,
+	CompareView:     Model.handleCompareKey,
+	HTTPView:        Model.handleHTTPKey,
+	DeploysView:     Model.handleDeploysKey,
+	RightSizingView: Model.handleRightSizingKey,
+	ReportView:      Model.handleReportKey,
+	PowerTuneView:   Model.handlePowerTuneKey,
+	InvokeView:      Model.handleInvokeKey,
+}
+
+// boundKeys are the keys bound in some view. Other views ignore them rather
+// than passing them on to the table or viewport, where several would scroll.
+var boundKeys = map[string]bool{
+	"\\": true, "enter": true, "a": true, "c": true, "C": true, "d": true, "D": true, "e": true, "E": true,
+	"f": true, "F": true, "h": true, "H": true, "i": true, "l": true, "m": true, "o": true,
+	"r": true, "R": true, "s": true, "S": true, "t": true, "T": true, "v": true, "w": true,
+	"x": true, "y": true,
+}
+
+// handleViewKey hands a key to the current view's sub-model, or to its
+// handler in viewKeys
+func (m Model) handleViewKey(key string) (tea.Model, tea.Cmd, bool) {
+	ctx, msg := m.viewContext(), viewKeyMsg(key)
+	var cmd tea.Cmd
+	switch m.currentView {
+	case ListView:
+		m.list, cmd = m.list.Update(ctx, msg)
+	case DetailView:
+		m.detail, cmd = m.detail.Update(ctx, msg)
+	case LogsView:
+		m.logs, cmd = m.logs.Update(ctx, msg)
+	case MetricsView:
+		m.metrics, cmd = m.metrics.Update(ctx, msg)
+	case CodeView, CodeDisplayView:
+		m.code, cmd = m.code.Update(ctx, msg)
+	default:
+		handler, ok := viewKeys[m.currentView]
+		if !ok {
+			return m, nil, false
+		}
+		return handler(m, key)
+	}
+	return m, cmd, cmd != nil
+}
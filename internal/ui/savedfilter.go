@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"f6n/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// executeFilterCommand handles ":filter [name]", ":filter save <name>
+// [expression]" and ":filter delete <name>". Without an expression, save
+// keeps the filter applied to the list.
+func (m Model) executeFilterCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) == 0 {
+		return m.openFilterPicker()
+	}
+
+	switch args[0] {
+	case "save":
+		if len(args) < 2 {
+			m.statusMsg = "usage: :filter save <name> [expression], e.g. :filter save prod-python runtime:python memory>512"
+			return m, nil
+		}
+		expr := strings.Join(args[2:], " ")
+		if expr == "" {
+			expr = m.list.activeFilter
+		}
+		if expr == "" {
+			m.statusMsg = "No filter to save: apply one with \\ first, or give the expression"
+			return m, nil
+		}
+		m.cfg.File.SetFilter(config.SavedFilter{Name: args[1], Expression: expr})
+		if err := m.cfg.Save(); err != nil {
+			m.statusMsg = fmt.Sprintf("Failed to save filter: %v", err)
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Saved filter %q (%s)", args[1], expr)
+		return m, nil
+	case "delete":
+		if len(args) != 2 {
+			m.statusMsg = "usage: :filter delete <name>"
+			return m, nil
+		}
+		if !m.cfg.File.DeleteFilter(args[1]) {
+			m.statusMsg = fmt.Sprintf("No saved filter named %q", args[1])
+			return m, nil
+		}
+		if err := m.cfg.Save(); err != nil {
+			m.statusMsg = fmt.Sprintf("Failed to delete filter: %v", err)
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Deleted filter %q", args[1])
+		return m, nil
+	}
+
+	filter, ok := m.cfg.File.FindFilter(args[0])
+	if !ok {
+		m.statusMsg = fmt.Sprintf("No saved filter named %q", args[0])
+		return m, nil
+	}
+	return m.applySavedFilter(&filter)
+}
+
+// openFilterPicker handles "f" in ListView, listing the saved filters
+func (m Model) openFilterPicker() (tea.Model, tea.Cmd) {
+	if len(m.cfg.File.Filters) == 0 {
+		m.statusMsg = "No saved filters: save the current one with :filter save <name>"
+		return m, nil
+	}
+	items := []pickerItem{{label: "(no filter)", detail: "all functions"}}
+	for _, f := range m.cfg.File.Filters {
+		items = append(items, pickerItem{label: f.Name, detail: f.Expression})
+	}
+
+	m.openPicker("Saved filters", items, func(m Model, idx int) (tea.Model, tea.Cmd) {
+		if idx == 0 {
+			return m.applySavedFilter(nil)
+		}
+		filter := m.cfg.File.Filters[idx-1]
+		return m.applySavedFilter(&filter)
+	})
+	return m, nil
+}
+
+// applySavedFilter filters the function list with a saved filter, or clears
+// the filter
+func (m Model) applySavedFilter(filter *config.SavedFilter) (tea.Model, tea.Cmd) {
+	if filter == nil {
+		m.list.filterActive, m.list.activeFilter = false, ""
+		m.applyFunctionFilter("")
+		m.statusMsg = "Filter cleared"
+		return m, nil
+	}
+	m.list.filterActive, m.list.activeFilter = true, filter.Expression
+	m.applyFunctionFilter(filter.Expression)
+	m.statusMsg = fmt.Sprintf("Filter %q applied: %d of %d functions", filter.Name, len(m.list.functions), len(m.list.allFunctions))
+	return m, nil
+}
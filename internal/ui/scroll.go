@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"f6n/internal/i18n"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// scrollable reports whether the current view scrolls the viewport
+func (m Model) scrollable() bool {
+	switch m.currentView {
+	case ListView, EnvEditView:
+		return false
+	case CodeView:
+		return !m.code.editMode
+	case InvokeView:
+		return m.invoke.result != nil
+	default:
+		return true
+	}
+}
+
+// handleJumpKey jumps to the top of the viewport with Home or gg and to the
+// bottom with End or G
+func (m Model) handleJumpKey(key string) (tea.Model, tea.Cmd, bool) {
+	pending := m.pendingG
+	m.pendingG = false
+	switch key {
+	case "home":
+		m.viewport.GotoTop()
+	case "end", "G":
+		m.viewport.GotoBottom()
+	case "g":
+		if !pending {
+			// Wait for the second g
+			m.pendingG = true
+			return m, nil, true
+		}
+		m.viewport.GotoTop()
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
+
+// positionIndicator renders "line X/Y (Z%)" for the top line of a viewport
+// whose content overflows, or "" when everything is visible
+func positionIndicator(vp viewport.Model) string {
+	total := vp.TotalLineCount()
+	if total <= vp.VisibleLineCount() {
+		return ""
+	}
+	return i18n.Tf("line %d/%d (%d%%)", vp.YOffset+1, total, int(vp.ScrollPercent()*100))
+}
@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/secrets"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// security holds the SecurityView state
+type security struct {
+	function     string
+	envFindings  []secrets.Finding
+	codeFindings []secrets.Finding
+	codeScanned  bool // false while scanning or when the code is not downloaded
+	downloaded   bool
+	codeErr      error
+	posture      *provider.PostureReport // nil while loading or when not supported
+	postureErr   error
+}
+
+type codeSecretsScannedMsg struct {
+	function string
+	findings []secrets.Finding
+	err      error
+}
+
+type postureAuditedMsg struct {
+	function string
+	report   *provider.PostureReport
+	err      error
+}
+
+// openSecurityView audits the current function's security posture and scans
+// its environment and downloaded code for secrets
+func (m Model) openSecurityView() (tea.Model, tea.Cmd) {
+	fn := m.currentFunction()
+	if fn == nil {
+		return m, nil
+	}
+	m.selectedFunc = fn
+	m.currentView = SecurityView
+
+	name := fn.Name
+	dir := filepath.Join("downloads", name)
+	_, err := os.Stat(dir)
+	m.security = security{
+		function:    name,
+		envFindings: secrets.ScanEnvironment(fn.Environment),
+		downloaded:  err == nil,
+	}
+	m.refreshSecurityView()
+
+	var cmds []tea.Cmd
+	if auditor, ok := provider.As[provider.PostureAuditor](m.provider); ok {
+		info := *fn
+		cmds = append(cmds, func() tea.Msg {
+			report, err := auditor.AuditPosture(context.Background(), info)
+			if err != nil {
+				logger.Logger.Printf("Error auditing security posture of %s: %v", name, err)
+			}
+			return postureAuditedMsg{function: name, report: report, err: err}
+		})
+	}
+	if m.security.downloaded {
+		cmds = append(cmds, func() tea.Msg {
+			findings, err := secrets.ScanDir(dir)
+			if err != nil {
+				logger.Logger.Printf("Error scanning code of %s for secrets: %v", name, err)
+			}
+			return codeSecretsScannedMsg{function: name, findings: findings, err: err}
+		})
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handlePostureAudited records the posture audit
+func (m Model) handlePostureAudited(msg postureAuditedMsg) (tea.Model, tea.Cmd) {
+	if msg.function != m.security.function {
+		return m, nil
+	}
+	m.security.posture, m.security.postureErr = msg.report, msg.err
+	if m.currentView == SecurityView {
+		m.refreshSecurityView()
+	}
+	return m, nil
+}
+
+// handleCodeSecretsScanned records the findings of the code scan
+func (m Model) handleCodeSecretsScanned(msg codeSecretsScannedMsg) (tea.Model, tea.Cmd) {
+	if msg.function != m.security.function {
+		return m, nil
+	}
+	m.security.codeFindings, m.security.codeErr = msg.findings, msg.err
+	m.security.codeScanned = true
+	if m.currentView == SecurityView {
+		m.refreshSecurityView()
+	}
+	return m, nil
+}
+
+// refreshSecurityView renders the findings by source and severity
+func (m *Model) refreshSecurityView() {
+	s := m.security
+	var b strings.Builder
+	b.WriteString(styles.SelectedStyle.Render(fmt.Sprintf("━━━ Security: %s ━━━", s.function)) + "\n\n")
+
+	if _, ok := provider.As[provider.PostureAuditor](m.provider); ok {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("Posture:") + "\n")
+		switch {
+		case s.postureErr != nil:
+			b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  Error auditing posture: %v", s.postureErr)) + "\n")
+		case s.posture == nil:
+			b.WriteString(styles.HelpStyle.Render("  Auditing access and encryption settings...") + "\n")
+		default:
+			renderPosture(&b, s.posture)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Secrets in environment variables:") + "\n")
+	renderFindings(&b, s.envFindings, "No secrets found")
+
+	b.WriteString("\n" + lipgloss.NewStyle().Bold(true).Render("Secrets in code:") + "\n")
+	switch {
+	case !s.downloaded:
+		b.WriteString(styles.HelpStyle.Render("  Code not downloaded. Press 'w' in the list to download it, then 'S' again") + "\n")
+	case !s.codeScanned:
+		b.WriteString(styles.HelpStyle.Render("  Scanning downloaded code...") + "\n")
+	case s.codeErr != nil:
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  Error scanning code: %v", s.codeErr)) + "\n")
+	default:
+		renderFindings(&b, s.codeFindings, "No secrets found")
+	}
+
+	b.WriteString("\n" + styles.HelpStyle.Render("Findings come from pattern and entropy heuristics; LOW findings are often false positives."))
+	m.viewport.SetContent(b.String())
+}
+
+// renderFindings writes one line per finding, most severe first
+func renderFindings(b *strings.Builder, findings []secrets.Finding, none string) {
+	if len(findings) == 0 {
+		b.WriteString(styles.HelpStyle.Render("  "+none) + "\n")
+		return
+	}
+	for _, f := range findings {
+		line := fmt.Sprintf("  %-8s %-32s %-40s %s", f.Severity, f.Rule, f.Location, f.Match)
+		switch f.Severity {
+		case secrets.SeverityCritical, secrets.SeverityHigh:
+			line = styles.ErrorStyle.Render(line)
+		case secrets.SeverityLow:
+			line = styles.HelpStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+}
+
+// postureSeverityRank orders posture findings, most severe first
+var postureSeverityRank = map[string]int{"CRITICAL": 0, "HIGH": 1, "MEDIUM": 2, "LOW": 3}
+
+// renderPosture writes the posture findings with their explanation and
+// remediation, followed by the checks that ran
+func renderPosture(b *strings.Builder, report *provider.PostureReport) {
+	findings := append([]provider.PostureFinding(nil), report.Findings...)
+	sort.SliceStable(findings, func(i, j int) bool {
+		return postureSeverityRank[findings[i].Severity] < postureSeverityRank[findings[j].Severity]
+	})
+	for _, f := range findings {
+		line := fmt.Sprintf("  %-8s %s", f.Severity, f.Title)
+		if f.Severity == "CRITICAL" || f.Severity == "HIGH" {
+			line = styles.ErrorStyle.Render(line)
+		} else {
+			line = lipgloss.NewStyle().Bold(true).Render(line)
+		}
+		b.WriteString(line + "\n")
+		b.WriteString("           " + f.Detail + "\n")
+		b.WriteString(styles.HelpStyle.Render("           Why: "+f.Explanation) + "\n")
+		b.WriteString(styles.InfoValueStyle.Render("           Fix: "+f.Remediation) + "\n")
+	}
+	if len(report.Findings) == 0 {
+		b.WriteString("  ✅ No issues found\n")
+	}
+	b.WriteString(styles.HelpStyle.Render("  Checked: "+strings.Join(report.Checks, ", ")) + "\n")
+}
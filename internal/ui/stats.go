@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"context"
+	"time"
+
+	"f6n/internal/insights"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/telemetry"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type functionStatsLoadedMsg struct {
+	stats map[string]provider.FunctionStats
+	err   error
+}
+
+// fetchFunctionStats loads the list stats in the background so the list can
+// flag timeout risks
+func (m Model) fetchFunctionStats(functions []provider.FunctionInfo) tea.Cmd {
+	if len(functions) == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		stats, err := insights.CollectStats(context.Background(), m.provider, functions, time.Now().Add(-timeoutLookback))
+		if err != nil {
+			logger.Logger.Printf("Error fetching function stats: %v", err)
+		} else {
+			telemetry.RecordFunctionStats(context.Background(), m.provider.GetProviderName(), stats)
+		}
+		return functionStatsLoadedMsg{stats: stats, err: err}
+	}
+}
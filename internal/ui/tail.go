@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxTailFunctions caps how many functions are streamed together, each
+// stream polling the provider on its own
+const maxTailFunctions = 8
+
+// tailSeparator sits between the function name and the lines of merged streams
+const tailSeparator = " │ "
+
+// tailLabel is the label merged stream entries carry their function name in
+const tailLabel = "f6n_function"
+
+// tailColors are the colors of the function names of merged streams, in the
+// order the functions are given. Red and yellow are left to severities.
+var tailColors = []string{"#00CED1", "#FF69B4", "#1E90FF", "#32CD32", "#FF8C00", "#BA55D3", "#7FFFD4", "#F0E68C"}
+
+// toggleTailMark handles "T" in ListView, marking the function under the
+// cursor to stream its logs with the other marked functions
+func (m Model) toggleTailMark() (tea.Model, tea.Cmd) {
+	fn := m.list.selected()
+	if fn == nil {
+		return m, nil
+	}
+	if i := slices.Index(m.list.tailMarks, fn.Name); i >= 0 {
+		m.list.tailMarks = slices.Delete(slices.Clone(m.list.tailMarks), i, i+1)
+	} else if len(m.list.tailMarks) >= maxTailFunctions {
+		m.statusMsg = fmt.Sprintf("At most %d functions can be streamed together", maxTailFunctions)
+		return m, nil
+	} else {
+		m.list.tailMarks = append(slices.Clone(m.list.tailMarks), fn.Name)
+	}
+	m.updateTable()
+	m.statusMsg = fmt.Sprintf("%d functions marked; :tail streams their logs together", len(m.list.tailMarks))
+	if len(m.list.tailMarks) == 0 {
+		m.statusMsg = "No functions marked"
+	}
+	return m, nil
+}
+
+// executeTailCommand handles ":tail [function...]", streaming the logs of the
+// functions given, or of those marked with "T", merged into LogsView
+func (m Model) executeTailCommand(args []string) (tea.Model, tea.Cmd) {
+	names := args
+	if len(names) == 0 {
+		names = m.list.tailMarks
+	}
+	if len(names) == 0 {
+		m.statusMsg = "Usage: :tail <function> [function...], or mark functions with T first"
+		return m, nil
+	}
+	if len(names) > maxTailFunctions {
+		m.statusMsg = fmt.Sprintf("At most %d functions can be streamed together", maxTailFunctions)
+		return m, nil
+	}
+
+	var first *provider.FunctionInfo
+	for _, name := range names {
+		fn, ok := m.findFunction(name)
+		if !ok {
+			m.statusMsg = fmt.Sprintf("Function %s not found", name)
+			return m, nil
+		}
+		if first == nil {
+			first = &fn
+		}
+	}
+	// The other LogsView keys act on the first function
+	m.selectedFunc = first
+	m.currentView = LogsView
+	m.list.tailMarks = nil
+	m.updateTable()
+	return m, m.startTail(names)
+}
+
+// startTail starts streaming the logs of several functions into one view
+func (m Model) startTail(names []string) tea.Cmd {
+	functions := slices.Clone(names)
+	return func() tea.Msg {
+		return logStreamStartedMsg{functions: functions}
+	}
+}
+
+// setTailing records the functions streamed, when there are several, and the
+// width their names are padded to
+func (l *logsModel) setTailing(functions []string) {
+	l.tailing, l.tailWidth = nil, 0
+	if len(functions) < 2 {
+		return
+	}
+	l.tailing = functions
+	for _, name := range functions {
+		l.tailWidth = max(l.tailWidth, len([]rune(name)))
+	}
+}
+
+// tagTailEntry labels an entry of merged streams with its function
+func (l logsModel) tagTailEntry(function string, entry provider.LogEntry) provider.LogEntry {
+	if len(l.tailing) < 2 {
+		return entry
+	}
+	entry.Labels = maps.Clone(entry.Labels)
+	if entry.Labels == nil {
+		entry.Labels = make(map[string]string)
+	}
+	entry.Labels[tailLabel] = function
+	return entry
+}
+
+// tailPrefix returns the function name put before a line of merged streams,
+// padded so that the lines align, or "" for other entries
+func (l logsModel) tailPrefix(entry provider.LogEntry) string {
+	name := entry.Labels[tailLabel]
+	if name == "" {
+		return ""
+	}
+	return fmt.Sprintf("%-*s%s", l.tailWidth, name, tailSeparator)
+}
+
+// colorTailPrefix splits the function name off a line of merged streams,
+// returning it in the function's color and the rest of the line
+func (l logsModel) colorTailPrefix(line string) (string, string) {
+	name, rest, ok := strings.Cut(line, tailSeparator)
+	if !ok {
+		return "", line
+	}
+	i := slices.Index(l.tailing, strings.TrimRight(name, " "))
+	if i < 0 {
+		return "", line
+	}
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color(tailColors[i%len(tailColors)])).Bold(true)
+	return style.Render(name) + tailSeparator, rest
+}
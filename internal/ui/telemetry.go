@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SendUsage posts the usage report when the program exits, if telemetry is
+// on and an endpoint is configured
+func (m Model) SendUsage(ctx context.Context) {
+	if err := m.usage.Send(ctx); err != nil {
+		logger.Logger.Printf("Error sending usage report: %v", err)
+	}
+}
+
+// executeTelemetryCommand handles ":telemetry [on|off]": on and off opt in or
+// out and save the choice to the config file, and all forms show the status
+func (m Model) executeTelemetryCommand(args []string) (tea.Model, tea.Cmd) {
+	if len(args) > 0 {
+		switch args[0] {
+		case "on", "off":
+			enabled := args[0] == "on"
+			m.cfg.File.Telemetry.Enabled = enabled
+			m.usage.SetEnabled(enabled)
+			if err := m.cfg.Save(); err != nil {
+				m.statusMsg = fmt.Sprintf("❌ Failed to save telemetry setting: %v", err)
+			} else {
+				m.statusMsg = fmt.Sprintf("✅ Telemetry turned %s in %s", args[0], m.cfg.ConfigPath)
+			}
+		default:
+			m.statusMsg = "Usage: :telemetry [on|off]"
+			return m, nil
+		}
+	}
+	m.currentView = TelemetryView
+	m.refreshTelemetryView()
+	return m, nil
+}
+
+// refreshTelemetryView shows whether usage is reported, where to, and the
+// report as it would be sent now
+func (m *Model) refreshTelemetryView() {
+	var b strings.Builder
+	b.WriteString(styles.SelectedStyle.Render("━━━ Telemetry ━━━") + "\n\n")
+
+	status := "off"
+	switch {
+	case m.usage.Enabled():
+		status = "on"
+	case m.cfg.File.Telemetry.Enabled && m.usage.DisabledBy() != "":
+		status = "off (" + m.usage.DisabledBy() + ")"
+	}
+	b.WriteString(styles.InfoLabelStyle.Render("Status:   ") + status + "\n")
+	endpoint := m.usage.Endpoint()
+	if endpoint == "" {
+		endpoint = "none configured, nothing is sent"
+	}
+	b.WriteString(styles.InfoLabelStyle.Render("Endpoint: ") + endpoint + "\n\n")
+
+	b.WriteString("Telemetry is opt-in. When on, f6n counts the views and commands used and\n")
+	b.WriteString("the kinds of errors met (e.g. network, throttled, access-denied), and posts\n")
+	b.WriteString("them to the endpoint on exit. Function names, accounts, regions, arguments\n")
+	b.WriteString("and error messages are never included.\n\n")
+	b.WriteString(styles.HelpStyle.Render(":telemetry on / :telemetry off, or telemetry.enabled in "+m.cfg.ConfigPath) + "\n\n")
+
+	if m.usage.Enabled() {
+		b.WriteString(styles.SelectedStyle.Render("Report so far") + "\n\n")
+		data, err := json.MarshalIndent(m.usage.Snapshot(), "", "  ")
+		if err != nil {
+			data = []byte(err.Error())
+		}
+		b.Write(data)
+		b.WriteString("\n")
+	}
+	m.viewport.SetContent(b.String())
+	m.viewport.GotoTop()
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"f6n/internal/insights"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+)
+
+// timeoutLookback is the window of the list stats used to find each
+// function's peak duration
+const timeoutLookback = 24 * time.Hour
+
+// timeoutRisk returns the timeout assessment for a function, combining the
+// background function stats with REPORT lines when they belong to fn
+func (m Model) timeoutRisk(fn provider.FunctionInfo) (insights.TimeoutRisk, bool) {
+	stats, ok := m.functionStats[fn.Name]
+	peak := stats.MaxDurationMs
+	if m.selectedFunc != nil && m.selectedFunc.Name == fn.Name {
+		if fromReports := insights.PeakDuration(m.metrics.reports); fromReports > peak {
+			peak, ok = fromReports, true
+		}
+	}
+	if !ok || fn.Timeout <= 0 {
+		return insights.TimeoutRisk{}, false
+	}
+	return insights.AssessTimeout(peak, fn.Timeout), true
+}
+
+// renderTimeoutRisk renders the MetricsView timeout section
+func renderTimeoutRisk(risk insights.TimeoutRisk, units unitFormat) string {
+	line := fmt.Sprintf("⏳ Peak duration %s is %.0f%% of the %s timeout (last %s)",
+		units.millis(risk.PeakMs), risk.Ratio()*100, units.millis(risk.TimeoutMs), timeoutLookback)
+	if risk.AtRisk() {
+		return styles.ErrorStyle.Render(fmt.Sprintf("⚠️  Timeout risk: %s. Invocations regularly run within %.0f%% of the timeout; raise the timeout or investigate slow calls.",
+			line, insights.TimeoutRiskRatio*100))
+	}
+	return line
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// shiftBarWidth is the width of the traffic shift progress bar
+const shiftBarWidth = 20
+
+type trafficShiftsLoadedMsg struct {
+	functionName string
+	shifts       []provider.TrafficShift
+	err          error
+}
+
+// fetchTrafficShifts loads the gradual deployments in progress on a
+// function, if supported
+func (m Model) fetchTrafficShifts(fn provider.FunctionInfo) tea.Cmd {
+	reader, ok := provider.As[provider.TrafficShiftReader](m.provider)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		shifts, err := reader.GetTrafficShifts(context.Background(), fn)
+		if err != nil {
+			logger.Logger.Printf("Error reading gradual deployments of %s: %v", fn.Name, err)
+		}
+		return trafficShiftsLoadedMsg{functionName: fn.Name, shifts: shifts, err: err}
+	}
+}
+
+// renderTrafficShifts renders the gradual deployments in progress: the
+// traffic moved to the new version so far, and the alarms that roll it back
+//
+//	live: 6 → 7  [██░░░░░░░░░░░░░░░░░░] 10%  InProgress
+func (d detailModel) renderTrafficShifts() string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Gradual Deployment:") + "\n")
+
+	switch {
+	case d.shiftsErr != nil:
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("  Error loading deployments: %v", d.shiftsErr)) + "\n")
+		return b.String()
+	case d.shifts == nil:
+		b.WriteString(styles.HelpStyle.Render("  Loading...") + "\n")
+		return b.String()
+	case len(d.shifts) == 0:
+		b.WriteString(styles.HelpStyle.Render("  No deployment in progress") + "\n")
+		return b.String()
+	}
+
+	for _, shift := range d.shifts {
+		filled := int(shift.TargetWeight*shiftBarWidth + 0.5)
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", shiftBarWidth-filled)
+		b.WriteString(fmt.Sprintf("  %s: %s → %s  [%s] %.0f%%  %s\n", shift.Alias, shift.CurrentVersion, shift.TargetVersion,
+			bar, shift.TargetWeight*100, styles.InfoValueStyle.Render(shift.Status)))
+
+		details := []string{shift.ID, shift.Config}
+		if !shift.Started.IsZero() {
+			details = append(details, "started "+shift.Started.Local().Format("2006-01-02 15:04:05"))
+		}
+		b.WriteString("  " + styles.HelpStyle.Render(strings.Join(details, " • ")) + "\n")
+		if shift.Error != "" {
+			b.WriteString(styles.ErrorStyle.Render("  ❌ "+shift.Error) + "\n")
+		}
+
+		for _, alarm := range shift.Alarms {
+			state := alarm.State
+			switch state {
+			case "ALARM":
+				state = styles.ErrorStyle.Render("❌ ALARM")
+			case "OK":
+				state = "✅ OK"
+			case "":
+				state = "unknown"
+			}
+			b.WriteString(fmt.Sprintf("  Rollback alarm %s: %s\n", alarm.Name, state))
+		}
+		if len(shift.Alarms) == 0 {
+			b.WriteString(styles.ErrorStyle.Render("  ⚠ No rollback alarms") + "\n")
+		}
+		if len(shift.AutoRollback) > 0 {
+			b.WriteString("  Rolls back on: " + strings.Join(shift.AutoRollback, ", ") + "\n")
+		}
+		if shift.URL != "" {
+			b.WriteString(styles.HelpStyle.Render("  "+shift.URL) + "\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxUndoSteps caps the undo history of the editor
+const maxUndoSteps = 200
+
+// editSnapshot is the editor content and cursor position before an edit
+type editSnapshot struct {
+	value    string
+	row, col int
+}
+
+// editHistory is the undo/redo history of the textarea. Consecutive typed
+// characters are undone as one word.
+type editHistory struct {
+	original string         // Content when editing started, to tell unsaved changes
+	past     []editSnapshot // Edits to undo, last first
+	future   []editSnapshot // Undone edits, redone last first
+	typing   bool           // Whether the last edit typed a word, extended by the next
+}
+
+// newEditHistory starts the history of an edit of original
+func newEditHistory(original string) editHistory {
+	return editHistory{original: original}
+}
+
+// snapshot captures the textarea content and cursor
+func snapshot(ta textarea.Model) editSnapshot {
+	info := ta.LineInfo()
+	return editSnapshot{value: ta.Value(), row: ta.Line(), col: info.StartColumn + info.CharOffset}
+}
+
+// restore puts a snapshot back into the textarea
+func (s editSnapshot) restore(ta *textarea.Model) {
+	ta.SetValue(s.value)
+	for ta.Line() > s.row {
+		ta.CursorUp()
+	}
+	ta.SetCursor(s.col)
+}
+
+// record notes that key changed the content from before
+func (h *editHistory) record(before editSnapshot, key tea.KeyMsg) {
+	typing := key.Type == tea.KeyRunes && !key.Paste
+	for _, r := range key.Runes {
+		typing = typing && !unicode.IsSpace(r)
+	}
+	if !typing || !h.typing {
+		h.past = append(h.past, before)
+		if len(h.past) > maxUndoSteps {
+			h.past = h.past[1:]
+		}
+	}
+	h.typing = typing
+	h.future = nil
+}
+
+// undo reverts the last edit, reporting whether there was one
+func (h *editHistory) undo(ta *textarea.Model) bool {
+	if len(h.past) == 0 {
+		return false
+	}
+	h.future = append(h.future, snapshot(*ta))
+	h.past[len(h.past)-1].restore(ta)
+	h.past = h.past[:len(h.past)-1]
+	h.typing = false
+	return true
+}
+
+// redo applies the last undone edit again, reporting whether there was one
+func (h *editHistory) redo(ta *textarea.Model) bool {
+	if len(h.future) == 0 {
+		return false
+	}
+	h.past = append(h.past, snapshot(*ta))
+	h.future[len(h.future)-1].restore(ta)
+	h.future = h.future[:len(h.future)-1]
+	h.typing = false
+	return true
+}
+
+// dirty reports whether value differs from the content editing started with
+func (h editHistory) dirty(value string) bool {
+	return value != h.original
+}
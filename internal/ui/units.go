@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unitFormat renders memory sizes, code sizes and durations in the table,
+// DetailView and MetricsView: in the largest fitting unit, e.g. "1.5 GB" or
+// "5 min", or as raw numbers when ui.raw_units is set in the config file
+type unitFormat struct {
+	raw bool
+}
+
+// units returns the unit format of the config file
+func (m Model) units() unitFormat {
+	return unitFormat{raw: m.cfg != nil && m.cfg.File.UI.RawUnits}
+}
+
+// memory renders a memory size configured or measured in MB
+func (u unitFormat) memory(mb float64) string {
+	if u.raw || mb < 1024 {
+		return fmt.Sprintf("%.0f MB", mb)
+	}
+	return trimZero(mb/1024) + " GB"
+}
+
+// bytes renders a size in bytes, such as a deployment package
+func (u unitFormat) bytes(n int64) string {
+	if u.raw {
+		return fmt.Sprintf("%d B", n)
+	}
+	return formatSize(n)
+}
+
+// seconds renders a duration configured in whole seconds, such as a timeout
+func (u unitFormat) seconds(s int32) string {
+	if u.raw {
+		return fmt.Sprintf("%d s", s)
+	}
+	return formatDuration(time.Duration(s) * time.Second)
+}
+
+// millis renders a duration measured in milliseconds, such as a billed duration
+func (u unitFormat) millis(ms float64) string {
+	if u.raw || ms < 1000 {
+		return fmt.Sprintf("%.0f ms", ms)
+	}
+	return formatDuration(time.Duration(ms * float64(time.Millisecond)))
+}
+
+// formatDuration renders d in seconds up to a minute, in minutes up to an
+// hour and in hours beyond, with the remainder in the next smaller unit
+func formatDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return trimZero(d.Seconds()) + " s"
+	case d < time.Hour:
+		minutes, seconds := int(d/time.Minute), int((d%time.Minute)/time.Second)
+		if seconds == 0 {
+			return fmt.Sprintf("%d min", minutes)
+		}
+		return fmt.Sprintf("%d min %d s", minutes, seconds)
+	default:
+		hours, minutes := int(d/time.Hour), int((d%time.Hour)/time.Minute)
+		if minutes == 0 {
+			return fmt.Sprintf("%d h", hours)
+		}
+		return fmt.Sprintf("%d h %d min", hours, minutes)
+	}
+}
+
+// trimZero renders v with one decimal, dropping it when it is zero
+func trimZero(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 1, 64)
+	return strings.TrimSuffix(s, ".0")
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/charts"
+	"f6n/internal/insights"
+	"f6n/internal/logger"
+	"f6n/internal/provider"
+	"f6n/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reportsLookback is how far back MetricsView reads invocation REPORT lines
+const reportsLookback = 24 * time.Hour
+
+// reportsLogLimit caps the number of REPORT lines read per refresh
+const reportsLogLimit = 2000
+
+type invocationReportsLoadedMsg struct {
+	functionName string
+	reports      []insights.Report
+	err          error
+}
+
+// fetchInvocationReports loads the REPORT lines Lambda writes after each invocation
+func (m Model) fetchInvocationReports(name string) tea.Cmd {
+	return func() tea.Msg {
+		query := provider.LogQuery{
+			Limit: reportsLogLimit,
+			Since: time.Now().Add(-reportsLookback),
+			Text:  insights.ReportMarker,
+		}
+		entries, err := m.provider.GetFunctionLogs(context.Background(), name, query)
+		if err != nil {
+			logger.Logger.Printf("Error fetching invocation reports for %s: %v", name, err)
+			return invocationReportsLoadedMsg{functionName: name, err: err}
+		}
+		return invocationReportsLoadedMsg{functionName: name, reports: insights.ParseReports(entries)}
+	}
+}
+
+// fetchMetricsViewData loads everything MetricsView shows for a function
+func (m Model) fetchMetricsViewData(name string) tea.Cmd {
+	return tea.Batch(m.fetchFunctionMetrics(name), m.fetchConcurrency(name), m.fetchEnhancedMetrics(name), m.fetchInvocationReports(name), m.fetchMetricsHistory(name))
+}
+
+// renderUsage renders the sections computed from invocation reports
+func (mt metricsModel) renderUsage(fn *provider.FunctionInfo, width int, units unitFormat) string {
+	if mt.reportsErr != nil {
+		return styles.HelpStyle.Render(fmt.Sprintf("Invocation reports unavailable: %v", mt.reportsErr))
+	}
+	if mt.reports == nil {
+		return styles.HelpStyle.Render("Loading invocation reports...")
+	}
+
+	configured := 0
+	if fn != nil {
+		configured = int(fn.Memory)
+	}
+	memory, ok := insights.AnalyzeMemory(mt.reports, configured)
+	if !ok {
+		return styles.HelpStyle.Render(fmt.Sprintf("No invocation reports in the last %s", reportsLookback))
+	}
+	sections := []string{renderMemoryUtilization(memory, width, units)}
+	if cost, ok := insights.AnalyzeCost(mt.reports); ok {
+		sections = append(sections, renderCostSummary(cost, width, units))
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// renderMemoryUtilization charts peak memory usage against the configured size
+func renderMemoryUtilization(u insights.MemoryUtilization, width int, units unitFormat) string {
+	var b strings.Builder
+	b.WriteString(charts.RenderTimeSeriesChart(u.Points, width-8, 10,
+		fmt.Sprintf("💾 Memory utilization (%% of %s, last %s)", units.memory(float64(u.ConfiguredMB)), reportsLookback)))
+	b.WriteString("\n\n")
+
+	b.WriteString(fmt.Sprintf("• Invocations analyzed: %d\n", u.Samples))
+	b.WriteString(fmt.Sprintf("• Max used: %s  •  p95: %s  •  Avg: %s\n",
+		units.memory(u.MaxUsedMB), units.memory(u.P95UsedMB), units.memory(u.AvgUsedMB)))
+
+	verdict := fmt.Sprintf("• Sizing: %s — %s", u.Provisioning, u.Suggestion())
+	switch u.Provisioning {
+	case insights.UnderProvisioned:
+		b.WriteString(styles.ErrorStyle.Render("⚠️  " + verdict))
+	case insights.OverProvisioned:
+		b.WriteString(styles.InfoValueStyle.Render("💡 " + verdict))
+	default:
+		b.WriteString(verdict)
+	}
+	return b.String()
+}
+
+// renderCostSummary renders the billed duration histogram and estimated cost
+func renderCostSummary(c insights.CostSummary, width int, units unitFormat) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("💰 Billed duration and cost (last %s)\n\n", reportsLookback))
+
+	maxCount := 0
+	for _, bucket := range c.Histogram {
+		if bucket.Count > maxCount {
+			maxCount = bucket.Count
+		}
+	}
+	maxBar := width - 40
+	if maxBar < 10 {
+		maxBar = 10
+	}
+	for _, bucket := range c.Histogram {
+		barLength := 0
+		if maxCount > 0 {
+			barLength = bucket.Count * maxBar / maxCount
+		}
+		if bucket.Count > 0 && barLength < 1 {
+			barLength = 1
+		}
+		b.WriteString(fmt.Sprintf("%-7s │%s %d\n", bucket.Label, strings.Repeat("█", barLength), bucket.Count))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("• Billed duration: avg %s  •  p50 %s  •  p90 %s  •  p99 %s\n",
+		units.millis(c.AvgBilledMs), units.millis(c.P50BilledMs), units.millis(c.P90BilledMs), units.millis(c.P99BilledMs)))
+	b.WriteString(fmt.Sprintf("• Compute: %.2f GB-s over %d invocations\n", c.GBSeconds, c.Invocations))
+	b.WriteString(fmt.Sprintf("• Estimated cost: $%.8f per invocation  •  $%.4f total\n", c.PerInvocationUSD, c.TotalUSD))
+	b.WriteString(styles.HelpStyle.Render("  Estimate uses on-demand x86 pricing in us-east-1 and excludes free tier."))
+	return b.String()
+}
@@ -16,6 +16,42 @@ const (
 	CodeDisplayView
 	// MetricsView shows metrics and charts for a selected function
 	MetricsView
+	// ErrorsView shows recent errors grouped by similarity
+	ErrorsView
+	// RankingView ranks functions by invocations, errors, duration or cost
+	RankingView
+	// ExportView shows a function's configuration exported as infrastructure-as-code
+	ExportView
+	// BulkDownloadView shows the progress of :download-all
+	BulkDownloadView
+	// DepsView lists the dependencies declared in a function's downloaded code
+	DepsView
+	// SecurityView shows secrets found in a function's environment and code
+	SecurityView
+	// ComplianceView lists the compliance policy violations of all functions
+	ComplianceView
+	// EnvEditView edits a function's environment variables
+	EnvEditView
+	// CompareView shows the configurations of two functions side by side
+	CompareView
+	// AuditView lists the changes recorded in the audit log
+	AuditView
+	// TelemetryView shows the telemetry status and the usage report
+	TelemetryView
+	// HTTPView shows the response to a request sent to a function's endpoint
+	HTTPView
+	// DeploysView lists a function's recent deployments
+	DeploysView
+	// RightSizingView recommends memory sizes with their projected monthly savings
+	RightSizingView
+	// PowerTuneView shows a function's duration and cost at several memory sizes
+	PowerTuneView
+	// HelpView lists the keys and commands
+	HelpView
+	// ReportView previews the inventory report
+	ReportView
+	// InvokeView edits a payload to invoke a function with and shows the result
+	InvokeView
 )
 
 // String returns the string representation of the view type
@@ -33,6 +69,42 @@ func (v ViewType) String() string {
 		return "code-display"
 	case MetricsView:
 		return "metrics"
+	case ErrorsView:
+		return "errors"
+	case RankingView:
+		return "ranking"
+	case ExportView:
+		return "export"
+	case BulkDownloadView:
+		return "downloads"
+	case DepsView:
+		return "dependencies"
+	case SecurityView:
+		return "security"
+	case ComplianceView:
+		return "compliance"
+	case EnvEditView:
+		return "env"
+	case CompareView:
+		return "compare"
+	case AuditView:
+		return "audit"
+	case TelemetryView:
+		return "telemetry"
+	case HTTPView:
+		return "http"
+	case DeploysView:
+		return "deploys"
+	case RightSizingView:
+		return "rightsizing"
+	case PowerTuneView:
+		return "powertune"
+	case HelpView:
+		return "help"
+	case ReportView:
+		return "report"
+	case InvokeView:
+		return "invoke"
 	default:
 		return "unknown"
 	}
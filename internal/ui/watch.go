@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"f6n/internal/insights"
+	"f6n/internal/logger"
+	"f6n/internal/notify"
+	"f6n/internal/provider"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// defaultWatchInterval is used when :watch is toggled on without an interval
+	defaultWatchInterval = time.Minute
+	// watchStatsWindow is the window error rates are compared over between polls
+	watchStatsWindow = 15 * time.Minute
+)
+
+// watchState holds the watch mode state
+type watchState struct {
+	interval time.Duration      // 0 when watch mode is off
+	gen      int                // bumped on every change so stale polls are dropped
+	stop     context.CancelFunc // Stops the watcher, nil when off
+	stats    map[string]provider.FunctionStats
+}
+
+type watchPolledMsg struct {
+	gen       int
+	functions []provider.FunctionInfo
+	stats     map[string]provider.FunctionStats
+	err       error
+}
+
+type notificationsSentMsg struct {
+	sent int
+	err  error
+}
+
+// setWatch turns watch mode on with the given interval, or off with 0. The
+// watcher polls right away when pollNow is set, otherwise after interval.
+func (m *Model) setWatch(interval time.Duration, pollNow bool) {
+	if m.watch.stop != nil {
+		m.watch.stop()
+		m.watch.stop = nil
+	}
+	m.watch.gen++
+	m.watch.interval = interval
+	m.watch.stats = nil
+	if interval <= 0 {
+		return
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	m.watch.stop = stop
+	go runWatcher(ctx, m.bus, m.provider, m.watch.gen, interval, pollNow)
+}
+
+// runWatcher polls the functions every interval and publishes each poll on
+// the bus, until ctx is done. The next wait starts once the poll is handled.
+func runWatcher(ctx context.Context, bus *Bus, prov provider.Provider, gen int, interval time.Duration, pollNow bool) {
+	wait := interval
+	if pollNow {
+		wait = 0
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		msg := pollWatch(ctx, prov, gen)
+		if ctx.Err() != nil {
+			return
+		}
+		bus.Publish(msg)
+		timer.Reset(interval)
+	}
+}
+
+// pollWatch lists the functions and their recent stats
+func pollWatch(ctx context.Context, prov provider.Provider, gen int) watchPolledMsg {
+	functions, err := prov.ListFunctions(ctx)
+	if err != nil {
+		logger.Logger.Printf("Watch: error listing functions: %v", err)
+		return watchPolledMsg{gen: gen, err: err}
+	}
+	stats, err := insights.CollectStats(ctx, prov, functions, time.Now().Add(-watchStatsWindow))
+	if err != nil {
+		logger.Logger.Printf("Watch: error fetching function stats: %v", err)
+	}
+	return watchPolledMsg{gen: gen, functions: functions, stats: stats, err: err}
+}
+
+// handleWatchPolled refreshes the list and reports anomalies
+func (m Model) handleWatchPolled(msg watchPolledMsg) (tea.Model, tea.Cmd) {
+	if msg.gen != m.watch.gen || m.watch.interval <= 0 {
+		return m, nil
+	}
+	if msg.functions == nil && msg.err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Watch poll failed: %v", msg.err)
+		return m, nil
+	}
+
+	anomalies := insights.DetectStateChanges(m.list.allFunctions, msg.functions)
+	if msg.err == nil {
+		anomalies = append(anomalies, insights.DetectErrorSpikes(m.watch.stats, msg.stats)...)
+		m.watch.stats = msg.stats
+	}
+
+	m.list.allFunctions = msg.functions
+	filter := m.list.activeFilter
+	if m.inputMode == FilterMode {
+		filter = m.textInput.Value()
+	}
+	m.applyFunctionFilter(filter)
+
+	if len(anomalies) == 0 {
+		return m, nil
+	}
+	for _, a := range anomalies {
+		logger.Logger.Printf("Watch: %s %s: %s", a.Kind, a.FunctionName, a.Summary)
+	}
+	m.statusMsg = fmt.Sprintf("⚠️  %s: %s", anomalies[0].FunctionName, anomalies[0].Summary)
+	if len(anomalies) > 1 {
+		m.statusMsg += fmt.Sprintf(" (+%d more)", len(anomalies)-1)
+	}
+	return m, m.sendNotifications(anomalies)
+}
+
+// sendNotifications posts anomalies to the configured webhook, if any
+func (m Model) sendNotifications(anomalies []insights.Anomaly) tea.Cmd {
+	if m.notifier == nil {
+		return nil
+	}
+	notifier := m.notifier
+	providerName := string(m.provider.GetProviderName())
+	return func() tea.Msg {
+		sent := 0
+		for _, a := range anomalies {
+			err := notifier.Send(context.Background(), notify.Notification{
+				Provider: providerName,
+				Function: a.FunctionName,
+				Kind:     string(a.Kind),
+				Summary:  a.Summary,
+			})
+			if err != nil {
+				logger.Logger.Printf("Error sending notification for %s: %v", a.FunctionName, err)
+				return notificationsSentMsg{sent: sent, err: err}
+			}
+			sent++
+		}
+		return notificationsSentMsg{sent: sent}
+	}
+}
+
+// executeWatchCommand handles ":watch [interval|off]"; without arguments it toggles watch mode
+func (m Model) executeWatchCommand(args []string) (tea.Model, tea.Cmd) {
+	interval := defaultWatchInterval
+	if m.cfg.WatchInterval > 0 {
+		interval = m.cfg.WatchInterval
+	}
+
+	switch {
+	case len(args) == 0 && m.watch.interval > 0, len(args) > 0 && strings.EqualFold(args[0], "off"):
+		interval = 0
+	case len(args) > 0:
+		d, err := time.ParseDuration(args[0])
+		if err != nil || d < 10*time.Second {
+			m.statusMsg = fmt.Sprintf("Invalid watch interval %q (use e.g. 30s or 5m, at least 10s)", args[0])
+			return m, nil
+		}
+		interval = d
+	}
+
+	m.setWatch(interval, true)
+	if interval == 0 {
+		m.statusMsg = "Watch mode off"
+	} else {
+		m.statusMsg = fmt.Sprintf("Watching functions every %s", interval)
+		if m.notifier == nil {
+			m.statusMsg += " (no --notify-webhook set, anomalies are only shown here)"
+		}
+	}
+	return m, nil
+}
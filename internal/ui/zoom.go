@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+
+	"f6n/internal/i18n"
+	"f6n/internal/ui/styles"
+)
+
+// zoomable reports whether 'z' can expand the current view to the full terminal
+func (m Model) zoomable() bool {
+	switch m.currentView {
+	case LogsView, CodeView, CodeDisplayView, MetricsView, ErrorsView, CompareView:
+		return !m.code.editMode
+	default:
+		return false
+	}
+}
+
+// toggleZoom expands the viewport to the full terminal, or restores the
+// regular layout
+func (m *Model) toggleZoom() {
+	if !m.zoomed && !m.zoomable() {
+		return
+	}
+	m.zoomed = !m.zoomed
+	m.layoutViewport()
+}
+
+// layoutViewport sizes the viewport for the regular layout, or the whole
+// terminal minus the status line when zoomed
+func (m *Model) layoutViewport() {
+	if m.zoomed {
+		m.viewport.Width = m.width
+		m.viewport.Height = m.height - 1
+		return
+	}
+	m.viewport.Width = m.width - 4
+	m.viewport.Height = m.height - 8
+}
+
+// renderZoomed renders only the viewport and a status line, without the logo,
+// info panel and shortcuts
+func renderZoomed(m Model) string {
+	var b strings.Builder
+	if m.inputMode == FilterMode || m.inputMode == CommandMode || m.inputMode == LogFilterMode {
+		b.WriteString(m.textInput.View() + "\n")
+	}
+	if m.offline.active {
+		b.WriteString(m.renderOfflineBanner())
+	}
+	b.WriteString(m.viewport.View() + "\n")
+	hint := i18n.T("z: restore layout • esc: back")
+	if pos := positionIndicator(m.viewport); pos != "" {
+		hint = pos + " • " + hint
+	}
+	status := styles.HelpStyle.Render(hint)
+	if m.statusMsg != "" {
+		status = styles.InfoValueStyle.Render(m.statusMsg) + "  " + status
+	}
+	b.WriteString(status)
+	return b.String()
+}
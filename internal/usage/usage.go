@@ -0,0 +1,205 @@
+// Package usage collects which features are used and which kinds of errors
+// occur, when the user opts in, and reports them anonymously: no function
+// names, accounts, regions or error messages leave the machine.
+package usage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"f6n/internal/config"
+	"f6n/internal/provider"
+	"f6n/internal/version"
+)
+
+// Report is what is sent: counts of the features used and of the error
+// categories seen during a session
+type Report struct {
+	InstallID string         `json:"installId"` // random, kept in the state directory
+	Version   string         `json:"version"`
+	OS        string         `json:"os"`
+	Arch      string         `json:"arch"`
+	Provider  string         `json:"provider"` // aws or gcp
+	Started   time.Time      `json:"started"`
+	Features  map[string]int `json:"features,omitempty"` // e.g. "view logs", "command :deps"
+	Errors    map[string]int `json:"errors,omitempty"`   // e.g. "get-logs network"
+}
+
+// Reporter counts usage while telemetry is enabled
+type Reporter struct {
+	endpoint   string
+	enabled    bool
+	disabledBy string // why telemetry is off although enabled in the config
+	mu         sync.Mutex
+	report     Report
+}
+
+// New creates a reporter from the config file settings. DO_NOT_TRACK=1 in the
+// environment turns telemetry off whatever the settings.
+func New(settings config.TelemetrySettings, providerName string) *Reporter {
+	r := &Reporter{
+		endpoint: settings.Endpoint,
+		report: Report{
+			Version:  version.Short(),
+			OS:       runtime.GOOS,
+			Arch:     runtime.GOARCH,
+			Provider: providerName,
+			Started:  time.Now().UTC().Truncate(time.Hour),
+		},
+	}
+	if v := os.Getenv("DO_NOT_TRACK"); v != "" && v != "0" {
+		r.disabledBy = "DO_NOT_TRACK is set"
+	}
+	r.SetEnabled(settings.Enabled)
+	return r
+}
+
+// SetEnabled turns counting on or off, e.g. from :telemetry on
+func (r *Reporter) SetEnabled(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.enabled = enabled && r.disabledBy == ""
+	if !r.enabled {
+		r.report.Features, r.report.Errors = nil, nil
+	}
+}
+
+// Enabled reports whether usage is being counted
+func (r *Reporter) Enabled() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enabled
+}
+
+// DisabledBy returns why telemetry is off although enabled, if it is
+func (r *Reporter) DisabledBy() string {
+	return r.disabledBy
+}
+
+// Endpoint returns where the report is sent, empty when it is not
+func (r *Reporter) Endpoint() string {
+	return r.endpoint
+}
+
+// Feature counts a use of a feature. Names must not carry user data.
+func (r *Reporter) Feature(name string) {
+	if r != nil {
+		r.count(&r.report.Features, name)
+	}
+}
+
+// Error counts an error of an operation by its category, never its message
+func (r *Reporter) Error(operation string, err error) {
+	if r != nil && err != nil {
+		r.count(&r.report.Errors, operation+" "+Category(err))
+	}
+}
+
+// count increments a key of one of the report's counts while enabled
+func (r *Reporter) count(counts *map[string]int, key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.enabled {
+		return
+	}
+	if *counts == nil {
+		*counts = make(map[string]int)
+	}
+	(*counts)[key]++
+}
+
+// Snapshot returns the report as it would be sent now. The install ID is only
+// created once telemetry is enabled.
+func (r *Reporter) Snapshot() Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rep := r.report
+	rep.Features, rep.Errors = maps.Clone(rep.Features), maps.Clone(rep.Errors)
+	if r.enabled {
+		rep.InstallID = installID()
+	}
+	return rep
+}
+
+// Send posts the report to the endpoint. Nothing is sent when telemetry is
+// off, no endpoint is configured or nothing was counted.
+func (r *Reporter) Send(ctx context.Context) error {
+	if r == nil || !r.Enabled() || r.endpoint == "" {
+		return nil
+	}
+	rep := r.Snapshot()
+	if len(rep.Features) == 0 && len(rep.Errors) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(rep)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid telemetry endpoint: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send usage report: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("usage report rejected: %s", resp.Status)
+	}
+	return nil
+}
+
+// Category sorts an error into a coarse kind without its details
+func Category(err error) string {
+	var credsErr *provider.CredentialsError
+	if errors.As(err, &credsErr) {
+		return "credentials"
+	}
+	if provider.IsNetworkError(err) {
+		return "network"
+	}
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "throttl") || strings.Contains(msg, "toomanyrequests") || strings.Contains(msg, "429"):
+		return "throttled"
+	case strings.Contains(msg, "accessdenied") || strings.Contains(msg, "forbidden") || strings.Contains(msg, "permission") || strings.Contains(msg, "403"):
+		return "access-denied"
+	case strings.Contains(msg, "notfound") || strings.Contains(msg, "not found") || strings.Contains(msg, "404"):
+		return "not-found"
+	}
+	return "other"
+}
+
+// installID returns the random identifier of this installation, creating it
+// in the state directory the first time. It tells reports of the same user
+// apart from those of others, and nothing more.
+func installID() string {
+	path := filepath.Join(config.StateDir(), "telemetry-id")
+	if data, err := os.ReadFile(path); err == nil {
+		return strings.TrimSpace(string(data))
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	id := hex.EncodeToString(b)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err == nil {
+		_ = os.WriteFile(path, []byte(id+"\n"), 0600)
+	}
+	return id
+}